@@ -53,23 +53,22 @@ func (cmd *listTracepointCmd) Run(ctx *globalOptions) error {
 	}
 
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"ID", "Path", "Line", "Args"})
-	var data deeppb.LoadTracepointResponse
+	table.SetHeader([]string{"ID", "Path", "Line", "Args", "Installed", "Failed"})
+	var data deeppb.ListTracepointsResponse
 
 	err = proto.Unmarshal(all, &data)
 	if err != nil {
 		return err
 	}
 
-	tps := data.Response.Response
-
-	for _, tp := range tps {
+	for _, tp := range data.Response {
 		marshal, err := json.Marshal(tp.Args)
 		if err != nil {
 			return err
 		}
 
-		table.Append([]string{tp.ID, tp.Path, fmt.Sprintf("%d", tp.LineNumber), string(marshal)})
+		installStatus := data.Status[tp.ID]
+		table.Append([]string{tp.ID, tp.Path, fmt.Sprintf("%d", tp.LineNumber), string(marshal), fmt.Sprintf("%d", installStatus.GetInstalled()), fmt.Sprintf("%d", installStatus.GetFailed())})
 	}
 
 	table.Render()
@@ -0,0 +1,163 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/intergral/deep/pkg/deeppb"
+	"github.com/intergral/deep/pkg/tpconfig"
+	"github.com/weaveworks/common/middleware"
+	"github.com/weaveworks/common/user"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type tracepointSyncOptions struct {
+	Endpoint string `help:"The grpc address of the tracepoint service" default:"localhost:43315"`
+	Tenant   string `help:"The tenant to sync the tracepoints of" default:"single-tenant"`
+}
+
+// connect returns a client for the tracepoint service and a context with the tenant set
+func (o *tracepointSyncOptions) connect() (deeppb.TracepointConfigServiceClient, context.Context, func() error, error) {
+	conn, err := grpc.Dial(o.Endpoint,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(middleware.ClientUserHeaderInterceptor),
+	)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	ctx := user.InjectOrgID(context.Background(), o.Tenant)
+	return deeppb.NewTracepointConfigServiceClient(conn), ctx, conn.Close, nil
+}
+
+// plan loads the tracepoint file and the current tracepoints, and returns the changes to make them match
+func (o *tracepointSyncOptions) plan(path string, prune bool) (deeppb.TracepointConfigServiceClient, context.Context, func() error, []tpconfig.Change, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	file, err := tpconfig.Parse(data)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	client, ctx, closer, err := o.connect()
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	current, err := tpconfig.Load(ctx, client)
+	if err != nil {
+		_ = closer()
+		return nil, nil, nil, nil, err
+	}
+
+	return client, ctx, closer, tpconfig.Plan(file, current, prune), nil
+}
+
+type cmdExportTracepoints struct {
+	tracepointSyncOptions
+
+	File string `short:"f" help:"The file to write the tracepoints to, defaults to stdout"`
+}
+
+func (cmd *cmdExportTracepoints) Run(_ *globalOptions) error {
+	client, ctx, closer, err := cmd.connect()
+	if err != nil {
+		return err
+	}
+	defer closer()
+
+	current, err := tpconfig.Load(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	data, err := tpconfig.Marshal(tpconfig.FromResponse(current))
+	if err != nil {
+		return err
+	}
+
+	if cmd.File == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(cmd.File, data, 0o644)
+}
+
+type cmdDiffTracepoints struct {
+	tracepointSyncOptions
+
+	File  string `arg:"" type:"existingfile" help:"The file of declared tracepoints"`
+	Prune bool   `help:"Include the tracepoints that are not in the file"`
+}
+
+func (cmd *cmdDiffTracepoints) Run(_ *globalOptions) error {
+	_, _, closer, changes, err := cmd.plan(cmd.File, cmd.Prune)
+	if err != nil {
+		return err
+	}
+	defer closer()
+
+	printChanges(changes)
+	return nil
+}
+
+type cmdApplyTracepoints struct {
+	tracepointSyncOptions
+
+	File   string `arg:"" type:"existingfile" help:"The file of declared tracepoints"`
+	Prune  bool   `help:"Delete the tracepoints that are not in the file"`
+	DryRun bool   `help:"Print the changes without applying them"`
+	Actor  string `help:"The actor recorded in the audit log" default:"deep-cli"`
+}
+
+func (cmd *cmdApplyTracepoints) Run(_ *globalOptions) error {
+	client, ctx, closer, changes, err := cmd.plan(cmd.File, cmd.Prune)
+	if err != nil {
+		return err
+	}
+	defer closer()
+
+	printChanges(changes)
+	if cmd.DryRun || len(changes) == 0 {
+		return nil
+	}
+
+	err = tpconfig.Apply(ctx, client, changes, cmd.Actor)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("applied %d changes\n", len(changes))
+	return nil
+}
+
+func printChanges(changes []tpconfig.Change) {
+	if len(changes) == 0 {
+		fmt.Println("no changes")
+		return
+	}
+	for _, change := range changes {
+		fmt.Println(change.String())
+	}
+}
@@ -73,6 +73,12 @@ var cli struct {
 	Delete struct {
 		Tracepoint cmdDeleteTracepoint `cmd:"tp" help:"Delete a tracepoint"`
 	} `cmd:"delete"`
+
+	Tracepoints struct {
+		Export cmdExportTracepoints `cmd:"export" help:"Export the tracepoints of a tenant as yaml"`
+		Diff   cmdDiffTracepoints   `cmd:"diff" help:"Show the changes needed to make the tracepoints match a file"`
+		Apply  cmdApplyTracepoints  `cmd:"apply" help:"Make the tracepoints of a tenant match a file"`
+	} `cmd:"tracepoints" aliases:"tps"`
 }
 
 func main() {
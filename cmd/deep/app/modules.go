@@ -76,6 +76,7 @@ const (
 	MetricsGenerator     string = "metrics-generator"
 	Querier              string = "querier"
 	QueryFrontend        string = "query-frontend"
+	QueryFrontendTail    string = "query-frontend-tail"
 	Compactor            string = "compactor"
 	Store                string = "store"
 	MemberlistKV         string = "memberlist-kv"
@@ -219,6 +220,9 @@ func (t *App) initDistributor() (services.Service, error) {
 		t.Server.HTTP.Handle("/distributor/ring", t.distributor.DistributorRing)
 	}
 
+	agentsHandler := t.HTTPAuthMiddleware.Wrap(http.HandlerFunc(t.distributor.AgentsHandler))
+	t.Server.HTTP.Handle(addHTTPAPIPrefix(&t.cfg, api.PathAgents), agentsHandler)
+
 	return t.distributor, nil
 }
 
@@ -236,7 +240,7 @@ func (t *App) initTracepointClient() (services.Service, error) {
 // initTracepoint creates the service that will actually deal with storing the tracepoint configs
 func (t *App) initTracepoint() (services.Service, error) {
 	t.cfg.Tracepoint.LifecyclerConfig.ListenPort = t.cfg.Server.GRPCListenPort
-	newTracepointService, err := tracepoint.New(t.cfg.Tracepoint, t.store, log.Logger, prometheus.DefaultRegisterer)
+	newTracepointService, err := tracepoint.New(t.cfg.Tracepoint, t.store, t.overrides, log.Logger, prometheus.DefaultRegisterer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create tracepoint config service: %w", err)
 	}
@@ -273,9 +277,22 @@ func (t *App) initTracepointAPI() (services.Service, error) {
 	loadHandler := t.HTTPAuthMiddleware.Wrap(http.HandlerFunc(t.tracepointAPI.LoadTracepointHandler))
 	t.Server.HTTP.Handle(path.Join(api.PathPrefixTracepoints, addHTTPAPIPrefix(&t.cfg, api.PathTracepoints)), loadHandler)
 
+	// the bulk routes must be registered before the tracepoint ID routes, otherwise 'bulk' is matched as an ID
+	bulkHandler := t.HTTPAuthMiddleware.Wrap(http.HandlerFunc(t.tracepointAPI.BulkTracepointsHandler))
+	t.Server.HTTP.Handle(path.Join(api.PathPrefixTracepoints, addHTTPAPIPrefix(&t.cfg, api.PathBulkTracepoints)), bulkHandler)
+
+	bulkStateHandler := t.HTTPAuthMiddleware.Wrap(http.HandlerFunc(t.tracepointAPI.BulkTracepointsStateHandler))
+	t.Server.HTTP.Handle(path.Join(api.PathPrefixTracepoints, addHTTPAPIPrefix(&t.cfg, api.PathBulkTracepointsState)), bulkStateHandler)
+
 	deleteHandler := t.HTTPAuthMiddleware.Wrap(http.HandlerFunc(t.tracepointAPI.DeleteTracepointHandler))
 	t.Server.HTTP.Handle(path.Join(api.PathPrefixTracepoints, addHTTPAPIPrefix(&t.cfg, api.PathDeleteTracepoint)), deleteHandler)
 
+	historyHandler := t.HTTPAuthMiddleware.Wrap(http.HandlerFunc(t.tracepointAPI.TracepointHistoryHandler))
+	t.Server.HTTP.Handle(path.Join(api.PathPrefixTracepoints, addHTTPAPIPrefix(&t.cfg, api.PathTracepointHistory)), historyHandler)
+
+	stateHandler := t.HTTPAuthMiddleware.Wrap(http.HandlerFunc(t.tracepointAPI.TracepointStateHandler))
+	t.Server.HTTP.Handle(path.Join(api.PathPrefixTracepoints, addHTTPAPIPrefix(&t.cfg, api.PathTracepointState)), stateHandler)
+
 	return t.tracepointAPI, t.tracepointAPI.CreateAndRegisterWorker(t.Server.HTTPServer.Handler)
 }
 
@@ -340,6 +357,10 @@ func (t *App) initQuerier() (services.Service, error) {
 		t.HTTPAuthMiddleware,
 	)
 
+	// the diff route must be registered before the snapshot ID route, otherwise 'diff' is matched as an ID
+	snapshotDiffHandler := frontEndMiddleware.Wrap(http.HandlerFunc(t.querier.SnapshotDiffHandler))
+	t.Server.HTTP.Handle(path.Join(api.PathPrefixQuerier, addHTTPAPIPrefix(&t.cfg, api.PathSnapshotDiff)), snapshotDiffHandler)
+
 	tracesHandler := frontEndMiddleware.Wrap(http.HandlerFunc(t.querier.SnapshotByIdHandler))
 	t.Server.HTTP.Handle(path.Join(api.PathPrefixQuerier, addHTTPAPIPrefix(&t.cfg, api.PathSnapshots)), tracesHandler)
 
@@ -383,11 +404,15 @@ func (t *App) initQueryFrontend() (services.Service, error) {
 	)
 
 	traceByIDHandler := frontEndMiddleware.Wrap(queryFrontend.SnapshotByID)
+	snapshotDiffHandler := frontEndMiddleware.Wrap(queryFrontend.SnapshotDiff)
 	searchHandler := frontEndMiddleware.Wrap(queryFrontend.Search)
 
 	// register grpc server for workers to connect to
 	frontend_v1pb.RegisterFrontendServer(t.Server.GRPC, t.frontend)
 
+	// http snapshot diff endpoint, this must be registered before the snapshot by id endpoint
+	t.Server.HTTP.Handle(addHTTPAPIPrefix(&t.cfg, api.PathSnapshotDiff), snapshotDiffHandler)
+
 	// http snapshot by id endpoint
 	t.Server.HTTP.Handle(addHTTPAPIPrefix(&t.cfg, api.PathSnapshots), traceByIDHandler)
 
@@ -397,12 +422,26 @@ func (t *App) initQueryFrontend() (services.Service, error) {
 	t.Server.HTTP.Handle(addHTTPAPIPrefix(&t.cfg, api.PathSearchTagValues), searchHandler)
 	t.Server.HTTP.Handle(addHTTPAPIPrefix(&t.cfg, api.PathSearchTagValuesV2), searchHandler)
 
+	// http trace snapshots endpoint, this is a search for the snapshots taken in a trace
+	t.Server.HTTP.Handle(addHTTPAPIPrefix(&t.cfg, api.PathTraceSnapshots), frontEndMiddleware.Wrap(queryFrontend.TraceSnapshots))
+
+	// http export endpoint, this is not gzipped as it streams the snapshots and reports truncation in trailers
+	t.Server.HTTP.Handle(addHTTPAPIPrefix(&t.cfg, api.PathSearchExport), t.HTTPAuthMiddleware.Wrap(queryFrontend.Export))
+
 	loadTracepointHandler := frontEndMiddleware.Wrap(queryFrontend.LoadTracepointHandler)
 	delTracepointHandler := frontEndMiddleware.Wrap(queryFrontend.DelTracepointHandler)
+	tracepointHistoryHandler := frontEndMiddleware.Wrap(queryFrontend.TracepointHistory)
+	bulkTracepointHandler := frontEndMiddleware.Wrap(queryFrontend.BulkTracepointHandler)
+	tracepointStateHandler := frontEndMiddleware.Wrap(queryFrontend.TracepointStateHandler)
 
 	// http tracepoint endpoints
 	t.Server.HTTP.Handle(addHTTPAPIPrefix(&t.cfg, api.PathTracepoints), loadTracepointHandler)
+	// the bulk routes must be registered before the tracepoint ID routes, otherwise 'bulk' is matched as an ID
+	t.Server.HTTP.Handle(addHTTPAPIPrefix(&t.cfg, api.PathBulkTracepoints), bulkTracepointHandler)
+	t.Server.HTTP.Handle(addHTTPAPIPrefix(&t.cfg, api.PathBulkTracepointsState), bulkTracepointHandler)
 	t.Server.HTTP.Handle(addHTTPAPIPrefix(&t.cfg, api.PathDeleteTracepoint), delTracepointHandler)
+	t.Server.HTTP.Handle(addHTTPAPIPrefix(&t.cfg, api.PathTracepointHistory), tracepointHistoryHandler)
+	t.Server.HTTP.Handle(addHTTPAPIPrefix(&t.cfg, api.PathTracepointState), tracepointStateHandler)
 
 	// the query frontend needs to have knowledge of the blocks, so it can shard search jobs
 	t.store.EnablePolling(nil)
@@ -416,6 +455,17 @@ func (t *App) initQueryFrontend() (services.Service, error) {
 	return t.frontend, nil
 }
 
+// initQueryFrontendTail creates the live tail endpoint, this connects directly to the ingesters, so it is kept
+// apart from the query frontend which only talks to the queriers.
+func (t *App) initQueryFrontendTail() (services.Service, error) {
+	tailer := frontend.NewTailer(t.cfg.Frontend.Tail, t.cfg.IngesterClient, t.ring, t.overrides, log.Logger)
+
+	// responses are streamed, so they are not gzipped
+	t.Server.HTTP.Handle(addHTTPAPIPrefix(&t.cfg, api.PathSearchTail), t.HTTPAuthMiddleware.Wrap(tailer))
+
+	return tailer, nil
+}
+
 func (t *App) initCompactor() (services.Service, error) {
 	if t.cfg.Target == ScalableSingleBinary && t.cfg.Compactor.ShardingRing.KVStore.Store == "" {
 		t.cfg.Compactor.ShardingRing.KVStore.Store = "memberlist"
@@ -536,6 +586,7 @@ func (t *App) setupModuleManager() error {
 	mm.RegisterModule(TracepointAPI, t.initTracepointAPI)
 	mm.RegisterModule(Querier, t.initQuerier)
 	mm.RegisterModule(QueryFrontend, t.initQueryFrontend)
+	mm.RegisterModule(QueryFrontendTail, t.initQueryFrontendTail, modules.UserInvisibleModule)
 	mm.RegisterModule(Compactor, t.initCompactor)
 	mm.RegisterModule(MetricsGenerator, t.initGenerator)
 	mm.RegisterModule(Store, t.initStore, modules.UserInvisibleModule)
@@ -548,7 +599,8 @@ func (t *App) setupModuleManager() error {
 		// Store:        nil,
 		Overrides:            {Server},
 		MemberlistKV:         {Server},
-		QueryFrontend:        {Store, Server, Overrides, UsageReport},
+		QueryFrontend:        {Store, Server, Overrides, UsageReport, QueryFrontendTail},
+		QueryFrontendTail:    {Ring, Server, Overrides},
 		Ring:                 {Server, MemberlistKV},
 		TPRing:               {Server, MemberlistKV},
 		MetricsGeneratorRing: {Server, MemberlistKV},
@@ -82,6 +82,7 @@ require (
 	github.com/grpc-ecosystem/go-grpc-middleware v1.3.0
 	github.com/intergral/go-deep-proto v1.0.2
 	golang.org/x/exp v0.0.0-20221002003631-540bb7301a08
+	google.golang.org/genproto v0.0.0-20221027153422-115e99e71e1c
 )
 
 require (
@@ -190,7 +191,6 @@ require (
 	golang.org/x/tools v0.8.1-0.20230408012837-a5338c9fa792 // indirect
 	golang.org/x/xerrors v0.0.0-20220907171357-04be3eba64a2 // indirect
 	google.golang.org/appengine v1.6.7 // indirect
-	google.golang.org/genproto v0.0.0-20221027153422-115e99e71e1c // indirect
 	gopkg.in/ini.v1 v1.66.6 // indirect
 )
 
@@ -0,0 +1,382 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package distributor
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/services"
+	"github.com/intergral/deep/pkg/deeppb"
+	cp "github.com/intergral/deep/pkg/deeppb/common/v1"
+	pb "github.com/intergral/deep/pkg/deeppb/poll/v1"
+	"github.com/intergral/deep/pkg/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// agentIDAttribute is used as the ID of the agent if it is set, otherwise the ID is a hash of the resource
+	agentIDAttribute      = "service.instance.id"
+	agentNameAttribute    = "telemetry.sdk.name"
+	agentVersionAttribute = "telemetry.sdk.version"
+
+	// agentRegistryInterval is how often stale agents are removed, the agent metrics are updated and the agents are
+	// reported to the tracepoint service
+	agentRegistryInterval = 15 * time.Second
+)
+
+var (
+	metricAgents = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "deep",
+		Subsystem: "distributor",
+		Name:      "agents",
+		Help:      "The number of agents that have polled or subscribed to this distributor, by state.",
+	}, []string{"tenant", "state"})
+	metricAgentsOutOfSync = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "deep",
+		Subsystem: "distributor",
+		Name:      "agents_out_of_sync",
+		Help:      "The number of live agents that have not applied the current tracepoints for their resource.",
+	}, []string{"tenant"})
+)
+
+// Agent is an agent that has polled or subscribed to a distributor
+type Agent struct {
+	ID           string            `json:"id"`
+	Resource     map[string]string `json:"resource"`
+	AgentName    string            `json:"agentName,omitempty"`
+	AgentVersion string            `json:"agentVersion,omitempty"`
+	FirstSeen    time.Time         `json:"firstSeen"`
+	LastSeen     time.Time         `json:"lastSeen"`
+	// Subscribed is true while the agent has an open subscription, these agents do not poll
+	Subscribed bool `json:"subscribed"`
+	// AppliedHash is the hash of the tracepoints the agent has installed
+	AppliedHash string `json:"appliedHash"`
+	// CurrentHash is the hash of the tracepoints the agent should have installed
+	CurrentHash string `json:"currentHash"`
+	// Tracepoints are the IDs of the tracepoints for the resource of the agent
+	Tracepoints []string `json:"tracepoints"`
+	InSync      bool     `json:"inSync"`
+	Stale       bool     `json:"stale"`
+}
+
+// reportAgentsFunc sends the agents of the tenant seen by this distributor to the tracepoint service
+type reportAgentsFunc func(ctx context.Context, tenantID string, agents []*deeppb.AgentInfo) error
+
+// loadAgentsFunc loads the agents of the tenant reported by all the distributors from the tracepoint service
+type loadAgentsFunc func(ctx context.Context, tenantID string) ([]*deeppb.AgentInfo, error)
+
+// agentRegistry tracks the agents of each tenant from their poll requests, agents that have not been seen for the
+// retention are removed. Each distributor only sees the agents that poll it, so the agents are periodically reported
+// to the tracepoint service and the agents of every distributor are loaded from there.
+type agentRegistry struct {
+	services.Service
+
+	cfg    AgentRegistryConfig
+	logger log.Logger
+	now    func() time.Time
+
+	// reportFunc and loadFunc are nil if there is no tracepoint service, then only the local agents are known
+	reportFunc reportAgentsFunc
+	loadFunc   loadAgentsFunc
+
+	// per-tenant agents by agent ID
+	tenants map[string]map[string]*Agent
+	mutex   sync.RWMutex
+}
+
+func newAgentRegistry(cfg AgentRegistryConfig, logger log.Logger, report reportAgentsFunc, load loadAgentsFunc) *agentRegistry {
+	r := &agentRegistry{
+		cfg:        cfg,
+		logger:     logger,
+		now:        time.Now,
+		reportFunc: report,
+		loadFunc:   load,
+		tenants:    map[string]map[string]*Agent{},
+	}
+
+	r.Service = services.NewTimerService(agentRegistryInterval, nil, r.iteration, nil)
+
+	return r
+}
+
+// Record the poll of an agent. The request hash is the hash the agent has applied, and the response hash is the hash
+// of the tracepoints it should have.
+func (r *agentRegistry) Record(tenantID string, req *pb.PollRequest, resp *pb.PollResponse) {
+	r.record(tenantID, req.GetResource().GetAttributes(), req.GetCurrentHash(), resp, false)
+}
+
+// RecordSubscription records the response sent to a subscribed agent, as the agent applies every response it is sent
+// the response hash is also the applied hash
+func (r *agentRegistry) RecordSubscription(tenantID string, req *pb.PollRequest, resp *pb.PollResponse) {
+	r.record(tenantID, req.GetResource().GetAttributes(), resp.GetCurrentHash(), resp, true)
+}
+
+// Unsubscribe marks the agent as no longer subscribed, it will become stale unless it polls or subscribes again
+func (r *agentRegistry) Unsubscribe(tenantID string, req *pb.PollRequest) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if agent, ok := r.tenants[tenantID][agentID(req.GetResource().GetAttributes())]; ok {
+		agent.Subscribed = false
+		agent.LastSeen = r.now()
+	}
+}
+
+func (r *agentRegistry) record(tenantID string, attributes []*cp.KeyValue, appliedHash string, resp *pb.PollResponse, subscribed bool) {
+	resource := make(map[string]string, len(attributes))
+	for _, kv := range attributes {
+		resource[kv.Key] = util.StringifyAnyValue(kv.Value)
+	}
+
+	tracepoints := make([]string, 0, len(resp.GetResponse()))
+	for _, config := range resp.GetResponse() {
+		tracepoints = append(tracepoints, config.ID)
+	}
+	sort.Strings(tracepoints)
+
+	now := r.now()
+	id := agentID(attributes)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	agents, ok := r.tenants[tenantID]
+	if !ok {
+		agents = map[string]*Agent{}
+		r.tenants[tenantID] = agents
+	}
+
+	agent, ok := agents[id]
+	if !ok {
+		agent = &Agent{ID: id, FirstSeen: now}
+		agents[id] = agent
+	}
+
+	agent.Resource = resource
+	agent.AgentName = resource[agentNameAttribute]
+	agent.AgentVersion = resource[agentVersionAttribute]
+	agent.LastSeen = now
+	agent.Subscribed = subscribed
+	agent.AppliedHash = appliedHash
+	agent.CurrentHash = resp.GetCurrentHash()
+	agent.Tracepoints = tracepoints
+}
+
+// Agents returns the agents of the tenant ordered by ID, including the agents reported by the other distributors. If
+// tpID is set only the agents that should have the tracepoint installed are returned.
+func (r *agentRegistry) Agents(ctx context.Context, tenantID string, tpID string) ([]Agent, error) {
+	var reported []*deeppb.AgentInfo
+	if r.loadFunc != nil {
+		var err error
+		reported, err = r.loadFunc(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := r.now()
+	byID := make(map[string]Agent, len(reported))
+	for _, info := range reported {
+		agent := fromAgentInfo(info)
+		if now.Sub(agent.LastSeen) > r.cfg.Retention {
+			continue
+		}
+		// the reporting distributor updates the last seen of subscribed agents each time it reports them, so they
+		// become stale if the distributor stops reporting them
+		agent.Stale = now.Sub(agent.LastSeen) > r.cfg.StaleAfter
+		byID[agent.ID] = agent
+	}
+
+	r.mutex.RLock()
+	for _, agent := range r.tenants[tenantID] {
+		// our own view of the agent is newer than our last report, unless the agent has since moved to another
+		// distributor
+		if other, ok := byID[agent.ID]; ok && other.LastSeen.After(r.lastSeen(agent, now)) {
+			continue
+		}
+
+		copied := *agent
+		copied.Stale = r.isStale(agent, now)
+		byID[agent.ID] = copied
+	}
+	r.mutex.RUnlock()
+
+	agents := make([]Agent, 0, len(byID))
+	for _, agent := range byID {
+		if tpID != "" && !contains(agent.Tracepoints, tpID) {
+			continue
+		}
+
+		agent.InSync = agent.AppliedHash == agent.CurrentHash
+		agents = append(agents, agent)
+	}
+
+	sort.Slice(agents, func(i, j int) bool {
+		return agents[i].ID < agents[j].ID
+	})
+
+	return agents, nil
+}
+
+func (r *agentRegistry) isStale(agent *Agent, now time.Time) bool {
+	return !agent.Subscribed && now.Sub(agent.LastSeen) > r.cfg.StaleAfter
+}
+
+// lastSeen is when the agent was last seen, an agent with an open subscription is seen now
+func (r *agentRegistry) lastSeen(agent *Agent, now time.Time) time.Time {
+	if agent.Subscribed {
+		return now
+	}
+	return agent.LastSeen
+}
+
+// iteration removes the agents that have not been seen for the retention, updates the metrics and reports the agents
+// to the tracepoint service
+func (r *agentRegistry) iteration(ctx context.Context) error {
+	reports := r.prune()
+
+	for tenantID, agents := range reports {
+		err := r.reportFunc(ctx, tenantID, agents)
+		if err != nil {
+			level.Error(r.logger).Log("msg", "failed to report agents", "tenant", tenantID, "err", err)
+		}
+	}
+
+	return nil
+}
+
+// prune removes the agents that have not been seen for the retention and updates the metrics. The remaining agents of
+// each tenant are returned, if there is a tracepoint service to report them to.
+func (r *agentRegistry) prune() map[string][]*deeppb.AgentInfo {
+	now := r.now()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var reports map[string][]*deeppb.AgentInfo
+	if r.reportFunc != nil {
+		reports = make(map[string][]*deeppb.AgentInfo, len(r.tenants))
+	}
+
+	for tenantID, agents := range r.tenants {
+		live, stale, outOfSync := 0, 0, 0
+		for id, agent := range agents {
+			if !agent.Subscribed && now.Sub(agent.LastSeen) > r.cfg.Retention {
+				delete(agents, id)
+				continue
+			}
+
+			if r.isStale(agent, now) {
+				stale++
+				continue
+			}
+
+			live++
+			if agent.AppliedHash != agent.CurrentHash {
+				outOfSync++
+			}
+		}
+
+		if reports != nil {
+			for _, agent := range agents {
+				reports[tenantID] = append(reports[tenantID], r.toAgentInfo(agent, now))
+			}
+		}
+
+		if len(agents) == 0 {
+			delete(r.tenants, tenantID)
+			metricAgents.DeleteLabelValues(tenantID, "live")
+			metricAgents.DeleteLabelValues(tenantID, "stale")
+			metricAgentsOutOfSync.DeleteLabelValues(tenantID)
+			continue
+		}
+
+		metricAgents.WithLabelValues(tenantID, "live").Set(float64(live))
+		metricAgents.WithLabelValues(tenantID, "stale").Set(float64(stale))
+		metricAgentsOutOfSync.WithLabelValues(tenantID).Set(float64(outOfSync))
+	}
+
+	return reports
+}
+
+func (r *agentRegistry) toAgentInfo(agent *Agent, now time.Time) *deeppb.AgentInfo {
+	return &deeppb.AgentInfo{
+		ID:             agent.ID,
+		Resource:       agent.Resource,
+		FirstSeenNanos: uint64(agent.FirstSeen.UnixNano()),
+		LastSeenNanos:  uint64(r.lastSeen(agent, now).UnixNano()),
+		Subscribed:     agent.Subscribed,
+		AppliedHash:    agent.AppliedHash,
+		CurrentHash:    agent.CurrentHash,
+		Tracepoints:    agent.Tracepoints,
+	}
+}
+
+func fromAgentInfo(info *deeppb.AgentInfo) Agent {
+	return Agent{
+		ID:           info.ID,
+		Resource:     info.Resource,
+		AgentName:    info.Resource[agentNameAttribute],
+		AgentVersion: info.Resource[agentVersionAttribute],
+		FirstSeen:    time.Unix(0, int64(info.FirstSeenNanos)),
+		LastSeen:     time.Unix(0, int64(info.LastSeenNanos)),
+		Subscribed:   info.Subscribed,
+		AppliedHash:  info.AppliedHash,
+		CurrentHash:  info.CurrentHash,
+		Tracepoints:  info.Tracepoints,
+	}
+}
+
+// agentID returns the service.instance.id of the resource, or a hash of the resource if it is not set
+func agentID(attributes []*cp.KeyValue) string {
+	for _, kv := range attributes {
+		if kv.Key == agentIDAttribute && kv.Value.GetStringValue() != "" {
+			return kv.Value.GetStringValue()
+		}
+	}
+
+	keys := make([]string, 0, len(attributes))
+	values := make(map[string]string, len(attributes))
+	for _, kv := range attributes {
+		keys = append(keys, kv.Key)
+		values[kv.Key] = util.StringifyAnyValue(kv.Value)
+	}
+	sort.Strings(keys)
+
+	h := fnv.New64a()
+	for _, key := range keys {
+		_, _ = h.Write([]byte(key))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(values[key]))
+		_, _ = h.Write([]byte{0})
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+func contains(values []string, value string) bool {
+	i := sort.SearchStrings(values, value)
+	return i < len(values) && values[i] == value
+}
@@ -0,0 +1,239 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package distributor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/intergral/deep/pkg/deeppb"
+	cp "github.com/intergral/deep/pkg/deeppb/common/v1"
+	pb "github.com/intergral/deep/pkg/deeppb/poll/v1"
+	rp "github.com/intergral/deep/pkg/deeppb/resource/v1"
+	tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/intergral/deep/pkg/util"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pollRequest(hash string, attributes map[string]string) *pb.PollRequest {
+	resource := &rp.Resource{}
+	for key, value := range attributes {
+		resource.Attributes = append(resource.Attributes, &cp.KeyValue{Key: key, Value: &cp.AnyValue{Value: &cp.AnyValue_StringValue{StringValue: value}}})
+	}
+	return &pb.PollRequest{CurrentHash: hash, Resource: resource}
+}
+
+func pollResponse(hash string, tpIDs ...string) *pb.PollResponse {
+	response := &pb.PollResponse{CurrentHash: hash}
+	for _, id := range tpIDs {
+		response.Response = append(response.Response, &tp.TracePointConfig{ID: id})
+	}
+	return response
+}
+
+func loadAgents(t *testing.T, registry *agentRegistry, tenantID string, tpID string) []Agent {
+	agents, err := registry.Agents(context.Background(), tenantID, tpID)
+	require.NoError(t, err)
+	return agents
+}
+
+func TestAgentRegistryRecord(t *testing.T) {
+	now := time.Unix(1000, 0)
+	registry := newAgentRegistry(AgentRegistryConfig{StaleAfter: time.Minute, Retention: 10 * time.Minute}, log.NewNopLogger(), nil, nil)
+	registry.now = func() time.Time { return now }
+
+	pod1 := map[string]string{"service.instance.id": "pod-1", "telemetry.sdk.name": "deep", "telemetry.sdk.version": "1.2.0"}
+	pod2 := map[string]string{"service.name": "shop"}
+
+	// the first poll has no hash, so the agent has not applied the tracepoints yet
+	registry.Record("tenant", pollRequest("", pod1), pollResponse("abc", "tp-2", "tp-1"))
+	registry.Record("tenant", pollRequest("old", pod2), pollResponse("abc", "tp-1"))
+	registry.Record("other", pollRequest("abc", pod2), pollResponse("abc"))
+
+	agents := loadAgents(t, registry, "tenant", "")
+	require.Len(t, agents, 2)
+	assert.Equal(t, Agent{
+		ID:           "pod-1",
+		Resource:     pod1,
+		AgentName:    "deep",
+		AgentVersion: "1.2.0",
+		FirstSeen:    now,
+		LastSeen:     now,
+		AppliedHash:  "",
+		CurrentHash:  "abc",
+		Tracepoints:  []string{"tp-1", "tp-2"},
+		InSync:       false,
+		Stale:        false,
+	}, agents[1])
+	// without an instance ID the agent is identified by its resource
+	assert.Equal(t, agentID(pollRequest("", pod2).Resource.Attributes), agents[0].ID)
+
+	// the next poll reports the applied hash
+	now = now.Add(30 * time.Second)
+	registry.Record("tenant", pollRequest("abc", pod1), pollResponse("abc", "tp-1", "tp-2"))
+
+	agents = loadAgents(t, registry, "tenant", "tp-2")
+	require.Len(t, agents, 1)
+	assert.Equal(t, "pod-1", agents[0].ID)
+	assert.True(t, agents[0].InSync)
+	assert.Equal(t, time.Unix(1000, 0), agents[0].FirstSeen)
+	assert.Equal(t, now, agents[0].LastSeen)
+
+	assert.Len(t, loadAgents(t, registry, "other", ""), 1)
+	assert.Empty(t, loadAgents(t, registry, "unknown", ""))
+}
+
+func TestAgentRegistryStaleness(t *testing.T) {
+	now := time.Unix(1000, 0)
+	registry := newAgentRegistry(AgentRegistryConfig{StaleAfter: time.Minute, Retention: 10 * time.Minute}, log.NewNopLogger(), nil, nil)
+	registry.now = func() time.Time { return now }
+
+	polling := pollRequest("old", map[string]string{"service.instance.id": "polling"})
+	subscribed := pollRequest("", map[string]string{"service.instance.id": "subscribed"})
+
+	registry.Record("tenant", polling, pollResponse("abc", "tp-1"))
+	registry.RecordSubscription("tenant", subscribed, pollResponse("abc", "tp-1"))
+
+	require.NoError(t, registry.iteration(context.Background()))
+	assert.Equal(t, 2.0, testutil.ToFloat64(metricAgents.WithLabelValues("tenant", "live")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metricAgents.WithLabelValues("tenant", "stale")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metricAgentsOutOfSync.WithLabelValues("tenant")))
+
+	// subscribed agents do not poll, so they are not stale while the subscription is open
+	now = now.Add(2 * time.Minute)
+	agents := loadAgents(t, registry, "tenant", "")
+	require.Len(t, agents, 2)
+	assert.True(t, agents[0].Stale)
+	assert.False(t, agents[1].Stale)
+	assert.True(t, agents[1].Subscribed)
+	assert.True(t, agents[1].InSync)
+
+	require.NoError(t, registry.iteration(context.Background()))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metricAgents.WithLabelValues("tenant", "live")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metricAgents.WithLabelValues("tenant", "stale")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metricAgentsOutOfSync.WithLabelValues("tenant")))
+
+	// after the retention the agents are removed, once they have unsubscribed
+	registry.Unsubscribe("tenant", subscribed)
+	now = now.Add(9 * time.Minute)
+	require.NoError(t, registry.iteration(context.Background()))
+	agents = loadAgents(t, registry, "tenant", "")
+	require.Len(t, agents, 1)
+	assert.Equal(t, "subscribed", agents[0].ID)
+
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, registry.iteration(context.Background()))
+	assert.Empty(t, loadAgents(t, registry, "tenant", ""))
+}
+
+func TestAgentsHandler(t *testing.T) {
+	d := &Distributor{agents: newAgentRegistry(AgentRegistryConfig{StaleAfter: time.Minute, Retention: 10 * time.Minute}, log.NewNopLogger(), nil, nil)}
+	d.agents.Record("tenant", pollRequest("abc", map[string]string{"service.instance.id": "pod-1"}), pollResponse("abc", "tp-1"))
+	d.agents.Record("tenant", pollRequest("abc", map[string]string{"service.instance.id": "pod-2"}), pollResponse("abc", "tp-2"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/agents?tracepoint=tp-2", nil)
+	req = req.WithContext(util.InjectTenantID(req.Context(), "tenant"))
+	w := httptest.NewRecorder()
+	d.AgentsHandler(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	response := AgentsResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Agents, 1)
+	assert.Equal(t, "pod-2", response.Agents[0].ID)
+	assert.True(t, response.Agents[0].InSync)
+}
+
+// sharedAgents stands in for the tracepoint service, keeping the latest report of each agent
+type sharedAgents struct {
+	mutex  sync.Mutex
+	agents map[string]*deeppb.AgentInfo
+}
+
+func (s *sharedAgents) report(_ context.Context, _ string, agents []*deeppb.AgentInfo) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, agent := range agents {
+		if existing, ok := s.agents[agent.ID]; !ok || existing.LastSeenNanos <= agent.LastSeenNanos {
+			s.agents[agent.ID] = agent
+		}
+	}
+	return nil
+}
+
+func (s *sharedAgents) load(context.Context, string) ([]*deeppb.AgentInfo, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	agents := make([]*deeppb.AgentInfo, 0, len(s.agents))
+	for _, agent := range s.agents {
+		agents = append(agents, agent)
+	}
+	return agents, nil
+}
+
+func TestAgentRegistrySharedBetweenDistributors(t *testing.T) {
+	now := time.Unix(1000, 0)
+	shared := &sharedAgents{agents: map[string]*deeppb.AgentInfo{}}
+	cfg := AgentRegistryConfig{StaleAfter: time.Minute, Retention: 10 * time.Minute}
+	registryA := newAgentRegistry(cfg, log.NewNopLogger(), shared.report, shared.load)
+	registryA.now = func() time.Time { return now }
+	registryB := newAgentRegistry(cfg, log.NewNopLogger(), shared.report, shared.load)
+	registryB.now = func() time.Time { return now }
+
+	pod1 := pollRequest("abc", map[string]string{"service.instance.id": "pod-1"})
+	pod2 := pollRequest("", map[string]string{"service.instance.id": "pod-2"})
+	registryA.Record("tenant", pod1, pollResponse("abc", "tp-1"))
+	registryA.RecordSubscription("tenant", pod2, pollResponse("abc", "tp-1"))
+
+	// the agents of A are only known to B once A has reported them
+	assert.Empty(t, loadAgents(t, registryB, "tenant", ""))
+	require.NoError(t, registryA.iteration(context.Background()))
+
+	seen := loadAgents(t, registryB, "tenant", "")
+	require.Len(t, seen, 2)
+	assert.Equal(t, "pod-1", seen[0].ID)
+	assert.True(t, seen[0].InSync)
+	assert.Equal(t, now, seen[0].LastSeen)
+	assert.True(t, seen[1].Subscribed)
+
+	// pod-1 moves to B, the latest poll is used by both distributors
+	now = now.Add(30 * time.Second)
+	registryB.Record("tenant", pollRequest("old", map[string]string{"service.instance.id": "pod-1"}), pollResponse("def", "tp-1"))
+	require.NoError(t, registryB.iteration(context.Background()))
+	for _, registry := range []*agentRegistry{registryA, registryB} {
+		seen = loadAgents(t, registry, "tenant", "")
+		require.Len(t, seen, 2)
+		assert.Equal(t, now, seen[0].LastSeen)
+		assert.False(t, seen[0].InSync)
+	}
+
+	// A stops reporting, so its subscribed agent becomes stale for the other distributors
+	now = now.Add(2 * time.Minute)
+	seen = loadAgents(t, registryB, "tenant", "")
+	require.Len(t, seen, 2)
+	assert.True(t, seen[1].Stale)
+	assert.False(t, loadAgents(t, registryA, "tenant", "")[1].Stale)
+}
@@ -46,6 +46,15 @@ type Config struct {
 
 	Forwarders forwarder.ConfigList `yaml:"forwarders"`
 
+	// how often the snapshot counts for each tracepoint are sent to the tracepoint service
+	SnapshotCountFlushInterval time.Duration `yaml:"snapshot_count_flush_interval"`
+
+	// how often subscribed agents reload their tracepoints, in case a change from the tracepoint service was missed
+	PollSubscriptionResyncInterval time.Duration `yaml:"poll_subscription_resync_interval"`
+
+	// tracks the agents that poll this distributor
+	AgentRegistry AgentRegistryConfig `yaml:"agent_registry"`
+
 	// disables write extension with inactive ingesters. Use this along with ingester.lifecycler.unregister_on_shutdown = true
 	//  note that setting these two config values reduces tolerance to failures on rollout b/c there is always one guaranteed to be failing replica
 	ExtendWrites bool `yaml:"extend_writes"`
@@ -54,6 +63,13 @@ type Config struct {
 	factory func(addr string) (ring_client.PoolClient, error) `yaml:"-"`
 }
 
+type AgentRegistryConfig struct {
+	// how long after its last poll an agent is reported as stale
+	StaleAfter time.Duration `yaml:"stale_after"`
+	// how long after its last poll an agent is removed
+	Retention time.Duration `yaml:"retention"`
+}
+
 type LogReceivedSnapshotsConfig struct {
 	Enabled              bool `yaml:"enabled"`
 	IncludeAllAttributes bool `yaml:"include_all_attributes"`
@@ -70,4 +86,8 @@ func (cfg *Config) RegisterFlagsAndApplyDefaults(prefix string, f *flag.FlagSet)
 
 	f.BoolVar(&cfg.LogReceivedSnapshots.Enabled, util.PrefixConfig(prefix, "log-received-snapshots.enabled"), false, "Enable to log every received snapshot to help debug ingestion using the logs.")
 	f.BoolVar(&cfg.LogReceivedSnapshots.IncludeAllAttributes, util.PrefixConfig(prefix, "log-received-snapshots.include-attributes"), false, "Enable to include snapshot attributes in the logs.")
+	f.DurationVar(&cfg.SnapshotCountFlushInterval, util.PrefixConfig(prefix, "snapshot-count-flush-interval"), 10*time.Second, "How often the snapshot counts for each tracepoint are sent to the tracepoint service.")
+	f.DurationVar(&cfg.PollSubscriptionResyncInterval, util.PrefixConfig(prefix, "poll-subscription-resync-interval"), time.Minute, "How often subscribed agents reload their tracepoints, in case a change was missed.")
+	f.DurationVar(&cfg.AgentRegistry.StaleAfter, util.PrefixConfig(prefix, "agent-registry.stale-after"), time.Minute, "How long after its last poll an agent is reported as stale.")
+	f.DurationVar(&cfg.AgentRegistry.Retention, util.PrefixConfig(prefix, "agent-registry.retention"), 15*time.Minute, "How long after its last poll an agent is removed from the agent registry.")
 }
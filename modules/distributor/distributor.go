@@ -27,6 +27,7 @@ import (
 	"github.com/intergral/deep/modules/distributor/snapshotreceiver"
 	"github.com/intergral/deep/modules/tracepoint/client"
 	deeppb_poll "github.com/intergral/deep/pkg/deeppb/poll/v1"
+	"github.com/intergral/deep/pkg/receivers/types"
 	"github.com/intergral/deep/pkg/util"
 	pb "github.com/intergral/go-deep-proto/poll/v1"
 	tp "github.com/intergral/go-deep-proto/tracepoint/v1"
@@ -63,6 +64,8 @@ const (
 	reasonSnapshotTooLarge = "snapshot_too_large"
 	// reasonLiveSnapshotsExceeded indicates that deep is already tracking too many live snapshots in the ingesters for this user
 	reasonLiveSnapshotsExceeded = "live_snapshots_exceeded"
+	// reasonTracepointRateLimited indicates that the snapshots/second or bytes/second of a single tracepoint exceeded its limits
+	reasonTracepointRateLimited = "tracepoint_rate_limited"
 	// reasonInternalError indicates an unexpected error occurred processing this snapshot, analogous to a 500
 	reasonInternalError = "internal_error"
 
@@ -157,8 +160,21 @@ type Distributor struct {
 	// Generic Forwarder
 	forwardersManager *forwarder.Manager
 
+	// counts the snapshots for each tracepoint, so they can be retired
+	snapshotCounter *snapshotCounter
+
+	// pushes tracepoint changes to subscribed agents
+	pollSubscriptions *pollSubscriptions
+
+	// tracks the agents that poll this distributor
+	agents *agentRegistry
+
 	// Per-tenant rate limiter.
 	ingestionRateLimiter *limiter.RateLimiter
+	// Per-tracepoint rate limiter.
+	tracepointRateLimiter *tracepointRateLimiter
+	// masks or drops variables using the per-tenant redaction rules
+	redactor *redactor
 
 	// Manager for subservices
 	subservices        *services.Manager
@@ -184,6 +200,7 @@ func New(cfg Config, tpClient *client.TPClient, clientCfg ingester_client.Config
 	// Create the configured ingestion rate limit strategy (local or global).
 	var ingestionRateStrategy limiter.RateLimiterStrategy
 	var distributorRing *ring.Ring
+	var distributorLifecycler ReadLifecycler
 
 	// using global ingestion rate means we monitor the number of distributor instances with a ring and divide
 	// the ingest rate between the instances
@@ -196,6 +213,7 @@ func New(cfg Config, tpClient *client.TPClient, clientCfg ingester_client.Config
 		}
 		subServices = append(subServices, lifecycler)
 		ingestionRateStrategy = newGlobalIngestionRateStrategy(o, lifecycler)
+		distributorLifecycler = lifecycler
 
 		newRing, err := ring.New(lifecyclerCfg.RingConfig, "distributor", cfg.OverrideRingKey, logger, prometheus.WrapRegistererWithPrefix("deep_", reg))
 		if err != nil {
@@ -218,19 +236,29 @@ func New(cfg Config, tpClient *client.TPClient, clientCfg ingester_client.Config
 	subServices = append(subServices, pool)
 
 	d := &Distributor{
-		cfg:                  cfg,
-		clientCfg:            clientCfg,
-		ingestersRing:        ingestersRing,
-		pool:                 pool,
-		DistributorRing:      distributorRing,
-		ingestionRateLimiter: limiter.NewRateLimiter(ingestionRateStrategy, 10*time.Second),
-		generatorClientCfg:   generatorClientCfg,
-		generatorsRing:       generatorsRing,
-		overrides:            o,
-		snapshotEncoder:      model.MustNewSegmentDecoder(model.CurrentEncoding),
-		logger:               logger,
-		tpClient:             tpClient,
+		cfg:                   cfg,
+		clientCfg:             clientCfg,
+		ingestersRing:         ingestersRing,
+		pool:                  pool,
+		DistributorRing:       distributorRing,
+		ingestionRateLimiter:  limiter.NewRateLimiter(ingestionRateStrategy, 10*time.Second),
+		tracepointRateLimiter: newTracepointRateLimiter(distributorLifecycler),
+		redactor:              newRedactor(logger),
+		generatorClientCfg:    generatorClientCfg,
+		generatorsRing:        generatorsRing,
+		overrides:             o,
+		snapshotEncoder:       model.MustNewSegmentDecoder(model.CurrentEncoding),
+		logger:                logger,
+		tpClient:              tpClient,
+	}
+
+	// the agents are shared with the other distributors through the tracepoint service
+	var reportAgents reportAgentsFunc
+	var loadAgents loadAgentsFunc
+	if tpClient != nil {
+		reportAgents, loadAgents = d.reportAgents, d.loadAgents
 	}
+	d.agents = newAgentRegistry(cfg.AgentRegistry, logger, reportAgents, loadAgents)
 
 	// this pool lets the distributor generate metrics via the generator client
 	d.generatorsPool = ring_client.NewPool(
@@ -244,7 +272,7 @@ func New(cfg Config, tpClient *client.TPClient, clientCfg ingester_client.Config
 		logger,
 	)
 
-	subServices = append(subServices, d.generatorsPool)
+	subServices = append(subServices, d.generatorsPool, d.tracepointRateLimiter, d.agents)
 	// create forwarder for metrics generator
 	d.generatorForwarder = newGeneratorForwarder(logger, d.sendToGenerators, o)
 	subServices = append(subServices, d.generatorForwarder)
@@ -257,6 +285,14 @@ func New(cfg Config, tpClient *client.TPClient, clientCfg ingester_client.Config
 	d.forwardersManager = forwardersManager
 	subServices = append(subServices, d.forwardersManager)
 
+	if tpClient != nil {
+		d.snapshotCounter = newSnapshotCounter(logger, d.recordSnapshots, cfg.SnapshotCountFlushInterval)
+		subServices = append(subServices, d.snapshotCounter)
+
+		d.pollSubscriptions = newPollSubscriptions(logger, d.loadTracepoints, d.watchTracepoints, cfg.PollSubscriptionResyncInterval)
+		subServices = append(subServices, d.pollSubscriptions)
+	}
+
 	// setup receivers
 	cfgReceivers := cfg.Receivers
 	if len(cfgReceivers) == 0 {
@@ -334,7 +370,13 @@ func (d *Distributor) PushPoll(ctx context.Context, pollRequest *pb.PollRequest)
 		return nil, err
 	}
 
-	request := &deeppb.LoadTracepointRequest{Request: req}
+	// the hash sent to the agent includes the limits, see withTracepointLimits
+	snapshotRateLimit, bytesRateLimit := d.overrides.TracepointSnapshotRateLimit(tenantID), d.overrides.TracepointIngestionRateLimitBytes(tenantID)
+	request := &deeppb.LoadTracepointRequest{Request: &deeppb_poll.PollRequest{
+		TsNanos:     req.TsNanos,
+		CurrentHash: tracepointServiceHash(req.CurrentHash, snapshotRateLimit, bytesRateLimit),
+		Resource:    req.Resource,
+	}}
 
 	size := proto.Size(pollRequest)
 	metricPollBytesIngested.WithLabelValues(tenantID).Add(float64(size))
@@ -344,7 +386,8 @@ func (d *Distributor) PushPoll(ctx context.Context, pollRequest *pb.PollRequest)
 		return nil, err
 	}
 
-	response := tracepoints.GetResponse()
+	response := withTracepointLimits(tracepoints.GetResponse(), snapshotRateLimit, bytesRateLimit)
+	d.agents.Record(tenantID, req, response)
 
 	responseSize := proto.Size(response)
 	metricPollBytesResponded.WithLabelValues(tenantID).Add(float64(responseSize))
@@ -360,6 +403,83 @@ func (d *Distributor) PushPoll(ctx context.Context, pollRequest *pb.PollRequest)
 	return resp, nil
 }
 
+// PushSubscribe will send the tracepoints for the agent each time they change, until the agent disconnects
+func (d *Distributor) PushSubscribe(ctx context.Context, pollRequest *deeppb_poll.PollRequest, send types.PollSender) error {
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if d.pollSubscriptions == nil {
+		return status.Error(codes.Unimplemented, "tracepoint subscriptions are not available")
+	}
+
+	defer d.agents.Unsubscribe(tenantID, pollRequest)
+
+	// the hash sent to the agent includes the limits, see withTracepointLimits
+	subscribeRequest := &deeppb_poll.PollRequest{
+		TsNanos:     pollRequest.TsNanos,
+		CurrentHash: tracepointServiceHash(pollRequest.CurrentHash, d.overrides.TracepointSnapshotRateLimit(tenantID), d.overrides.TracepointIngestionRateLimitBytes(tenantID)),
+		Resource:    pollRequest.Resource,
+	}
+
+	return d.pollSubscriptions.Subscribe(ctx, tenantID, subscribeRequest, func(response *deeppb_poll.PollResponse) error {
+		response = withTracepointLimits(response, d.overrides.TracepointSnapshotRateLimit(tenantID), d.overrides.TracepointIngestionRateLimitBytes(tenantID))
+		metricPollBytesResponded.WithLabelValues(tenantID).Add(float64(proto.Size(response)))
+		d.agents.RecordSubscription(tenantID, pollRequest, response)
+		return send(response)
+	})
+}
+
+// maxSnapshotBytes is the size of the largest snapshot the tenant can send, this is limited by both the max snapshot
+// size and the burst of the tenant ingestion limit
+func (d *Distributor) maxSnapshotBytes(tenantID string) int {
+	maxBytes := d.overrides.IngestionBurstSizeBytes(tenantID)
+	if perSnapshot := d.overrides.MaxBytesPerSnapshot(tenantID); perSnapshot > 0 && perSnapshot < maxBytes {
+		maxBytes = perSnapshot
+	}
+	return maxBytes
+}
+
+// PushStatus sends the tracepoint install status reported by an agent to the tracepoint service
+func (d *Distributor) PushStatus(ctx context.Context, req *deeppb.ReportStatusRequest) (*deeppb.ReportStatusResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "distributor.PushStatus")
+	defer span.Finish()
+
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	span.SetTag("tenantID", tenantID)
+
+	if d.tpClient == nil {
+		return nil, status.Error(codes.Unimplemented, "tracepoint status is not available")
+	}
+
+	_, err = d.tpClient.RecordInstallStatus(ctx, &deeppb.RecordInstallStatusRequest{
+		AgentID: agentID(req.GetResource().GetAttributes()),
+		Status:  req.GetStatus(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &deeppb.ReportStatusResponse{}, nil
+}
+
+// loadTracepoints will load the tracepoints for a subscribed agent
+func (d *Distributor) loadTracepoints(ctx context.Context, pollRequest *deeppb_poll.PollRequest) (*deeppb_poll.PollResponse, error) {
+	tracepoints, err := d.tpClient.LoadTracepoints(ctx, &deeppb.LoadTracepointRequest{Request: pollRequest})
+	if err != nil {
+		return nil, err
+	}
+	return tracepoints.GetResponse(), nil
+}
+
+func (d *Distributor) watchTracepoints(ctx context.Context) (watchStream, error) {
+	return d.tpClient.WatchTracepoints(ctx, &deeppb.WatchTracepointsRequest{})
+}
+
 func (d *Distributor) PushSnapshot(ctx context.Context, in *tp.Snapshot) (*tp.SnapshotResponse, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "distributor.PushSnapshot")
 	defer span.Finish()
@@ -386,6 +506,9 @@ func (d *Distributor) PushSnapshot(ctx context.Context, in *tp.Snapshot) (*tp.Sn
 		return nil, err
 	}
 
+	// redact before anything else sees the snapshot, including the received snapshot logging
+	d.redactor.Redact(tenantID, d.overrides.RedactionRules(tenantID), snapshot)
+
 	if d.cfg.LogReceivedSnapshots.Enabled {
 		if d.cfg.LogReceivedSnapshots.IncludeAllAttributes {
 			logSnapshotWithAllAttributes(snapshot, d.logger)
@@ -397,9 +520,24 @@ func (d *Distributor) PushSnapshot(ctx context.Context, in *tp.Snapshot) (*tp.Sn
 	size := proto.Size(snapshot)
 	metricSnapshotBytesIngested.WithLabelValues(tenantID).Add(float64(size))
 
-	// check limits
+	// check limits, the tracepoint limits are checked first so a noisy tracepoint does not use the tenant limit. The
+	// tokens taken from the tracepoint limit are given back if the tenant limit rejects the snapshot.
 	now := time.Now()
+	snapshotLimit, bytesLimit := tracepointLimits(snapshot.GetTracepoint(), d.overrides.TracepointSnapshotRateLimit(tenantID), d.overrides.TracepointIngestionRateLimitBytes(tenantID))
+	allowed, cancel := d.tracepointRateLimiter.ReserveN(now, tenantID, snapshot.GetTracepoint().GetID(), snapshotLimit, bytesLimit, d.maxSnapshotBytes(tenantID), size)
+	if !allowed {
+		metricDiscardedSnapshots.WithLabelValues(reasonTracepointRateLimited, tenantID).Add(1)
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"%s tracepoint %s ingestion rate limit (%v snapshots, %d bytes) exceeded while adding %d bytes",
+			overrides.ErrorPrefixRateLimited,
+			snapshot.GetTracepoint().GetID(),
+			snapshotLimit,
+			bytesLimit,
+			size)
+	}
+
 	if !d.ingestionRateLimiter.AllowN(now, tenantID, size) {
+		cancel()
 		metricDiscardedSnapshots.WithLabelValues(reasonRateLimited, tenantID).Add(1)
 		return nil, status.Errorf(codes.ResourceExhausted,
 			"%s ingestion rate limit (%d bytes) exceeded while adding %d bytes",
@@ -420,17 +558,42 @@ func (d *Distributor) PushSnapshot(ctx context.Context, in *tp.Snapshot) (*tp.Sn
 		return nil, err
 	}
 
+	if d.snapshotCounter != nil {
+		d.snapshotCounter.Inc(tenantID, snapshot.GetTracepoint().GetID())
+	}
+
 	if len(d.overrides.MetricsGeneratorProcessors(tenantID)) > 0 {
 		d.generatorForwarder.SendSnapshot(ctx, tenantID, keys, snapshot)
 	}
 
 	if err := d.forwardersManager.ForTenant(tenantID).ForwardSnapshot(ctx, snapshot); err != nil {
-		_ = level.Warn(d.logger).Log("msg", "failed to forward batches for tenant=%s: %w", tenantID, err)
+		_ = level.Warn(d.logger).Log("msg", "failed to forward snapshot", "tenant", tenantID, "err", err)
 	}
 
 	return &tp.SnapshotResponse{}, nil
 }
 
+// recordSnapshots sends the snapshot counts to the tracepoint service
+func (d *Distributor) recordSnapshots(ctx context.Context, tenantID string, counts map[string]uint64) error {
+	_, err := d.tpClient.RecordSnapshots(util.InjectTenantID(ctx, tenantID), &deeppb.RecordSnapshotsRequest{Counts: counts})
+	return err
+}
+
+// reportAgents sends the agents seen by this distributor to the tracepoint service
+func (d *Distributor) reportAgents(ctx context.Context, tenantID string, agents []*deeppb.AgentInfo) error {
+	_, err := d.tpClient.RecordAgents(util.InjectTenantID(ctx, tenantID), &deeppb.RecordAgentsRequest{Agents: agents})
+	return err
+}
+
+// loadAgents loads the agents reported by all the distributors from the tracepoint service
+func (d *Distributor) loadAgents(ctx context.Context, tenantID string) ([]*deeppb.AgentInfo, error) {
+	response, err := d.tpClient.LoadAgents(util.InjectTenantID(ctx, tenantID), &deeppb.LoadAgentsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return response.Agents, nil
+}
+
 func extractKeys(snapshot *deeppb_tp.Snapshot, userId string) ([]uint32, error) {
 	keys := make([]uint32, 1)
 
@@ -22,17 +22,20 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/intergral/deep/modules/distributor/forwarder/loki"
 	"github.com/intergral/deep/modules/distributor/forwarder/otlpgrpc"
 )
 
 const (
 	OTLPGRPCBackend = "otlpgrpc"
+	LokiBackend     = "loki"
 )
 
 type Config struct {
 	Name     string          `yaml:"name"`
 	Backend  string          `yaml:"backend"`
 	OTLPGRPC otlpgrpc.Config `yaml:"otlpgrpc"`
+	Loki     loki.Config     `yaml:"loki"`
 }
 
 func (cfg *Config) Validate() error {
@@ -43,6 +46,8 @@ func (cfg *Config) Validate() error {
 	switch cfg.Backend {
 	case OTLPGRPCBackend:
 		return cfg.OTLPGRPC.Validate()
+	case LokiBackend:
+		return cfg.Loki.Validate()
 	default:
 	}
 
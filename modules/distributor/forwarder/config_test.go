@@ -22,6 +22,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/intergral/deep/modules/distributor/forwarder/loki"
 	"github.com/intergral/deep/modules/distributor/forwarder/otlpgrpc"
 )
 
@@ -30,6 +31,7 @@ func TestConfig_Validate(t *testing.T) {
 		Name     string
 		Backend  string
 		OTLPGRPC otlpgrpc.Config
+		Loki     loki.Config
 	}
 	tests := []struct {
 		name    string
@@ -65,6 +67,26 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "ReturnsNoErrorWithValidLokiArguments",
+			fields: fields{
+				Name:    "test",
+				Backend: LokiBackend,
+				Loki: loki.Config{
+					Endpoint: "http://loki:3100/loki/api/v1/push",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ReturnsErrorWithInvalidLokiArguments",
+			fields: fields{
+				Name:    "test",
+				Backend: LokiBackend,
+				Loki:    loki.Config{},
+			},
+			wantErr: true,
+		},
 		{
 			name: "ReturnsErrorWithUnsupportedBackendName",
 			fields: fields{
@@ -86,6 +108,7 @@ func TestConfig_Validate(t *testing.T) {
 				Name:     tt.fields.Name,
 				Backend:  tt.fields.Backend,
 				OTLPGRPC: tt.fields.OTLPGRPC,
+				Loki:     tt.fields.Loki,
 			}
 
 			err := cfg.Validate()
@@ -28,6 +28,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/multierr"
 
+	"github.com/intergral/deep/modules/distributor/forwarder/loki"
 	"github.com/intergral/deep/modules/distributor/forwarder/otlpgrpc"
 )
 
@@ -50,9 +51,13 @@ func (l List) ForwardTraces(ctx context.Context, traces ptrace.Traces) error {
 	return multierr.Combine(errs...)
 }
 
+// ForwardSnapshot converts the snapshot to a trace and forwards it to all forwarders in the list.
 func (l List) ForwardSnapshot(ctx context.Context, snapshot *tp.Snapshot) error {
-	// todo complete this
-	return nil
+	if len(l) == 0 {
+		return nil
+	}
+
+	return l.ForwardTraces(ctx, otlpgrpc.SnapshotToTraces(snapshot))
 }
 
 func New(cfg Config, logger log.Logger) (Forwarder, error) {
@@ -73,6 +78,13 @@ func New(cfg Config, logger log.Logger) (Forwarder, error) {
 			return nil, fmt.Errorf("failed to dial: %w", err)
 		}
 
+		return f, nil
+	case LokiBackend:
+		f, err := loki.NewForwarder(cfg.Loki, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create new loki forwarder: %w", err)
+		}
+
 		return f, nil
 	default:
 		return nil, fmt.Errorf("%s backend is not supported", cfg.Backend)
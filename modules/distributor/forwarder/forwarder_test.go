@@ -24,6 +24,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/intergral/deep/pkg/util/test"
 )
 
 type mockCountingForwarder struct {
@@ -107,3 +109,32 @@ func TestList_ForwardTraces_DoesNotPanicWhenNil(t *testing.T) {
 	// Then
 	require.NotPanics(t, panicFunc)
 }
+
+func TestList_ForwardSnapshot_ForwardsConvertedSnapshotToAllUnderlyingForwarders(t *testing.T) {
+	// Given
+	forwarder1 := &mockCountingForwarder{next: &mockWorkingForwarder{}, forwardTracesCount: 0}
+	forwarder2 := &mockCountingForwarder{next: &mockWorkingForwarder{}, forwardTracesCount: 0}
+	list := List([]Forwarder{forwarder1, forwarder2})
+
+	// When
+	err := list.ForwardSnapshot(context.Background(), test.GenerateSnapshot(1, nil))
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, 1, forwarder1.forwardTracesCount)
+	require.Equal(t, 1, forwarder2.forwardTracesCount)
+}
+
+func TestList_ForwardSnapshot_DoesNotPanicWhenNil(t *testing.T) {
+	// Given
+	list := List(nil)
+
+	// When
+	panicFunc := func() {
+		err := list.ForwardSnapshot(context.Background(), test.GenerateSnapshot(1, nil))
+		require.NoError(t, err)
+	}
+
+	// Then
+	require.NotPanics(t, panicFunc)
+}
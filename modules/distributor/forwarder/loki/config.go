@@ -0,0 +1,116 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package loki
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/grafana/dskit/backoff"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultBatchSize   = 100
+	defaultBatchWait   = time.Second
+	defaultTimeout     = 10 * time.Second
+	defaultPushTimeout = time.Minute
+	defaultQueueSize   = 10
+)
+
+var defaultBackoff = backoff.Config{
+	MinBackoff: 100 * time.Millisecond,
+	MaxBackoff: 10 * time.Second,
+	MaxRetries: 5,
+}
+
+type Config struct {
+	// Endpoint is the Loki push URL, e.g. http://loki:3100/loki/api/v1/push
+	Endpoint string `yaml:"endpoint"`
+	// BatchSize is the number of log lines to buffer for a tenant before they are pushed
+	BatchSize int `yaml:"batch_size"`
+	// BatchWait is the longest time a log line is buffered before it is pushed
+	BatchWait time.Duration `yaml:"batch_wait"`
+	// Timeout is the timeout for a single push request
+	Timeout time.Duration `yaml:"timeout"`
+	// PushTimeout is the longest time a batch is retried before its log lines are dropped
+	PushTimeout time.Duration `yaml:"push_timeout"`
+	// QueueSize is the number of full batches waiting to be pushed, further batches are dropped until there is space
+	QueueSize int `yaml:"queue_size"`
+	// Backoff controls the retries of failed push requests
+	Backoff backoff.Config `yaml:"backoff"`
+	// ExternalLabels are added to every stream pushed to Loki
+	ExternalLabels map[string]string `yaml:"external_labels"`
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint is empty")
+	}
+
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return errors.Wrap(err, "endpoint is not a valid url")
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("endpoint must be an http or https url")
+	}
+
+	if cfg.BatchSize < 0 {
+		return errors.New("batch_size must not be negative")
+	}
+
+	if cfg.BatchWait < 0 {
+		return errors.New("batch_wait must not be negative")
+	}
+
+	if cfg.PushTimeout < 0 {
+		return errors.New("push_timeout must not be negative")
+	}
+
+	if cfg.QueueSize < 0 {
+		return errors.New("queue_size must not be negative")
+	}
+
+	return nil
+}
+
+// withDefaults returns a copy of the config with any unset values replaced by the defaults.
+func (cfg Config) withDefaults() Config {
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.BatchWait == 0 {
+		cfg.BatchWait = defaultBatchWait
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.PushTimeout == 0 {
+		cfg.PushTimeout = defaultPushTimeout
+	}
+	if cfg.QueueSize == 0 {
+		cfg.QueueSize = defaultQueueSize
+	}
+	if cfg.Backoff == (backoff.Config{}) {
+		cfg.Backoff = defaultBackoff
+	}
+
+	return cfg
+}
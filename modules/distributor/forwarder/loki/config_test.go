@@ -0,0 +1,85 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package loki
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{
+			name:    "ReturnsNoErrorForValidConfig",
+			cfg:     Config{Endpoint: "http://loki:3100/loki/api/v1/push"},
+			wantErr: false,
+		},
+		{
+			name:    "ReturnsErrorWithEmptyEndpoint",
+			cfg:     Config{Endpoint: ""},
+			wantErr: true,
+		},
+		{
+			name:    "ReturnsErrorWithInvalidEndpoint",
+			cfg:     Config{Endpoint: "loki:3100"},
+			wantErr: true,
+		},
+		{
+			name:    "ReturnsErrorWithNegativeBatchSize",
+			cfg:     Config{Endpoint: "http://loki:3100/loki/api/v1/push", BatchSize: -1},
+			wantErr: true,
+		},
+		{
+			name:    "ReturnsErrorWithNegativeBatchWait",
+			cfg:     Config{Endpoint: "http://loki:3100/loki/api/v1/push", BatchWait: -time.Second},
+			wantErr: true,
+		},
+		{
+			name:    "ReturnsErrorWithNegativeQueueSize",
+			cfg:     Config{Endpoint: "http://loki:3100/loki/api/v1/push", QueueSize: -1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_WithDefaults(t *testing.T) {
+	cfg := Config{Endpoint: "http://loki:3100/loki/api/v1/push", BatchSize: 10}.withDefaults()
+
+	require.Equal(t, 10, cfg.BatchSize)
+	require.Equal(t, defaultBatchWait, cfg.BatchWait)
+	require.Equal(t, defaultTimeout, cfg.Timeout)
+	require.Equal(t, defaultPushTimeout, cfg.PushTimeout)
+	require.Equal(t, defaultQueueSize, cfg.QueueSize)
+	require.Equal(t, defaultBackoff, cfg.Backoff)
+}
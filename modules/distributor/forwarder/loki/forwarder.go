@@ -0,0 +1,429 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package loki
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/backoff"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/weaveworks/common/user"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+
+	"github.com/intergral/deep/modules/distributor/forwarder/otlpgrpc"
+	"github.com/intergral/deep/pkg/util"
+)
+
+const (
+	labelService   = "service"
+	labelPod       = "pod"
+	labelNamespace = "namespace"
+	labelPath      = "path"
+	labelLine      = "line"
+
+	unknownService = "unknown_service"
+
+	maxErrMsgLen = 1024
+)
+
+var (
+	metricSentEntries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "deep",
+		Subsystem: "distributor",
+		Name:      "forwarder_loki_sent_entries_total",
+		Help:      "The total number of log lines pushed to Loki",
+	}, []string{"tenant"})
+	metricFailedEntries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "deep",
+		Subsystem: "distributor",
+		Name:      "forwarder_loki_failed_entries_total",
+		Help:      "The total number of log lines that could not be pushed to Loki",
+	}, []string{"tenant"})
+)
+
+// resourceLabels maps the resource attributes that are carried over to the Loki stream labels.
+var resourceLabels = map[string]string{
+	"service.name":       labelService,
+	"k8s.pod.name":       labelPod,
+	"k8s.namespace.name": labelNamespace,
+}
+
+// Forwarder pushes the log messages of snapshots to a Loki push endpoint. Log lines are batched per tenant and
+// pushed when the batch is full or when the oldest line has waited for the configured batch wait. Batches are only
+// pushed in the background, so forwarding a snapshot never waits on Loki.
+type Forwarder struct {
+	cfg    Config
+	logger log.Logger
+	client *http.Client
+
+	batches map[string]*batch
+	mu      sync.Mutex
+
+	// full holds the batches that are full and waiting to be pushed by run
+	full chan queuedBatch
+
+	quit     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func NewForwarder(cfg Config, logger log.Logger) (*Forwarder, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate config: %w", err)
+	}
+
+	cfg = cfg.withDefaults()
+	f := &Forwarder{
+		cfg:     cfg,
+		logger:  logger,
+		client:  &http.Client{Timeout: cfg.Timeout},
+		batches: make(map[string]*batch),
+		full:    make(chan queuedBatch, cfg.QueueSize),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	go f.run()
+
+	return f, nil
+}
+
+// ForwardTraces adds the log messages found on the spans to the batch of the tenant in the context. Spans without a
+// log message are ignored. A full batch is queued to be pushed, if the queue is full the batch is dropped.
+func (f *Forwarder) ForwardTraces(ctx context.Context, traces ptrace.Traces) error {
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to extract tenant id: %w", err)
+	}
+
+	entries := f.entriesFromTraces(traces)
+	if len(entries) == 0 {
+		return nil
+	}
+
+	f.mu.Lock()
+	b, ok := f.batches[tenantID]
+	if !ok {
+		b = newBatch()
+		f.batches[tenantID] = b
+	}
+	for _, e := range entries {
+		b.add(e)
+	}
+	if b.size < f.cfg.BatchSize {
+		f.mu.Unlock()
+		return nil
+	}
+	delete(f.batches, tenantID)
+	f.mu.Unlock()
+
+	select {
+	case f.full <- queuedBatch{tenantID: tenantID, batch: b}:
+		return nil
+	default:
+		metricFailedEntries.WithLabelValues(tenantID).Add(float64(b.size))
+		return fmt.Errorf("failed to queue %d log lines for endpoint=%s: the push queue is full", b.size, f.cfg.Endpoint)
+	}
+}
+
+// Shutdown stops the background flushing and pushes any buffered log lines.
+func (f *Forwarder) Shutdown(ctx context.Context) error {
+	f.stopOnce.Do(func() {
+		close(f.quit)
+	})
+	select {
+	case <-f.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	f.mu.Lock()
+	batches := f.batches
+	f.batches = make(map[string]*batch)
+	f.mu.Unlock()
+
+	// the full batches that are still queued are pushed first, as they are older
+	var errs []error
+	for len(f.full) > 0 {
+		q := <-f.full
+		if err := f.push(ctx, q.tenantID, q.batch); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for tenantID, b := range batches {
+		if err := f.push(ctx, tenantID, b); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return multierr.Combine(errs...)
+}
+
+func (f *Forwarder) run() {
+	defer close(f.done)
+
+	ticker := time.NewTicker(f.cfg.BatchWait / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-f.quit:
+			return
+		case q := <-f.full:
+			f.pushInBackground(q.tenantID, q.batch)
+		case now := <-ticker.C:
+			f.flushExpired(now)
+		}
+	}
+}
+
+// flushExpired pushes all batches that have been waiting for at least the batch wait.
+func (f *Forwarder) flushExpired(now time.Time) {
+	f.mu.Lock()
+	expired := make(map[string]*batch)
+	for tenantID, b := range f.batches {
+		if now.Sub(b.createdAt) >= f.cfg.BatchWait {
+			expired[tenantID] = b
+			delete(f.batches, tenantID)
+		}
+	}
+	f.mu.Unlock()
+
+	for tenantID, b := range expired {
+		f.pushInBackground(tenantID, b)
+	}
+}
+
+// pushInBackground pushes the batch with its own timeout, as there is no request waiting for the result
+func (f *Forwarder) pushInBackground(tenantID string, b *batch) {
+	ctx, cancel := context.WithTimeout(context.Background(), f.cfg.PushTimeout)
+	defer cancel()
+
+	if err := f.push(ctx, tenantID, b); err != nil {
+		_ = level.Warn(f.logger).Log("msg", "failed to push log lines to loki", "tenant", tenantID, "err", err)
+	}
+}
+
+// push sends the batch to Loki, retrying server errors and rate limiting with backoff.
+func (f *Forwarder) push(ctx context.Context, tenantID string, b *batch) error {
+	body, err := json.Marshal(b.request())
+	if err != nil {
+		metricFailedEntries.WithLabelValues(tenantID).Add(float64(b.size))
+		return fmt.Errorf("failed to marshal push request: %w", err)
+	}
+
+	var lastErr error
+	retries := backoff.New(ctx, f.cfg.Backoff)
+	for retries.Ongoing() {
+		var status int
+		status, lastErr = f.send(ctx, tenantID, body)
+		if lastErr == nil {
+			metricSentEntries.WithLabelValues(tenantID).Add(float64(b.size))
+			return nil
+		}
+
+		// client errors other than rate limiting will fail again, so do not retry them
+		if status/100 == 4 && status != http.StatusTooManyRequests {
+			break
+		}
+
+		retries.Wait()
+	}
+
+	if lastErr == nil {
+		lastErr = retries.Err()
+	}
+
+	metricFailedEntries.WithLabelValues(tenantID).Add(float64(b.size))
+	return fmt.Errorf("failed to push %d log lines to endpoint=%s: %w", b.size, f.cfg.Endpoint, lastErr)
+}
+
+func (f *Forwarder) send(ctx context.Context, tenantID string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(user.OrgIDHeaderName, tenantID)
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrMsgLen))
+		return resp.StatusCode, fmt.Errorf("server returned HTTP status %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+type queuedBatch struct {
+	tenantID string
+	batch    *batch
+}
+
+type entry struct {
+	labels map[string]string
+	ts     pcommon.Timestamp
+	line   string
+}
+
+// entriesFromTraces creates a log entry for each span that carries a log message.
+func (f *Forwarder) entriesFromTraces(traces ptrace.Traces) []entry {
+	var entries []entry
+
+	rss := traces.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resource := rs.Resource().Attributes()
+
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				logMsg, ok := span.Attributes().Get(otlpgrpc.AttrLogMsg)
+				if !ok {
+					continue
+				}
+
+				entries = append(entries, entry{
+					labels: f.labels(resource, span.Attributes()),
+					ts:     span.StartTimestamp(),
+					line:   logMsg.AsString(),
+				})
+			}
+		}
+	}
+
+	return entries
+}
+
+func (f *Forwarder) labels(resource, attributes pcommon.Map) map[string]string {
+	labels := make(map[string]string, len(f.cfg.ExternalLabels)+len(resourceLabels)+2)
+	for name, value := range f.cfg.ExternalLabels {
+		labels[name] = value
+	}
+
+	labels[labelService] = unknownService
+	for attr, name := range resourceLabels {
+		if value, ok := resource.Get(attr); ok {
+			labels[name] = value.AsString()
+		}
+	}
+
+	if value, ok := attributes.Get(otlpgrpc.AttrCodeFilepath); ok {
+		labels[labelPath] = value.AsString()
+	}
+	if value, ok := attributes.Get(otlpgrpc.AttrCodeLineno); ok {
+		labels[labelLine] = value.AsString()
+	}
+
+	return labels
+}
+
+type stream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+type pushRequest struct {
+	Streams []*stream `json:"streams"`
+}
+
+// batch holds the log lines of a single tenant grouped into streams by their labels.
+type batch struct {
+	streams   map[string]*stream
+	size      int
+	createdAt time.Time
+}
+
+func newBatch() *batch {
+	return &batch{
+		streams:   make(map[string]*stream),
+		createdAt: time.Now(),
+	}
+}
+
+func (b *batch) add(e entry) {
+	key := labelsString(e.labels)
+	s, ok := b.streams[key]
+	if !ok {
+		s = &stream{Stream: e.labels}
+		b.streams[key] = s
+	}
+
+	s.Values = append(s.Values, [2]string{fmt.Sprintf("%d", uint64(e.ts)), e.line})
+	b.size++
+}
+
+func (b *batch) request() pushRequest {
+	keys := make([]string, 0, len(b.streams))
+	for key := range b.streams {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	req := pushRequest{Streams: make([]*stream, 0, len(keys))}
+	for _, key := range keys {
+		req.Streams = append(req.Streams, b.streams[key])
+	}
+
+	return req
+}
+
+// labelsString renders the labels in the Loki stream selector format, e.g. {line="12", service="app"}
+func labelsString(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	sb.WriteString("{")
+	for i, name := range names {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(name)
+		sb.WriteString("=")
+		sb.WriteString(fmt.Sprintf("%q", labels[name]))
+	}
+	sb.WriteString("}")
+
+	return sb.String()
+}
@@ -0,0 +1,369 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package loki
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/grafana/dskit/backoff"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/user"
+
+	"github.com/intergral/deep/modules/distributor/forwarder/otlpgrpc"
+	cp "github.com/intergral/deep/pkg/deeppb/common/v1"
+	tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/intergral/deep/pkg/util"
+	"github.com/intergral/deep/pkg/util/test"
+)
+
+type recordedPush struct {
+	tenantID string
+	req      pushRequest
+}
+
+// mockLoki is a stand-in for the Loki push endpoint. It responds with the given status codes in order, and with
+// 204 once they are used up.
+type mockLoki struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	requests int
+	pushes   []recordedPush
+}
+
+func newMockLoki(t *testing.T, statuses ...int) *mockLoki {
+	t.Helper()
+
+	m := &mockLoki{statuses: statuses}
+	m.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		m.requests++
+		if len(m.statuses) > 0 {
+			status := m.statuses[0]
+			m.statuses = m.statuses[1:]
+			if status/100 != 2 {
+				http.Error(w, "mock error", status)
+				return
+			}
+		}
+
+		var req pushRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		m.pushes = append(m.pushes, recordedPush{tenantID: r.Header.Get(user.OrgIDHeaderName), req: req})
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(m.Close)
+
+	return m
+}
+
+func (m *mockLoki) recorded() (int, []recordedPush) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.requests, append([]recordedPush(nil), m.pushes...)
+}
+
+func newForwarder(t *testing.T, cfg Config) *Forwarder {
+	t.Helper()
+
+	f, err := NewForwarder(cfg, log.NewNopLogger())
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, f.Shutdown(context.Background()))
+	})
+
+	return f
+}
+
+func fastBackoff(maxRetries int) backoff.Config {
+	return backoff.Config{MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond, MaxRetries: maxRetries}
+}
+
+func snapshotWithLogMsg(msg string) *tp.Snapshot {
+	snapshot := test.GenerateSnapshot(12, &test.GenerateOptions{
+		ServiceName: "test-service",
+		Resource: map[string]string{
+			"k8s.pod.name":       "test-pod",
+			"k8s.namespace.name": "test-ns",
+		},
+	})
+	snapshot.LogMsg = &msg
+	return snapshot
+}
+
+func TestForwarder_ForwardTraces_PushesLogMessageWhenBatchIsFull(t *testing.T) {
+	// Given
+	loki := newMockLoki(t)
+	f := newForwarder(t, Config{
+		Endpoint:       loki.URL,
+		BatchSize:      1,
+		BatchWait:      time.Hour,
+		Backoff:        fastBackoff(1),
+		ExternalLabels: map[string]string{"cluster": "test-cluster"},
+	})
+	snapshot := snapshotWithLogMsg("a log line")
+	ctx := util.InjectTenantID(context.Background(), "tenant-1")
+
+	// When
+	err := f.ForwardTraces(ctx, otlpgrpc.SnapshotToTraces(snapshot))
+
+	// Then
+	require.NoError(t, err)
+	pushes := waitForPushes(t, loki, 1)
+	require.Equal(t, "tenant-1", pushes[0].tenantID)
+	require.Equal(t, []*stream{{
+		Stream: map[string]string{
+			"cluster":      "test-cluster",
+			labelService:   "test-service",
+			labelPod:       "test-pod",
+			labelNamespace: "test-ns",
+			labelPath:      snapshot.Tracepoint.Path,
+			labelLine:      "12",
+		},
+		Values: [][2]string{{strconv.FormatUint(snapshot.TsNanos, 10), "a log line"}},
+	}}, pushes[0].req.Streams)
+}
+
+func TestForwarder_ForwardTraces_IgnoresSnapshotsWithoutLogMessage(t *testing.T) {
+	// Given
+	loki := newMockLoki(t)
+	f := newForwarder(t, Config{Endpoint: loki.URL, BatchSize: 1, BatchWait: time.Hour})
+	ctx := util.InjectTenantID(context.Background(), "tenant-1")
+
+	// When
+	err := f.ForwardTraces(ctx, otlpgrpc.SnapshotToTraces(test.GenerateSnapshot(1, nil)))
+
+	// Then
+	require.NoError(t, err)
+	requests, _ := loki.recorded()
+	require.Equal(t, 0, requests)
+}
+
+func TestForwarder_ForwardTraces_ReturnsErrorWithNoTenantInContext(t *testing.T) {
+	// Given
+	loki := newMockLoki(t)
+	f := newForwarder(t, Config{Endpoint: loki.URL})
+
+	// When
+	err := f.ForwardTraces(context.Background(), otlpgrpc.SnapshotToTraces(snapshotWithLogMsg("a log line")))
+
+	// Then
+	require.Error(t, err)
+}
+
+func TestForwarder_ForwardTraces_GroupsLinesIntoStreamsByLabels(t *testing.T) {
+	// Given
+	loki := newMockLoki(t)
+	f := newForwarder(t, Config{Endpoint: loki.URL, BatchSize: 3, BatchWait: time.Hour})
+	ctx := util.InjectTenantID(context.Background(), "tenant-1")
+	first := snapshotWithLogMsg("first")
+	second := snapshotWithLogMsg("second")
+	second.Tracepoint = first.Tracepoint
+	other := snapshotWithLogMsg("other")
+	other.Resource = append(other.Resource, &cp.KeyValue{Key: "k8s.pod.name", Value: &cp.AnyValue{Value: &cp.AnyValue_StringValue{StringValue: "other-pod"}}})
+
+	// When
+	require.NoError(t, f.ForwardTraces(ctx, otlpgrpc.SnapshotToTraces(first)))
+	require.NoError(t, f.ForwardTraces(ctx, otlpgrpc.SnapshotToTraces(second)))
+	requests, _ := loki.recorded()
+	require.Equal(t, 0, requests)
+	require.NoError(t, f.ForwardTraces(ctx, otlpgrpc.SnapshotToTraces(other)))
+
+	// Then
+	pushes := waitForPushes(t, loki, 1)
+	streams := pushes[0].req.Streams
+	require.Len(t, streams, 2)
+	lines := map[string][]string{}
+	for _, s := range streams {
+		for _, v := range s.Values {
+			lines[s.Stream[labelPod]] = append(lines[s.Stream[labelPod]], v[1])
+		}
+	}
+	require.Equal(t, map[string][]string{
+		"test-pod":  {"first", "second"},
+		"other-pod": {"other"},
+	}, lines)
+}
+
+func TestForwarder_FlushesBatchAfterBatchWait(t *testing.T) {
+	// Given
+	loki := newMockLoki(t)
+	f := newForwarder(t, Config{Endpoint: loki.URL, BatchSize: 100, BatchWait: 20 * time.Millisecond})
+	ctx := util.InjectTenantID(context.Background(), "tenant-1")
+
+	// When
+	err := f.ForwardTraces(ctx, otlpgrpc.SnapshotToTraces(snapshotWithLogMsg("a log line")))
+
+	// Then
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		_, pushes := loki.recorded()
+		return len(pushes) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestForwarder_Shutdown_PushesBufferedLines(t *testing.T) {
+	// Given
+	loki := newMockLoki(t)
+	f, err := NewForwarder(Config{Endpoint: loki.URL, BatchSize: 100, BatchWait: time.Hour}, log.NewNopLogger())
+	require.NoError(t, err)
+	ctx := util.InjectTenantID(context.Background(), "tenant-1")
+	require.NoError(t, f.ForwardTraces(ctx, otlpgrpc.SnapshotToTraces(snapshotWithLogMsg("a log line"))))
+
+	// When
+	err = f.Shutdown(context.Background())
+
+	// Then
+	require.NoError(t, err)
+	_, pushes := loki.recorded()
+	require.Len(t, pushes, 1)
+	require.Equal(t, "a log line", pushes[0].req.Streams[0].Values[0][1])
+}
+
+func TestForwarder_RetriesServerErrors(t *testing.T) {
+	// Given
+	loki := newMockLoki(t, http.StatusInternalServerError, http.StatusTooManyRequests)
+	f := newForwarder(t, Config{Endpoint: loki.URL, BatchSize: 1, Backoff: fastBackoff(5)})
+	ctx := util.InjectTenantID(context.Background(), "tenant-1")
+
+	// When
+	err := f.ForwardTraces(ctx, otlpgrpc.SnapshotToTraces(snapshotWithLogMsg("a log line")))
+
+	// Then
+	require.NoError(t, err)
+	waitForPushes(t, loki, 1)
+	requests, _ := loki.recorded()
+	require.Equal(t, 3, requests)
+}
+
+func TestForwarder_DropsLinesWhenRetriesAreExhausted(t *testing.T) {
+	// Given
+	loki := newMockLoki(t, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError)
+	f := newForwarder(t, Config{Endpoint: loki.URL, BatchSize: 1, Backoff: fastBackoff(2)})
+	ctx := util.InjectTenantID(context.Background(), "retries-exhausted")
+	failed := failedEntries("retries-exhausted")
+
+	// When
+	err := f.ForwardTraces(ctx, otlpgrpc.SnapshotToTraces(snapshotWithLogMsg("a log line")))
+
+	// Then
+	require.NoError(t, err)
+	waitForFailedEntries(t, "retries-exhausted", failed+1)
+	requests, _ := loki.recorded()
+	require.Equal(t, 2, requests)
+}
+
+func TestForwarder_DoesNotRetryClientErrors(t *testing.T) {
+	// Given
+	loki := newMockLoki(t, http.StatusBadRequest)
+	f := newForwarder(t, Config{Endpoint: loki.URL, BatchSize: 1, Backoff: fastBackoff(5)})
+	ctx := util.InjectTenantID(context.Background(), "client-error")
+	failed := failedEntries("client-error")
+
+	// When
+	err := f.ForwardTraces(ctx, otlpgrpc.SnapshotToTraces(snapshotWithLogMsg("a log line")))
+
+	// Then
+	require.NoError(t, err)
+	waitForFailedEntries(t, "client-error", failed+1)
+	requests, _ := loki.recorded()
+	require.Equal(t, 1, requests)
+}
+
+func TestForwarder_ForwardTraces_PushesWhenRequestIsCancelled(t *testing.T) {
+	// Given
+	loki := newMockLoki(t)
+	f := newForwarder(t, Config{Endpoint: loki.URL, BatchSize: 1, BatchWait: time.Hour, Backoff: fastBackoff(1)})
+	ctx, cancel := context.WithCancel(util.InjectTenantID(context.Background(), "tenant-1"))
+
+	// When
+	cancel()
+	err := f.ForwardTraces(ctx, otlpgrpc.SnapshotToTraces(snapshotWithLogMsg("a log line")))
+
+	// Then
+	require.NoError(t, err)
+	pushes := waitForPushes(t, loki, 1)
+	require.Equal(t, "a log line", pushes[0].req.Streams[0].Values[0][1])
+}
+
+func TestForwarder_ForwardTraces_DoesNotWaitOnLoki(t *testing.T) {
+	// Given
+	received := make(chan struct{}, 10)
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+		<-unblock
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+	f := newForwarder(t, Config{Endpoint: server.URL, BatchSize: 1, BatchWait: time.Hour, QueueSize: 1, Backoff: fastBackoff(1)})
+	t.Cleanup(func() { close(unblock) })
+	ctx := util.InjectTenantID(context.Background(), "queue-full")
+	failed := failedEntries("queue-full")
+
+	// When
+	require.NoError(t, f.ForwardTraces(ctx, otlpgrpc.SnapshotToTraces(snapshotWithLogMsg("first"))))
+	<-received
+	// the first batch is being pushed, so the second fills the queue and the third is dropped
+	require.NoError(t, f.ForwardTraces(ctx, otlpgrpc.SnapshotToTraces(snapshotWithLogMsg("second"))))
+	err := f.ForwardTraces(ctx, otlpgrpc.SnapshotToTraces(snapshotWithLogMsg("third")))
+
+	// Then
+	require.ErrorContains(t, err, "queue is full")
+	require.Equal(t, failed+1, failedEntries("queue-full"))
+}
+
+func waitForPushes(t *testing.T, loki *mockLoki, count int) []recordedPush {
+	t.Helper()
+
+	var pushes []recordedPush
+	require.Eventually(t, func() bool {
+		_, pushes = loki.recorded()
+		return len(pushes) == count
+	}, 5*time.Second, 10*time.Millisecond)
+	return pushes
+}
+
+func waitForFailedEntries(t *testing.T, tenantID string, count float64) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		return failedEntries(tenantID) == count
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func failedEntries(tenantID string) float64 {
+	return testutil.ToFloat64(metricFailedEntries.WithLabelValues(tenantID))
+}
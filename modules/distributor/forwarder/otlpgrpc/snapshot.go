@@ -0,0 +1,207 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package otlpgrpc
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	cp "github.com/intergral/deep/pkg/deeppb/common/v1"
+	tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/intergral/deep/pkg/util"
+)
+
+const (
+	scopeName = "deep"
+
+	eventNameFrame = "frame"
+	eventNameWatch = "watch"
+)
+
+// The attribute keys used on the spans and span events created from a snapshot. The code.* keys follow the
+// OpenTelemetry semantic conventions.
+const (
+	AttrSnapshotID      = "deep.snapshot.id"
+	AttrTracepointID    = "deep.tracepoint.id"
+	AttrLogMsg          = "deep.log_msg"
+	AttrFrameIndex      = "deep.frame.index"
+	AttrFrameAppFrame   = "deep.frame.app_frame"
+	AttrWatchExpression = "deep.watch.expression"
+	AttrWatchValue      = "deep.watch.value"
+	AttrWatchType       = "deep.watch.type"
+	AttrWatchError      = "deep.watch.error"
+
+	AttrCodeFilepath  = "code.filepath"
+	AttrCodeLineno    = "code.lineno"
+	AttrCodeColumn    = "code.column"
+	AttrCodeFunction  = "code.function"
+	AttrCodeNamespace = "code.namespace"
+)
+
+// SnapshotToTraces converts a snapshot into a trace with a single span. The span covers the time it took to
+// collect the snapshot, the snapshot resource becomes the span resource and the snapshot attributes become the
+// span attributes. Each frame and each watch result is added to the span as an event, in the order they were
+// captured. If the snapshot was taken in a trace, the span is added to that trace as a child of the active span.
+func SnapshotToTraces(snapshot *tp.Snapshot) ptrace.Traces {
+	traces := ptrace.NewTraces()
+
+	rs := traces.ResourceSpans().AppendEmpty()
+	insertAttributes(rs.Resource().Attributes(), snapshot.Resource)
+
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName(scopeName)
+
+	id := util.PadSnapshotIDTo16Bytes(snapshot.ID)
+	var traceID [16]byte
+	var spanID [8]byte
+	copy(traceID[:], id)
+	copy(spanID[:], id[8:])
+
+	span := ss.Spans().AppendEmpty()
+	if activeTraceID, activeSpanID, hasTrace, hasSpan := activeSpan(snapshot.Attributes); hasTrace {
+		traceID = activeTraceID
+		if hasSpan {
+			span.SetParentSpanID(pcommon.NewSpanID(activeSpanID))
+		}
+	}
+	span.SetTraceID(pcommon.NewTraceID(traceID))
+	span.SetSpanID(pcommon.NewSpanID(spanID))
+	span.SetName(spanName(snapshot.Tracepoint))
+	span.SetKind(ptrace.SpanKindInternal)
+	span.SetStartTimestamp(pcommon.Timestamp(snapshot.TsNanos))
+	span.SetEndTimestamp(pcommon.Timestamp(snapshot.TsNanos + snapshot.DurationNanos))
+
+	attrs := span.Attributes()
+	insertAttributes(attrs, snapshot.Attributes)
+	attrs.UpsertString(AttrSnapshotID, util.SnapshotIDToHexString(snapshot.ID))
+	if snapshot.Tracepoint != nil {
+		attrs.UpsertString(AttrTracepointID, snapshot.Tracepoint.ID)
+		attrs.UpsertString(AttrCodeFilepath, snapshot.Tracepoint.Path)
+		attrs.UpsertInt(AttrCodeLineno, int64(snapshot.Tracepoint.LineNumber))
+	}
+	if snapshot.LogMsg != nil {
+		attrs.UpsertString(AttrLogMsg, *snapshot.LogMsg)
+	}
+
+	for i, frame := range snapshot.Frames {
+		appendFrameEvent(span.Events().AppendEmpty(), snapshot.TsNanos, i, frame)
+	}
+
+	for _, watch := range snapshot.Watches {
+		appendWatchEvent(span.Events().AppendEmpty(), snapshot.TsNanos, watch, snapshot.VarLookup)
+	}
+
+	return traces
+}
+
+// activeSpan returns the trace and span the snapshot was taken in, from the trace_id and span_id attributes. The span
+// is only used with a trace, as the parent must be in the same trace. The IDs are already validated as hex, so they
+// always decode.
+func activeSpan(attributes []*cp.KeyValue) (traceID [16]byte, spanID [8]byte, hasTrace, hasSpan bool) {
+	traceHex, spanHex := util.TraceIDsFromAttributes(attributes)
+	if traceHex == "" {
+		return traceID, spanID, false, false
+	}
+	_, _ = hex.Decode(traceID[:], []byte(traceHex))
+	if spanHex == "" {
+		return traceID, spanID, true, false
+	}
+	_, _ = hex.Decode(spanID[:], []byte(spanHex))
+	return traceID, spanID, true, true
+}
+
+func spanName(tracepoint *tp.TracePointConfig) string {
+	if tracepoint == nil {
+		return "snapshot"
+	}
+	return fmt.Sprintf("%s:%d", tracepoint.Path, tracepoint.LineNumber)
+}
+
+func appendFrameEvent(event ptrace.SpanEvent, ts uint64, index int, frame *tp.StackFrame) {
+	event.SetName(eventNameFrame)
+	event.SetTimestamp(pcommon.Timestamp(ts))
+
+	attrs := event.Attributes()
+	attrs.UpsertInt(AttrFrameIndex, int64(index))
+	attrs.UpsertString(AttrCodeFilepath, frame.FileName)
+	attrs.UpsertString(AttrCodeFunction, frame.MethodName)
+	attrs.UpsertInt(AttrCodeLineno, int64(frame.LineNumber))
+	if frame.ColumnNumber != nil {
+		attrs.UpsertInt(AttrCodeColumn, int64(*frame.ColumnNumber))
+	}
+	if frame.ClassName != nil {
+		attrs.UpsertString(AttrCodeNamespace, *frame.ClassName)
+	}
+	if frame.AppFrame != nil {
+		attrs.UpsertBool(AttrFrameAppFrame, *frame.AppFrame)
+	}
+}
+
+func appendWatchEvent(event ptrace.SpanEvent, ts uint64, watch *tp.WatchResult, lookup map[string]*tp.Variable) {
+	event.SetName(eventNameWatch)
+	event.SetTimestamp(pcommon.Timestamp(ts))
+
+	attrs := event.Attributes()
+	attrs.UpsertString(AttrWatchExpression, watch.Expression)
+
+	switch result := watch.Result.(type) {
+	case *tp.WatchResult_GoodResult:
+		if variable, ok := lookup[result.GoodResult.GetID()]; ok {
+			attrs.UpsertString(AttrWatchValue, variable.Value)
+			attrs.UpsertString(AttrWatchType, variable.Type)
+		}
+	case *tp.WatchResult_ErrorResult:
+		attrs.UpsertString(AttrWatchError, result.ErrorResult)
+	}
+}
+
+func insertAttributes(dest pcommon.Map, kvs []*cp.KeyValue) {
+	for _, kv := range kvs {
+		dest.Upsert(kv.Key, convertAnyValue(kv.Value))
+	}
+}
+
+func convertAnyValue(value *cp.AnyValue) pcommon.Value {
+	switch v := value.GetValue().(type) {
+	case *cp.AnyValue_StringValue:
+		return pcommon.NewValueString(v.StringValue)
+	case *cp.AnyValue_BoolValue:
+		return pcommon.NewValueBool(v.BoolValue)
+	case *cp.AnyValue_IntValue:
+		return pcommon.NewValueInt(v.IntValue)
+	case *cp.AnyValue_DoubleValue:
+		return pcommon.NewValueDouble(v.DoubleValue)
+	case *cp.AnyValue_BytesValue:
+		return pcommon.NewValueBytes(pcommon.NewImmutableByteSlice(v.BytesValue))
+	case *cp.AnyValue_ArrayValue:
+		ret := pcommon.NewValueSlice()
+		for _, item := range v.ArrayValue.GetValues() {
+			convertAnyValue(item).CopyTo(ret.SliceVal().AppendEmpty())
+		}
+		return ret
+	case *cp.AnyValue_KvlistValue:
+		ret := pcommon.NewValueMap()
+		insertAttributes(ret.MapVal(), v.KvlistValue.GetValues())
+		return ret
+	default:
+		return pcommon.NewValueEmpty()
+	}
+}
@@ -0,0 +1,204 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package otlpgrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"google.golang.org/grpc"
+
+	cp "github.com/intergral/deep/pkg/deeppb/common/v1"
+	tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/intergral/deep/pkg/util"
+	"github.com/intergral/deep/pkg/util/test"
+)
+
+func TestSnapshotToTraces(t *testing.T) {
+	// Given
+	snapshot := test.GenerateSnapshot(7, &test.GenerateOptions{
+		Id:            test.MakeSnapshotID(),
+		ServiceName:   "test-service",
+		DurationNanos: 1000,
+		LogMsg:        true,
+		Attrs:         map[string]string{"custom": "value"},
+	})
+	snapshot.Watches = []*tp.WatchResult{
+		{Expression: "cnt", Result: &tp.WatchResult_GoodResult{GoodResult: &tp.VariableID{ID: "watch-1", Name: "cnt"}}},
+		{Expression: "missing", Result: &tp.WatchResult_ErrorResult{ErrorResult: "name 'missing' is not defined"}},
+	}
+	snapshot.VarLookup["watch-1"] = &tp.Variable{Type: "int", Value: "25"}
+
+	// When
+	traces := SnapshotToTraces(snapshot)
+
+	// Then
+	require.Equal(t, 1, traces.SpanCount())
+	rs := traces.ResourceSpans().At(0)
+	serviceName, ok := rs.Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	require.Equal(t, "test-service", serviceName.StringVal())
+
+	ss := rs.ScopeSpans().At(0)
+	require.Equal(t, scopeName, ss.Scope().Name())
+
+	span := ss.Spans().At(0)
+	traceID := span.TraceID().Bytes()
+	spanID := span.SpanID().Bytes()
+	require.Equal(t, snapshot.ID, traceID[:])
+	require.Equal(t, snapshot.ID[8:], spanID[:])
+	require.Equal(t, snapshot.Tracepoint.Path+":7", span.Name())
+	require.Equal(t, ptrace.SpanKindInternal, span.Kind())
+	require.Equal(t, pcommon.Timestamp(snapshot.TsNanos), span.StartTimestamp())
+	require.Equal(t, pcommon.Timestamp(snapshot.TsNanos+1000), span.EndTimestamp())
+
+	attrs := span.Attributes().AsRaw()
+	require.Equal(t, util.SnapshotIDToHexString(snapshot.ID), attrs[AttrSnapshotID])
+	require.Equal(t, snapshot.Tracepoint.ID, attrs[AttrTracepointID])
+	require.Equal(t, snapshot.Tracepoint.Path, attrs[AttrCodeFilepath])
+	require.Equal(t, int64(7), attrs[AttrCodeLineno])
+	require.Equal(t, *snapshot.LogMsg, attrs[AttrLogMsg])
+	require.Equal(t, "value", attrs["custom"])
+
+	events := span.Events()
+	require.Equal(t, len(snapshot.Frames)+2, events.Len())
+	for i, frame := range snapshot.Frames {
+		event := events.At(i)
+		require.Equal(t, eventNameFrame, event.Name())
+		require.Equal(t, pcommon.Timestamp(snapshot.TsNanos), event.Timestamp())
+
+		frameAttrs := event.Attributes().AsRaw()
+		require.Equal(t, int64(i), frameAttrs[AttrFrameIndex])
+		require.Equal(t, frame.FileName, frameAttrs[AttrCodeFilepath])
+		require.Equal(t, frame.MethodName, frameAttrs[AttrCodeFunction])
+		require.Equal(t, int64(frame.LineNumber), frameAttrs[AttrCodeLineno])
+	}
+	require.Equal(t, "SimpleTest", events.At(0).Attributes().AsRaw()[AttrCodeNamespace])
+
+	goodWatch := events.At(len(snapshot.Frames))
+	require.Equal(t, eventNameWatch, goodWatch.Name())
+	require.Equal(t, map[string]interface{}{
+		AttrWatchExpression: "cnt",
+		AttrWatchValue:      "25",
+		AttrWatchType:       "int",
+	}, goodWatch.Attributes().AsRaw())
+
+	badWatch := events.At(len(snapshot.Frames) + 1)
+	require.Equal(t, eventNameWatch, badWatch.Name())
+	require.Equal(t, map[string]interface{}{
+		AttrWatchExpression: "missing",
+		AttrWatchError:      "name 'missing' is not defined",
+	}, badWatch.Attributes().AsRaw())
+}
+
+func TestSnapshotToTraces_UsesActiveTrace(t *testing.T) {
+	// Given
+	snapshot := &tp.Snapshot{
+		ID: []byte{0x01, 0x02},
+		Attributes: []*cp.KeyValue{
+			{Key: util.AttributeTraceID, Value: &cp.AnyValue{Value: &cp.AnyValue_StringValue{StringValue: "1234567890ABCDEF"}}},
+			{Key: util.AttributeSpanID, Value: &cp.AnyValue{Value: &cp.AnyValue_StringValue{StringValue: "b7ad6b7169203331"}}},
+		},
+	}
+
+	// When
+	span := SnapshotToTraces(snapshot).ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+
+	// Then
+	require.Equal(t, "00000000000000001234567890abcdef", span.TraceID().HexString())
+	require.Equal(t, "b7ad6b7169203331", span.ParentSpanID().HexString())
+	spanID := span.SpanID().Bytes()
+	require.Equal(t, util.PadSnapshotIDTo16Bytes(snapshot.ID)[8:], spanID[:])
+
+	// the span is not used without a trace, and an invalid trace is ignored
+	snapshot.Attributes[0].Value = &cp.AnyValue{Value: &cp.AnyValue_StringValue{StringValue: "not a trace id"}}
+	span = SnapshotToTraces(snapshot).ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	traceID := span.TraceID().Bytes()
+	require.Equal(t, util.PadSnapshotIDTo16Bytes(snapshot.ID), traceID[:])
+	require.True(t, span.ParentSpanID().IsEmpty())
+}
+
+func TestSnapshotToTraces_ConvertsAttributeValues(t *testing.T) {
+	// Given
+	snapshot := &tp.Snapshot{
+		ID: []byte{0x01, 0x02},
+		Attributes: []*cp.KeyValue{
+			{Key: "bool", Value: &cp.AnyValue{Value: &cp.AnyValue_BoolValue{BoolValue: true}}},
+			{Key: "int", Value: &cp.AnyValue{Value: &cp.AnyValue_IntValue{IntValue: 12}}},
+			{Key: "double", Value: &cp.AnyValue{Value: &cp.AnyValue_DoubleValue{DoubleValue: 1.5}}},
+			{Key: "bytes", Value: &cp.AnyValue{Value: &cp.AnyValue_BytesValue{BytesValue: []byte("abc")}}},
+			{Key: "array", Value: &cp.AnyValue{Value: &cp.AnyValue_ArrayValue{ArrayValue: &cp.ArrayValue{Values: []*cp.AnyValue{
+				{Value: &cp.AnyValue_StringValue{StringValue: "a"}},
+				{Value: &cp.AnyValue_IntValue{IntValue: 1}},
+			}}}}},
+			{Key: "kvlist", Value: &cp.AnyValue{Value: &cp.AnyValue_KvlistValue{KvlistValue: &cp.KeyValueList{Values: []*cp.KeyValue{
+				{Key: "nested", Value: &cp.AnyValue{Value: &cp.AnyValue_StringValue{StringValue: "value"}}},
+			}}}}},
+			{Key: "empty"},
+		},
+	}
+
+	// When
+	traces := SnapshotToTraces(snapshot)
+
+	// Then
+	span := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	require.Equal(t, "snapshot", span.Name())
+	require.Equal(t, map[string]interface{}{
+		"bool":         true,
+		"int":          int64(12),
+		"double":       1.5,
+		"bytes":        []byte("abc"),
+		"array":        []interface{}{"a", int64(1)},
+		"kvlist":       map[string]interface{}{"nested": "value"},
+		"empty":        nil,
+		AttrSnapshotID: "102",
+	}, span.Attributes().AsRaw())
+}
+
+func Test_Forwarder_ForwardTraces_SendsConvertedSnapshot(t *testing.T) {
+	// Given
+	cfg := Config{
+		Endpoints: []string{"test:1234"},
+		TLS:       TLSConfig{Insecure: true},
+	}
+	logger := log.NewNopLogger()
+	f := newForwarder(t, cfg, logger)
+	srv := &mockRecordingPTraceOTLPServer{next: &mockWorkingPTraceOTLPServer{}}
+	l := newListener(t, srv)
+	d := newContextDialer(l)
+	err := f.Dial(context.Background(), grpc.WithContextDialer(d), grpc.WithBlock())
+	require.NoError(t, err)
+	snapshot := test.GenerateSnapshot(1, nil)
+	traces := SnapshotToTraces(snapshot)
+	ctx := util.InjectTenantID(context.Background(), "123")
+
+	// When
+	err = f.ForwardTraces(ctx, traces)
+
+	// Then
+	require.NoError(t, err)
+	received := srv.req.Traces()
+	require.Equal(t, traces, received)
+	traceID := received.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID().Bytes()
+	require.Equal(t, snapshot.ID, traceID[:])
+}
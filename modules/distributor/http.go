@@ -0,0 +1,56 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package distributor
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/intergral/deep/pkg/api"
+	"github.com/intergral/deep/pkg/util"
+)
+
+// AgentsResponse is the agents of a tenant that have polled or subscribed to any distributor
+type AgentsResponse struct {
+	Agents []Agent `json:"agents"`
+}
+
+// AgentsHandler returns the agents of the tenant, the optional tracepoint param limits the agents to those that
+// should have the tracepoint installed
+func (d *Distributor) AgentsHandler(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := util.ExtractTenantID(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	agents, err := d.agents.Agents(r.Context(), tenantID, r.URL.Query().Get(api.URLParamTracepoint))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := AgentsResponse{Agents: agents}
+
+	w.Header().Set(api.HeaderContentType, api.HeaderAcceptJSON)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
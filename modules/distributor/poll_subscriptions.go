@@ -0,0 +1,237 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package distributor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/backoff"
+	"github.com/grafana/dskit/services"
+	"github.com/intergral/deep/pkg/deeppb"
+	pb "github.com/intergral/deep/pkg/deeppb/poll/v1"
+	"github.com/intergral/deep/pkg/receivers/types"
+	"github.com/intergral/deep/pkg/util"
+)
+
+type (
+	loadFunc  func(ctx context.Context, req *pb.PollRequest) (*pb.PollResponse, error)
+	watchFunc func(ctx context.Context) (watchStream, error)
+)
+
+// watchStream receives a response each time the tracepoints for a tenant change
+type watchStream interface {
+	Recv() (*deeppb.WatchTracepointsResponse, error)
+}
+
+var watchBackoff = backoff.Config{
+	MinBackoff: 100 * time.Millisecond,
+	MaxBackoff: 10 * time.Second,
+}
+
+// pollSubscriptions pushes tracepoint changes to the agents that have subscribed to this distributor. For each
+// tenant with subscribers we watch the tracepoint service, when it reports a change each subscriber reloads its
+// tracepoints and is sent the response if its hash has changed. As every distributor watches the tracepoint service
+// for its own subscribers, changes reach agents connected to any distributor.
+type pollSubscriptions struct {
+	services.Service
+
+	logger log.Logger
+	load   loadFunc
+	watch  watchFunc
+
+	tenants map[string]*tenantSubscriptions
+	mutex   sync.Mutex
+}
+
+type tenantSubscriptions struct {
+	subscribers map[*subscriber]struct{}
+	cancel      context.CancelFunc
+}
+
+type subscriber struct {
+	// changed has a buffer of 1, so changes that happen while the subscriber is reloading are coalesced
+	changed chan struct{}
+}
+
+func newPollSubscriptions(logger log.Logger, load loadFunc, watch watchFunc, resyncInterval time.Duration) *pollSubscriptions {
+	s := &pollSubscriptions{
+		logger:  logger,
+		load:    load,
+		watch:   watch,
+		tenants: map[string]*tenantSubscriptions{},
+	}
+
+	s.Service = services.NewTimerService(resyncInterval, nil, s.iteration, s.stopping)
+
+	return s
+}
+
+// iteration will ask all subscribers to reload, in case a change was missed while reconnecting to the tracepoint service
+func (s *pollSubscriptions) iteration(_ context.Context) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, tenant := range s.tenants {
+		tenant.notify()
+	}
+	return nil
+}
+
+func (s *pollSubscriptions) stopping(_ error) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for tenantID, tenant := range s.tenants {
+		tenant.cancel()
+		delete(s.tenants, tenantID)
+	}
+	return nil
+}
+
+// Subscribe will send the current tracepoints for the request, then send them again each time they change. This
+// blocks until the context is done.
+func (s *pollSubscriptions) Subscribe(ctx context.Context, tenantID string, req *pb.PollRequest, send types.PollSender) error {
+	sub := s.register(tenantID)
+	defer s.unregister(tenantID, sub)
+
+	// the first response is always sent, so the agent knows the subscription is active
+	response, err := s.load(ctx, req)
+	if err != nil {
+		return err
+	}
+	if err := send(response); err != nil {
+		return err
+	}
+	currentHash := response.CurrentHash
+
+	for {
+		select {
+		case <-sub.changed:
+			response, err := s.load(ctx, &pb.PollRequest{
+				TsNanos:     uint64(time.Now().UnixNano()),
+				CurrentHash: currentHash,
+				Resource:    req.Resource,
+			})
+			if err != nil {
+				// we keep the subscription open, the next change or resync will try again
+				_ = level.Warn(s.logger).Log("msg", "failed to reload tracepoints for subscription", "tenant", tenantID, "err", err)
+				continue
+			}
+			if response.ResponseType == pb.ResponseType_NO_CHANGE {
+				continue
+			}
+			if err := send(response); err != nil {
+				return err
+			}
+			currentHash = response.CurrentHash
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// register the subscriber, if this is the first subscriber for the tenant we start watching the tracepoint service
+func (s *pollSubscriptions) register(tenantID string) *subscriber {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	sub := &subscriber{changed: make(chan struct{}, 1)}
+
+	tenant, ok := s.tenants[tenantID]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		tenant = &tenantSubscriptions{subscribers: map[*subscriber]struct{}{}, cancel: cancel}
+		s.tenants[tenantID] = tenant
+		go s.watchTenant(ctx, tenantID)
+	}
+	tenant.subscribers[sub] = struct{}{}
+
+	return sub
+}
+
+// unregister the subscriber, if this is the last subscriber for the tenant we stop watching the tracepoint service
+func (s *pollSubscriptions) unregister(tenantID string, sub *subscriber) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tenant, ok := s.tenants[tenantID]
+	if !ok {
+		return
+	}
+	delete(tenant.subscribers, sub)
+	if len(tenant.subscribers) == 0 {
+		tenant.cancel()
+		delete(s.tenants, tenantID)
+	}
+}
+
+// watchTenant will watch the tracepoint service for changes to the tenant and notify the subscribers, reconnecting
+// until the context is cancelled
+func (s *pollSubscriptions) watchTenant(ctx context.Context, tenantID string) {
+	ctx = util.InjectTenantID(ctx, tenantID)
+	boff := backoff.New(ctx, watchBackoff)
+
+	for boff.Ongoing() {
+		stream, err := s.watch(ctx)
+		if err != nil {
+			_ = level.Warn(s.logger).Log("msg", "failed to watch tracepoints", "tenant", tenantID, "err", err)
+			boff.Wait()
+			continue
+		}
+
+		// changes could have been made before the watch started, so we reload on every connect
+		s.notify(tenantID)
+
+		for {
+			_, err = stream.Recv()
+			if err != nil {
+				break
+			}
+			boff.Reset()
+			s.notify(tenantID)
+		}
+
+		if ctx.Err() == nil {
+			_ = level.Warn(s.logger).Log("msg", "tracepoint watch closed, reconnecting", "tenant", tenantID, "err", err)
+		}
+		boff.Wait()
+	}
+}
+
+func (s *pollSubscriptions) notify(tenantID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if tenant, ok := s.tenants[tenantID]; ok {
+		tenant.notify()
+	}
+}
+
+func (t *tenantSubscriptions) notify() {
+	for sub := range t.subscribers {
+		select {
+		case sub.changed <- struct{}{}:
+		default:
+			// the subscriber already has a pending change
+		}
+	}
+}
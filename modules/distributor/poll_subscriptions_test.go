@@ -0,0 +1,145 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package distributor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/intergral/deep/pkg/deeppb"
+	pb "github.com/intergral/deep/pkg/deeppb/poll/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeWatchStream sends a response each time a value is put on changes
+type fakeWatchStream struct {
+	ctx     context.Context
+	changes chan struct{}
+}
+
+func (f *fakeWatchStream) Recv() (*deeppb.WatchTracepointsResponse, error) {
+	select {
+	case <-f.changes:
+		return &deeppb.WatchTracepointsResponse{}, nil
+	case <-f.ctx.Done():
+		return nil, f.ctx.Err()
+	}
+}
+
+func TestPollSubscriptionPushesChanges(t *testing.T) {
+	var mutex sync.Mutex
+	hash := "1"
+	load := func(ctx context.Context, req *pb.PollRequest) (*pb.PollResponse, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		responseType := pb.ResponseType_UPDATE
+		if req.CurrentHash == hash {
+			responseType = pb.ResponseType_NO_CHANGE
+		}
+		return &pb.PollResponse{CurrentHash: hash, ResponseType: responseType}, nil
+	}
+
+	changes := make(chan struct{})
+	watching := make(chan struct{}, 1)
+	watch := func(ctx context.Context) (watchStream, error) {
+		watching <- struct{}{}
+		return &fakeWatchStream{ctx: ctx, changes: changes}, nil
+	}
+
+	subscriptions := newPollSubscriptions(log.NewNopLogger(), load, watch, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	responses := make(chan *pb.PollResponse, 10)
+	done := make(chan error)
+	go func() {
+		done <- subscriptions.Subscribe(ctx, "tenant", &pb.PollRequest{}, func(response *pb.PollResponse) error {
+			responses <- response
+			return nil
+		})
+	}()
+
+	// the first response is always sent
+	assert.Equal(t, "1", receive(t, responses).CurrentHash)
+	<-watching
+
+	// a change that does not modify the hash is not sent
+	changes <- struct{}{}
+
+	mutex.Lock()
+	hash = "2"
+	mutex.Unlock()
+	changes <- struct{}{}
+
+	assert.Equal(t, "2", receive(t, responses).CurrentHash)
+
+	cancel()
+	assert.NoError(t, <-done)
+
+	// once the last subscriber has gone we stop watching the tenant
+	subscriptions.mutex.Lock()
+	assert.Equal(t, 0, len(subscriptions.tenants))
+	subscriptions.mutex.Unlock()
+}
+
+func TestPollSubscriptionResync(t *testing.T) {
+	var mutex sync.Mutex
+	hash := "1"
+	load := func(ctx context.Context, req *pb.PollRequest) (*pb.PollResponse, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return &pb.PollResponse{CurrentHash: hash, ResponseType: pb.ResponseType_UPDATE}, nil
+	}
+	// the watch never connects, so changes are only seen on resync
+	watch := func(ctx context.Context) (watchStream, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	subscriptions := newPollSubscriptions(log.NewNopLogger(), load, watch, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	responses := make(chan *pb.PollResponse, 10)
+	go func() {
+		_ = subscriptions.Subscribe(ctx, "tenant", &pb.PollRequest{}, func(response *pb.PollResponse) error {
+			responses <- response
+			return nil
+		})
+	}()
+	assert.Equal(t, "1", receive(t, responses).CurrentHash)
+
+	mutex.Lock()
+	hash = "2"
+	mutex.Unlock()
+	_ = subscriptions.iteration(context.Background())
+
+	assert.Equal(t, "2", receive(t, responses).CurrentHash)
+}
+
+func receive(t *testing.T, responses chan *pb.PollResponse) *pb.PollResponse {
+	select {
+	case response := <-responses:
+		return response
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for poll response")
+		return nil
+	}
+}
@@ -0,0 +1,418 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package distributor
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/intergral/deep/modules/overrides"
+	deeppb_tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+)
+
+// redactedValue replaces any value, or part of a value, that is masked
+const redactedValue = "[REDACTED]"
+
+// luhnCandidate finds the runs of 12 to 19 digits, which can be separated by spaces or dashes, that are checked with
+// the Luhn check in log messages when the rule has no value pattern
+var luhnCandidate = regexp.MustCompile(`[0-9](?:[ -]?[0-9]){11,18}`)
+
+var metricRedactedVariables = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "deep",
+	Subsystem: "distributor",
+	Name:      "redacted_variables_total",
+	Help:      "The total number of snapshot variables masked or dropped by redaction rules, per tenant.",
+}, []string{"tenant", "action"})
+
+// redactor masks or drops snapshot variables using the redaction rules of the tenant. This happens before the
+// snapshot is sent anywhere, so the raw values are never stored or forwarded.
+type redactor struct {
+	logger log.Logger
+
+	// the compiled rules for each tenant, these are kept until the rules in the overrides change
+	tenants map[string]*tenantRedaction
+	mutex   sync.Mutex
+}
+
+type tenantRedaction struct {
+	config []overrides.RedactionRule
+	rules  []*redactionRule
+}
+
+type redactionRule struct {
+	variableName *regexp.Regexp
+	variableType *regexp.Regexp
+	value        *regexp.Regexp
+	luhn         bool
+	action       string
+}
+
+func newRedactor(logger log.Logger) *redactor {
+	return &redactor{
+		logger:  logger,
+		tenants: map[string]*tenantRedaction{},
+	}
+}
+
+// Redact applies the rules to the snapshot, modifying it in place
+func (r *redactor) Redact(tenantID string, config []overrides.RedactionRule, snapshot *deeppb_tp.Snapshot) {
+	if len(config) == 0 {
+		return
+	}
+
+	masked, dropped := redactSnapshot(snapshot, r.rulesFor(tenantID, config))
+	if masked > 0 {
+		metricRedactedVariables.WithLabelValues(tenantID, overrides.RedactionActionMask).Add(float64(masked))
+	}
+	if dropped > 0 {
+		metricRedactedVariables.WithLabelValues(tenantID, overrides.RedactionActionDrop).Add(float64(dropped))
+	}
+}
+
+func (r *redactor) rulesFor(tenantID string, config []overrides.RedactionRule) []*redactionRule {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	cached, ok := r.tenants[tenantID]
+	if ok && reflect.DeepEqual(cached.config, config) {
+		return cached.rules
+	}
+
+	rules := make([]*redactionRule, 0, len(config))
+	for _, c := range config {
+		// rules are validated when the overrides are loaded, so this only catches rules that are created in code
+		if err := c.Validate(); err != nil {
+			level.Error(r.logger).Log("msg", "ignoring invalid redaction rule", "tenant", tenantID, "err", err)
+			continue
+		}
+
+		rules = append(rules, &redactionRule{
+			variableName: compileOptional(c.VariableName),
+			variableType: compileOptional(c.VariableType),
+			value:        compileOptional(c.Value),
+			luhn:         c.Luhn,
+			action:       c.Action,
+		})
+	}
+
+	r.tenants[tenantID] = &tenantRedaction{config: config, rules: rules}
+	return rules
+}
+
+func compileOptional(pattern string) *regexp.Regexp {
+	if pattern == "" {
+		return nil
+	}
+	return regexp.MustCompile(pattern)
+}
+
+// match returns true if the variable matches the rule, along with the parts of the value that matched. If the
+// rule has no value pattern the locations are nil, and the whole value should be masked.
+func (r *redactionRule) match(id *deeppb_tp.VariableID, variable *deeppb_tp.Variable) (bool, [][]int) {
+	if r.variableName != nil && !r.variableName.MatchString(id.Name) && !r.variableName.MatchString(id.GetOriginalName()) {
+		return false, nil
+	}
+
+	if r.variableType != nil && !r.variableType.MatchString(variable.Type) {
+		return false, nil
+	}
+
+	if r.value != nil {
+		locations := r.valueLocations(r.value, variable.Value)
+		return len(locations) > 0, locations
+	}
+
+	if r.luhn && !luhnValid(variable.Value) {
+		return false, nil
+	}
+	return true, nil
+}
+
+// logMsgLocations returns the parts of the log message that match the value pattern of the rule, or that pass the
+// Luhn check. The log message has no name or type, so rules without a value pattern or Luhn check never match.
+func (r *redactionRule) logMsgLocations(logMsg string) [][]int {
+	switch {
+	case r.value != nil:
+		return r.valueLocations(r.value, logMsg)
+	case r.luhn:
+		return r.valueLocations(luhnCandidate, logMsg)
+	}
+	return nil
+}
+
+// valueLocations returns the non empty parts of the value matched by the pattern, that pass the Luhn check if the
+// rule requires it
+func (r *redactionRule) valueLocations(pattern *regexp.Regexp, value string) [][]int {
+	var locations [][]int
+	for _, loc := range pattern.FindAllStringIndex(value, -1) {
+		if loc[0] == loc[1] {
+			continue
+		}
+		if r.luhn && !luhnValid(value[loc[0]:loc[1]]) {
+			continue
+		}
+		locations = append(locations, loc)
+	}
+	return locations
+}
+
+// snapshotRedaction walks the variables of a single snapshot
+type snapshotRedaction struct {
+	rules   []*redactionRule
+	lookup  map[string]*deeppb_tp.Variable
+	visited map[string]struct{}
+	// droppedIDs are the variables that were dropped, every other reference to them is dropped as well
+	droppedIDs map[string]struct{}
+
+	masked  int
+	dropped int
+}
+
+// redactSnapshot masks or drops the frame variables and watch results of the snapshot that match the rules,
+// returning the number of variables that were masked and dropped. Any variables that can no longer be reached are
+// removed from the var lookup, so the values of dropped variables are not kept. The log message is masked by the
+// rules that match values, and counted as a masked variable.
+func redactSnapshot(snapshot *deeppb_tp.Snapshot, rules []*redactionRule) (int, int) {
+	if len(rules) == 0 {
+		return 0, 0
+	}
+
+	s := &snapshotRedaction{
+		rules:      rules,
+		lookup:     snapshot.VarLookup,
+		visited:    map[string]struct{}{},
+		droppedIDs: map[string]struct{}{},
+	}
+
+	if snapshot.LogMsg != nil {
+		if logMsg, ok := redactLogMsg(*snapshot.LogMsg, rules); ok {
+			snapshot.LogMsg = &logMsg
+			s.masked++
+		}
+	}
+
+	for _, frame := range snapshot.Frames {
+		frame.Variables = s.redactVariables(frame.Variables)
+	}
+
+	for _, watch := range snapshot.Watches {
+		result := watch.GetGoodResult()
+		if result == nil {
+			continue
+		}
+		if len(s.redactVariables([]*deeppb_tp.VariableID{result})) == 0 {
+			watch.Result = &deeppb_tp.WatchResult_ErrorResult{ErrorResult: redactedValue}
+		}
+	}
+
+	if len(s.droppedIDs) > 0 {
+		s.dropReferences(snapshot)
+	}
+
+	if s.masked > 0 || s.dropped > 0 {
+		removeUnreachableVariables(snapshot)
+	}
+
+	return s.masked, s.dropped
+}
+
+// redactLogMsg masks the parts of the log message that match the rules, returning false if nothing was masked
+func redactLogMsg(logMsg string, rules []*redactionRule) (string, bool) {
+	masked := false
+	for _, rule := range rules {
+		locations := rule.logMsgLocations(logMsg)
+		if len(locations) == 0 {
+			continue
+		}
+		logMsg = maskValue(logMsg, locations)
+		masked = true
+	}
+	return logMsg, masked
+}
+
+// dropReferences removes the references to dropped variables that were kept, as a variable can be referenced by
+// other names that the rules do not match
+func (s *snapshotRedaction) dropReferences(snapshot *deeppb_tp.Snapshot) {
+	for _, frame := range snapshot.Frames {
+		frame.Variables = s.withoutDropped(frame.Variables)
+	}
+	for _, variable := range s.lookup {
+		if variable != nil {
+			variable.Children = s.withoutDropped(variable.Children)
+		}
+	}
+	for _, watch := range snapshot.Watches {
+		if result := watch.GetGoodResult(); result != nil && len(s.withoutDropped([]*deeppb_tp.VariableID{result})) == 0 {
+			watch.Result = &deeppb_tp.WatchResult_ErrorResult{ErrorResult: redactedValue}
+		}
+	}
+}
+
+func (s *snapshotRedaction) withoutDropped(ids []*deeppb_tp.VariableID) []*deeppb_tp.VariableID {
+	kept := ids[:0]
+	for _, id := range ids {
+		if _, ok := s.droppedIDs[id.ID]; ok {
+			s.dropped++
+			continue
+		}
+		kept = append(kept, id)
+	}
+	return kept
+}
+
+// redactVariables returns the references that are not dropped, the children of each variable are only walked
+// once as variables can be referenced many times, or by their own children.
+func (s *snapshotRedaction) redactVariables(ids []*deeppb_tp.VariableID) []*deeppb_tp.VariableID {
+	kept := ids[:0]
+	for _, id := range ids {
+		variable, ok := s.lookup[id.ID]
+		if !ok || variable == nil {
+			kept = append(kept, id)
+			continue
+		}
+
+		if s.redactVariable(id, variable) {
+			s.droppedIDs[id.ID] = struct{}{}
+			s.dropped++
+			continue
+		}
+		kept = append(kept, id)
+
+		if _, ok := s.visited[id.ID]; ok {
+			continue
+		}
+		s.visited[id.ID] = struct{}{}
+		variable.Children = s.redactVariables(variable.Children)
+	}
+	return kept
+}
+
+// redactVariable applies the rules to a single reference of a variable, returning true if it should be dropped
+func (s *snapshotRedaction) redactVariable(id *deeppb_tp.VariableID, variable *deeppb_tp.Variable) bool {
+	masked := false
+	for _, rule := range s.rules {
+		matched, locations := rule.match(id, variable)
+		if !matched {
+			continue
+		}
+		if rule.action == overrides.RedactionActionDrop {
+			return true
+		}
+
+		maskVariable(variable, locations)
+		masked = true
+	}
+
+	if masked {
+		s.masked++
+	}
+	return false
+}
+
+// maskVariable replaces the given parts of the value, or the whole value if there are no locations. When the whole
+// value is masked the children are removed as well, as they are the contents of the value.
+func maskVariable(variable *deeppb_tp.Variable, locations [][]int) {
+	if locations == nil {
+		variable.Value = redactedValue
+		variable.Children = nil
+		variable.Truncated = nil
+		return
+	}
+
+	variable.Value = maskValue(variable.Value, locations)
+}
+
+// maskValue replaces the given parts of the value
+func maskValue(value string, locations [][]int) string {
+	var sb strings.Builder
+	last := 0
+	for _, loc := range locations {
+		sb.WriteString(value[last:loc[0]])
+		sb.WriteString(redactedValue)
+		last = loc[1]
+	}
+	sb.WriteString(value[last:])
+	return sb.String()
+}
+
+// removeUnreachableVariables deletes any variable in the var lookup that is not referenced by a frame or watch
+func removeUnreachableVariables(snapshot *deeppb_tp.Snapshot) {
+	reachable := map[string]struct{}{}
+
+	var mark func(ids []*deeppb_tp.VariableID)
+	mark = func(ids []*deeppb_tp.VariableID) {
+		for _, id := range ids {
+			if _, ok := reachable[id.ID]; ok {
+				continue
+			}
+			reachable[id.ID] = struct{}{}
+			if variable, ok := snapshot.VarLookup[id.ID]; ok && variable != nil {
+				mark(variable.Children)
+			}
+		}
+	}
+
+	for _, frame := range snapshot.Frames {
+		mark(frame.Variables)
+	}
+	for _, watch := range snapshot.Watches {
+		if result := watch.GetGoodResult(); result != nil {
+			mark([]*deeppb_tp.VariableID{result})
+		}
+	}
+
+	for id := range snapshot.VarLookup {
+		if _, ok := reachable[id]; !ok {
+			delete(snapshot.VarLookup, id)
+		}
+	}
+}
+
+// luhnValid returns true if s contains 12 to 19 digits, ignoring spaces and dashes, that pass the Luhn checksum
+func luhnValid(s string) bool {
+	sum := 0
+	digits := 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c == ' ' || c == '-' {
+			continue
+		}
+		if c < '0' || c > '9' {
+			return false
+		}
+
+		d := int(c - '0')
+		if digits%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		digits++
+	}
+
+	return digits >= 12 && digits <= 19 && sum%10 == 0
+}
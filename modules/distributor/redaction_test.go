@@ -0,0 +1,207 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package distributor
+
+import (
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/intergral/deep/modules/overrides"
+	deeppb_tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+)
+
+func redactionTestSnapshot() *deeppb_tp.Snapshot {
+	return &deeppb_tp.Snapshot{
+		Frames: []*deeppb_tp.StackFrame{
+			{
+				MethodName: "checkout",
+				Variables: []*deeppb_tp.VariableID{
+					{ID: "1", Name: "user"},
+					{ID: "4", Name: "note"},
+					{ID: "5", Name: "total"},
+				},
+			},
+		},
+		VarLookup: map[string]*deeppb_tp.Variable{
+			"1": {Type: "User", Value: "User@1", Children: []*deeppb_tp.VariableID{
+				{ID: "2", Name: "password"},
+				{ID: "3", Name: "token"},
+			}},
+			"2": {Type: "str", Value: "hunter2"},
+			"3": {Type: "Token", Value: "Token@2", Children: []*deeppb_tp.VariableID{
+				{ID: "6", Name: "secret"},
+			}},
+			"4": {Type: "str", Value: "paid with 4111 1111 1111 1111, ref 1234 5678 9012 3456"},
+			"5": {Type: "float", Value: "149.99"},
+			"6": {Type: "str", Value: "abc123"},
+		},
+		Watches: []*deeppb_tp.WatchResult{
+			{Expression: "user.password", Result: &deeppb_tp.WatchResult_GoodResult{GoodResult: &deeppb_tp.VariableID{ID: "2", Name: "user.password"}}},
+			{Expression: "total", Result: &deeppb_tp.WatchResult_GoodResult{GoodResult: &deeppb_tp.VariableID{ID: "5", Name: "total"}}},
+		},
+	}
+}
+
+func TestRedactSnapshot(t *testing.T) {
+	r := newRedactor(log.NewNopLogger())
+	rules := r.rulesFor("test", []overrides.RedactionRule{
+		{VariableName: "password", Action: overrides.RedactionActionDrop},
+		{VariableType: "^Token$", Action: overrides.RedactionActionMask},
+		{Value: `\d[\d -]{10,20}\d`, Luhn: true, Action: overrides.RedactionActionMask},
+	})
+
+	snapshot := redactionTestSnapshot()
+	masked, dropped := redactSnapshot(snapshot, rules)
+
+	// password is dropped from the user and from the watch
+	assert.Equal(t, 2, dropped)
+	assert.Equal(t, []*deeppb_tp.VariableID{{ID: "3", Name: "token"}}, snapshot.VarLookup["1"].Children)
+	assert.Equal(t, redactedValue, snapshot.Watches[0].GetErrorResult())
+	assert.Equal(t, "5", snapshot.Watches[1].GetGoodResult().ID)
+
+	// the token is masked along with its children, and only the valid card number is masked in the note
+	assert.Equal(t, 2, masked)
+	assert.Equal(t, redactedValue, snapshot.VarLookup["3"].Value)
+	assert.Empty(t, snapshot.VarLookup["3"].Children)
+	assert.Equal(t, "paid with [REDACTED], ref 1234 5678 9012 3456", snapshot.VarLookup["4"].Value)
+	assert.Equal(t, "149.99", snapshot.VarLookup["5"].Value)
+
+	// the values that can no longer be reached are removed
+	assert.NotContains(t, snapshot.VarLookup, "2")
+	assert.NotContains(t, snapshot.VarLookup, "6")
+	assert.Len(t, snapshot.VarLookup, 4)
+}
+
+func TestRedactSnapshotNoMatch(t *testing.T) {
+	r := newRedactor(log.NewNopLogger())
+	rules := r.rulesFor("test", []overrides.RedactionRule{
+		{VariableName: "^ssn$", Action: overrides.RedactionActionDrop},
+	})
+
+	snapshot := redactionTestSnapshot()
+	masked, dropped := redactSnapshot(snapshot, rules)
+
+	assert.Equal(t, 0, masked)
+	assert.Equal(t, 0, dropped)
+	assert.Equal(t, redactionTestSnapshot(), snapshot)
+}
+
+func TestRedactSnapshotCycle(t *testing.T) {
+	r := newRedactor(log.NewNopLogger())
+	rules := r.rulesFor("test", []overrides.RedactionRule{
+		{VariableName: "secret", Action: overrides.RedactionActionMask},
+	})
+
+	// a node that references itself as its parent
+	snapshot := &deeppb_tp.Snapshot{
+		Frames: []*deeppb_tp.StackFrame{{Variables: []*deeppb_tp.VariableID{{ID: "1", Name: "node"}}}},
+		VarLookup: map[string]*deeppb_tp.Variable{
+			"1": {Type: "Node", Value: "Node@1", Children: []*deeppb_tp.VariableID{{ID: "1", Name: "parent"}, {ID: "2", Name: "secret"}}},
+			"2": {Type: "str", Value: "abc"},
+		},
+	}
+
+	masked, dropped := redactSnapshot(snapshot, rules)
+	assert.Equal(t, 1, masked)
+	assert.Equal(t, 0, dropped)
+	assert.Equal(t, redactedValue, snapshot.VarLookup["2"].Value)
+}
+
+func TestRedactSnapshotDropsEveryReference(t *testing.T) {
+	r := newRedactor(log.NewNopLogger())
+	rules := r.rulesFor("test", []overrides.RedactionRule{
+		{VariableName: "^password$", Action: overrides.RedactionActionDrop},
+	})
+
+	// the same value is referenced as credentials.pw before it is found as user.password, and by a watch
+	snapshot := &deeppb_tp.Snapshot{
+		Frames: []*deeppb_tp.StackFrame{{Variables: []*deeppb_tp.VariableID{
+			{ID: "1", Name: "credentials"},
+			{ID: "2", Name: "user"},
+		}}},
+		VarLookup: map[string]*deeppb_tp.Variable{
+			"1": {Type: "Credentials", Value: "Credentials@1", Children: []*deeppb_tp.VariableID{{ID: "3", Name: "pw"}}},
+			"2": {Type: "User", Value: "User@2", Children: []*deeppb_tp.VariableID{{ID: "3", Name: "password"}}},
+			"3": {Type: "str", Value: "hunter2"},
+		},
+		Watches: []*deeppb_tp.WatchResult{
+			{Expression: "pw", Result: &deeppb_tp.WatchResult_GoodResult{GoodResult: &deeppb_tp.VariableID{ID: "3", Name: "pw"}}},
+		},
+	}
+
+	_, dropped := redactSnapshot(snapshot, rules)
+	assert.Equal(t, 3, dropped)
+	assert.Empty(t, snapshot.VarLookup["1"].Children)
+	assert.Empty(t, snapshot.VarLookup["2"].Children)
+	assert.Equal(t, redactedValue, snapshot.Watches[0].GetErrorResult())
+	assert.NotContains(t, snapshot.VarLookup, "3")
+}
+
+func TestRedactSnapshotLogMsg(t *testing.T) {
+	r := newRedactor(log.NewNopLogger())
+	rules := r.rulesFor("test", []overrides.RedactionRule{
+		{VariableName: "password", Action: overrides.RedactionActionDrop},
+		{Value: `token=\w+`, Action: overrides.RedactionActionMask},
+		{Luhn: true, Action: overrides.RedactionActionDrop},
+	})
+
+	logMsg := "paid with 4111-1111-1111-1111 using token=abc123, order 1234 5678 9012 3456"
+	snapshot := &deeppb_tp.Snapshot{LogMsg: &logMsg}
+
+	masked, dropped := redactSnapshot(snapshot, rules)
+	assert.Equal(t, 1, masked)
+	assert.Equal(t, 0, dropped)
+	assert.Equal(t, "paid with [REDACTED] using [REDACTED], order 1234 5678 9012 3456", *snapshot.LogMsg)
+
+	// log messages without a match are not changed
+	logMsg = "nothing to see"
+	snapshot = &deeppb_tp.Snapshot{LogMsg: &logMsg}
+	masked, _ = redactSnapshot(snapshot, rules)
+	assert.Equal(t, 0, masked)
+	assert.Equal(t, "nothing to see", *snapshot.LogMsg)
+}
+
+func TestRedactorRulesFor(t *testing.T) {
+	r := newRedactor(log.NewNopLogger())
+
+	config := []overrides.RedactionRule{{VariableName: "password", Action: overrides.RedactionActionDrop}}
+	rules := r.rulesFor("test", config)
+	require.Len(t, rules, 1)
+
+	// the compiled rules are reused until the config changes
+	assert.Same(t, rules[0], r.rulesFor("test", config)[0])
+
+	changed := []overrides.RedactionRule{{VariableName: "token", Action: overrides.RedactionActionDrop}}
+	assert.NotSame(t, rules[0], r.rulesFor("test", changed)[0])
+
+	// invalid rules are ignored
+	assert.Empty(t, r.rulesFor("other", []overrides.RedactionRule{{VariableName: "(", Action: overrides.RedactionActionDrop}}))
+}
+
+func TestLuhnValid(t *testing.T) {
+	assert.True(t, luhnValid("4111111111111111"))
+	assert.True(t, luhnValid("4111 1111 1111 1111"))
+	assert.True(t, luhnValid("5500-0000-0000-0004"))
+	assert.False(t, luhnValid("4111111111111112"))
+	assert.False(t, luhnValid("1234 5678 9012 3456"))
+	assert.False(t, luhnValid("0"))
+	assert.False(t, luhnValid("4111a111111111111"))
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+package distributor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/services"
+)
+
+type recordFunc func(ctx context.Context, tenantID string, counts map[string]uint64) error
+
+// snapshotCounter counts the snapshots received for each tracepoint, the counts are periodically sent to the
+// tracepoint service so tracepoints can be retired once they have received their max snapshots
+type snapshotCounter struct {
+	services.Service
+
+	logger log.Logger
+
+	// per-tenant counts of snapshots by tracepoint ID
+	counts map[string]map[string]uint64
+	mutex  sync.Mutex
+
+	recordFunc    recordFunc
+	flushInterval time.Duration
+}
+
+func newSnapshotCounter(logger log.Logger, fn recordFunc, flushInterval time.Duration) *snapshotCounter {
+	c := &snapshotCounter{
+		logger:        logger,
+		counts:        map[string]map[string]uint64{},
+		recordFunc:    fn,
+		flushInterval: flushInterval,
+	}
+
+	c.Service = services.NewTimerService(flushInterval, nil, c.iteration, c.stopping)
+
+	return c
+}
+
+// Inc will increment the count for the tracepoint
+func (c *snapshotCounter) Inc(tenantID, tpID string) {
+	if tpID == "" {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	tenantCounts, ok := c.counts[tenantID]
+	if !ok {
+		tenantCounts = map[string]uint64{}
+		c.counts[tenantID] = tenantCounts
+	}
+	tenantCounts[tpID]++
+}
+
+func (c *snapshotCounter) iteration(ctx context.Context) error {
+	c.flush(ctx)
+	return nil
+}
+
+func (c *snapshotCounter) stopping(_ error) error {
+	c.flush(context.Background())
+	return nil
+}
+
+// flush will send the current counts to the tracepoint service and reset them
+func (c *snapshotCounter) flush(ctx context.Context) {
+	c.mutex.Lock()
+	counts := c.counts
+	c.counts = map[string]map[string]uint64{}
+	c.mutex.Unlock()
+
+	for tenantID, tenantCounts := range counts {
+		err := c.recordFunc(ctx, tenantID, tenantCounts)
+		if err != nil {
+			// we do not retry as the counts are only used to retire tracepoints, so losing some is acceptable
+			_ = level.Warn(c.logger).Log("msg", "failed to record snapshot counts", "tenant", tenantID, "err", err)
+		}
+	}
+}
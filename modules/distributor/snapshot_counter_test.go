@@ -0,0 +1,53 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+package distributor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotCounterFlush(t *testing.T) {
+	recorded := map[string]map[string]uint64{}
+	counter := newSnapshotCounter(log.NewNopLogger(), func(ctx context.Context, tenantID string, counts map[string]uint64) error {
+		recorded[tenantID] = counts
+		return nil
+	}, time.Minute)
+
+	counter.Inc("tenant-a", "tp-1")
+	counter.Inc("tenant-a", "tp-1")
+	counter.Inc("tenant-a", "tp-2")
+	counter.Inc("tenant-b", "tp-1")
+	// snapshots without a tracepoint are not counted
+	counter.Inc("tenant-b", "")
+
+	counter.flush(context.Background())
+
+	assert.Equal(t, map[string]map[string]uint64{
+		"tenant-a": {"tp-1": 2, "tp-2": 1},
+		"tenant-b": {"tp-1": 1},
+	}, recorded)
+
+	// the counts are reset after a flush
+	recorded = map[string]map[string]uint64{}
+	counter.flush(context.Background())
+	assert.Equal(t, 0, len(recorded))
+}
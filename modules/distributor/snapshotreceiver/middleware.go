@@ -20,6 +20,8 @@ package snapshotreceiver
 import (
 	"context"
 
+	"github.com/intergral/deep/pkg/deeppb"
+	deeppb_poll "github.com/intergral/deep/pkg/deeppb/poll/v1"
 	"github.com/intergral/deep/pkg/receivers/config/client"
 	receivers "github.com/intergral/deep/pkg/receivers/types"
 	"github.com/intergral/deep/pkg/util"
@@ -35,6 +37,8 @@ import (
 type Middleware interface {
 	WrapPoll(poll receivers.ProcessPoll) receivers.ProcessPoll
 	WrapSnapshots(snap receivers.ProcessSnapshots) receivers.ProcessSnapshots
+	WrapSubscribe(subscribe receivers.ProcessSubscribe) receivers.ProcessSubscribe
+	WrapStatus(status receivers.ProcessStatus) receivers.ProcessStatus
 }
 
 // fakeTenantMiddleware creates a middleware that puts all requests into util.FakeTenantID scope.
@@ -58,6 +62,20 @@ func (m *fakeTenantMiddleware) WrapSnapshots(snap receivers.ProcessSnapshots) re
 	}
 }
 
+func (m *fakeTenantMiddleware) WrapSubscribe(subscribe receivers.ProcessSubscribe) receivers.ProcessSubscribe {
+	return func(ctx context.Context, pollRequest *deeppb_poll.PollRequest, send receivers.PollSender) error {
+		ctx = util.InjectTenantID(ctx, util.FakeTenantID)
+		return subscribe(ctx, pollRequest, send)
+	}
+}
+
+func (m *fakeTenantMiddleware) WrapStatus(status receivers.ProcessStatus) receivers.ProcessStatus {
+	return func(ctx context.Context, req *deeppb.ReportStatusRequest) (*deeppb.ReportStatusResponse, error) {
+		ctx = util.InjectTenantID(ctx, util.FakeTenantID)
+		return status(ctx, req)
+	}
+}
+
 // multiTenancyMiddleware is the main middleware that will look for the user.OrgIDHeaderName header
 // and attach it to the context
 type multiTenancyMiddleware struct{}
@@ -86,6 +104,26 @@ func (m *multiTenancyMiddleware) WrapSnapshots(snap receivers.ProcessSnapshots)
 	}
 }
 
+func (m *multiTenancyMiddleware) WrapSubscribe(subscribe receivers.ProcessSubscribe) receivers.ProcessSubscribe {
+	return func(ctx context.Context, pollRequest *deeppb_poll.PollRequest, send receivers.PollSender) error {
+		idCtx, err := m.findAttachTenantId(ctx)
+		if err != nil {
+			return err
+		}
+		return subscribe(idCtx, pollRequest, send)
+	}
+}
+
+func (m *multiTenancyMiddleware) WrapStatus(status receivers.ProcessStatus) receivers.ProcessStatus {
+	return func(ctx context.Context, req *deeppb.ReportStatusRequest) (*deeppb.ReportStatusResponse, error) {
+		idCtx, err := m.findAttachTenantId(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return status(idCtx, req)
+	}
+}
+
 func (m *multiTenancyMiddleware) findAttachTenantId(ctx context.Context) (context.Context, error) {
 	var err error
 	_, ctx, err = user.ExtractFromGRPCRequest(ctx)
@@ -26,6 +26,8 @@ import (
 	gkLog "github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/grafana/dskit/services"
+	"github.com/intergral/deep/pkg/deeppb"
+	deeppb_poll "github.com/intergral/deep/pkg/deeppb/poll/v1"
 	"github.com/intergral/deep/pkg/receivers"
 	"github.com/intergral/deep/pkg/receivers/types"
 	"github.com/intergral/deep/pkg/util"
@@ -73,6 +75,26 @@ var (
 		Help:      "Number of completed poll requests.",
 	}, []string{"tenant", "receiver"})
 
+	metricStatusAccepted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "deep",
+		Subsystem: "distributor",
+		Name:      "status_requests",
+		Help:      "Number of tracepoint status reports received from agents.",
+	}, []string{"tenant", "receiver"})
+	metricStatusRefused = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "deep",
+		Subsystem: "distributor",
+		Name:      "failed_status_requests",
+		Help:      "Number of tracepoint status reports that could not be recorded.",
+	}, []string{"tenant", "receiver"})
+
+	metricPollSubscriptions = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "deep",
+		Subsystem: "distributor",
+		Name:      "poll_subscriptions",
+		Help:      "Number of agents currently subscribed to tracepoint changes.",
+	}, []string{"tenant", "receiver"})
+
 	metricDistributorRefused = promauto.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "deep",
 		Subsystem: "distributor",
@@ -90,6 +112,8 @@ var (
 type SnapshotPusher interface {
 	PushSnapshot(ctx context.Context, snapshot *tp.Snapshot) (*tp.SnapshotResponse, error)
 	PushPoll(ctx context.Context, pollRequest *pb.PollRequest) (*pb.PollResponse, error)
+	PushSubscribe(ctx context.Context, pollRequest *deeppb_poll.PollRequest, send types.PollSender) error
+	PushStatus(ctx context.Context, req *deeppb.ReportStatusRequest) (*deeppb.ReportStatusResponse, error)
 }
 
 type SnapshotReceiver struct {
@@ -136,6 +160,26 @@ func (sr *snapshotReceiver) Poll(ctx context.Context, pollRequest *pb.PollReques
 	return pollResponse, err
 }
 
+// Subscribe will accept a subscription from a receiver and push it on to be distributed. This blocks until the
+// agent disconnects. Here we also track the number of active subscriptions
+func (sr *snapshotReceiver) Subscribe(ctx context.Context, pollRequest *deeppb_poll.PollRequest, send types.PollSender) error {
+	name := receivers.ExtractReceiverName(ctx)
+
+	// we always have a tenant ID by this point
+	tenantID, _ := util.ExtractTenantID(ctx)
+
+	gauge := metricPollSubscriptions.WithLabelValues(tenantID, name)
+	gauge.Inc()
+	defer gauge.Dec()
+
+	err := sr.pusher.PushSubscribe(ctx, pollRequest, send)
+	if err != nil {
+		sr.logger.Log("msg", "pusher failed to process subscription", "err", err)
+		metricPollRefused.WithLabelValues(tenantID, name).Inc()
+	}
+	return err
+}
+
 // Send will accept a tp.Snapshot from a receiver and push it on to be distributed.
 // Here we also track metrics for received snapshots
 func (sr *snapshotReceiver) Send(ctx context.Context, in *tp.Snapshot) (*tp.SnapshotResponse, error) {
@@ -160,6 +204,27 @@ func (sr *snapshotReceiver) Send(ctx context.Context, in *tp.Snapshot) (*tp.Snap
 	return snapshotResponse, err
 }
 
+// ReportStatus will accept the tracepoint install status from a receiver and push it on to be recorded.
+// Here we also track metrics for received reports
+func (sr *snapshotReceiver) ReportStatus(ctx context.Context, req *deeppb.ReportStatusRequest) (*deeppb.ReportStatusResponse, error) {
+	name := receivers.ExtractReceiverName(ctx)
+	span, ctx := opentracing.StartSpanFromContext(ctx, "SnapshotReceiver.ReportStatus")
+	span.SetTag("receiver", name)
+	defer span.Finish()
+
+	statusResponse, err := sr.pusher.PushStatus(ctx, req)
+
+	// we always have a tenant ID by this point
+	tenantID, _ := util.ExtractTenantID(ctx)
+
+	if err != nil {
+		sr.logger.Log("msg", "pusher failed to record tracepoint status", "err", err)
+		metricStatusRefused.WithLabelValues(tenantID, name).Inc()
+	}
+	metricStatusAccepted.WithLabelValues(tenantID, name).Inc()
+	return statusResponse, err
+}
+
 // New creates a new snapshotReceiver that can accept and process snapshots
 func New(receiverCfg map[string]interface{}, pusher SnapshotPusher, middleware Middleware, logger gkLog.Logger) (services.Service, tp.SnapshotServiceServer, error) {
 	receiver := &snapshotReceiver{
@@ -168,7 +233,7 @@ func New(receiverCfg map[string]interface{}, pusher SnapshotPusher, middleware M
 		fatal:  make(chan error),
 	}
 
-	receiversFor, err := receivers.ForConfig(receiverCfg, middleware.WrapSnapshots(receiver.Send), middleware.WrapPoll(receiver.Poll), logger)
+	receiversFor, err := receivers.ForConfig(receiverCfg, middleware.WrapSnapshots(receiver.Send), middleware.WrapPoll(receiver.Poll), middleware.WrapSubscribe(receiver.Subscribe), middleware.WrapStatus(receiver.ReportStatus), logger)
 	if len(receiversFor) == 0 {
 		return nil, nil, errors.New("no receivers configured")
 	}
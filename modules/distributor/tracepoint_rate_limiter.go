@@ -0,0 +1,274 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package distributor
+
+import (
+	"context"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/grafana/dskit/services"
+	pb "github.com/intergral/deep/pkg/deeppb/poll/v1"
+	deeppb_tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/intergral/deep/pkg/util"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// tracepoint limiters that have not been used for this long are removed
+	tracepointLimiterIdleTimeout = 10 * time.Minute
+	// limitsHashSeparator separates the hash from the tracepoint service and the hash of the limits, see withTracepointLimits
+	limitsHashSeparator = "-"
+)
+
+// tracepointRateLimiter limits the snapshots and bytes per second that are accepted for each tracepoint, this stops
+// a single tracepoint from using the whole ingestion limit of the tenant. When the global ingestion rate strategy is
+// used the limits are divided between the healthy distributors, like the tenant ingestion limits.
+type tracepointRateLimiter struct {
+	services.Service
+
+	ring     ReadLifecycler
+	limiters map[string]*tracepointLimiter
+	mutex    sync.Mutex
+}
+
+type tracepointLimiter struct {
+	snapshots *rate.Limiter
+	bytes     *rate.Limiter
+	lastSeen  time.Time
+}
+
+// newTracepointRateLimiter creates the limiter, ring is nil when the limits are applied by each distributor
+func newTracepointRateLimiter(ring ReadLifecycler) *tracepointRateLimiter {
+	l := &tracepointRateLimiter{
+		ring:     ring,
+		limiters: map[string]*tracepointLimiter{},
+	}
+
+	l.Service = services.NewTimerService(time.Minute, nil, l.iteration, nil)
+
+	return l
+}
+
+func (l *tracepointRateLimiter) iteration(_ context.Context) error {
+	l.prune(time.Now())
+	return nil
+}
+
+// ReserveN returns true if the snapshot of the given size is within the limits of the tracepoint. A limit of 0 is
+// not enforced. The burst of the bytes limit is never less than maxSnapshotBytes, so any snapshot the tenant can send
+// will pass a limiter that has not been used. If the snapshot is then rejected by another limit, the returned func
+// must be called to give back the tokens that were taken.
+func (l *tracepointRateLimiter) ReserveN(now time.Time, tenantID, tpID string, snapshotLimit float64, bytesLimit int, maxSnapshotBytes int, size int) (bool, func()) {
+	if tpID == "" || (snapshotLimit <= 0 && bytesLimit <= 0) {
+		return true, func() {}
+	}
+
+	snapshotLimit, bytesLimit = l.shareLimits(snapshotLimit, bytesLimit)
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	key := tenantID + "/" + tpID
+	limiter, ok := l.limiters[key]
+	if !ok {
+		limiter = &tracepointLimiter{
+			snapshots: rate.NewLimiter(limitAndBurst(snapshotLimit, 1)),
+			bytes:     rate.NewLimiter(limitAndBurst(float64(bytesLimit), maxSnapshotBytes)),
+		}
+		l.limiters[key] = limiter
+	}
+	limiter.lastSeen = now
+
+	// the limits can change at any time, so we update them on each snapshot
+	setLimit(limiter.snapshots, now, snapshotLimit, 1)
+	setLimit(limiter.bytes, now, float64(bytesLimit), maxSnapshotBytes)
+
+	snapshots := limiter.snapshots.ReserveN(now, 1)
+	if !snapshots.OK() || snapshots.DelayFrom(now) > 0 {
+		snapshots.CancelAt(now)
+		return false, nil
+	}
+	bytes := limiter.bytes.ReserveN(now, size)
+	if !bytes.OK() || bytes.DelayFrom(now) > 0 {
+		bytes.CancelAt(now)
+		snapshots.CancelAt(now)
+		return false, nil
+	}
+
+	return true, func() {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+
+		bytes.CancelAt(now)
+		snapshots.CancelAt(now)
+	}
+}
+
+// shareLimits divides the limits between the healthy distributors, a limit that is enforced is never divided to 0
+func (l *tracepointRateLimiter) shareLimits(snapshotLimit float64, bytesLimit int) (float64, int) {
+	if l.ring == nil {
+		return snapshotLimit, bytesLimit
+	}
+	numDistributors := l.ring.HealthyInstancesCount()
+	if numDistributors <= 1 {
+		return snapshotLimit, bytesLimit
+	}
+
+	snapshotLimit = snapshotLimit / float64(numDistributors)
+	if bytesLimit > 0 {
+		bytesLimit = bytesLimit / numDistributors
+		if bytesLimit < 1 {
+			bytesLimit = 1
+		}
+	}
+	return snapshotLimit, bytesLimit
+}
+
+// prune will remove the limiters for tracepoints that have not received a snapshot recently
+func (l *tracepointRateLimiter) prune(now time.Time) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for key, limiter := range l.limiters {
+		if now.Sub(limiter.lastSeen) > tracepointLimiterIdleTimeout {
+			delete(l.limiters, key)
+		}
+	}
+}
+
+// setLimit will update the limiter if the limit or burst has changed
+func setLimit(limiter *rate.Limiter, now time.Time, limit float64, minBurst int) {
+	newLimit, burst := limitAndBurst(limit, minBurst)
+	if limiter.Limit() != newLimit || limiter.Burst() != burst {
+		limiter.SetLimitAt(now, newLimit)
+		limiter.SetBurstAt(now, burst)
+	}
+}
+
+// limitAndBurst converts the limit into a rate, the burst allows one second of the limit but is never less than
+// minBurst. A limit of 0 is not enforced.
+func limitAndBurst(limit float64, minBurst int) (rate.Limit, int) {
+	if limit <= 0 {
+		return rate.Inf, 0
+	}
+
+	burst := int(limit)
+	if burst < minBurst {
+		burst = minBurst
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return rate.Limit(limit), burst
+}
+
+// tracepointLimits returns the snapshot and byte limits for the tracepoint. The limits set on the tracepoint are
+// used when they are lower than the limits of the tenant, so a tracepoint can never exceed the tenant limits.
+func tracepointLimits(config *deeppb_tp.TracePointConfig, tenantSnapshotLimit float64, tenantBytesLimit int) (float64, int) {
+	snapshotLimit := tenantSnapshotLimit
+	if arg, err := strconv.ParseFloat(config.GetArgs()[util.ArgSnapshotRateLimit], 64); err == nil && arg > 0 {
+		if snapshotLimit <= 0 || arg < snapshotLimit {
+			snapshotLimit = arg
+		}
+	}
+
+	bytesLimit := tenantBytesLimit
+	if arg, err := strconv.Atoi(config.GetArgs()[util.ArgBytesRateLimit]); err == nil && arg > 0 {
+		if bytesLimit <= 0 || arg < bytesLimit {
+			bytesLimit = arg
+		}
+	}
+
+	return snapshotLimit, bytesLimit
+}
+
+// withTracepointLimits will set the limits for each tracepoint as args in the response, so the agents can throttle
+// snapshots before they are sent. These are the limits of the whole tracepoint, not the share of this distributor, as
+// an agent can send snapshots to any distributor. The tracepoints are shared with the tracepoint client, so they are copied before
+// being changed.
+func withTracepointLimits(response *pb.PollResponse, tenantSnapshotLimit float64, tenantBytesLimit int) *pb.PollResponse {
+	if response == nil || (tenantSnapshotLimit <= 0 && tenantBytesLimit <= 0) {
+		return response
+	}
+
+	tracepoints := make([]*deeppb_tp.TracePointConfig, len(response.Response))
+	for i, config := range response.Response {
+		snapshotLimit, bytesLimit := tracepointLimits(config, tenantSnapshotLimit, tenantBytesLimit)
+
+		args := map[string]string{}
+		if snapshotLimit > 0 {
+			args[util.ArgSnapshotRateLimit] = strconv.FormatFloat(snapshotLimit, 'f', -1, 64)
+		}
+		if bytesLimit > 0 {
+			args[util.ArgBytesRateLimit] = strconv.Itoa(bytesLimit)
+		}
+
+		tracepoints[i] = config
+		for key, value := range args {
+			if config.GetArgs()[key] == value {
+				continue
+			}
+			if tracepoints[i] == config {
+				tracepoints[i] = proto.Clone(config).(*deeppb_tp.TracePointConfig)
+				if tracepoints[i].Args == nil {
+					tracepoints[i].Args = map[string]string{}
+				}
+			}
+			tracepoints[i].Args[key] = value
+		}
+	}
+
+	return &pb.PollResponse{
+		TsNanos:      response.TsNanos,
+		CurrentHash:  response.CurrentHash + limitsHashSeparator + limitsHash(tenantSnapshotLimit, tenantBytesLimit),
+		Response:     tracepoints,
+		ResponseType: response.ResponseType,
+	}
+}
+
+// limitsHash identifies the tenant limits that withTracepointLimits sets as args. This is added to the hash sent to
+// the agents, so the agents are sent the tracepoints again when the limits change.
+func limitsHash(tenantSnapshotLimit float64, tenantBytesLimit int) string {
+	h := fnv.New32()
+	_, _ = h.Write([]byte(strconv.FormatFloat(tenantSnapshotLimit, 'f', -1, 64)))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(strconv.Itoa(tenantBytesLimit)))
+	return strconv.Itoa(int(h.Sum32()))
+}
+
+// tracepointServiceHash returns the hash the tracepoint service should compare for a hash sent by an agent. If the
+// agent has the tracepoints for different limits, then no hash is returned so the agent is sent the tracepoints again.
+func tracepointServiceHash(agentHash string, tenantSnapshotLimit float64, tenantBytesLimit int) string {
+	hash, agentLimits, hasLimits := strings.Cut(agentHash, limitsHashSeparator)
+	if tenantSnapshotLimit <= 0 && tenantBytesLimit <= 0 {
+		if hasLimits {
+			return ""
+		}
+		return hash
+	}
+
+	if !hasLimits || agentLimits != limitsHash(tenantSnapshotLimit, tenantBytesLimit) {
+		return ""
+	}
+	return hash
+}
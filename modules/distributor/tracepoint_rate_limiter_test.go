@@ -0,0 +1,208 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package distributor
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/intergral/deep/pkg/deeppb/poll/v1"
+	deeppb_tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/intergral/deep/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+// allowN reserves the snapshot from the limiter, the tokens are never given back
+func allowN(limiter *tracepointRateLimiter, now time.Time, tenantID, tpID string, snapshotLimit float64, bytesLimit int, size int) bool {
+	allowed, _ := limiter.ReserveN(now, tenantID, tpID, snapshotLimit, bytesLimit, 0, size)
+	return allowed
+}
+
+func TestTracepointRateLimiterSnapshots(t *testing.T) {
+	limiter := newTracepointRateLimiter(nil)
+	now := time.Now()
+
+	assert.True(t, allowN(limiter, now, "tenant", "tp-1", 2, 0, 10))
+	assert.True(t, allowN(limiter, now, "tenant", "tp-1", 2, 0, 10))
+	assert.False(t, allowN(limiter, now, "tenant", "tp-1", 2, 0, 10))
+
+	// each tracepoint has its own limit
+	assert.True(t, allowN(limiter, now, "tenant", "tp-2", 2, 0, 10))
+	assert.True(t, allowN(limiter, now, "other", "tp-1", 2, 0, 10))
+
+	// the limit refills over time
+	assert.True(t, allowN(limiter, now.Add(time.Second), "tenant", "tp-1", 2, 0, 10))
+}
+
+func TestTracepointRateLimiterBytes(t *testing.T) {
+	limiter := newTracepointRateLimiter(nil)
+	now := time.Now()
+
+	assert.True(t, allowN(limiter, now, "tenant", "tp-1", 0, 100, 60))
+	assert.False(t, allowN(limiter, now, "tenant", "tp-1", 0, 100, 60))
+
+	// snapshots without a tracepoint, or without limits, are always allowed
+	assert.True(t, allowN(limiter, now, "tenant", "", 0, 100, 1000))
+	assert.True(t, allowN(limiter, now, "tenant", "tp-2", 0, 0, 1000))
+}
+
+func TestTracepointRateLimiterAllowsLargestSnapshot(t *testing.T) {
+	limiter := newTracepointRateLimiter(nil)
+	now := time.Now()
+
+	// the snapshot is larger than the bytes per second, but the tenant allows snapshots of this size
+	allowed, _ := limiter.ReserveN(now, "tenant", "tp-1", 0, 100, 500, 400)
+	assert.True(t, allowed)
+	allowed, _ = limiter.ReserveN(now, "tenant", "tp-1", 0, 100, 500, 400)
+	assert.False(t, allowed)
+}
+
+func TestTracepointRateLimiterCancel(t *testing.T) {
+	limiter := newTracepointRateLimiter(nil)
+	now := time.Now()
+
+	allowed, cancel := limiter.ReserveN(now, "tenant", "tp-1", 1, 100, 0, 60)
+	assert.True(t, allowed)
+
+	// the snapshot was rejected by another limit, so the tokens can be used by the next snapshot
+	cancel()
+	allowed, _ = limiter.ReserveN(now, "tenant", "tp-1", 1, 100, 0, 60)
+	assert.True(t, allowed)
+	allowed, _ = limiter.ReserveN(now, "tenant", "tp-1", 1, 100, 0, 60)
+	assert.False(t, allowed)
+}
+
+func TestTracepointRateLimiterRejectedBytesDoNotTakeSnapshots(t *testing.T) {
+	limiter := newTracepointRateLimiter(nil)
+	now := time.Now()
+
+	// the snapshot is too large for the bytes limit, so the snapshot limit should not be used
+	assert.False(t, allowN(limiter, now, "tenant", "tp-1", 1, 100, 200))
+	assert.True(t, allowN(limiter, now, "tenant", "tp-1", 1, 100, 50))
+}
+
+func TestTracepointRateLimiterPrune(t *testing.T) {
+	limiter := newTracepointRateLimiter(nil)
+	now := time.Now()
+
+	allowN(limiter, now, "tenant", "tp-1", 1, 0, 10)
+	allowN(limiter, now.Add(tracepointLimiterIdleTimeout), "tenant", "tp-2", 1, 0, 10)
+
+	limiter.prune(now.Add(tracepointLimiterIdleTimeout + time.Second))
+
+	assert.Equal(t, 1, len(limiter.limiters))
+	assert.NotNil(t, limiter.limiters["tenant/tp-2"])
+}
+
+func TestTracepointRateLimiterSharedBetweenDistributors(t *testing.T) {
+	ring := newReadLifecyclerMock()
+	ring.On("HealthyInstancesCount").Return(2)
+	limiter := newTracepointRateLimiter(ring)
+	now := time.Now()
+
+	// each of the 2 distributors allows half of the tracepoint limit
+	assert.True(t, allowN(limiter, now, "tenant", "tp-1", 4, 0, 10))
+	assert.True(t, allowN(limiter, now, "tenant", "tp-1", 4, 0, 10))
+	assert.False(t, allowN(limiter, now, "tenant", "tp-1", 4, 0, 10))
+
+	assert.True(t, allowN(limiter, now, "tenant", "tp-2", 0, 100, 50))
+	assert.False(t, allowN(limiter, now, "tenant", "tp-2", 0, 100, 50))
+
+	// a limit is never divided so far that it is no longer enforced
+	snapshotLimit, bytesLimit := limiter.shareLimits(0.1, 1)
+	assert.Equal(t, 0.05, snapshotLimit)
+	assert.Equal(t, 1, bytesLimit)
+}
+
+func TestTracepointLimits(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          map[string]string
+		tenantSnaps   float64
+		tenantBytes   int
+		expectedSnaps float64
+		expectedBytes int
+	}{
+		{name: "no limits"},
+		{name: "tenant limits", tenantSnaps: 10, tenantBytes: 1000, expectedSnaps: 10, expectedBytes: 1000},
+		{
+			name:          "tracepoint limits",
+			args:          map[string]string{util.ArgSnapshotRateLimit: "0.5", util.ArgBytesRateLimit: "100"},
+			expectedSnaps: 0.5,
+			expectedBytes: 100,
+		},
+		{
+			name:          "lower tracepoint limits are used",
+			args:          map[string]string{util.ArgSnapshotRateLimit: "1", util.ArgBytesRateLimit: "100"},
+			tenantSnaps:   10,
+			tenantBytes:   1000,
+			expectedSnaps: 1,
+			expectedBytes: 100,
+		},
+		{
+			name:          "higher tracepoint limits are ignored",
+			args:          map[string]string{util.ArgSnapshotRateLimit: "100", util.ArgBytesRateLimit: "10000"},
+			tenantSnaps:   10,
+			tenantBytes:   1000,
+			expectedSnaps: 10,
+			expectedBytes: 1000,
+		},
+		{
+			name:          "invalid tracepoint limits are ignored",
+			args:          map[string]string{util.ArgSnapshotRateLimit: "fast", util.ArgBytesRateLimit: "-1"},
+			tenantSnaps:   10,
+			expectedSnaps: 10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snaps, bytes := tracepointLimits(&deeppb_tp.TracePointConfig{Args: tt.args}, tt.tenantSnaps, tt.tenantBytes)
+			assert.Equal(t, tt.expectedSnaps, snaps)
+			assert.Equal(t, tt.expectedBytes, bytes)
+		})
+	}
+}
+
+func TestWithTracepointLimits(t *testing.T) {
+	original := &deeppb_tp.TracePointConfig{ID: "1"}
+	limited := &deeppb_tp.TracePointConfig{ID: "2", Args: map[string]string{util.ArgSnapshotRateLimit: "1"}}
+	response := &pb.PollResponse{CurrentHash: "hash", Response: []*deeppb_tp.TracePointConfig{original, limited}}
+
+	withLimits := withTracepointLimits(response, 10, 0)
+
+	assert.Equal(t, "hash-"+limitsHash(10, 0), withLimits.CurrentHash)
+	assert.Equal(t, "10", withLimits.Response[0].Args[util.ArgSnapshotRateLimit])
+	assert.Equal(t, "1", withLimits.Response[1].Args[util.ArgSnapshotRateLimit])
+
+	// the configs from the tracepoint service must not be changed
+	assert.Nil(t, original.Args)
+	assert.Same(t, limited, withLimits.Response[1])
+}
+
+func TestTracepointServiceHash(t *testing.T) {
+	limited := withTracepointLimits(&pb.PollResponse{CurrentHash: "123"}, 10, 1000)
+
+	// the agent has the tracepoints with the current limits
+	assert.Equal(t, "123", tracepointServiceHash(limited.CurrentHash, 10, 1000))
+	// the limits have changed, so the agent needs the tracepoints again
+	assert.Equal(t, "", tracepointServiceHash(limited.CurrentHash, 5, 1000))
+	assert.Equal(t, "", tracepointServiceHash(limited.CurrentHash, 0, 0))
+	assert.Equal(t, "", tracepointServiceHash("123", 10, 1000))
+	// no limits are set
+	assert.Equal(t, "123", tracepointServiceHash("123", 0, 0))
+}
@@ -38,6 +38,8 @@ type Config struct {
 	TolerateFailedBlocks int                `yaml:"tolerate_failed_blocks,omitempty"`
 	Search               SearchConfig       `yaml:"search"`
 	SnapshotByID         SnapshotByIDConfig `yaml:"snapshot_by_id"`
+	Tail                 TailConfig         `yaml:"tail"`
+	Export               ExportConfig       `yaml:"export"`
 }
 
 type SearchConfig struct {
@@ -51,6 +53,19 @@ type SnapshotByIDConfig struct {
 	SLO         SLOConfig     `yaml:",inline"`
 }
 
+type TailConfig struct {
+	// KeepAlive is how often a comment is written to idle tails, so proxies do not close the connection
+	KeepAlive time.Duration `yaml:"keep_alive,omitempty"`
+}
+
+type ExportConfig struct {
+	// MaxSnapshots is the most snapshots a single export can return, and the limit used when none is requested.
+	// 0 to disable.
+	MaxSnapshots uint32 `yaml:"max_snapshots,omitempty"`
+	// ConcurrentRequests is the number of snapshots fetched at once for an export
+	ConcurrentRequests int `yaml:"concurrent_requests,omitempty"`
+}
+
 type HedgingConfig struct {
 	HedgeRequestsAt   time.Duration `yaml:"hedge_requests_at"`
 	HedgeRequestsUpTo int           `yaml:"hedge_requests_up_to"`
@@ -76,6 +91,7 @@ func (cfg *Config) RegisterFlagsAndApplyDefaults(string, *flag.FlagSet) {
 			QueryIngestersUntil:   30 * time.Minute,
 			DefaultLimit:          20,
 			MaxLimit:              0,
+			MaxMetricsSnapshots:   100_000,
 			MaxDuration:           168 * time.Hour, // 1 week
 			ConcurrentRequests:    defaultConcurrentRequests,
 			TargetBytesPerRequest: defaultTargetBytesPerRequest,
@@ -90,6 +106,13 @@ func (cfg *Config) RegisterFlagsAndApplyDefaults(string, *flag.FlagSet) {
 			HedgeRequestsUpTo: 2,
 		},
 	}
+	cfg.Tail = TailConfig{
+		KeepAlive: 15 * time.Second,
+	}
+	cfg.Export = ExportConfig{
+		MaxSnapshots:       10_000,
+		ConcurrentRequests: 20,
+	}
 }
 
 type CortexNoQuerierLimits struct{}
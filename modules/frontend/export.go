@@ -0,0 +1,364 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package frontend
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/golang/protobuf/jsonpb" //nolint:all //deprecated
+	"github.com/golang/protobuf/proto"  //nolint:all //deprecated
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/intergral/deep/modules/overrides"
+	"github.com/intergral/deep/pkg/api"
+	"github.com/intergral/deep/pkg/deepdb/encoding/vparquet"
+	"github.com/intergral/deep/pkg/deeppb"
+	deep_tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/intergral/deep/pkg/util"
+)
+
+const (
+	// HeaderExportTruncated is the trailer set to true when an export stopped before every matching snapshot was
+	// written, because it reached the byte limit of the tenant
+	HeaderExportTruncated = "X-Deep-Export-Truncated"
+	// HeaderExportError is the trailer that holds the error that stopped an export after it started streaming
+	HeaderExportError = "X-Deep-Export-Error"
+
+	contentTypeNDJSON  = "application/x-ndjson"
+	contentTypeParquet = "application/vnd.apache.parquet"
+
+	// exportRowGroupSize is the number of snapshots in each row group of a parquet export
+	exportRowGroupSize = 1000
+)
+
+// exportHandler streams the full snapshots that match a search. The search is run through the search sharder, then
+// the snapshots are fetched in batches through the snapshot by id sharder and written as they arrive.
+type exportHandler struct {
+	cfg              ExportConfig
+	search           http.RoundTripper
+	snapshots        http.RoundTripper
+	limits           *overrides.Overrides
+	queriesPerTenant *prometheus.CounterVec
+	logger           log.Logger
+}
+
+func newExportHandler(cfg ExportConfig, search, snapshots http.RoundTripper, limits *overrides.Overrides, queriesPerTenant *prometheus.CounterVec, logger log.Logger) http.Handler {
+	if cfg.ConcurrentRequests <= 0 {
+		cfg.ConcurrentRequests = 1
+	}
+	return &exportHandler{
+		cfg:              cfg,
+		search:           search,
+		snapshots:        snapshots,
+		limits:           limits,
+		queriesPerTenant: queriesPerTenant,
+		logger:           logger,
+	}
+}
+
+// ServeHTTP implements http.Handler
+func (e *exportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	tenantID, err := util.ExtractTenantID(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	statusCode := http.StatusOK
+	defer func() {
+		e.queriesPerTenant.WithLabelValues(tenantID, strconv.Itoa(statusCode)).Inc()
+	}()
+
+	searchReq, format, err := api.ParseExportRequest(r)
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if e.cfg.MaxSnapshots > 0 && (searchReq.Limit == 0 || searchReq.Limit > e.cfg.MaxSnapshots) {
+		searchReq.Limit = e.cfg.MaxSnapshots
+	}
+
+	ids, statusCode, err := e.searchSnapshotIDs(r, searchReq)
+	if err != nil {
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	var writer snapshotWriter
+	switch format {
+	case api.ExportFormatParquet:
+		writer = &parquetSnapshotWriter{w: vparquet.NewSnapshotWriter(w)}
+		w.Header().Set(api.HeaderContentType, contentTypeParquet)
+		w.Header().Set("Content-Disposition", `attachment; filename="snapshots.parquet"`)
+	default:
+		writer = &ndjsonSnapshotWriter{w: w, marshaller: &jsonpb.Marshaler{}}
+		w.Header().Set(api.HeaderContentType, contentTypeNDJSON)
+	}
+	w.Header().Set("Trailer", HeaderExportTruncated+", "+HeaderExportError)
+	w.WriteHeader(http.StatusOK)
+
+	written, truncated, err := e.writeSnapshots(r, tenantID, searchReq, ids, writer)
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	w.Header().Set(HeaderExportTruncated, strconv.FormatBool(truncated))
+	if err != nil {
+		w.Header().Set(HeaderExportError, err.Error())
+		level.Error(e.logger).Log("msg", "snapshot export failed", "tenant", tenantID, "err", err)
+	}
+
+	level.Info(e.logger).Log(
+		"msg", "snapshot export",
+		"tenant", tenantID,
+		"query", searchReq.Query,
+		"format", format,
+		"matched", len(ids),
+		"written", written,
+		"truncated", truncated,
+		"duration", time.Since(start).String(),
+	)
+}
+
+// searchSnapshotIDs runs the search and returns the ids of the matching snapshots. If the search fails the status
+// code to respond with is returned alongside the error.
+func (e *exportHandler) searchSnapshotIDs(r *http.Request, searchReq *deeppb.SearchRequest) ([]string, int, error) {
+	subR := r.Clone(r.Context())
+	subR.URL.Path = api.PathSearch
+	subR.URL.RawQuery = ""
+	subR, err := api.BuildSearchRequest(subR, searchReq)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	subR.RequestURI = subR.URL.RequestURI()
+
+	resp, err := e.search.RoundTrip(subR)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, resp.StatusCode, errors.New(string(body))
+	}
+
+	results := &deeppb.SearchResponse{}
+	err = jsonpb.Unmarshal(resp.Body, results)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.Wrap(err, "error reading search results")
+	}
+
+	ids := make([]string, 0, len(results.Snapshots))
+	for _, s := range results.Snapshots {
+		ids = append(ids, s.SnapshotID)
+	}
+	return ids, http.StatusOK, nil
+}
+
+// writeSnapshots fetches and writes the snapshots in the order the search returned them, until they are all written
+// or the byte limit of the tenant is reached. It returns the number of snapshots written, and if the export was
+// truncated.
+func (e *exportHandler) writeSnapshots(r *http.Request, tenantID string, searchReq *deeppb.SearchRequest, ids []string, writer snapshotWriter) (int, bool, error) {
+	maxBytes := e.limits.MaxBytesPerExport(tenantID)
+	totalBytes := 0
+	written := 0
+
+	for batchStart := 0; batchStart < len(ids); batchStart += e.cfg.ConcurrentRequests {
+		batchEnd := batchStart + e.cfg.ConcurrentRequests
+		if batchEnd > len(ids) {
+			batchEnd = len(ids)
+		}
+
+		snapshots, err := e.fetchSnapshots(r, searchReq, ids[batchStart:batchEnd])
+		if err != nil {
+			return written, false, err
+		}
+
+		for _, snapshot := range snapshots {
+			// the snapshot may have been removed since the search ran
+			if snapshot == nil {
+				continue
+			}
+
+			size := proto.Size(snapshot)
+			if maxBytes > 0 && totalBytes+size > maxBytes {
+				return written, true, nil
+			}
+
+			err = writer.Write(snapshot)
+			if err != nil {
+				return written, false, err
+			}
+			totalBytes += size
+			written++
+		}
+
+		err = writer.Flush()
+		if err != nil {
+			return written, false, err
+		}
+	}
+
+	return written, false, nil
+}
+
+// fetchSnapshots fetches the snapshots with the passed ids concurrently. The result is in the same order as ids, with
+// nil for any snapshot that was not found.
+func (e *exportHandler) fetchSnapshots(r *http.Request, searchReq *deeppb.SearchRequest, ids []string) ([]*deep_tp.Snapshot, error) {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	snapshots := make([]*deep_tp.Snapshot, len(ids))
+	errs := make([]error, len(ids))
+
+	wg := sync.WaitGroup{}
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			snapshots[i], errs[i] = e.fetchSnapshot(ctx, r, searchReq, id)
+			if errs[i] != nil {
+				// no need to finish the batch once a fetch has failed
+				cancel()
+			}
+		}(i, id)
+	}
+	wg.Wait()
+
+	// report the error that failed the batch, not the cancellation of the others
+	var err error
+	for _, fetchErr := range errs {
+		if fetchErr != nil && (err == nil || errors.Is(err, context.Canceled)) {
+			err = fetchErr
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+func (e *exportHandler) fetchSnapshot(ctx context.Context, parent *http.Request, searchReq *deeppb.SearchRequest, id string) (*deep_tp.Snapshot, error) {
+	subR := parent.Clone(ctx)
+	subR.URL.Path = path.Join(path.Dir(api.PathSnapshots), id)
+	// limit the blocks searched for the snapshot to the range of the export
+	subR.URL.RawQuery = fmt.Sprintf("start=%d&end=%d", searchReq.Start, searchReq.End)
+	subR.RequestURI = subR.URL.RequestURI()
+	subR.Header.Set(api.HeaderAccept, api.HeaderAcceptProtobuf)
+	subR = mux.SetURLVars(subR, map[string]string{api.URLParamSnapshotID: id})
+
+	resp, err := e.snapshots.RoundTrip(subR)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading snapshot "+id)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching snapshot %s: %s", id, bytes.TrimSpace(body))
+	}
+
+	snapshot := &deep_tp.Snapshot{}
+	err = proto.Unmarshal(body, snapshot)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding snapshot "+id)
+	}
+	return snapshot, nil
+}
+
+// snapshotWriter writes snapshots in the format requested for an export
+type snapshotWriter interface {
+	Write(snapshot *deep_tp.Snapshot) error
+	Flush() error
+	Close() error
+}
+
+type ndjsonSnapshotWriter struct {
+	w          http.ResponseWriter
+	marshaller *jsonpb.Marshaler
+}
+
+func (n *ndjsonSnapshotWriter) Write(snapshot *deep_tp.Snapshot) error {
+	err := n.marshaller.Marshal(n.w, snapshot)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(n.w, "\n")
+	return err
+}
+
+func (n *ndjsonSnapshotWriter) Flush() error {
+	if flusher, ok := n.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+func (n *ndjsonSnapshotWriter) Close() error {
+	return nil
+}
+
+// parquetSnapshotWriter writes a row group every exportRowGroupSize snapshots, so the whole export is not held in
+// memory
+type parquetSnapshotWriter struct {
+	w        *vparquet.SnapshotWriter
+	buffered int
+}
+
+func (p *parquetSnapshotWriter) Write(snapshot *deep_tp.Snapshot) error {
+	p.buffered++
+	return p.w.Write(snapshot)
+}
+
+func (p *parquetSnapshotWriter) Flush() error {
+	if p.buffered < exportRowGroupSize {
+		return nil
+	}
+	p.buffered = 0
+	return p.w.Flush()
+}
+
+func (p *parquetSnapshotWriter) Close() error {
+	return p.w.Close()
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package frontend
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/golang/protobuf/jsonpb" //nolint:all //deprecated
+	"github.com/golang/protobuf/proto"  //nolint:all //deprecated
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/segmentio/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/intergral/deep/modules/overrides"
+	"github.com/intergral/deep/pkg/deeppb"
+	deep_tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/intergral/deep/pkg/util"
+	"github.com/intergral/deep/pkg/util/test"
+)
+
+func newTestExportHandler(t *testing.T, maxBytes int, snapshots map[string]*deep_tp.Snapshot, ids ...string) (http.Handler, *deeppb.SearchRequest) {
+	o, err := overrides.NewOverrides(overrides.Limits{MaxBytesPerExport: maxBytes})
+	require.NoError(t, err)
+
+	searched := &deeppb.SearchRequest{}
+	search := RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		searched.Query = r.URL.Query().Get("q")
+		limit, err := strconv.ParseUint(r.URL.Query().Get("limit"), 10, 32)
+		require.NoError(t, err)
+		searched.Limit = uint32(limit)
+
+		resp := &deeppb.SearchResponse{}
+		for _, id := range ids {
+			resp.Snapshots = append(resp.Snapshots, &deeppb.SnapshotSearchMetadata{SnapshotID: id})
+		}
+		body, err := (&jsonpb.Marshaler{}).MarshalToString(resp)
+		require.NoError(t, err)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+	byID := RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		snapshot, ok := snapshots[mux.Vars(r)["snapshotID"]]
+		if !ok {
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(""))}, nil
+		}
+		body, err := proto.Marshal(snapshot)
+		require.NoError(t, err)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(body))}, nil
+	})
+
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test"}, []string{"tenant", "status"})
+	return newExportHandler(ExportConfig{MaxSnapshots: 100, ConcurrentRequests: 2}, search, byID, o, counter, log.NewNopLogger()), searched
+}
+
+func exportRequest(query string) *http.Request {
+	req := httptest.NewRequest("GET", "/api/search/export?"+query, nil)
+	return req.WithContext(util.InjectTenantID(req.Context(), "test"))
+}
+
+func testExportSnapshots(ids ...string) map[string]*deep_tp.Snapshot {
+	snapshots := map[string]*deep_tp.Snapshot{}
+	for i, id := range ids {
+		snapshotID, _ := util.HexStringToSnapshotID(id)
+		snapshots[id] = test.GenerateSnapshot(i, &test.GenerateOptions{Id: snapshotID})
+	}
+	return snapshots
+}
+
+func TestExportNDJSON(t *testing.T) {
+	snapshots := testExportSnapshots("1", "2", "3")
+	// 4 is returned by the search, but has gone when it is fetched
+	handler, searched := newTestExportHandler(t, 0, snapshots, "1", "2", "4", "3")
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, exportRequest("start=10&end=20&q="+url.QueryEscape(`{ .foo = "bar" }`)))
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	assert.Equal(t, contentTypeNDJSON, res.Header().Get("Content-Type"))
+	assert.Equal(t, `{ .foo = "bar" }`, searched.Query)
+	// no limit was requested, so the max is used
+	assert.Equal(t, uint32(100), searched.Limit)
+
+	scanner := bufio.NewScanner(res.Body)
+	scanner.Buffer(nil, 10<<20)
+	var got []string
+	for scanner.Scan() {
+		snapshot := &deep_tp.Snapshot{}
+		require.NoError(t, jsonpb.UnmarshalString(scanner.Text(), snapshot))
+		got = append(got, util.SnapshotIDToHexString(snapshot.ID))
+	}
+	assert.Equal(t, []string{"1", "2", "3"}, got)
+
+	trailer := res.Result().Trailer
+	assert.Equal(t, "false", trailer.Get(HeaderExportTruncated))
+	assert.Empty(t, trailer.Get(HeaderExportError))
+}
+
+func TestExportParquet(t *testing.T) {
+	snapshots := testExportSnapshots("1", "2", "3")
+	handler, _ := newTestExportHandler(t, 0, snapshots, "1", "2", "3")
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, exportRequest("start=10&end=20&format=parquet"))
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	assert.Equal(t, contentTypeParquet, res.Header().Get("Content-Type"))
+
+	file, err := parquet.OpenFile(bytes.NewReader(res.Body.Bytes()), int64(res.Body.Len()))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), file.NumRows())
+}
+
+func TestExportTruncatedAtMaxBytes(t *testing.T) {
+	snapshots := testExportSnapshots("1", "2", "3")
+	maxBytes := proto.Size(snapshots["1"]) + proto.Size(snapshots["2"])
+	handler, _ := newTestExportHandler(t, maxBytes, snapshots, "1", "2", "3")
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, exportRequest("start=10&end=20"))
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+
+	assert.Equal(t, 2, strings.Count(res.Body.String(), "\n"))
+	assert.Equal(t, "true", res.Result().Trailer.Get(HeaderExportTruncated))
+}
+
+func TestExportBadRequest(t *testing.T) {
+	handler, _ := newTestExportHandler(t, 0, nil)
+
+	for _, query := range []string{"", "start=10&end=20&format=csv"} {
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, exportRequest(query))
+		assert.Equal(t, http.StatusBadRequest, res.Code, query)
+	}
+}
@@ -44,16 +44,25 @@ import (
 )
 
 const (
-	snapshotByIDOp = "snapshots"
-	searchOp       = "search"
+	snapshotByIDOp   = "snapshots"
+	snapshotDiffOp   = "snapshotdiff"
+	exportOp         = "export"
+	searchOp         = "search"
+	traceSnapshotsOp = "tracesnapshots"
 )
 
 type QueryFrontend struct {
-	SnapshotByID, Search  http.Handler
-	logger                log.Logger
-	store                 storage.Store
-	LoadTracepointHandler http.Handler
-	DelTracepointHandler  http.Handler
+	SnapshotByID, Search   http.Handler
+	SnapshotDiff           http.Handler
+	Export                 http.Handler
+	TraceSnapshots         http.Handler
+	logger                 log.Logger
+	store                  storage.Store
+	LoadTracepointHandler  http.Handler
+	DelTracepointHandler   http.Handler
+	TracepointHistory      http.Handler
+	BulkTracepointHandler  http.Handler
+	TracepointStateHandler http.Handler
 }
 
 // New returns a new QueryFrontend
@@ -87,25 +96,40 @@ func New(cfg Config, next http.RoundTripper, tpNext http.RoundTripper, o *overri
 
 	snapshotByIDMiddleware := MergeMiddlewares(newSnapshotByIDMiddleware(cfg, logger), retryWare)
 	searchMiddleware := MergeMiddlewares(newSearchMiddleware(cfg, o, store, logger), retryWare)
+	snapshotDiffMiddleware := MergeMiddlewares(newSnapshotDiffMiddleware(), retryWare)
 
 	snapshotByIDCounter := queriesPerTenant.MustCurryWith(prometheus.Labels{"op": snapshotByIDOp})
 	searchCounter := queriesPerTenant.MustCurryWith(prometheus.Labels{"op": searchOp})
+	snapshotDiffCounter := queriesPerTenant.MustCurryWith(prometheus.Labels{"op": snapshotDiffOp})
+	exportCounter := queriesPerTenant.MustCurryWith(prometheus.Labels{"op": exportOp})
+	traceSnapshotsCounter := queriesPerTenant.MustCurryWith(prometheus.Labels{"op": traceSnapshotsOp})
 	loadTp := queriesPerTenant.MustCurryWith(prometheus.Labels{"op": "loadtp"})
 	delTp := queriesPerTenant.MustCurryWith(prometheus.Labels{"op": "deltp"})
+	tpHistory := queriesPerTenant.MustCurryWith(prometheus.Labels{"op": "tphistory"})
+	bulkTp := queriesPerTenant.MustCurryWith(prometheus.Labels{"op": "bulktp"})
+	tpState := queriesPerTenant.MustCurryWith(prometheus.Labels{"op": "tpstate"})
 
 	snapshots := snapshotByIDMiddleware.Wrap(next)
 	search := searchMiddleware.Wrap(next)
+	snapshotDiff := snapshotDiffMiddleware.Wrap(next)
+	traceSnapshots := newTraceSnapshotsMiddleware().Wrap(search)
 
 	tpMiddleware := newTracepointForwardMiddleware()
 	tpHandler := tpMiddleware.Wrap(tpNext)
 
 	return &QueryFrontend{
-		SnapshotByID:          newHandler(snapshots, snapshotByIDCounter, logger),
-		Search:                newHandler(search, searchCounter, logger),
-		LoadTracepointHandler: newHandler(tpHandler, loadTp, logger),
-		DelTracepointHandler:  newHandler(tpHandler, delTp, logger),
-		logger:                logger,
-		store:                 store,
+		SnapshotByID:           newHandler(snapshots, snapshotByIDCounter, logger),
+		Search:                 newHandler(search, searchCounter, logger),
+		SnapshotDiff:           newHandler(snapshotDiff, snapshotDiffCounter, logger),
+		Export:                 newExportHandler(cfg.Export, search, snapshots, o, exportCounter, logger),
+		TraceSnapshots:         newHandler(traceSnapshots, traceSnapshotsCounter, logger),
+		LoadTracepointHandler:  newHandler(tpHandler, loadTp, logger),
+		DelTracepointHandler:   newHandler(tpHandler, delTp, logger),
+		TracepointHistory:      newHandler(tpHandler, tpHistory, logger),
+		BulkTracepointHandler:  newHandler(tpHandler, bulkTp, logger),
+		TracepointStateHandler: newHandler(tpHandler, tpState, logger),
+		logger:                 logger,
+		store:                  store,
 	}, nil
 }
 
@@ -207,6 +231,70 @@ func newSnapshotByIDMiddleware(cfg Config, logger log.Logger) Middleware {
 	})
 }
 
+// newSnapshotDiffMiddleware creates a new frontend middleware that proxies snapshot diff requests to a single
+// querier, which finds both snapshots.
+func newSnapshotDiffMiddleware() Middleware {
+	return MiddlewareFunc(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			_, _, err := api.ParseSnapshotDiffRequest(r)
+			if err == nil {
+				_, _, _, _, _, err = api.ValidateAndSanitizeRequest(r)
+			}
+			if err != nil {
+				return &http.Response{
+					StatusCode: http.StatusBadRequest,
+					Body:       io.NopCloser(strings.NewReader(err.Error())),
+					Header:     http.Header{},
+				}, nil
+			}
+
+			tenantID, _ := util.ExtractTenantID(r.Context())
+
+			r.Header.Set(util.TenantIDHeaderName, tenantID)
+			r.RequestURI = buildUpstreamRequestURI(r.RequestURI, nil)
+
+			return next.RoundTrip(r)
+		})
+	})
+}
+
+// newTraceSnapshotsMiddleware creates a new frontend middleware that rewrites a trace snapshots request into a search
+// for the trace and span ID. The next round tripper is expected to be the search round tripper, so the search is
+// sharded in the same way as any other search.
+func newTraceSnapshotsMiddleware() Middleware {
+	return MiddlewareFunc(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			searchReq, err := api.ParseTraceSnapshotsRequest(r)
+			if err != nil {
+				return &http.Response{
+					StatusCode: http.StatusBadRequest,
+					Body:       io.NopCloser(strings.NewReader(err.Error())),
+					Header:     http.Header{},
+				}, nil
+			}
+
+			subR := r.Clone(r.Context())
+			subR.URL.Path = api.PathSearch
+			subR.URL.RawQuery = ""
+			subR, err = api.BuildSearchRequest(subR, searchReq)
+			if err != nil {
+				return nil, err
+			}
+
+			// without a time range only the ingesters are searched, the same as a search without start and end
+			if searchReq.Start == 0 && searchReq.End == 0 {
+				q := subR.URL.Query()
+				q.Del("start")
+				q.Del("end")
+				subR.URL.RawQuery = q.Encode()
+			}
+			subR.RequestURI = subR.URL.RequestURI()
+
+			return next.RoundTrip(subR)
+		})
+	})
+}
+
 // newSearchMiddleware creates a new frontend middleware to handle search and search tags requests.
 func newSearchMiddleware(cfg Config, o *overrides.Overrides, reader deepdb.Reader, logger log.Logger) Middleware {
 	return MiddlewareFunc(func(next http.RoundTripper) http.RoundTripper {
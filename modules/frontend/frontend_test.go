@@ -26,8 +26,11 @@ import (
 	"time"
 
 	"github.com/go-kit/log"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/intergral/deep/pkg/api"
 )
 
 type mockNextTripperware struct{}
@@ -147,3 +150,64 @@ func TestFrontendBadConfigFails(t *testing.T) {
 	assert.EqualError(t, err, "query backend after should be less than or equal to query ingester until")
 	assert.Nil(t, f)
 }
+
+func TestSnapshotDiffMiddleware(t *testing.T) {
+	var forwarded *http.Request
+	rt := newSnapshotDiffMiddleware().Wrap(RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		forwarded = r
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader([]byte("diff")))}, nil
+	}))
+
+	req := httptest.NewRequest("GET", "/api/snapshots/diff?a=1234", nil)
+	resp, err := rt.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Nil(t, forwarded)
+
+	req = httptest.NewRequest("GET", "/api/snapshots/diff?a=1234&b=abcd&mode=nope", nil)
+	resp, err = rt.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Nil(t, forwarded)
+
+	req = httptest.NewRequest("GET", "/api/snapshots/diff?a=1234&b=abcd", nil)
+	resp, err = rt.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NotNil(t, forwarded)
+	assert.Equal(t, "/querier/api/snapshots/diff?a=1234&b=abcd", forwarded.RequestURI)
+}
+
+func TestTraceSnapshotsMiddleware(t *testing.T) {
+	var forwarded *http.Request
+	rt := newTraceSnapshotsMiddleware().Wrap(RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		forwarded = r
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader([]byte("search")))}, nil
+	}))
+
+	traceSnapshotsRequest := func(traceID, query string) *http.Request {
+		req := httptest.NewRequest("GET", "/api/traces/"+traceID+"/snapshots?"+query, nil)
+		return mux.SetURLVars(req, map[string]string{api.URLParamTraceID: traceID})
+	}
+
+	resp, err := rt.RoundTrip(traceSnapshotsRequest("not-a-trace", ""))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Nil(t, forwarded)
+
+	// without a time range the search is not sent to the backend
+	resp, err = rt.RoundTrip(traceSnapshotsRequest("abcdef", "spanID=12"))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NotNil(t, forwarded)
+	assert.Equal(t, api.PathSearch, forwarded.URL.Path)
+	assert.False(t, api.IsBackendSearch(forwarded))
+	assert.Equal(t, `{ traceID = "00000000000000000000000000abcdef" && spanID = "0000000000000012" }`, forwarded.URL.Query().Get("q"))
+	assert.Equal(t, forwarded.URL.RequestURI(), forwarded.RequestURI)
+
+	resp, err = rt.RoundTrip(traceSnapshotsRequest("abcdef", "start=10&end=20"))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.True(t, api.IsBackendSearch(forwarded))
+	assert.Equal(t, `{ traceID = "00000000000000000000000000abcdef" }`, forwarded.URL.Query().Get("q"))
+}
@@ -22,8 +22,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -38,6 +40,7 @@ import (
 	"github.com/intergral/deep/pkg/boundedwaitgroup"
 	"github.com/intergral/deep/pkg/deepdb"
 	"github.com/intergral/deep/pkg/deepdb/backend"
+	"github.com/intergral/deep/pkg/deepql"
 	"github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -89,6 +92,9 @@ type SearchSharderConfig struct {
 	MaxDuration           time.Duration `yaml:"max_duration"`
 	QueryBackendAfter     time.Duration `yaml:"query_backend_after,omitempty"`
 	QueryIngestersUntil   time.Duration `yaml:"query_ingesters_until,omitempty"`
+	// MaxMetricsSnapshots is the most snapshots a metrics query, or a pipeline that aggregates, can be evaluated over.
+	// 0 to disable
+	MaxMetricsSnapshots uint32 `yaml:"max_metrics_snapshots"`
 }
 
 // newSearchSharder creates a sharding middleware for search
@@ -121,8 +127,27 @@ func (s searchSharder) RoundTrip(r *http.Request) (*http.Response, error) {
 		}, nil
 	}
 
-	// adjust limit based on config
-	searchReq.Limit = adjustLimit(searchReq.Limit, s.cfg.DefaultLimit, s.cfg.MaxLimit)
+	// adjust limit based on config. Metrics queries and pipelines that aggregate are evaluated over every matching
+	// snapshot, so they are limited by the max metrics snapshots instead. We ask for one more so we can tell when the
+	// limit is exceeded. The result limit is applied once they have been evaluated.
+	resultLimit := adjustLimit(searchReq.Limit, s.cfg.DefaultLimit, s.cfg.MaxLimit)
+	collectAll := deepql.CollectsAllSnapshots(searchReq.Query)
+	if collectAll {
+		searchReq.Limit = 0
+		if s.cfg.MaxMetricsSnapshots > 0 {
+			searchReq.Limit = s.cfg.MaxMetricsSnapshots + 1
+		}
+		// the backend requests are built from the parent, so the limit must be set there too
+		r = r.Clone(r.Context())
+		query := r.URL.Query()
+		query.Del("limit")
+		if searchReq.Limit > 0 {
+			query.Set("limit", strconv.FormatUint(uint64(searchReq.Limit), 10))
+		}
+		r.URL.RawQuery = query.Encode()
+	} else {
+		searchReq.Limit = resultLimit
+	}
 
 	ctx := r.Context()
 	tenantID, err := util.ExtractTenantID(ctx)
@@ -181,9 +206,14 @@ func (s searchSharder) RoundTrip(r *http.Request) (*http.Response, error) {
 	}
 	span.SetTag("request-count", len(reqs))
 
+	limit := int(searchReq.Limit)
+	if collectAll && limit == 0 {
+		limit = math.MaxInt
+	}
+
 	// execute requests
 	wg := boundedwaitgroup.New(uint(s.cfg.ConcurrentRequests))
-	overallResponse := newSearchResponse(ctx, int(searchReq.Limit), subCancel)
+	overallResponse := newSearchResponse(ctx, limit, subCancel)
 	overallResponse.resultsMetrics.InspectedBlocks = uint32(len(blocks))
 
 	totalBlockBytes := uint64(0)
@@ -296,8 +326,34 @@ func (s searchSharder) RoundTrip(r *http.Request) (*http.Response, error) {
 		}, nil
 	}
 
+	result := overallResponse.result()
+	if collectAll {
+		if s.cfg.MaxMetricsSnapshots > 0 && len(result.Snapshots) > int(s.cfg.MaxMetricsSnapshots) {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(fmt.Sprintf("query matched more than %d snapshots, use a shorter range or a more specific query", s.cfg.MaxMetricsSnapshots))),
+			}, nil
+		}
+
+		result.Snapshots, err = deepql.EvaluatePipeline(searchReq, result.Snapshots)
+		if err != nil {
+			return nil, err
+		}
+
+		if deepql.IsMetricsQuery(searchReq.Query) {
+			result.Series, err = deepql.BuildSeries(searchReq, result.Snapshots)
+			if err != nil {
+				return nil, err
+			}
+			result.Snapshots = nil
+		} else if resultLimit > 0 && len(result.Snapshots) > int(resultLimit) {
+			result.Snapshots = result.Snapshots[:resultLimit]
+		}
+	}
+
 	m := &jsonpb.Marshaler{}
-	bodyString, err := m.MarshalToString(overallResponse.result())
+	bodyString, err := m.MarshalToString(result)
 	if err != nil {
 		return nil, err
 	}
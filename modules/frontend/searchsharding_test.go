@@ -24,6 +24,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strconv"
 	"strings"
 	"testing"
@@ -619,6 +620,70 @@ func TestSearchSharderRoundTrip(t *testing.T) {
 	}
 }
 
+func TestSearchSharderMetricsQueryLimit(t *testing.T) {
+	tests := []struct {
+		name           string
+		snapshots      int
+		expectedStatus int
+	}{
+		{name: "within limit", snapshots: 1, expectedStatus: http.StatusOK},
+		{name: "exceeds limit", snapshots: 2, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var limits []string
+			next := RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				limits = append(limits, r.URL.Query().Get("limit"))
+
+				// each sub query returns different snapshots, so the total is twice the snapshots
+				response := &deeppb.SearchResponse{Metrics: &deeppb.SearchMetrics{}}
+				for i := 0; i < tc.snapshots; i++ {
+					response.Snapshots = append(response.Snapshots, &deeppb.SnapshotSearchMetadata{
+						SnapshotID:        uuid.New().String(),
+						StartTimeUnixNano: 1_100_000_000_000,
+					})
+				}
+				resString, err := (&jsonpb.Marshaler{}).MarshalToString(response)
+				require.NoError(t, err)
+				return &http.Response{
+					Body:       io.NopCloser(strings.NewReader(resString)),
+					StatusCode: http.StatusOK,
+				}, nil
+			})
+
+			o, err := overrides.NewOverrides(overrides.Limits{})
+			require.NoError(t, err)
+
+			sharder := newSearchSharder(&mockReader{
+				metas: []*backend.BlockMeta{
+					{
+						StartTime:    time.Unix(1100, 0),
+						EndTime:      time.Unix(1200, 0),
+						Size:         defaultTargetBytesPerRequest * 2,
+						TotalRecords: 2,
+						BlockID:      uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+					},
+				},
+			}, o, SearchSharderConfig{
+				ConcurrentRequests:    1,
+				TargetBytesPerRequest: defaultTargetBytesPerRequest,
+				MaxMetricsSnapshots:   3,
+			}, testSLOcfg, log.NewNopLogger())
+			testRT := NewRoundTripper(next, sharder)
+
+			req := httptest.NewRequest("GET", "/?start=1000&end=1500&limit=1&q="+url.QueryEscape("count_over_time({}[5m])"), nil)
+			req = req.WithContext(util.InjectTenantID(req.Context(), "blerg"))
+
+			resp, err := testRT.RoundTrip(req)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+
+			// the sub queries ask for one more than the max, so we can tell when it is exceeded
+			assert.Equal(t, []string{"4", "4"}, limits)
+		})
+	}
+}
+
 func TestSearchSharderRoundTripBadRequest(t *testing.T) {
 	next := RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
 		return nil, nil
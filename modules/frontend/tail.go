@@ -0,0 +1,315 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/golang/protobuf/jsonpb" //nolint:all //deprecated
+	"github.com/grafana/dskit/ring"
+	ring_client "github.com/grafana/dskit/ring/client"
+	"github.com/grafana/dskit/services"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	ingester_client "github.com/intergral/deep/modules/ingester/client"
+	"github.com/intergral/deep/modules/overrides"
+	"github.com/intergral/deep/pkg/api"
+	"github.com/intergral/deep/pkg/deeppb"
+	"github.com/intergral/deep/pkg/deepql"
+	"github.com/intergral/deep/pkg/util"
+)
+
+// tailSeenSnapshots is the number of snapshot ids remembered per tail, to drop the copies sent by each replica
+const tailSeenSnapshots = 10_000
+
+var (
+	metricTailIngesterClients = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "deep",
+		Subsystem: "query_frontend",
+		Name:      "tail_ingester_clients",
+		Help:      "The current number of ingester clients used by live tails.",
+	})
+	metricLiveTails = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "deep",
+		Subsystem: "query_frontend",
+		Name:      "live_tails",
+		Help:      "The current number of live tails per tenant.",
+	}, []string{"tenant"})
+)
+
+// Tailer streams snapshots matching a DeepQL query to the client as server-sent events, as they are pushed to
+// the ingesters.
+type Tailer struct {
+	services.Service
+
+	cfg    TailConfig
+	limits *overrides.Overrides
+	logger log.Logger
+
+	// clients returns a client for every ingester that should be tailed
+	clients func() ([]deeppb.QuerierServiceClient, error)
+
+	// active counts the tails open on this frontend only, so the limit is enforced per frontend
+	activeMtx sync.Mutex
+	active    map[string]int
+}
+
+// NewTailer creates a Tailer that connects to the ingesters in the provided ring
+func NewTailer(cfg TailConfig, clientCfg ingester_client.Config, ingesterRing ring.ReadRing, limits *overrides.Overrides, logger log.Logger) *Tailer {
+	factory := func(addr string) (ring_client.PoolClient, error) {
+		return ingester_client.New(addr, clientCfg)
+	}
+	pool := ring_client.NewPool("frontend_tail_pool",
+		clientCfg.PoolConfig,
+		ring_client.NewRingServiceDiscovery(ingesterRing),
+		factory,
+		metricTailIngesterClients,
+		logger)
+
+	t := &Tailer{
+		cfg:    cfg,
+		limits: limits,
+		logger: logger,
+		clients: func() ([]deeppb.QuerierServiceClient, error) {
+			replicationSet, err := ingesterRing.GetReplicationSetForOperation(ring.Read)
+			if err != nil {
+				return nil, errors.Wrap(err, "error finding ingesters to tail")
+			}
+
+			clients := make([]deeppb.QuerierServiceClient, 0, len(replicationSet.Instances))
+			for _, ingester := range replicationSet.Instances {
+				client, err := pool.GetClientFor(ingester.Addr)
+				if err != nil {
+					return nil, errors.Wrap(err, fmt.Sprintf("failed to get client for %s", ingester.Addr))
+				}
+				clients = append(clients, client.(deeppb.QuerierServiceClient))
+			}
+			return clients, nil
+		},
+		active: map[string]int{},
+	}
+
+	t.Service = services.NewIdleService(func(ctx context.Context) error {
+		return services.StartAndAwaitRunning(ctx, pool)
+	}, func(_ error) error {
+		return services.StopAndAwaitTerminated(context.Background(), pool)
+	})
+	return t
+}
+
+// ServeHTTP opens a tail on every ingester and writes each matching snapshot as a 'snapshot' event until the
+// client goes away. If an ingester could not keep up a 'dropped' event is written with the number of snapshots
+// that were lost.
+func (t *Tailer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := util.ExtractTenantID(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	req, err := api.ParseTailRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// check the query here, so we do not open a tail on every ingester just to have them all reject it
+	_, err = deepql.NewSnapshotMatcher(req.Query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	if !t.acquire(tenantID) {
+		http.Error(w, fmt.Sprintf("max concurrent tails (%d) on this query frontend reached for tenant %s", t.limits.MaxConcurrentTails(tenantID), tenantID), http.StatusTooManyRequests)
+		return
+	}
+	defer t.release(tenantID)
+
+	clients, err := t.clients()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// results is not buffered, so a slow client stops us reading from the ingesters and they start dropping
+	results := make(chan *deeppb.TailSnapshotsResponse)
+	errs := make(chan error, len(clients))
+	for _, client := range clients {
+		go t.tailIngester(ctx, client, req, results, errs)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(t.cfg.KeepAlive)
+	defer keepAlive.Stop()
+
+	seen := newSeenSnapshots(tailSeenSnapshots)
+	marshaller := &jsonpb.Marshaler{}
+	failed := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepAlive.C:
+			_, err = io.WriteString(w, ": keep-alive\n\n")
+		case tailErr := <-errs:
+			failed++
+			level.Warn(t.logger).Log("msg", "live tail failed on ingester", "tenant", tenantID, "err", tailErr)
+			if failed < len(clients) {
+				continue
+			}
+			_ = writeEvent(w, "error", tailErr.Error())
+			flusher.Flush()
+			return
+		case resp := <-results:
+			if resp.DroppedSnapshots > 0 {
+				err = writeEvent(w, "dropped", strconv.FormatUint(uint64(resp.DroppedSnapshots), 10))
+			}
+			for _, snapshot := range resp.Snapshots {
+				if err != nil {
+					break
+				}
+				if !seen.add(snapshot.SnapshotID) {
+					continue
+				}
+
+				var data string
+				data, err = marshaller.MarshalToString(snapshot)
+				if err != nil {
+					break
+				}
+				err = writeEvent(w, "snapshot", data)
+			}
+		}
+		if err != nil {
+			level.Debug(t.logger).Log("msg", "live tail closed", "tenant", tenantID, "err", err)
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// tailIngester reads the tail from a single ingester, any error is sent to errs unless the tail was closed
+func (t *Tailer) tailIngester(ctx context.Context, client deeppb.QuerierServiceClient, req *deeppb.TailSnapshotsRequest, results chan<- *deeppb.TailSnapshotsResponse, errs chan<- error) {
+	stream, err := client.TailSnapshots(ctx, req)
+	if err != nil {
+		errs <- err
+		return
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() == nil {
+				errs <- err
+			}
+			return
+		}
+		if len(resp.Snapshots) == 0 && resp.DroppedSnapshots == 0 {
+			continue
+		}
+
+		select {
+		case results <- resp:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// acquire returns true if the tenant can open another tail on this frontend. The frontends do not share their counts,
+// so a tenant can have max concurrent tails open on each frontend.
+func (t *Tailer) acquire(tenantID string) bool {
+	t.activeMtx.Lock()
+	defer t.activeMtx.Unlock()
+
+	maxTails := t.limits.MaxConcurrentTails(tenantID)
+	if maxTails <= 0 || t.active[tenantID] >= maxTails {
+		return false
+	}
+
+	t.active[tenantID]++
+	metricLiveTails.WithLabelValues(tenantID).Set(float64(t.active[tenantID]))
+	return true
+}
+
+func (t *Tailer) release(tenantID string) {
+	t.activeMtx.Lock()
+	defer t.activeMtx.Unlock()
+
+	t.active[tenantID]--
+	metricLiveTails.WithLabelValues(tenantID).Set(float64(t.active[tenantID]))
+	if t.active[tenantID] == 0 {
+		delete(t.active, tenantID)
+	}
+}
+
+func writeEvent(w io.Writer, event, data string) error {
+	_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
+
+// seenSnapshots remembers the most recent snapshot ids, forgetting the oldest once full
+type seenSnapshots struct {
+	ids   map[string]struct{}
+	order []string
+	next  int
+}
+
+func newSeenSnapshots(size int) *seenSnapshots {
+	return &seenSnapshots{
+		ids:   make(map[string]struct{}, size),
+		order: make([]string, size),
+	}
+}
+
+// add records the id, returning false if it has already been seen
+func (s *seenSnapshots) add(id string) bool {
+	if _, ok := s.ids[id]; ok {
+		return false
+	}
+
+	delete(s.ids, s.order[s.next])
+	s.order[s.next] = id
+	s.next = (s.next + 1) % len(s.order)
+	s.ids[id] = struct{}{}
+	return true
+}
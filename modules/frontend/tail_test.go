@@ -0,0 +1,147 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package frontend
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/intergral/deep/modules/overrides"
+	"github.com/intergral/deep/pkg/deeppb"
+	"github.com/intergral/deep/pkg/util"
+)
+
+type mockTailClient struct {
+	deeppb.QuerierServiceClient
+	responses []*deeppb.TailSnapshotsResponse
+}
+
+func (m *mockTailClient) TailSnapshots(_ context.Context, _ *deeppb.TailSnapshotsRequest, _ ...grpc.CallOption) (deeppb.QuerierService_TailSnapshotsClient, error) {
+	return &mockTailStream{responses: m.responses}, nil
+}
+
+type mockTailStream struct {
+	grpc.ClientStream
+	responses []*deeppb.TailSnapshotsResponse
+}
+
+func (m *mockTailStream) Recv() (*deeppb.TailSnapshotsResponse, error) {
+	if len(m.responses) == 0 {
+		return nil, io.EOF
+	}
+	resp := m.responses[0]
+	m.responses = m.responses[1:]
+	return resp, nil
+}
+
+func newTestTailer(t *testing.T, maxTails int, clients ...deeppb.QuerierServiceClient) *Tailer {
+	o, err := overrides.NewOverrides(overrides.Limits{MaxConcurrentTails: maxTails})
+	require.NoError(t, err)
+
+	return &Tailer{
+		cfg:    TailConfig{KeepAlive: time.Minute},
+		limits: o,
+		logger: log.NewNopLogger(),
+		clients: func() ([]deeppb.QuerierServiceClient, error) {
+			return clients, nil
+		},
+		active: map[string]int{},
+	}
+}
+
+func tailRequest(query string) *http.Request {
+	req := httptest.NewRequest("GET", "/api/search/tail?q="+url.QueryEscape(query), nil)
+	return req.WithContext(util.InjectTenantID(req.Context(), "test"))
+}
+
+func TestTailerStreamsSnapshots(t *testing.T) {
+	snapshot := &deeppb.SnapshotSearchMetadata{SnapshotID: "1234", ServiceName: "checkout"}
+	// both replicas send the same snapshot
+	replica := &mockTailClient{responses: []*deeppb.TailSnapshotsResponse{
+		{},
+		{Snapshots: []*deeppb.SnapshotSearchMetadata{snapshot}},
+	}}
+	slow := &mockTailClient{responses: []*deeppb.TailSnapshotsResponse{
+		{},
+		{Snapshots: []*deeppb.SnapshotSearchMetadata{snapshot}, DroppedSnapshots: 2},
+	}}
+	tailer := newTestTailer(t, 1, replica, slow)
+
+	w := httptest.NewRecorder()
+	tailer.ServeHTTP(w, tailRequest(`{ resource.service.name = "checkout" }`))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+
+	body := w.Body.String()
+	assert.Equal(t, 1, strings.Count(body, "event: snapshot\ndata: {\"snapshotID\":\"1234\",\"serviceName\":\"checkout\"}\n\n"), body)
+	assert.Contains(t, body, "event: dropped\ndata: 2\n\n")
+	// the mock streams end, so once every ingester has failed the tail is closed
+	assert.Contains(t, body, "event: error\ndata: EOF\n\n")
+
+	assert.Empty(t, tailer.active)
+}
+
+func TestTailerRejectsRequests(t *testing.T) {
+	tailer := newTestTailer(t, 1)
+
+	w := httptest.NewRecorder()
+	tailer.ServeHTTP(w, tailRequest(""))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	tailer.ServeHTTP(w, tailRequest(`{} | count() > 1`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	tailer.ServeHTTP(w, httptest.NewRequest("GET", "/api/search/tail?q={}", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	tailer.active["test"] = 1
+	w = httptest.NewRecorder()
+	tailer.ServeHTTP(w, tailRequest(`{}`))
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+	disabled := newTestTailer(t, 0)
+	w = httptest.NewRecorder()
+	disabled.ServeHTTP(w, tailRequest(`{}`))
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+}
+
+func TestSeenSnapshots(t *testing.T) {
+	seen := newSeenSnapshots(2)
+
+	assert.True(t, seen.add("a"))
+	assert.False(t, seen.add("a"))
+	assert.True(t, seen.add("b"))
+	assert.True(t, seen.add("c"))
+	// a has been forgotten to make room for c
+	assert.True(t, seen.add("a"))
+	assert.False(t, seen.add("c"))
+}
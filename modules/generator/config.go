@@ -23,6 +23,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/intergral/deep/modules/generator/processor/dynamicmetrics"
 	"github.com/intergral/deep/modules/generator/processor/spanmetrics"
 	"github.com/intergral/deep/modules/generator/registry"
 	"github.com/intergral/deep/modules/generator/storage"
@@ -58,11 +59,13 @@ func (cfg *Config) RegisterFlagsAndApplyDefaults(prefix string, f *flag.FlagSet)
 }
 
 type ProcessorConfig struct {
-	SpanMetrics spanmetrics.Config `yaml:"span_metrics"`
+	SpanMetrics    spanmetrics.Config    `yaml:"span_metrics"`
+	DynamicMetrics dynamicmetrics.Config `yaml:"dynamic_metrics"`
 }
 
 func (cfg *ProcessorConfig) RegisterFlagsAndApplyDefaults(prefix string, f *flag.FlagSet) {
 	cfg.SpanMetrics.RegisterFlagsAndApplyDefaults(prefix, f)
+	cfg.DynamicMetrics.RegisterFlagsAndApplyDefaults(prefix, f)
 }
 
 // copyWithOverrides creates a copy of the config using values set in the overrides.
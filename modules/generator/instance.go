@@ -33,6 +33,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
 	"github.com/intergral/deep/modules/generator/processor"
+	"github.com/intergral/deep/modules/generator/processor/dynamicmetrics"
 	"github.com/intergral/deep/modules/generator/processor/spanmetrics"
 	"github.com/intergral/deep/modules/generator/registry"
 	"github.com/intergral/deep/modules/generator/storage"
@@ -40,7 +41,7 @@ import (
 )
 
 var (
-	allSupportedProcessors = []string{spanmetrics.Name}
+	allSupportedProcessors = []string{spanmetrics.Name, dynamicmetrics.Name}
 
 	metricActiveProcessors = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "deep",
@@ -209,6 +210,10 @@ func (i *instance) diffProcessors(desiredProcessors map[string]struct{}, desired
 			if !reflect.DeepEqual(p.Cfg, desiredCfg.SpanMetrics) {
 				toReplace = append(toReplace, processorName)
 			}
+		case *dynamicmetrics.Processor:
+			if !reflect.DeepEqual(p.Cfg, desiredCfg.DynamicMetrics) {
+				toReplace = append(toReplace, processorName)
+			}
 		default:
 			level.Error(i.logger).Log(
 				"msg", fmt.Sprintf("processor does not exist, supported processors: [%s]", strings.Join(allSupportedProcessors, ", ")),
@@ -230,6 +235,8 @@ func (i *instance) addProcessor(processorName string, cfg ProcessorConfig) error
 	switch processorName {
 	case spanmetrics.Name:
 		newProcessor = spanmetrics.New(cfg.SpanMetrics, i.registry)
+	case dynamicmetrics.Name:
+		newProcessor = dynamicmetrics.New(cfg.DynamicMetrics, i.tenantID, i.registry)
 	default:
 		level.Error(i.logger).Log(
 			"msg", fmt.Sprintf("processor does not exist, supported processors: [%s]", strings.Join(allSupportedProcessors, ", ")),
@@ -0,0 +1,43 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dynamicmetrics
+
+import (
+	"flag"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	Name = "dynamic-metrics"
+)
+
+type Config struct {
+	// Buckets for the histograms defined by tracepoints, the values are in the unit of the value expression.
+	HistogramBuckets []float64 `yaml:"histogram_buckets"`
+	// MaxMetrics is the maximum number of metrics the tracepoints of a tenant can define. 0 to disable.
+	MaxMetrics int `yaml:"max_metrics"`
+	// MaxSeriesPerMetric is the maximum number of label value combinations recorded for each metric. 0 to disable.
+	MaxSeriesPerMetric int `yaml:"max_series_per_metric"`
+}
+
+func (cfg *Config) RegisterFlagsAndApplyDefaults(prefix string, f *flag.FlagSet) {
+	cfg.HistogramBuckets = prometheus.ExponentialBuckets(0.002, 2, 14)
+	cfg.MaxMetrics = 100
+	cfg.MaxSeriesPerMetric = 1000
+}
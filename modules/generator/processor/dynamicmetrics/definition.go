@@ -0,0 +1,157 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dynamicmetrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/intergral/deep/pkg/deepql"
+)
+
+const (
+	// argPrefix is the prefix of the tracepoint args that define metrics, the args are named metric.<name>.<field>
+	argPrefix = "metric."
+
+	fieldType   = "type"
+	fieldValue  = "value"
+	fieldLabels = "label."
+
+	typeCounter   = "counter"
+	typeGauge     = "gauge"
+	typeHistogram = "histogram"
+
+	// reservedPrefix is used by the metrics of the span-metrics processor, which share the registry of the tenant
+	reservedPrefix = "snapshot_metrics_"
+)
+
+// definition is a metric defined by the args of a tracepoint
+type definition struct {
+	name       string
+	metricType string
+	// value is nil for counters that count the snapshots
+	value *deepql.Attribute
+	// labels are sorted by name, labelValues holds the attribute for the label with the same index
+	labels      []string
+	labelValues []deepql.Attribute
+}
+
+// signature identifies the registry metric the definition needs, if it changes the metric has to be replaced
+func (d *definition) signature() string {
+	return d.metricType + "{" + strings.Join(d.labels, ",") + "}"
+}
+
+// parseDefinitions returns the metrics defined by the args of a tracepoint, sorted by name. A definition that is
+// not valid is left out, and its error returned, so it does not stop the other metrics from being recorded.
+//
+// A metric is defined by args with the keys:
+//
+//	metric.<name>.type          counter, gauge or histogram, defaults to counter
+//	metric.<name>.value         the DeepQL attribute to record, e.g. var.order.total or watch.len(items), counters
+//	                            count the snapshots if it is not set
+//	metric.<name>.label.<label> the DeepQL attribute to use as the value of the label
+func parseDefinitions(args map[string]string) ([]*definition, []error) {
+	fields := map[string]map[string]string{}
+	for key, value := range args {
+		if !strings.HasPrefix(key, argPrefix) {
+			continue
+		}
+		name, field, _ := strings.Cut(strings.TrimPrefix(key, argPrefix), ".")
+		if _, ok := fields[name]; !ok {
+			fields[name] = map[string]string{}
+		}
+		fields[name][field] = value
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var definitions []*definition
+	var errs []error
+	for _, name := range names {
+		d, err := parseDefinition(name, fields[name])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid metric %s: %w", name, err))
+			continue
+		}
+		definitions = append(definitions, d)
+	}
+
+	return definitions, errs
+}
+
+func parseDefinition(name string, fields map[string]string) (*definition, error) {
+	if !model.IsValidMetricName(model.LabelValue(name)) {
+		return nil, fmt.Errorf("not a valid metric name")
+	}
+	if strings.HasPrefix(name, reservedPrefix) {
+		return nil, fmt.Errorf("the prefix %s is reserved", reservedPrefix)
+	}
+
+	d := &definition{
+		name:       name,
+		metricType: typeCounter,
+	}
+
+	for field, value := range fields {
+		switch {
+		case field == fieldType:
+			switch value {
+			case typeCounter, typeGauge, typeHistogram:
+				d.metricType = value
+			default:
+				return nil, fmt.Errorf("unknown type %s", value)
+			}
+		case field == fieldValue:
+			attribute, err := deepql.ParseIdentifier(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value: %w", err)
+			}
+			d.value = &attribute
+		case strings.HasPrefix(field, fieldLabels):
+			label := strings.TrimPrefix(field, fieldLabels)
+			if !model.LabelName(label).IsValid() || strings.HasPrefix(label, "__") {
+				return nil, fmt.Errorf("%s is not a valid label name", label)
+			}
+			d.labels = append(d.labels, label)
+		default:
+			return nil, fmt.Errorf("unknown field %s", field)
+		}
+	}
+
+	if d.value == nil && d.metricType != typeCounter {
+		return nil, fmt.Errorf("a %s requires a value", d.metricType)
+	}
+
+	sort.Strings(d.labels)
+	for _, label := range d.labels {
+		attribute, err := deepql.ParseIdentifier(fields[fieldLabels+label])
+		if err != nil {
+			return nil, fmt.Errorf("invalid label %s: %w", label, err)
+		}
+		d.labelValues = append(d.labelValues, attribute)
+	}
+
+	return d, nil
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dynamicmetrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/intergral/deep/pkg/deepql"
+)
+
+func TestParseDefinitions(t *testing.T) {
+	definitions, errs := parseDefinitions(map[string]string{
+		"fire_count":                    "5",
+		"metric.orders_total.type":      "counter",
+		"metric.order_value.type":       "histogram",
+		"metric.order_value.value":      "var.order.total",
+		"metric.order_value.label.paid": "var.order.paid",
+		"metric.order_value.label.app":  "resource.service.name",
+		"metric.queue_size.type":        "gauge",
+		"metric.queue_size.value":       "watch.len(queue)",
+	})
+	require.Empty(t, errs)
+	require.Len(t, definitions, 3)
+
+	orderValue := definitions[0]
+	assert.Equal(t, "order_value", orderValue.name)
+	assert.Equal(t, typeHistogram, orderValue.metricType)
+	assert.Equal(t, deepql.MustParseIdentifier("var.order.total"), *orderValue.value)
+	assert.Equal(t, []string{"app", "paid"}, orderValue.labels)
+	assert.Equal(t, []deepql.Attribute{deepql.MustParseIdentifier("resource.service.name"), deepql.MustParseIdentifier("var.order.paid")}, orderValue.labelValues)
+	assert.Equal(t, "histogram{app,paid}", orderValue.signature())
+
+	ordersTotal := definitions[1]
+	assert.Equal(t, "orders_total", ordersTotal.name)
+	assert.Equal(t, typeCounter, ordersTotal.metricType)
+	assert.Nil(t, ordersTotal.value)
+
+	queueSize := definitions[2]
+	assert.Equal(t, "queue_size", queueSize.name)
+	assert.Equal(t, typeGauge, queueSize.metricType)
+	assert.Equal(t, deepql.MustParseIdentifier("watch.len(queue)"), *queueSize.value)
+}
+
+func TestParseDefinitionsInvalid(t *testing.T) {
+	tests := map[string]map[string]string{
+		"invalid name":        {"metric.order-value.type": "counter"},
+		"reserved name":       {"metric.snapshot_metrics_calls_total.type": "counter"},
+		"unknown type":        {"metric.orders.type": "summary"},
+		"unknown field":       {"metric.orders.help": "the orders"},
+		"missing value":       {"metric.orders.type": "gauge"},
+		"invalid value":       {"metric.orders.value": "order.total"},
+		"invalid label":       {"metric.orders.label.__name__": "var.name"},
+		"invalid label value": {"metric.orders.label.name": "name"},
+	}
+
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			args["metric.valid.type"] = "counter"
+
+			definitions, errs := parseDefinitions(args)
+			assert.Len(t, errs, 1)
+			// the other metrics are still defined
+			require.Len(t, definitions, 1)
+			assert.Equal(t, "valid", definitions[0].name)
+		})
+	}
+}
@@ -0,0 +1,288 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dynamicmetrics
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	gen "github.com/intergral/deep/modules/generator/processor"
+	"github.com/intergral/deep/modules/generator/registry"
+	"github.com/intergral/deep/pkg/deeppb"
+	tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/intergral/deep/pkg/deepql"
+	deep_util "github.com/intergral/deep/pkg/util"
+)
+
+const (
+	reasonInvalid    = "invalid"
+	reasonMaxMetrics = "max_metrics"
+	reasonConflict   = "conflict"
+	reasonMaxSeries  = "max_series"
+
+	// seriesStaleDuration is how long a series is counted towards the max series of a metric after it was last
+	// recorded, this matches the default stale duration of the registry
+	seriesStaleDuration = 15 * time.Minute
+)
+
+var metricRejectedDefinitions = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "deep",
+	Subsystem: "metrics_generator",
+	Name:      "dynamic_metrics_rejected_total",
+	Help:      "The total number of metric definitions in snapshots that were not recorded",
+}, []string{"tenant", "reason"})
+
+// Processor records the metrics that are defined in the args of the tracepoint that created each snapshot, so
+// metrics can be added to running code without redeploying it.
+type Processor struct {
+	Cfg Config
+
+	tenant   string
+	registry registry.Registry
+
+	// metricsMtx protects the metrics map, not the metrics itself
+	metricsMtx sync.Mutex
+	metrics    map[string]*dynamicMetric
+}
+
+// dynamicMetric is the registry metric created for a definition, only the field matching its type is set
+type dynamicMetric struct {
+	signature string
+	// tracepoint is the id of the tracepoint that created the metric, only it can change the definition
+	tracepoint string
+	counter    registry.Counter
+	gauge      registry.Gauge
+	histogram  registry.Histogram
+
+	// seriesMtx protects series, which holds when each set of label values was last recorded
+	seriesMtx sync.Mutex
+	series    map[string]time.Time
+}
+
+func New(cfg Config, tenant string, registry registry.Registry) gen.Processor {
+	return &Processor{
+		Cfg:      cfg,
+		tenant:   tenant,
+		registry: registry,
+		metrics:  map[string]*dynamicMetric{},
+	}
+}
+
+func (p *Processor) Name() string {
+	return Name
+}
+
+func (p *Processor) PushSnapshot(ctx context.Context, req *deeppb.PushSnapshotRequest) {
+	span, _ := opentracing.StartSpanFromContext(ctx, "dynamicmetrics.PushSnapshot")
+	defer span.Finish()
+
+	args := req.Snapshot.GetTracepoint().GetArgs()
+	if len(args) == 0 {
+		return
+	}
+
+	definitions, errs := parseDefinitions(args)
+	if len(errs) > 0 {
+		metricRejectedDefinitions.WithLabelValues(p.tenant, reasonInvalid).Add(float64(len(errs)))
+	}
+
+	for _, d := range definitions {
+		p.record(req.Snapshot, d)
+	}
+}
+
+func (p *Processor) Shutdown(_ context.Context) {
+}
+
+// record resolves the value and labels of the definition from the snapshot and records them. Nothing is recorded
+// if the value is not in the snapshot or is not a number.
+func (p *Processor) record(snapshot *tp.Snapshot, d *definition) {
+	value := 1.0
+	if d.value != nil {
+		static, ok := deepql.ResolveAttribute(snapshot, *d.value)
+		if !ok {
+			return
+		}
+		value, ok = staticFloat(static)
+		if !ok {
+			return
+		}
+	}
+
+	m := p.metric(snapshot.GetTracepoint().GetID(), d)
+	if m == nil {
+		return
+	}
+
+	labelValues := make([]string, len(d.labelValues))
+	for i, attribute := range d.labelValues {
+		if static, ok := deepql.ResolveAttribute(snapshot, attribute); ok {
+			labelValues[i] = staticString(static)
+		}
+	}
+	if !p.allowSeries(m, labelValues) {
+		metricRejectedDefinitions.WithLabelValues(p.tenant, reasonMaxSeries).Inc()
+		return
+	}
+	registryLabelValues := p.registry.NewLabelValues(labelValues)
+
+	switch {
+	case m.counter != nil:
+		// counters can only increase
+		if value >= 0 {
+			m.counter.Inc(registryLabelValues, value)
+		}
+	case m.gauge != nil:
+		m.gauge.Set(registryLabelValues, value)
+	case m.histogram != nil:
+		m.histogram.ObserveWithExemplar(registryLabelValues, value, deep_util.SnapshotIDToHexString(snapshot.ID), 1)
+	}
+}
+
+// metric returns the registry metric for the definition. If the tracepoint that created the metric changes the
+// definition it is replaced, which resets its series. nil is returned if another tracepoint created the metric with
+// a different definition, or if the definition would exceed the max metrics.
+func (p *Processor) metric(tracepoint string, d *definition) *dynamicMetric {
+	p.metricsMtx.Lock()
+	defer p.metricsMtx.Unlock()
+
+	m, ok := p.metrics[d.name]
+	if ok && m.signature == d.signature() {
+		return m
+	}
+
+	// the registry has a single metric per name, so tracepoints cannot replace each other's definitions
+	if ok && m.tracepoint != tracepoint {
+		metricRejectedDefinitions.WithLabelValues(p.tenant, reasonConflict).Inc()
+		return nil
+	}
+
+	if !ok && p.Cfg.MaxMetrics > 0 && len(p.metrics) >= p.Cfg.MaxMetrics {
+		metricRejectedDefinitions.WithLabelValues(p.tenant, reasonMaxMetrics).Inc()
+		return nil
+	}
+
+	// remove the old definition first, otherwise its series are still counted as active by the registry
+	if ok {
+		p.registry.RemoveMetric(d.name)
+	}
+
+	// the registry truncates the labels in place, so give it a copy
+	labels := append([]string(nil), d.labels...)
+
+	m = &dynamicMetric{
+		signature:  d.signature(),
+		tracepoint: tracepoint,
+		series:     map[string]time.Time{},
+	}
+	switch d.metricType {
+	case typeCounter:
+		m.counter = p.registry.NewCounter(d.name, labels)
+	case typeGauge:
+		m.gauge = p.registry.NewGauge(d.name, labels)
+	case typeHistogram:
+		m.histogram = p.registry.NewHistogram(d.name, labels, p.Cfg.HistogramBuckets)
+	}
+	p.metrics[d.name] = m
+
+	return m
+}
+
+// allowSeries returns false if recording the label values would create a new series for the metric and exceed the
+// max series per metric. Series that have not been recorded within the stale duration no longer count.
+func (p *Processor) allowSeries(m *dynamicMetric, labelValues []string) bool {
+	m.seriesMtx.Lock()
+	defer m.seriesMtx.Unlock()
+
+	key := strings.Join(labelValues, "\xff")
+	now := time.Now()
+	if _, ok := m.series[key]; ok || p.Cfg.MaxSeriesPerMetric <= 0 {
+		m.series[key] = now
+		return true
+	}
+
+	if len(m.series) >= p.Cfg.MaxSeriesPerMetric {
+		for k, lastSeen := range m.series {
+			if now.Sub(lastSeen) > seriesStaleDuration {
+				delete(m.series, k)
+			}
+		}
+		if len(m.series) >= p.Cfg.MaxSeriesPerMetric {
+			return false
+		}
+	}
+
+	m.series[key] = now
+	return true
+}
+
+// staticFloat converts the value of an attribute to the value of a metric, durations are recorded in seconds
+func staticFloat(s deepql.Static) (float64, bool) {
+	var f float64
+	switch s.Type {
+	case deepql.TypeInt:
+		f = float64(s.N)
+	case deepql.TypeFloat:
+		f = s.F
+	case deepql.TypeDuration:
+		f = s.D.Seconds()
+	case deepql.TypeBoolean:
+		if s.B {
+			f = 1
+		}
+	case deepql.TypeString:
+		var err error
+		f, err = strconv.ParseFloat(s.S, 64)
+		if err != nil {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+	return f, true
+}
+
+// staticString converts the value of an attribute to the value of a label
+func staticString(s deepql.Static) string {
+	switch s.Type {
+	case deepql.TypeInt:
+		return strconv.Itoa(s.N)
+	case deepql.TypeFloat:
+		return strconv.FormatFloat(s.F, 'g', -1, 64)
+	case deepql.TypeString:
+		return s.S
+	case deepql.TypeBoolean:
+		return strconv.FormatBool(s.B)
+	case deepql.TypeDuration:
+		return s.D.String()
+	default:
+		return ""
+	}
+}
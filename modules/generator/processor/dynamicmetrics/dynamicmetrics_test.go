@@ -0,0 +1,181 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dynamicmetrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/intergral/deep/modules/generator/registry"
+	"github.com/intergral/deep/pkg/deeppb"
+	tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+)
+
+func testSnapshot(args map[string]string, total string, paid bool) *deeppb.PushSnapshotRequest {
+	paidValue := "false"
+	if paid {
+		paidValue = "true"
+	}
+
+	return &deeppb.PushSnapshotRequest{
+		Snapshot: &tp.Snapshot{
+			ID:         []byte{0x01},
+			Tracepoint: &tp.TracePointConfig{ID: "tp-1", Args: args},
+			Frames: []*tp.StackFrame{
+				{Variables: []*tp.VariableID{{ID: "1", Name: "order"}}},
+			},
+			VarLookup: map[string]*tp.Variable{
+				"1": {Type: "Order", Children: []*tp.VariableID{{ID: "2", Name: "total"}, {ID: "3", Name: "paid"}}},
+				"2": {Type: "float", Value: total},
+				"3": {Type: "bool", Value: paidValue},
+				"4": {Type: "int", Value: "7"},
+			},
+			Watches: []*tp.WatchResult{
+				{Expression: "len(queue)", Result: &tp.WatchResult_GoodResult{GoodResult: &tp.VariableID{ID: "4"}}},
+			},
+		},
+	}
+}
+
+func newTestProcessor(maxMetrics int) (*Processor, *registry.TestRegistry) {
+	testRegistry := registry.NewTestRegistry()
+	cfg := Config{}
+	cfg.RegisterFlagsAndApplyDefaults("", nil)
+	cfg.HistogramBuckets = []float64{10, 100}
+	cfg.MaxMetrics = maxMetrics
+
+	return New(cfg, "test", testRegistry).(*Processor), testRegistry
+}
+
+func TestDynamicMetrics(t *testing.T) {
+	p, testRegistry := newTestProcessor(0)
+	defer p.Shutdown(context.Background())
+
+	args := map[string]string{
+		"metric.orders_total.label.paid": "var.order.paid",
+		"metric.order_value.type":        "histogram",
+		"metric.order_value.value":       "var.order.total",
+		"metric.queue_size.type":         "gauge",
+		"metric.queue_size.value":        "watch.len(queue)",
+	}
+
+	p.PushSnapshot(context.Background(), testSnapshot(args, "42.5", true))
+	p.PushSnapshot(context.Background(), testSnapshot(args, "150", true))
+	p.PushSnapshot(context.Background(), testSnapshot(args, "5", false))
+
+	assert.Equal(t, 2.0, testRegistry.Query("orders_total", labels.FromStrings("paid", "true")))
+	assert.Equal(t, 1.0, testRegistry.Query("orders_total", labels.FromStrings("paid", "false")))
+
+	assert.Equal(t, 3.0, testRegistry.Query("order_value_count", labels.EmptyLabels()))
+	assert.Equal(t, 197.5, testRegistry.Query("order_value_sum", labels.EmptyLabels()))
+	assert.Equal(t, 1.0, testRegistry.Query("order_value_bucket", labels.FromStrings("le", "10")))
+	assert.Equal(t, 2.0, testRegistry.Query("order_value_bucket", labels.FromStrings("le", "100")))
+	assert.Equal(t, 3.0, testRegistry.Query("order_value_bucket", labels.FromStrings("le", "+Inf")))
+
+	assert.Equal(t, 7.0, testRegistry.Query("queue_size", labels.EmptyLabels()))
+}
+
+func TestDynamicMetricsSkipsMissingValues(t *testing.T) {
+	p, testRegistry := newTestProcessor(0)
+
+	p.PushSnapshot(context.Background(), testSnapshot(map[string]string{
+		"metric.missing.type":   "gauge",
+		"metric.missing.value":  "var.order.discount",
+		"metric.negative.value": "var.order.total",
+	}, "-1", false))
+	p.PushSnapshot(context.Background(), testSnapshot(map[string]string{
+		"metric.not_a_number.type":  "gauge",
+		"metric.not_a_number.value": "var.order.total",
+	}, "NaN", false))
+
+	assert.Equal(t, "", testRegistry.String())
+}
+
+func TestDynamicMetricsMaxMetrics(t *testing.T) {
+	p, testRegistry := newTestProcessor(1)
+
+	p.PushSnapshot(context.Background(), testSnapshot(map[string]string{"metric.first.type": "counter"}, "1", true))
+	p.PushSnapshot(context.Background(), testSnapshot(map[string]string{"metric.second.type": "counter"}, "1", true))
+
+	assert.Equal(t, 1.0, testRegistry.Query("first", labels.EmptyLabels()))
+	assert.Equal(t, 0.0, testRegistry.Query("second", labels.EmptyLabels()))
+	assert.Len(t, p.metrics, 1)
+}
+
+func TestDynamicMetricsReplacedWhenDefinitionChanges(t *testing.T) {
+	p, testRegistry := newTestProcessor(0)
+
+	p.PushSnapshot(context.Background(), testSnapshot(map[string]string{"metric.orders.type": "counter"}, "1", true))
+	counter := p.metrics["orders"]
+	assert.Equal(t, 1.0, testRegistry.Query("orders", labels.EmptyLabels()))
+
+	p.PushSnapshot(context.Background(), testSnapshot(map[string]string{"metric.orders.type": "counter"}, "1", true))
+	assert.Same(t, counter, p.metrics["orders"])
+
+	p.PushSnapshot(context.Background(), testSnapshot(map[string]string{"metric.orders.label.paid": "var.order.paid"}, "1", true))
+	assert.NotSame(t, counter, p.metrics["orders"])
+	assert.Equal(t, "counter{paid}", p.metrics["orders"].signature)
+	// the series of the old definition are removed from the registry
+	assert.Equal(t, 0.0, testRegistry.Query("orders", labels.EmptyLabels()))
+	assert.Equal(t, 1.0, testRegistry.Query("orders", labels.FromStrings("paid", "true")))
+}
+
+func TestDynamicMetricsConflictingDefinitionIsRejected(t *testing.T) {
+	p, testRegistry := newTestProcessor(0)
+
+	p.PushSnapshot(context.Background(), testSnapshot(map[string]string{"metric.orders.type": "counter"}, "1", true))
+	counter := p.metrics["orders"]
+
+	other := testSnapshot(map[string]string{"metric.orders.type": "gauge", "metric.orders.value": "var.order.total"}, "5", true)
+	other.Snapshot.Tracepoint.ID = "tp-2"
+	p.PushSnapshot(context.Background(), other)
+
+	assert.Same(t, counter, p.metrics["orders"])
+	assert.Equal(t, 1.0, testRegistry.Query("orders", labels.EmptyLabels()))
+
+	// the same definition can be shared
+	same := testSnapshot(map[string]string{"metric.orders.type": "counter"}, "1", true)
+	same.Snapshot.Tracepoint.ID = "tp-2"
+	p.PushSnapshot(context.Background(), same)
+
+	assert.Same(t, counter, p.metrics["orders"])
+	assert.Equal(t, 2.0, testRegistry.Query("orders", labels.EmptyLabels()))
+}
+
+func TestDynamicMetricsMaxSeriesPerMetric(t *testing.T) {
+	p, testRegistry := newTestProcessor(0)
+	p.Cfg.MaxSeriesPerMetric = 1
+
+	args := map[string]string{"metric.orders.label.total": "var.order.total"}
+	p.PushSnapshot(context.Background(), testSnapshot(args, "1", true))
+	p.PushSnapshot(context.Background(), testSnapshot(args, "2", true))
+	p.PushSnapshot(context.Background(), testSnapshot(args, "1", true))
+
+	assert.Equal(t, 2.0, testRegistry.Query("orders", labels.FromStrings("total", "1")))
+	assert.Equal(t, 0.0, testRegistry.Query("orders", labels.FromStrings("total", "2")))
+
+	// series that are stale no longer count towards the limit
+	p.metrics["orders"].series["1"] = time.Now().Add(-2 * seriesStaleDuration)
+	p.PushSnapshot(context.Background(), testSnapshot(args, "2", true))
+
+	assert.Equal(t, 1.0, testRegistry.Query("orders", labels.FromStrings("total", "2")))
+}
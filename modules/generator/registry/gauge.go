@@ -0,0 +1,160 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package registry
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/storage"
+	"go.uber.org/atomic"
+)
+
+type gauge struct {
+	metricName string
+	labels     []string
+
+	// seriesMtx is used to sync modifications to the map, not to the data in series
+	seriesMtx sync.RWMutex
+	series    map[uint64]*gaugeSeries
+
+	onAddSeries    func(count uint32) bool
+	onRemoveSeries func(count uint32)
+}
+
+type gaugeSeries struct {
+	// labelValues should not be modified after creation
+	labelValues []string
+	value       *atomic.Float64
+	lastUpdated *atomic.Int64
+}
+
+var (
+	_ Gauge  = (*gauge)(nil)
+	_ metric = (*gauge)(nil)
+)
+
+func newGauge(name string, labels []string, onAddSeries func(uint32) bool, onRemoveSeries func(count uint32)) *gauge {
+	if onAddSeries == nil {
+		onAddSeries = func(uint32) bool {
+			return true
+		}
+	}
+	if onRemoveSeries == nil {
+		onRemoveSeries = func(uint32) {}
+	}
+
+	return &gauge{
+		metricName:     name,
+		labels:         labels,
+		series:         make(map[uint64]*gaugeSeries),
+		onAddSeries:    onAddSeries,
+		onRemoveSeries: onRemoveSeries,
+	}
+}
+
+func (g *gauge) Set(labelValues *LabelValues, value float64) {
+	if len(g.labels) != len(labelValues.getValues()) {
+		panic(fmt.Sprintf("length of given label values does not match with labels, labels: %v, label values: %v", g.labels, labelValues))
+	}
+
+	hash := labelValues.getHash()
+
+	g.seriesMtx.RLock()
+	s, ok := g.series[hash]
+	g.seriesMtx.RUnlock()
+
+	if ok {
+		g.updateSeries(s, value)
+		return
+	}
+
+	if !g.onAddSeries(1) {
+		return
+	}
+
+	newSeries := &gaugeSeries{
+		labelValues: labelValues.getValuesCopy(),
+		value:       atomic.NewFloat64(value),
+		lastUpdated: atomic.NewInt64(time.Now().UnixMilli()),
+	}
+
+	g.seriesMtx.Lock()
+	defer g.seriesMtx.Unlock()
+
+	s, ok = g.series[hash]
+	if ok {
+		g.updateSeries(s, value)
+		return
+	}
+	g.series[hash] = newSeries
+}
+
+func (g *gauge) updateSeries(s *gaugeSeries, value float64) {
+	s.value.Store(value)
+	s.lastUpdated.Store(time.Now().UnixMilli())
+}
+
+func (g *gauge) name() string {
+	return g.metricName
+}
+
+func (g *gauge) collectMetrics(appender storage.Appender, timeMs int64, externalLabels map[string]string) (activeSeries int, err error) {
+	g.seriesMtx.RLock()
+	defer g.seriesMtx.RUnlock()
+
+	activeSeries = len(g.series)
+
+	lbls := make(labels.Labels, 1+len(externalLabels)+len(g.labels))
+	lb := labels.NewBuilder(lbls)
+
+	// set metric name
+	lb.Set(labels.MetricName, g.metricName)
+	// set external labels
+	for name, value := range externalLabels {
+		lb.Set(name, value)
+	}
+
+	for _, s := range g.series {
+		// set series-specific labels
+		for i, name := range g.labels {
+			lb.Set(name, s.labelValues[i])
+		}
+
+		_, err = appender.Append(0, lb.Labels(nil), timeMs, s.value.Load())
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (g *gauge) removeStaleSeries(staleTimeMs int64) {
+	g.seriesMtx.Lock()
+	defer g.seriesMtx.Unlock()
+
+	for hash, s := range g.series {
+		if s.lastUpdated.Load() < staleTimeMs {
+			delete(g.series, hash)
+			g.onRemoveSeries(1)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package registry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_gauge(t *testing.T) {
+	var seriesAdded int
+	onAdd := func(count uint32) bool {
+		seriesAdded++
+		return true
+	}
+
+	g := newGauge("my_gauge", []string{"label"}, onAdd, nil)
+
+	g.Set(newLabelValues([]string{"value-1"}), 1.0)
+	g.Set(newLabelValues([]string{"value-2"}), 2.0)
+
+	assert.Equal(t, 2, seriesAdded)
+
+	collectionTimeMs := time.Now().UnixMilli()
+	expectedSamples := []sample{
+		newSample(map[string]string{"__name__": "my_gauge", "label": "value-1"}, collectionTimeMs, 1),
+		newSample(map[string]string{"__name__": "my_gauge", "label": "value-2"}, collectionTimeMs, 2),
+	}
+	collectMetricAndAssert(t, g, collectionTimeMs, nil, 2, expectedSamples, nil)
+
+	// a gauge keeps the last value, which can go down
+	g.Set(newLabelValues([]string{"value-2"}), -3.0)
+	g.Set(newLabelValues([]string{"value-3"}), 3.0)
+
+	assert.Equal(t, 3, seriesAdded)
+
+	expectedSamples = []sample{
+		newSample(map[string]string{"__name__": "my_gauge", "label": "value-1"}, collectionTimeMs, 1),
+		newSample(map[string]string{"__name__": "my_gauge", "label": "value-2"}, collectionTimeMs, -3),
+		newSample(map[string]string{"__name__": "my_gauge", "label": "value-3"}, collectionTimeMs, 3),
+	}
+	collectMetricAndAssert(t, g, collectionTimeMs, nil, 3, expectedSamples, nil)
+}
+
+func Test_gauge_invalidLabelValues(t *testing.T) {
+	g := newGauge("my_gauge", []string{"label"}, nil, nil)
+
+	assert.Panics(t, func() {
+		g.Set(nil, 1.0)
+	})
+	assert.Panics(t, func() {
+		g.Set(newLabelValues([]string{"value-1", "value-2"}), 1.0)
+	})
+}
+
+func Test_gauge_removeStaleSeries(t *testing.T) {
+	var removedSeries int
+	onRemove := func(count uint32) {
+		assert.Equal(t, uint32(1), count)
+		removedSeries++
+	}
+
+	g := newGauge("my_gauge", []string{"label"}, nil, onRemove)
+
+	g.Set(newLabelValues([]string{"value-1"}), 1.0)
+	g.Set(newLabelValues([]string{"value-2"}), 2.0)
+
+	time.Sleep(10 * time.Millisecond)
+	timeMs := time.Now().UnixMilli()
+
+	// update value-2 series
+	g.Set(newLabelValues([]string{"value-2"}), 4.0)
+
+	g.removeStaleSeries(timeMs)
+
+	assert.Equal(t, 1, removedSeries)
+
+	collectionTimeMs := time.Now().UnixMilli()
+	expectedSamples := []sample{
+		newSample(map[string]string{"__name__": "my_gauge", "label": "value-2"}, collectionTimeMs, 4),
+	}
+	collectMetricAndAssert(t, g, collectionTimeMs, nil, 1, expectedSamples, nil)
+}
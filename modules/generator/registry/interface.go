@@ -22,6 +22,9 @@ type Registry interface {
 	NewLabelValues(values []string) *LabelValues
 	NewCounter(name string, labels []string) Counter
 	NewHistogram(name string, labels []string, buckets []float64) Histogram
+	NewGauge(name string, labels []string) Gauge
+	// RemoveMetric will remove the metric with the given name and all of its series
+	RemoveMetric(name string)
 }
 
 // Counter
@@ -30,6 +33,12 @@ type Counter interface {
 	Inc(values *LabelValues, value float64)
 }
 
+// Gauge
+// https://prometheus.io/docs/concepts/metric_types/#gauge
+type Gauge interface {
+	Set(values *LabelValues, value float64)
+}
+
 // Histogram
 // https://prometheus.io/docs/concepts/metric_types/#histogram
 type Histogram interface {
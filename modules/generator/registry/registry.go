@@ -19,6 +19,7 @@ package registry
 
 import (
 	"context"
+	"math"
 	"os"
 	"sync"
 	"time"
@@ -169,6 +170,14 @@ func (r *ManagedRegistry) NewHistogram(name string, labels []string, buckets []f
 	return h
 }
 
+func (r *ManagedRegistry) NewGauge(name string, labels []string) Gauge {
+	truncateLength(labels, r.cfg.MaxLabelNameLength)
+
+	g := newGauge(name, labels, r.onAddMetricSeries, r.onRemoveMetricSeries)
+	r.registerMetric(g)
+	return g
+}
+
 func (r *ManagedRegistry) registerMetric(m metric) {
 	r.metricsMtx.Lock()
 	defer r.metricsMtx.Unlock()
@@ -179,6 +188,20 @@ func (r *ManagedRegistry) registerMetric(m metric) {
 	r.metrics[m.name()] = m
 }
 
+// RemoveMetric will remove the metric and its series, so the series no longer count towards the active series
+func (r *ManagedRegistry) RemoveMetric(name string) {
+	r.metricsMtx.Lock()
+	defer r.metricsMtx.Unlock()
+
+	m, ok := r.metrics[name]
+	if !ok {
+		return
+	}
+	// every series is older than this, so all the series are removed
+	m.removeStaleSeries(math.MaxInt64)
+	delete(r.metrics, name)
+}
+
 func (r *ManagedRegistry) onAddMetricSeries(count uint32) bool {
 	maxActiveSeries := r.overrides.MetricsGeneratorMaxActiveSeries(r.tenant)
 	if maxActiveSeries != 0 && r.activeSeries.Load()+count > maxActiveSeries {
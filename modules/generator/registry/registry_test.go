@@ -192,6 +192,36 @@ func TestManagedRegistry_maxSeries(t *testing.T) {
 	collectRegistryMetricsAndAssert(t, registry, appender, expectedSamples)
 }
 
+func TestManagedRegistry_removeMetric(t *testing.T) {
+	appender := &capturingAppender{}
+
+	registry := New(&Config{}, &mockOverrides{}, "test", appender, log.NewNopLogger())
+	defer registry.Close()
+
+	counter := registry.NewCounter("metric_1", []string{"label"})
+	counter.Inc(newLabelValues([]string{"value-1"}), 1.0)
+	counter.Inc(newLabelValues([]string{"value-2"}), 1.0)
+	histogram := registry.NewHistogram("metric_2", nil, []float64{1.0})
+	histogram.ObserveWithExemplar(nil, 1.0, "", 1.0)
+	assert.Equal(t, uint32(6), registry.activeSeries.Load())
+
+	// replacing the metric does not leak the series of the removed metric
+	registry.RemoveMetric("metric_1")
+	counter = registry.NewCounter("metric_1", nil)
+	counter.Inc(nil, 1.0)
+	assert.Equal(t, uint32(5), registry.activeSeries.Load())
+
+	registry.RemoveMetric("metric_2")
+	registry.RemoveMetric("missing")
+	assert.Equal(t, uint32(1), registry.activeSeries.Load())
+
+	expectedSamples := []sample{
+		newSample(map[string]string{"__name__": "metric_1", "__metrics_gen_instance": mustGetHostname()}, 0, 0),
+		newSample(map[string]string{"__name__": "metric_1", "__metrics_gen_instance": mustGetHostname()}, 0, 1),
+	}
+	collectRegistryMetricsAndAssert(t, registry, appender, expectedSamples)
+}
+
 func TestManagedRegistry_disableCollection(t *testing.T) {
 	appender := &capturingAppender{}
 
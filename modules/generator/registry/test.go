@@ -63,6 +63,30 @@ func (t *TestRegistry) NewHistogram(name string, labels []string, buckets []floa
 	}
 }
 
+func (t *TestRegistry) NewGauge(name string, labels []string) Gauge {
+	return &testGauge{
+		name:     name,
+		labels:   labels,
+		registry: t,
+	}
+}
+
+func (t *TestRegistry) RemoveMetric(name string) {
+	for metric := range t.metrics {
+		switch strings.SplitN(metric, "{", 2)[0] {
+		case name, name + "_sum", name + "_count", name + "_bucket":
+			delete(t.metrics, metric)
+		}
+	}
+}
+
+func (t *TestRegistry) setMetric(name string, lbls labels.Labels, value float64) {
+	if t == nil || t.metrics == nil {
+		return
+	}
+	t.metrics[name+lbls.String()] = value
+}
+
 func (t *TestRegistry) addToMetric(name string, lbls labels.Labels, value float64) {
 	if t == nil || t.metrics == nil {
 		return
@@ -109,6 +133,24 @@ func (t testCounter) Inc(values *LabelValues, value float64) {
 	t.registry.addToMetric(t.name, lbls, value)
 }
 
+type testGauge struct {
+	name     string
+	labels   []string
+	registry *TestRegistry
+}
+
+var _ Gauge = (*testGauge)(nil)
+
+func (t testGauge) Set(values *LabelValues, value float64) {
+	lbls := make(labels.Labels, len(t.labels))
+	for i, label := range t.labels {
+		lbls[i] = labels.Label{Name: label, Value: values.values[i]}
+	}
+	sort.Sort(lbls)
+
+	t.registry.setMetric(t.name, lbls, value)
+}
+
 type testHistogram struct {
 	nameSum    string
 	nameCount  string
@@ -21,9 +21,12 @@ import (
 	"context"
 
 	"github.com/intergral/deep/pkg/deeppb"
+	"github.com/intergral/deep/pkg/deepql"
 	"github.com/intergral/deep/pkg/util"
 
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func (i *Ingester) SearchRecent(ctx context.Context, req *deeppb.SearchRequest) (*deeppb.SearchResponse, error) {
@@ -103,3 +106,23 @@ func (i *Ingester) SearchTagValuesV2(ctx context.Context, req *deeppb.SearchTagV
 func (i *Ingester) SearchBlock(context.Context, *deeppb.SearchBlockRequest) (*deeppb.SearchResponse, error) {
 	return nil, errors.New("not implemented")
 }
+
+// TailSnapshots streams every snapshot pushed to this ingester that matches the query, until the caller goes away
+func (i *Ingester) TailSnapshots(req *deeppb.TailSnapshotsRequest, stream deeppb.QuerierService_TailSnapshotsServer) error {
+	tenantID, err := util.ExtractTenantID(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	matcher, err := deepql.NewSnapshotMatcher(req.Query)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	inst, err := i.getOrCreateInstance(tenantID)
+	if err != nil {
+		return err
+	}
+
+	return inst.Tail(stream.Context(), matcher, stream.Send)
+}
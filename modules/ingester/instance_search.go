@@ -49,6 +49,12 @@ func (i *tenantBlockManager) Search(ctx context.Context, req *deeppb.SearchReque
 
 	span.LogFields(ot_log.String("SearchRequest", req.String()))
 
+	// metrics queries and pipelines that aggregate need every matching snapshot, so they are only limited when the
+	// frontend sets a limit
+	if deepql.CollectsAllSnapshots(req.Query) {
+		maxResults = int(req.Limit)
+	}
+
 	sr := search.NewResults()
 	defer sr.Close() // signal all running workers to quit
 
@@ -80,7 +86,7 @@ func (i *tenantBlockManager) Search(ctx context.Context, req *deeppb.SearchReque
 			resultsMap[result.SnapshotID] = result
 		}
 
-		if len(resultsMap) >= maxResults {
+		if maxResults > 0 && len(resultsMap) >= maxResults {
 			sr.Close() // signal pending workers to exit
 			break
 		}
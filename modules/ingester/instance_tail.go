@@ -0,0 +1,203 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package ingester
+
+import (
+	"context"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/atomic"
+
+	"github.com/intergral/deep/pkg/deeppb"
+	"github.com/intergral/deep/pkg/deepql"
+	"github.com/intergral/deep/pkg/model"
+	"github.com/intergral/deep/pkg/util/log"
+)
+
+const (
+	// tailBufferSize is the number of matched snapshots queued per tail before we start dropping them
+	tailBufferSize = 100
+	// tailQueueSize is the number of pushed snapshots queued to be matched against the live tails of a tenant before
+	// we start dropping them
+	tailQueueSize = 1000
+)
+
+var (
+	metricLiveTails = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "deep",
+		Subsystem: "ingester",
+		Name:      "live_tails",
+		Help:      "The current number of live tails per tenant.",
+	}, []string{"tenant"})
+	metricTailDroppedSnapshotsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "deep",
+		Subsystem: "ingester",
+		Name:      "tail_dropped_snapshots_total",
+		Help:      "The total number of matched snapshots dropped because a live tail could not keep up, per tenant.",
+	}, []string{"tenant"})
+)
+
+// liveTail is a single open tail, matched snapshots are queued on snapshots until they are sent to the client
+type liveTail struct {
+	matcher   *deepql.SnapshotMatcher
+	snapshots chan *deeppb.SnapshotSearchMetadata
+	dropped   atomic.Uint32
+}
+
+// Tail registers a live tail for this tenant and sends every pushed snapshot that matches to send until ctx is
+// done. If send cannot keep up the oldest snapshots are kept and newer ones are dropped, the number dropped is
+// reported on the next response.
+func (i *tenantBlockManager) Tail(ctx context.Context, matcher *deepql.SnapshotMatcher, send func(*deeppb.TailSnapshotsResponse) error) error {
+	t := &liveTail{
+		matcher:   matcher,
+		snapshots: make(chan *deeppb.SnapshotSearchMetadata, tailBufferSize),
+	}
+
+	i.addTail(t)
+	defer i.removeTail(t)
+
+	// send an empty response to let the caller know the tail is registered
+	err := send(&deeppb.TailSnapshotsResponse{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case metadata := <-t.snapshots:
+			resp := &deeppb.TailSnapshotsResponse{
+				Snapshots: []*deeppb.SnapshotSearchMetadata{metadata},
+			}
+			// batch up anything else that is already queued
+		drain:
+			for len(resp.Snapshots) < tailBufferSize {
+				select {
+				case metadata = <-t.snapshots:
+					resp.Snapshots = append(resp.Snapshots, metadata)
+				default:
+					break drain
+				}
+			}
+			resp.DroppedSnapshots = t.dropped.Swap(0)
+
+			err = send(resp)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (i *tenantBlockManager) addTail(t *liveTail) {
+	i.tailsMtx.Lock()
+	defer i.tailsMtx.Unlock()
+
+	i.tails[t] = struct{}{}
+	// the first tail starts the worker that matches pushed snapshots, so the push never waits on the matching
+	if len(i.tails) == 1 {
+		i.tailQueue = make(chan []byte, tailQueueSize)
+		go i.runTailWorker(i.tailQueue)
+	}
+	i.tailCount.Store(int32(len(i.tails)))
+	metricLiveTails.WithLabelValues(i.tenantID).Set(float64(len(i.tails)))
+}
+
+func (i *tenantBlockManager) removeTail(t *liveTail) {
+	i.tailsMtx.Lock()
+	defer i.tailsMtx.Unlock()
+
+	delete(i.tails, t)
+	// the last tail stops the worker once it has drained the queue
+	if len(i.tails) == 0 && i.tailQueue != nil {
+		close(i.tailQueue)
+		i.tailQueue = nil
+	}
+	i.tailCount.Store(int32(len(i.tails)))
+	metricLiveTails.WithLabelValues(i.tenantID).Set(float64(len(i.tails)))
+}
+
+// queueTailSnapshot queues a pushed snapshot to be offered to the live tails for this tenant. This is called for
+// every push, so it returns straight away if there are no live tails and never blocks. If the queue is full the
+// snapshot is dropped for every tail.
+func (i *tenantBlockManager) queueTailSnapshot(snapshotBytes []byte) {
+	if i.tailCount.Load() == 0 {
+		return
+	}
+
+	i.tailsMtx.RLock()
+	defer i.tailsMtx.RUnlock()
+
+	if i.tailQueue == nil {
+		return
+	}
+
+	select {
+	case i.tailQueue <- snapshotBytes:
+	default:
+		for t := range i.tails {
+			t.dropped.Inc()
+			metricTailDroppedSnapshotsTotal.WithLabelValues(i.tenantID).Inc()
+		}
+	}
+}
+
+// runTailWorker offers the queued snapshots to the live tails until the queue is closed
+func (i *tenantBlockManager) runTailWorker(queue <-chan []byte) {
+	for snapshotBytes := range queue {
+		i.tailSnapshot(snapshotBytes)
+	}
+}
+
+// tailSnapshot offers a pushed snapshot to every live tail for this tenant. This never blocks, if a tail is full the
+// snapshot is dropped for that tail.
+func (i *tenantBlockManager) tailSnapshot(snapshotBytes []byte) {
+	i.tailsMtx.RLock()
+	defer i.tailsMtx.RUnlock()
+
+	if len(i.tails) == 0 {
+		return
+	}
+
+	snapshot, err := model.MustNewSegmentDecoder(model.CurrentEncoding).PrepareForRead(snapshotBytes)
+	if err != nil {
+		level.Warn(log.Logger).Log("msg", "failed to decode snapshot for live tail", "tenant", i.tenantID, "err", err)
+		return
+	}
+
+	for t := range i.tails {
+		metadata, err := t.matcher.Match(snapshot)
+		if err != nil {
+			level.Debug(log.Logger).Log("msg", "failed to match snapshot for live tail", "tenant", i.tenantID, "err", err)
+			continue
+		}
+		if metadata == nil {
+			continue
+		}
+
+		select {
+		case t.snapshots <- metadata:
+		default:
+			t.dropped.Inc()
+			metricTailDroppedSnapshotsTotal.WithLabelValues(i.tenantID).Inc()
+		}
+	}
+}
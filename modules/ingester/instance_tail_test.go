@@ -0,0 +1,162 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package ingester
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/intergral/deep/pkg/deeppb"
+	"github.com/intergral/deep/pkg/deepql"
+	"github.com/intergral/deep/pkg/util"
+	"github.com/intergral/deep/pkg/util/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstanceTail(t *testing.T) {
+	i, _ := defaultInstance(t)
+
+	matcher, err := deepql.NewSnapshotMatcher(`{ resource.service.name = "tail-service" }`)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	responses := make(chan *deeppb.TailSnapshotsResponse, 10)
+	done := make(chan error)
+	go func() {
+		done <- i.Tail(ctx, matcher, func(resp *deeppb.TailSnapshotsResponse) error {
+			responses <- resp
+			return nil
+		})
+	}()
+
+	// the first response acknowledges the tail
+	ack := <-responses
+	assert.Empty(t, ack.Snapshots)
+
+	matchID := pushTailSnapshot(t, i, "tail-service")
+	pushTailSnapshot(t, i, "other-service")
+
+	select {
+	case resp := <-responses:
+		require.Len(t, resp.Snapshots, 1)
+		assert.Equal(t, util.SnapshotIDToHexString(matchID), resp.Snapshots[0].SnapshotID)
+		assert.Equal(t, "tail-service", resp.Snapshots[0].ServiceName)
+		assert.Equal(t, uint32(0), resp.DroppedSnapshots)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for tailed snapshot")
+	}
+
+	cancel()
+	require.NoError(t, <-done)
+
+	i.tailsMtx.RLock()
+	assert.Empty(t, i.tails)
+	// the worker is stopped with the last tail
+	assert.Nil(t, i.tailQueue)
+	i.tailsMtx.RUnlock()
+	assert.Equal(t, int32(0), i.tailCount.Load())
+}
+
+func TestInstanceTailSkippedWithoutTails(t *testing.T) {
+	i, _ := defaultInstance(t)
+
+	pushTailSnapshot(t, i, "test-service")
+
+	i.tailsMtx.RLock()
+	defer i.tailsMtx.RUnlock()
+	assert.Nil(t, i.tailQueue)
+}
+
+func TestInstanceTailDropsWhenFull(t *testing.T) {
+	i, _ := defaultInstance(t)
+
+	matcher, err := deepql.NewSnapshotMatcher(`{}`)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	acked := make(chan struct{})
+	blocked := make(chan struct{})
+	release := make(chan struct{})
+	responses := make(chan *deeppb.TailSnapshotsResponse, 10)
+	calls := 0
+	go func() {
+		_ = i.Tail(ctx, matcher, func(resp *deeppb.TailSnapshotsResponse) error {
+			calls++
+			switch calls {
+			case 1:
+				close(acked)
+			case 2:
+				// simulate a slow client
+				close(blocked)
+				<-release
+			}
+			responses <- resp
+			return nil
+		})
+	}()
+
+	<-acked
+	pushTailSnapshot(t, i, "test-service")
+	<-blocked
+
+	// the first snapshot is being sent, so these fill the buffer and then start dropping
+	for j := 0; j < tailBufferSize+5; j++ {
+		pushTailSnapshot(t, i, "test-service")
+	}
+	// the snapshots are matched by the tail worker, so wait for it to fill the buffer
+	require.Eventually(t, func() bool {
+		return tailDropped(i) == 5
+	}, 5*time.Second, 10*time.Millisecond)
+	close(release)
+
+	<-responses // ack
+	first := <-responses
+	assert.Len(t, first.Snapshots, 1)
+
+	select {
+	case resp := <-responses:
+		assert.Len(t, resp.Snapshots, tailBufferSize)
+		assert.Equal(t, uint32(5), resp.DroppedSnapshots)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for tailed snapshots")
+	}
+}
+
+// tailDropped returns the number of snapshots dropped for the tails of the tenant
+func tailDropped(i *tenantBlockManager) uint32 {
+	i.tailsMtx.RLock()
+	defer i.tailsMtx.RUnlock()
+
+	var dropped uint32
+	for t := range i.tails {
+		dropped += t.dropped.Load()
+	}
+	return dropped
+}
+
+func pushTailSnapshot(t *testing.T, i *tenantBlockManager, serviceName string) []byte {
+	id := test.ValidSnapshotID(nil)
+	req := makePushBytesRequest(id, test.GenerateSnapshot(1, &test.GenerateOptions{Id: id, ServiceName: serviceName}))
+	require.NoError(t, i.PushBytes(context.Background(), req.ID, req.Snapshot))
+
+	return id
+}
@@ -125,6 +125,12 @@ type tenantBlockManager struct {
 
 	lastBlockCut time.Time
 
+	tailsMtx  sync.RWMutex
+	tails     map[*liveTail]struct{}
+	tailQueue chan []byte
+	// tailCount is the number of tails, so pushes can skip the tails without taking tailsMtx
+	tailCount atomic.Int32
+
 	tenantID              string
 	snapshotsCreatedTotal prometheus.Counter
 	bytesReceivedTotal    *prometheus.CounterVec
@@ -143,6 +149,7 @@ func newTenantBlockManager(tenantID string, limiter *Limiter, writer deepdb.Writ
 	i := &tenantBlockManager{
 		liveSnapshots: map[uint32]*liveSnapshot{},
 		snapshotSizes: map[uint32]uint32{},
+		tails:         map[*liveTail]struct{}{},
 
 		tenantID:              tenantID,
 		snapshotsCreatedTotal: metricSnapshotsCreatedTotal.WithLabelValues(tenantID),
@@ -182,7 +189,13 @@ func (i *tenantBlockManager) PushBytes(ctx context.Context, id []byte, snapshotB
 		return status.Errorf(codes.FailedPrecondition, "%s max live snapshots exceeded for tenant %s: %v", overrides.ErrorPrefixLiveSnapshotsExceeded, i.tenantID, err)
 	}
 
-	return i.push(ctx, id, snapshotBytes)
+	err = i.push(ctx, id, snapshotBytes)
+	if err != nil {
+		return err
+	}
+
+	i.queueTailSnapshot(snapshotBytes)
+	return nil
 }
 
 func (i *tenantBlockManager) push(ctx context.Context, id, snapshotBytes []byte) error {
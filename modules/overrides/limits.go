@@ -63,6 +63,13 @@ type Limits struct {
 	IngestionRateStrategy   string `yaml:"ingestion_rate_strategy" json:"ingestion_rate_strategy"`
 	IngestionRateLimitBytes int    `yaml:"ingestion_rate_limit_bytes" json:"ingestion_rate_limit_bytes"`
 	IngestionBurstSizeBytes int    `yaml:"ingestion_burst_size_bytes" json:"ingestion_burst_size_bytes"`
+	// Per tracepoint limits, these stop a single tracepoint from using the whole tenant limit. Like the tenant limits,
+	// these are divided between the distributors when the global ingestion rate strategy is used.
+	TracepointSnapshotRateLimit       float64 `yaml:"tracepoint_snapshot_rate_limit" json:"tracepoint_snapshot_rate_limit"`
+	TracepointIngestionRateLimitBytes int     `yaml:"tracepoint_ingestion_rate_limit_bytes" json:"tracepoint_ingestion_rate_limit_bytes"`
+
+	// RedactionRules are applied to snapshot variables by the distributor
+	RedactionRules []RedactionRule `yaml:"redaction_rules" json:"redaction_rules"`
 
 	// Ingester enforced limits.
 	MaxLocalSnapshotsPerTenant  int `yaml:"max_snapshots_per_tenant" json:"max_snapshots_per_tenant"`
@@ -88,11 +95,16 @@ type Limits struct {
 	// Compactor enforced limits.
 	BlockRetention model.Duration `yaml:"block_retention" json:"block_retention"`
 
+	// Tracepoint enforced limits.
+	TracepointAuditRetention model.Duration `yaml:"tracepoint_audit_retention" json:"tracepoint_audit_retention"`
+
 	// Querier and Ingester enforced limits.
 	MaxBytesPerTagValuesQuery int `yaml:"max_bytes_per_tag_values_query" json:"max_bytes_per_tag_values_query"`
 
 	// QueryFrontend enforced limits
-	MaxSearchDuration model.Duration `yaml:"max_search_duration" json:"max_search_duration"`
+	MaxSearchDuration  model.Duration `yaml:"max_search_duration" json:"max_search_duration"`
+	MaxConcurrentTails int            `yaml:"max_concurrent_tails" json:"max_concurrent_tails"`
+	MaxBytesPerExport  int            `yaml:"max_bytes_per_export" json:"max_bytes_per_export"`
 
 	// MaxBytesPerSnapshot is enforced in the Ingester, Compactor, Querier (Search) and Serverless (Search). It
 	//  is not used when doing a snapshot by id lookup.
@@ -110,6 +122,8 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 	f.StringVar(&l.IngestionRateStrategy, "distributor.rate-limit-strategy", "local", "Whether the various ingestion rate limits should be applied individually to each distributor instance (local), or evenly shared across the cluster (global).")
 	f.IntVar(&l.IngestionRateLimitBytes, "distributor.ingestion-rate-limit-bytes", 15e6, "Per-user ingestion rate limit in bytes per second.")
 	f.IntVar(&l.IngestionBurstSizeBytes, "distributor.ingestion-burst-size-bytes", 20e6, "Per-user ingestion burst size in bytes. Should be set to the expected size (in bytes) of a single push request.")
+	f.Float64Var(&l.TracepointSnapshotRateLimit, "distributor.tracepoint-snapshot-rate-limit", 0, "Per-tracepoint ingestion rate limit in snapshots per second, shared across the cluster with the global rate limit strategy. 0 to disable.")
+	f.IntVar(&l.TracepointIngestionRateLimitBytes, "distributor.tracepoint-ingestion-rate-limit-bytes", 0, "Per-tracepoint ingestion rate limit in bytes per second, shared across the cluster with the global rate limit strategy. 0 to disable.")
 
 	// Ingester limits
 	f.IntVar(&l.MaxLocalSnapshotsPerTenant, "ingester.max-snapshots-per-tenant", 10e3, "Maximum number of active snapshots per tenant, per ingester. 0 to disable.")
@@ -119,6 +133,10 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 	// Querier limits
 	f.IntVar(&l.MaxBytesPerTagValuesQuery, "querier.max-bytes-per-tag-values-query", 50e5, "Maximum size of response for a tag-values query. Used mainly to limit large the number of values associated with a particular tag")
 
+	// QueryFrontend limits
+	f.IntVar(&l.MaxConcurrentTails, "frontend.max-concurrent-tails", 5, "Maximum number of concurrent live tails per tenant, per query frontend. 0 to disable.")
+	f.IntVar(&l.MaxBytesPerExport, "frontend.max-bytes-per-export", 100e6, "Maximum size of the snapshots written by a single export, per tenant. 0 to disable.")
+
 	f.StringVar(&l.PerTenantOverrideConfig, "limits.per-tenant-override-config", "", "File name of per tenant overrides.")
 	_ = l.PerTenantOverridePeriod.Set("10s")
 	f.Var(&l.PerTenantOverridePeriod, "limits.per-tenant-override-period", "Period with this to reload the overrides.")
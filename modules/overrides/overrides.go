@@ -282,6 +282,16 @@ func (o *Overrides) IngestionRateLimitBytes(tenantID string) float64 {
 	return float64(o.getOverridesForTenant(tenantID).IngestionRateLimitBytes)
 }
 
+// TracepointSnapshotRateLimit is the number of snapshots per second allowed for each tracepoint of this tenant.
+func (o *Overrides) TracepointSnapshotRateLimit(tenantID string) float64 {
+	return o.getOverridesForTenant(tenantID).TracepointSnapshotRateLimit
+}
+
+// TracepointIngestionRateLimitBytes is the number of bytes per second allowed for each tracepoint of this tenant.
+func (o *Overrides) TracepointIngestionRateLimitBytes(tenantID string) int {
+	return o.getOverridesForTenant(tenantID).TracepointIngestionRateLimitBytes
+}
+
 // IngestionBurstSizeBytes is the burst size in spans allowed for this tenant.
 func (o *Overrides) IngestionBurstSizeBytes(tenantID string) int {
 	return o.getOverridesForTenant(tenantID).IngestionBurstSizeBytes
@@ -361,11 +371,31 @@ func (o *Overrides) BlockRetention(tenantID string) time.Duration {
 	return time.Duration(o.getOverridesForTenant(tenantID).BlockRetention)
 }
 
+// TracepointAuditRetention is the duration to keep tracepoint audit events for this tenant, 0 keeps them forever.
+func (o *Overrides) TracepointAuditRetention(tenantID string) time.Duration {
+	return time.Duration(o.getOverridesForTenant(tenantID).TracepointAuditRetention)
+}
+
 // MaxSearchDuration is the duration of the max search duration for this tenant.
 func (o *Overrides) MaxSearchDuration(tenantID string) time.Duration {
 	return time.Duration(o.getOverridesForTenant(tenantID).MaxSearchDuration)
 }
 
+// RedactionRules are the rules used to mask or drop snapshot variables for this tenant.
+func (o *Overrides) RedactionRules(tenantID string) []RedactionRule {
+	return o.getOverridesForTenant(tenantID).RedactionRules
+}
+
+// MaxConcurrentTails is the maximum number of live tails this tenant can have open on a single query frontend.
+func (o *Overrides) MaxConcurrentTails(tenantID string) int {
+	return o.getOverridesForTenant(tenantID).MaxConcurrentTails
+}
+
+// MaxBytesPerExport is the maximum size of the snapshots written by a single export for this tenant.
+func (o *Overrides) MaxBytesPerExport(tenantID string) int {
+	return o.getOverridesForTenant(tenantID).MaxBytesPerExport
+}
+
 func (o *Overrides) getOverridesForTenant(tenantID string) *Limits {
 	if tenantOverrides := o.tenantOverrides(); tenantOverrides != nil {
 		l := tenantOverrides.forTenant(tenantID)
@@ -0,0 +1,96 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package overrides
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// RedactionActionMask replaces the matched value, leaving the variable in the snapshot
+	RedactionActionMask = "mask"
+	// RedactionActionDrop removes the variable from the snapshot
+	RedactionActionDrop = "drop"
+)
+
+// RedactionRule describes snapshot variables that the distributor should mask or drop before they are stored or
+// forwarded. A variable has to match every pattern that is set on the rule.
+type RedactionRule struct {
+	// VariableName is a regex matched against the name the variable is referenced by
+	VariableName string `yaml:"variable_name,omitempty" json:"variable_name,omitempty"`
+	// VariableType is a regex matched against the type of the variable
+	VariableType string `yaml:"variable_type,omitempty" json:"variable_type,omitempty"`
+	// Value is a regex matched against the value of the variable, only the matched part of the value is masked
+	Value string `yaml:"value,omitempty" json:"value,omitempty"`
+	// Luhn only matches values (or the parts matched by Value) that pass the Luhn checksum used by card numbers
+	Luhn bool `yaml:"luhn,omitempty" json:"luhn,omitempty"`
+	// Action is what to do with a matching variable, either mask or drop
+	Action string `yaml:"action" json:"action"`
+}
+
+var (
+	_ yaml.Unmarshaler = (*RedactionRule)(nil)
+	_ json.Unmarshaler = (*RedactionRule)(nil)
+)
+
+// Validate checks the rule can be used, so invalid rules are rejected when the overrides are loaded rather than
+// letting data through.
+func (r *RedactionRule) Validate() error {
+	if r.Action != RedactionActionMask && r.Action != RedactionActionDrop {
+		return fmt.Errorf("redaction rule action must be %s or %s, got %q", RedactionActionMask, RedactionActionDrop, r.Action)
+	}
+
+	if r.VariableName == "" && r.VariableType == "" && r.Value == "" && !r.Luhn {
+		return errors.New("redaction rule must set at least one of variable_name, variable_type, value or luhn")
+	}
+
+	for _, pattern := range []string{r.VariableName, r.VariableType, r.Value} {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid redaction rule pattern: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalYAML implements the Unmarshaler interface of the yaml pkg.
+func (r *RedactionRule) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain RedactionRule
+	err := unmarshal((*plain)(r))
+	if err != nil {
+		return err
+	}
+
+	return r.Validate()
+}
+
+// UnmarshalJSON implements the Unmarshal interface of the json pkg.
+func (r *RedactionRule) UnmarshalJSON(b []byte) error {
+	type plain RedactionRule
+	err := json.Unmarshal(b, (*plain)(r))
+	if err != nil {
+		return err
+	}
+
+	return r.Validate()
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package overrides
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestRedactionRulesUnmarshal(t *testing.T) {
+	inputYAML := `
+redaction_rules:
+  - variable_name: (?i)password|secret
+    action: drop
+  - value: '\d[\d -]{10,20}\d'
+    luhn: true
+    action: mask
+`
+	inputJSON := `
+{
+	"redaction_rules": [
+		{"variable_name": "(?i)password|secret", "action": "drop"},
+		{"value": "\\d[\\d -]{10,20}\\d", "luhn": true, "action": "mask"}
+	]
+}`
+	expected := []RedactionRule{
+		{VariableName: "(?i)password|secret", Action: RedactionActionDrop},
+		{Value: `\d[\d -]{10,20}\d`, Luhn: true, Action: RedactionActionMask},
+	}
+
+	limitsYAML := Limits{}
+	require.NoError(t, yaml.Unmarshal([]byte(inputYAML), &limitsYAML))
+	assert.Equal(t, expected, limitsYAML.RedactionRules)
+
+	limitsJSON := Limits{}
+	require.NoError(t, json.Unmarshal([]byte(inputJSON), &limitsJSON))
+	assert.Equal(t, expected, limitsJSON.RedactionRules)
+}
+
+func TestRedactionRulesInvalid(t *testing.T) {
+	for _, input := range []string{
+		"redaction_rules: [{variable_name: password}]",
+		"redaction_rules: [{variable_name: password, action: hide}]",
+		"redaction_rules: [{action: mask}]",
+		"redaction_rules: [{variable_name: '(', action: mask}]",
+	} {
+		limits := Limits{}
+		assert.Error(t, yaml.Unmarshal([]byte(input), &limits), input)
+	}
+
+	limits := Limits{}
+	assert.Error(t, json.Unmarshal([]byte(`{"redaction_rules": [{"value": "[", "action": "drop"}]}`), &limits))
+}
@@ -25,7 +25,10 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/intergral/deep/pkg/deeppb"
+	deep_tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
 	"github.com/intergral/deep/pkg/deepql"
+	"github.com/intergral/deep/pkg/model/snapshot"
+	"github.com/intergral/deep/pkg/util"
 	"github.com/pkg/errors"
 
 	"github.com/golang/protobuf/jsonpb" //nolint:all //deprecated
@@ -116,6 +119,69 @@ func (q *Querier) SnapshotByIdHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set(api.HeaderContentType, api.HeaderAcceptJSON)
 }
 
+// SnapshotDiffHandler finds the two snapshots and returns the differences between them
+func (q *Querier) SnapshotDiffHandler(w http.ResponseWriter, r *http.Request) {
+	// Enforce the query timeout while querying backends
+	ctx, cancel := context.WithDeadline(r.Context(), time.Now().Add(q.cfg.SnapshotByIDConfig.QueryTimeout))
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Querier.SnapshotDiffHandler")
+	defer span.Finish()
+
+	aID, bID, err := api.ParseSnapshotDiffRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	blockStart, blockEnd, queryMode, timeStart, timeEnd, err := api.ValidateAndSanitizeRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	snapshots := make([]*deep_tp.Snapshot, 0, 2)
+	for _, id := range [][]byte{aID, bID} {
+		resp, err := q.FindSnapshotByID(ctx, &deeppb.SnapshotByIDRequest{
+			ID:         id,
+			BlockStart: blockStart,
+			BlockEnd:   blockEnd,
+			QueryMode:  queryMode,
+		}, timeStart, timeEnd)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if resp.Snapshot == nil {
+			http.Error(w, fmt.Sprintf("snapshot %s not found", util.SnapshotIDToHexString(id)), http.StatusNotFound)
+			return
+		}
+		snapshots = append(snapshots, resp.Snapshot)
+	}
+
+	resp := snapshot.Diff(snapshots[0], snapshots[1])
+
+	if r.Header.Get(api.HeaderAccept) == api.HeaderAcceptProtobuf {
+		b, err := proto.Marshal(resp)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set(api.HeaderContentType, api.HeaderAcceptProtobuf)
+		_, _ = w.Write(b)
+		return
+	}
+
+	// emit defaults so zero values, like the index of the top frame, are not left out
+	marshaller := &jsonpb.Marshaler{EmitDefaults: true}
+	w.Header().Set(api.HeaderContentType, api.HeaderAcceptJSON)
+	err = marshaller.Marshal(w, resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
 func (q *Querier) SearchHandler(w http.ResponseWriter, r *http.Request) {
 	isSearchBlock := api.IsSearchBlock(r)
 
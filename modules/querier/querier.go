@@ -592,7 +592,7 @@ func (q *Querier) postProcessIngesterSearchResults(req *deeppb.SearchRequest, rr
 	sort.Slice(response.Snapshots, func(i, j int) bool {
 		return response.Snapshots[i].StartTimeUnixNano > response.Snapshots[j].StartTimeUnixNano
 	})
-	if req.Limit != 0 && int(req.Limit) < len(response.Snapshots) {
+	if req.Limit != 0 && int(req.Limit) < len(response.Snapshots) && !deepql.CollectsAllSnapshots(req.Query) {
 		response.Snapshots = response.Snapshots[:req.Limit]
 	}
 
@@ -26,6 +26,8 @@ import (
 type Config struct {
 	LoadTracepoint RouteConfig           `yaml:"load_tracepoint"`
 	Worker         worker.TPWorkerConfig `yaml:"worker"`
+	// ActorHeaders are the request headers checked (in order) for the user making a change, this is recorded in the audit log
+	ActorHeaders []string `yaml:"actor_headers"`
 }
 
 type RouteConfig struct {
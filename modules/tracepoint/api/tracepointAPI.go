@@ -19,6 +19,7 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -34,6 +35,7 @@ import (
 	"github.com/grafana/dskit/services"
 	"github.com/intergral/deep/modules/frontend/v1/frontendv1pb"
 	"github.com/intergral/deep/modules/tracepoint/client"
+	"github.com/intergral/deep/modules/tracepoint/validation"
 	"github.com/intergral/deep/pkg/api"
 	"github.com/intergral/deep/pkg/deeppb"
 	cp "github.com/intergral/deep/pkg/deeppb/common/v1"
@@ -42,6 +44,8 @@ import (
 	"github.com/intergral/deep/pkg/worker"
 	"github.com/opentracing/opentracing-go"
 	httpgrpc_server "github.com/weaveworks/common/httpgrpc/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type TracepointAPI struct {
@@ -125,9 +129,51 @@ func (ta *TracepointAPI) LoadTracepointHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	writeResponse(w, r, span, listResponse(tracepoints))
+}
+
+// listResponse adds the install status of the tracepoints to the poll response, the fields of the poll response are
+// kept so existing clients can still read the list
+func listResponse(tracepoints *deeppb.LoadTracepointResponse) *deeppb.ListTracepointsResponse {
+	response := tracepoints.GetResponse()
+	return &deeppb.ListTracepointsResponse{
+		TsNanos:      response.GetTsNanos(),
+		CurrentHash:  response.GetCurrentHash(),
+		Response:     response.GetResponse(),
+		ResponseType: response.GetResponseType(),
+		Status:       tracepoints.GetStatus(),
+	}
+}
+
+func (ta *TracepointAPI) DeleteTracepointHandler(w http.ResponseWriter, r *http.Request) {
+	// as with LoadTracepointHandler we cannot split the methods in the server handler
+	// so if we are a PUT then pass to UpdateTracepointHandler
+	if strings.ToLower(r.Method) == "put" {
+		ta.UpdateTracepointHandler(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithDeadline(r.Context(), time.Now().Add(ta.cfg.LoadTracepoint.Timeout))
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "TracepointAPI.DeleteTracepoint")
+	defer span.Finish()
+
+	req, err := ta.parseDeleteRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tracepoints, err := ta.client.DeleteTracepoint(ctx, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
 	if r.Header.Get(api.HeaderAccept) == api.HeaderAcceptProtobuf {
 		span.SetTag("contentType", api.HeaderAcceptProtobuf)
-		b, err := proto.Marshal(tracepoints.Response)
+		b, err := proto.Marshal(tracepoints)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -143,7 +189,7 @@ func (ta *TracepointAPI) LoadTracepointHandler(w http.ResponseWriter, r *http.Re
 
 	span.SetTag("contentType", api.HeaderAcceptJSON)
 	marshaller := &jsonpb.Marshaler{}
-	err = marshaller.Marshal(w, tracepoints.Response)
+	err = marshaller.Marshal(w, tracepoints)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -151,20 +197,24 @@ func (ta *TracepointAPI) LoadTracepointHandler(w http.ResponseWriter, r *http.Re
 	w.Header().Set(api.HeaderContentType, api.HeaderAcceptJSON)
 }
 
-func (ta *TracepointAPI) DeleteTracepointHandler(w http.ResponseWriter, r *http.Request) {
+func (ta *TracepointAPI) CreateTracepointHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithDeadline(r.Context(), time.Now().Add(ta.cfg.LoadTracepoint.Timeout))
 	defer cancel()
 
-	span, ctx := opentracing.StartSpanFromContext(ctx, "TracepointAPI.DeleteTracepoint")
+	span, ctx := opentracing.StartSpanFromContext(ctx, "TracepointAPI.CreateTracepoint")
 	defer span.Finish()
 
-	req, err := ta.parseDeleteRequest(r)
+	req, err := ta.parseCreateRequest(r)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	tracepoints, err := ta.client.DeleteTracepoint(ctx, req)
+	tracepoints, err := ta.client.CreateTracepoint(ctx, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
 
 	if r.Header.Get(api.HeaderAccept) == api.HeaderAcceptProtobuf {
 		span.SetTag("contentType", api.HeaderAcceptProtobuf)
@@ -192,24 +242,28 @@ func (ta *TracepointAPI) DeleteTracepointHandler(w http.ResponseWriter, r *http.
 	w.Header().Set(api.HeaderContentType, api.HeaderAcceptJSON)
 }
 
-func (ta *TracepointAPI) CreateTracepointHandler(w http.ResponseWriter, r *http.Request) {
+func (ta *TracepointAPI) UpdateTracepointHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithDeadline(r.Context(), time.Now().Add(ta.cfg.LoadTracepoint.Timeout))
 	defer cancel()
 
-	span, ctx := opentracing.StartSpanFromContext(ctx, "TracepointAPI.CreateTracepoint")
+	span, ctx := opentracing.StartSpanFromContext(ctx, "TracepointAPI.UpdateTracepoint")
 	defer span.Finish()
 
-	req, err := ta.parseCreateRequest(r)
+	req, err := ta.parseUpdateRequest(r)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	tracepoints, err := ta.client.CreateTracepoint(ctx, req)
+	tracepoint, err := ta.client.UpdateTracepoint(ctx, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
 
 	if r.Header.Get(api.HeaderAccept) == api.HeaderAcceptProtobuf {
 		span.SetTag("contentType", api.HeaderAcceptProtobuf)
-		b, err := proto.Marshal(tracepoints)
+		b, err := proto.Marshal(tracepoint)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -225,7 +279,7 @@ func (ta *TracepointAPI) CreateTracepointHandler(w http.ResponseWriter, r *http.
 
 	span.SetTag("contentType", api.HeaderAcceptJSON)
 	marshaller := &jsonpb.Marshaler{}
-	err = marshaller.Marshal(w, tracepoints)
+	err = marshaller.Marshal(w, tracepoint)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -233,6 +287,205 @@ func (ta *TracepointAPI) CreateTracepointHandler(w http.ResponseWriter, r *http.
 	w.Header().Set(api.HeaderContentType, api.HeaderAcceptJSON)
 }
 
+func (ta *TracepointAPI) TracepointHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithDeadline(r.Context(), time.Now().Add(ta.cfg.LoadTracepoint.Timeout))
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "TracepointAPI.TracepointHistory")
+	defer span.Finish()
+
+	req, err := ta.parseHistoryRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	history, err := ta.client.TracepointHistory(ctx, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if r.Header.Get(api.HeaderAccept) == api.HeaderAcceptProtobuf {
+		span.SetTag("contentType", api.HeaderAcceptProtobuf)
+		b, err := proto.Marshal(history)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set(api.HeaderContentType, api.HeaderAcceptProtobuf)
+		_, err = w.Write(b)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		return
+	}
+
+	span.SetTag("contentType", api.HeaderAcceptJSON)
+	marshaller := &jsonpb.Marshaler{}
+	err = marshaller.Marshal(w, history)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(api.HeaderContentType, api.HeaderAcceptJSON)
+}
+
+// BulkTracepointsHandler will create (POST) or delete by labels (DELETE) a group of tracepoints
+func (ta *TracepointAPI) BulkTracepointsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithDeadline(r.Context(), time.Now().Add(ta.cfg.LoadTracepoint.Timeout))
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "TracepointAPI.BulkTracepoints")
+	defer span.Finish()
+
+	// trying to split POST and DELETE in the server handler with .Methods() doesn't work
+	var response proto.Message
+	switch r.Method {
+	case http.MethodPost:
+		req, err := ta.parseBulkCreateRequest(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		response, err = ta.client.BulkCreateTracepoints(ctx, req)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+	case http.MethodDelete:
+		labels, err := parseLabels(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		response, err = ta.client.BulkDeleteTracepoints(ctx, &deeppb.BulkDeleteTracepointsRequest{Labels: labels, Actor: ta.actor(r)})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+	default:
+		http.Error(w, fmt.Sprintf("method %s not supported", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	writeResponse(w, r, span, response)
+}
+
+// BulkTracepointsStateHandler will enable or disable all the tracepoints that match the labels
+func (ta *TracepointAPI) BulkTracepointsStateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithDeadline(r.Context(), time.Now().Add(ta.cfg.LoadTracepoint.Timeout))
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "TracepointAPI.BulkTracepointsState")
+	defer span.Finish()
+
+	req, err := ta.parsePausedRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := ta.client.SetTracepointsPaused(ctx, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeResponse(w, r, span, response)
+}
+
+// TracepointStateHandler will enable or disable a single tracepoint, without removing it
+func (ta *TracepointAPI) TracepointStateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithDeadline(r.Context(), time.Now().Add(ta.cfg.LoadTracepoint.Timeout))
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "TracepointAPI.TracepointState")
+	defer span.Finish()
+
+	req, err := ta.parseTracepointPausedRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := ta.client.SetTracepointPaused(ctx, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeResponse(w, r, span, response)
+}
+
+// writeResponse will write the message as protobuf if requested, otherwise as json
+func writeResponse(w http.ResponseWriter, r *http.Request, span opentracing.Span, msg proto.Message) {
+	if r.Header.Get(api.HeaderAccept) == api.HeaderAcceptProtobuf {
+		span.SetTag("contentType", api.HeaderAcceptProtobuf)
+		b, err := proto.Marshal(msg)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set(api.HeaderContentType, api.HeaderAcceptProtobuf)
+		_, err = w.Write(b)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		return
+	}
+
+	span.SetTag("contentType", api.HeaderAcceptJSON)
+	w.Header().Set(api.HeaderContentType, api.HeaderAcceptJSON)
+	marshaller := &jsonpb.Marshaler{}
+	err := marshaller.Marshal(w, msg)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// validationResponse is returned when the tracepoint service rejects an invalid tracepoint
+type validationResponse struct {
+	Message string                  `json:"message"`
+	Errors  []validation.FieldError `json:"errors"`
+}
+
+// writeError writes the error from the tracepoint service, invalid tracepoints are written as json with the invalid
+// fields so the caller can show them next to the fields
+func writeError(w http.ResponseWriter, err error) {
+	fieldErrors, ok := validation.FromError(err)
+	if !ok {
+		http.Error(w, err.Error(), httpStatusForError(err))
+		return
+	}
+
+	w.Header().Set(api.HeaderContentType, api.HeaderAcceptJSON)
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(validationResponse{
+		Message: status.Convert(err).Message(),
+		Errors:  fieldErrors,
+	})
+}
+
+// httpStatusForError converts the grpc status from the tracepoint service to the http status we should return
+func httpStatusForError(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.Aborted, codes.AlreadyExists:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 func (ta *TracepointAPI) parseLoadRequest(r *http.Request) (*deeppb.LoadTracepointRequest, error) {
 	query := r.URL.Query()
 	ts := uint64(time.Now().UnixNano())
@@ -256,13 +509,14 @@ func (ta *TracepointAPI) parseLoadRequest(r *http.Request) (*deeppb.LoadTracepoi
 		}
 	}
 
+	// the api should show paused tracepoints, only agents should not receive them
 	return &deeppb.LoadTracepointRequest{Request: &pb.PollRequest{
 		TsNanos:     ts,
 		CurrentHash: ch,
 		Resource: &rp.Resource{
 			Attributes: atts,
 		},
-	}}, nil
+	}, IncludePaused: true, IncludeStatus: true}, nil
 }
 
 func (ta *TracepointAPI) parseCreateRequest(r *http.Request) (*deeppb.CreateTracepointRequest, error) {
@@ -277,6 +531,38 @@ func (ta *TracepointAPI) parseCreateRequest(r *http.Request) (*deeppb.CreateTrac
 	}
 
 	bodyTp.Tracepoint.ID = uuid.New().String()
+	bodyTp.Actor = ta.actor(r)
+
+	return &bodyTp, nil
+}
+
+func (ta *TracepointAPI) parseUpdateRequest(r *http.Request) (*deeppb.UpdateTracepointRequest, error) {
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(r.Body)
+
+	vars := mux.Vars(r)
+	tpID, ok := vars[api.URLParamTracepointID]
+	if !ok {
+		return nil, fmt.Errorf("please provide a tracepoint ID")
+	}
+
+	var bodyTp deeppb.UpdateTracepointRequest
+	err := jsonpb.Unmarshal(r.Body, &bodyTp)
+	if err != nil {
+		return nil, err
+	}
+
+	if bodyTp.Tracepoint == nil {
+		return nil, fmt.Errorf("please provide a tracepoint")
+	}
+
+	// the ID is taken from the path, so we can never change the ID of a tracepoint
+	if bodyTp.Tracepoint.ID != "" && bodyTp.Tracepoint.ID != tpID {
+		return nil, fmt.Errorf("tracepoint ID %s does not match path %s", bodyTp.Tracepoint.ID, tpID)
+	}
+	bodyTp.Tracepoint.ID = tpID
+	bodyTp.Actor = ta.actor(r)
 
 	return &bodyTp, nil
 }
@@ -288,7 +574,108 @@ func (ta *TracepointAPI) parseDeleteRequest(r *http.Request) (*deeppb.DeleteTrac
 		return nil, fmt.Errorf("please provide a tracepoint ID")
 	}
 
-	return &deeppb.DeleteTracepointRequest{TracepointID: tpID}, nil
+	return &deeppb.DeleteTracepointRequest{TracepointID: tpID, Actor: ta.actor(r)}, nil
+}
+
+func (ta *TracepointAPI) parseHistoryRequest(r *http.Request) (*deeppb.TracepointHistoryRequest, error) {
+	vars := mux.Vars(r)
+	tpID, ok := vars[api.URLParamTracepointID]
+	if !ok {
+		return nil, fmt.Errorf("please provide a tracepoint ID")
+	}
+
+	return &deeppb.TracepointHistoryRequest{TracepointID: tpID}, nil
+}
+
+func (ta *TracepointAPI) parseBulkCreateRequest(r *http.Request) (*deeppb.BulkCreateTracepointsRequest, error) {
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(r.Body)
+
+	var body deeppb.BulkCreateTracepointsRequest
+	err := jsonpb.Unmarshal(r.Body, &body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(body.Tracepoints) == 0 {
+		return nil, fmt.Errorf("please provide at least one tracepoint")
+	}
+
+	for i, create := range body.Tracepoints {
+		if create.Tracepoint == nil {
+			return nil, fmt.Errorf("please provide a tracepoint at index %d", i)
+		}
+		create.Tracepoint.ID = uuid.New().String()
+	}
+	body.Actor = ta.actor(r)
+
+	return &body, nil
+}
+
+func (ta *TracepointAPI) parsePausedRequest(r *http.Request) (*deeppb.SetTracepointsPausedRequest, error) {
+	paused, err := parsePaused(r)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := parseLabels(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deeppb.SetTracepointsPausedRequest{Labels: labels, Paused: paused, Actor: ta.actor(r)}, nil
+}
+
+func (ta *TracepointAPI) parseTracepointPausedRequest(r *http.Request) (*deeppb.SetTracepointPausedRequest, error) {
+	vars := mux.Vars(r)
+	tpID, ok := vars[api.URLParamTracepointID]
+	if !ok {
+		return nil, fmt.Errorf("please provide a tracepoint ID")
+	}
+
+	paused, err := parsePaused(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deeppb.SetTracepointPausedRequest{TracepointID: tpID, Paused: paused, Actor: ta.actor(r)}, nil
+}
+
+// parsePaused converts the state in the path to the paused state, disabled tracepoints are paused
+func parsePaused(r *http.Request) (bool, error) {
+	state := mux.Vars(r)[api.URLParamTracepointState]
+	switch state {
+	case "enable":
+		return false, nil
+	case "disable":
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown tracepoint state %q, must be one of enable or disable", state)
+	}
+}
+
+// parseLabels reads the label selector from the query params, every param is treated as a label
+func parseLabels(r *http.Request) (map[string]string, error) {
+	labels := map[string]string{}
+	for key, val := range r.URL.Query() {
+		labels[key] = val[0]
+	}
+	if len(labels) == 0 {
+		return nil, fmt.Errorf("please provide at least one label")
+	}
+
+	return labels, nil
+}
+
+// actor returns the user making the request, from the first configured header that is set
+func (ta *TracepointAPI) actor(r *http.Request) string {
+	for _, header := range ta.cfg.ActorHeaders {
+		if actor := r.Header.Get(header); actor != "" {
+			return actor
+		}
+	}
+	return ""
 }
 
 func (ta *TracepointAPI) CreateAndRegisterWorker(handler http.Handler) error {
@@ -36,8 +36,10 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/weaveworks/common/middleware"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 type TPClient struct {
@@ -133,22 +135,69 @@ func (ts *TPClient) CreateTracepoint(ctx context.Context, req *deeppb.CreateTrac
 	tokenFor := TokenFor(tenantID)
 
 	get, err := ts.ring.Get(tokenFor, ring.Read, nil, nil, nil)
-	_, err = get.Do(ctx, 0, func(funCtx context.Context, desc *ring.InstanceDesc) (interface{}, error) {
-		// todo error handling
-		client, _ := ts.pool.GetClientFor(desc.Addr)
+	if err != nil {
+		return nil, err
+	}
 
-		grpClient := client.(*tpClient)
-		tracepoints, err := grpClient.CreateTracepoint(funCtx, req)
+	doResults, err := get.Do(ctx, 0, func(funCtx context.Context, desc *ring.InstanceDesc) (interface{}, error) {
+		client, err := ts.pool.GetClientFor(desc.Addr)
 		if err != nil {
-			print(err)
+			return nil, err
 		}
 
-		return tracepoints, nil
+		return client.(*tpClient).CreateTracepoint(funCtx, req)
 	})
+	if err != nil {
+		// we return the error as is, so the status code (e.g. invalid targeting) can be used by the caller
+		return nil, err
+	}
+
+	for _, result := range doResults {
+		response := result.(*deeppb.CreateTracepointResponse)
+		if response != nil {
+			return response, nil
+		}
+	}
 
 	return &deeppb.CreateTracepointResponse{}, nil
 }
 
+func (ts *TPClient) UpdateTracepoint(ctx context.Context, req *deeppb.UpdateTracepointRequest) (*deeppb.UpdateTracepointResponse, error) {
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting tenant id in Tracepoint.UpdateTracepoint")
+	}
+
+	tokenFor := TokenFor(tenantID)
+
+	get, err := ts.ring.Get(tokenFor, ring.Read, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	doResults, err := get.Do(ctx, 0, func(funCtx context.Context, desc *ring.InstanceDesc) (interface{}, error) {
+		client, err := ts.pool.GetClientFor(desc.Addr)
+		if err != nil {
+			return nil, err
+		}
+
+		return client.(*tpClient).UpdateTracepoint(funCtx, req)
+	})
+	if err != nil {
+		// we return the error as is, so the status code (e.g. version conflict) can be used by the caller
+		return nil, err
+	}
+
+	for _, result := range doResults {
+		response := result.(*deeppb.UpdateTracepointResponse)
+		if response != nil {
+			return response, nil
+		}
+	}
+
+	return nil, errors.New("no response from tracepoint service")
+}
+
 func (ts *TPClient) DeleteTracepoint(ctx context.Context, req *deeppb.DeleteTracepointRequest) (*deeppb.DeleteTracepointResponse, error) {
 	tenantID, err := util.ExtractTenantID(ctx)
 	if err != nil {
@@ -158,20 +207,367 @@ func (ts *TPClient) DeleteTracepoint(ctx context.Context, req *deeppb.DeleteTrac
 	tokenFor := TokenFor(tenantID)
 
 	get, err := ts.ring.Get(tokenFor, ring.Read, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	doResults, err := get.Do(ctx, 0, func(funCtx context.Context, desc *ring.InstanceDesc) (interface{}, error) {
+		client, err := ts.pool.GetClientFor(desc.Addr)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := client.(*tpClient).DeleteTracepoint(funCtx, req)
+		if status.Code(err) == codes.NotFound {
+			// another replica may have deleted the tracepoint first, so this is only an error if no replica had it
+			return nil, nil
+		}
+		return response, err
+	})
+	if err != nil {
+		// we return the error as is, so the status code can be used by the caller
+		return nil, err
+	}
+
+	for _, result := range doResults {
+		response, _ := result.(*deeppb.DeleteTracepointResponse)
+		if response != nil {
+			return response, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.NotFound, "tracepoint %s not found", req.TracepointID)
+}
+
+func (ts *TPClient) RecordSnapshots(ctx context.Context, req *deeppb.RecordSnapshotsRequest) (*deeppb.RecordSnapshotsResponse, error) {
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting tenant id in Tracepoint.RecordSnapshots")
+	}
+
+	tokenFor := TokenFor(tenantID)
+
+	get, err := ts.ring.Get(tokenFor, ring.Write, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	_, err = get.Do(ctx, 0, func(funCtx context.Context, desc *ring.InstanceDesc) (interface{}, error) {
-		// todo error handling
-		client, _ := ts.pool.GetClientFor(desc.Addr)
+		client, err := ts.pool.GetClientFor(desc.Addr)
+		if err != nil {
+			return nil, err
+		}
 
-		grpClient := client.(*tpClient)
-		tracepoints, err := grpClient.DeleteTracepoint(funCtx, req)
+		return client.(*tpClient).RecordSnapshots(funCtx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &deeppb.RecordSnapshotsResponse{}, nil
+}
+
+func (ts *TPClient) RecordInstallStatus(ctx context.Context, req *deeppb.RecordInstallStatusRequest) (*deeppb.RecordInstallStatusResponse, error) {
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting tenant id in Tracepoint.RecordInstallStatus")
+	}
+
+	tokenFor := TokenFor(tenantID)
+
+	get, err := ts.ring.Get(tokenFor, ring.Write, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = get.Do(ctx, 0, func(funCtx context.Context, desc *ring.InstanceDesc) (interface{}, error) {
+		client, err := ts.pool.GetClientFor(desc.Addr)
 		if err != nil {
-			print(err)
+			return nil, err
 		}
 
-		return tracepoints, nil
+		return client.(*tpClient).RecordInstallStatus(funCtx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &deeppb.RecordInstallStatusResponse{}, nil
+}
+
+func (ts *TPClient) RecordAgents(ctx context.Context, req *deeppb.RecordAgentsRequest) (*deeppb.RecordAgentsResponse, error) {
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting tenant id in Tracepoint.RecordAgents")
+	}
+
+	tokenFor := TokenFor(tenantID)
+
+	get, err := ts.ring.Get(tokenFor, ring.Write, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = get.Do(ctx, 0, func(funCtx context.Context, desc *ring.InstanceDesc) (interface{}, error) {
+		client, err := ts.pool.GetClientFor(desc.Addr)
+		if err != nil {
+			return nil, err
+		}
+
+		return client.(*tpClient).RecordAgents(funCtx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &deeppb.RecordAgentsResponse{}, nil
+}
+
+func (ts *TPClient) LoadAgents(ctx context.Context, req *deeppb.LoadAgentsRequest) (*deeppb.LoadAgentsResponse, error) {
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting tenant id in Tracepoint.LoadAgents")
+	}
+
+	tokenFor := TokenFor(tenantID)
+
+	get, err := ts.ring.Get(tokenFor, ring.Read, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	doResults, err := get.Do(ctx, 0, func(funCtx context.Context, desc *ring.InstanceDesc) (interface{}, error) {
+		client, err := ts.pool.GetClientFor(desc.Addr)
+		if err != nil {
+			return nil, err
+		}
+
+		return client.(*tpClient).LoadAgents(funCtx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range doResults {
+		response := result.(*deeppb.LoadAgentsResponse)
+		if response != nil {
+			return response, nil
+		}
+	}
+
+	return nil, errors.New("no response from tracepoint service")
+}
+
+func (ts *TPClient) TracepointHistory(ctx context.Context, req *deeppb.TracepointHistoryRequest) (*deeppb.TracepointHistoryResponse, error) {
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting tenant id in Tracepoint.TracepointHistory")
+	}
+
+	tokenFor := TokenFor(tenantID)
+
+	get, err := ts.ring.Get(tokenFor, ring.Read, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	doResults, err := get.Do(ctx, 0, func(funCtx context.Context, desc *ring.InstanceDesc) (interface{}, error) {
+		client, err := ts.pool.GetClientFor(desc.Addr)
+		if err != nil {
+			return nil, err
+		}
+
+		return client.(*tpClient).TracepointHistory(funCtx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range doResults {
+		response := result.(*deeppb.TracepointHistoryResponse)
+		if response != nil {
+			return response, nil
+		}
+	}
+
+	return nil, errors.New("no response from tracepoint service")
+}
+
+func (ts *TPClient) BulkCreateTracepoints(ctx context.Context, req *deeppb.BulkCreateTracepointsRequest) (*deeppb.BulkCreateTracepointsResponse, error) {
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting tenant id in Tracepoint.BulkCreateTracepoints")
+	}
+
+	tokenFor := TokenFor(tenantID)
+
+	get, err := ts.ring.Get(tokenFor, ring.Read, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	doResults, err := get.Do(ctx, 0, func(funCtx context.Context, desc *ring.InstanceDesc) (interface{}, error) {
+		client, err := ts.pool.GetClientFor(desc.Addr)
+		if err != nil {
+			return nil, err
+		}
+
+		return client.(*tpClient).BulkCreateTracepoints(funCtx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range doResults {
+		response := result.(*deeppb.BulkCreateTracepointsResponse)
+		if response != nil {
+			return response, nil
+		}
+	}
+
+	return nil, errors.New("no response from tracepoint service")
+}
+
+func (ts *TPClient) BulkDeleteTracepoints(ctx context.Context, req *deeppb.BulkDeleteTracepointsRequest) (*deeppb.BulkDeleteTracepointsResponse, error) {
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting tenant id in Tracepoint.BulkDeleteTracepoints")
+	}
+
+	tokenFor := TokenFor(tenantID)
+
+	get, err := ts.ring.Get(tokenFor, ring.Read, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	doResults, err := get.Do(ctx, 0, func(funCtx context.Context, desc *ring.InstanceDesc) (interface{}, error) {
+		client, err := ts.pool.GetClientFor(desc.Addr)
+		if err != nil {
+			return nil, err
+		}
+
+		return client.(*tpClient).BulkDeleteTracepoints(funCtx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range doResults {
+		response := result.(*deeppb.BulkDeleteTracepointsResponse)
+		if response != nil {
+			return response, nil
+		}
+	}
+
+	return nil, errors.New("no response from tracepoint service")
+}
+
+func (ts *TPClient) SetTracepointsPaused(ctx context.Context, req *deeppb.SetTracepointsPausedRequest) (*deeppb.SetTracepointsPausedResponse, error) {
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting tenant id in Tracepoint.SetTracepointsPaused")
+	}
+
+	tokenFor := TokenFor(tenantID)
+
+	get, err := ts.ring.Get(tokenFor, ring.Read, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	doResults, err := get.Do(ctx, 0, func(funCtx context.Context, desc *ring.InstanceDesc) (interface{}, error) {
+		client, err := ts.pool.GetClientFor(desc.Addr)
+		if err != nil {
+			return nil, err
+		}
+
+		return client.(*tpClient).SetTracepointsPaused(funCtx, req)
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range doResults {
+		response := result.(*deeppb.SetTracepointsPausedResponse)
+		if response != nil {
+			return response, nil
+		}
+	}
 
-	return &deeppb.DeleteTracepointResponse{}, nil
+	return nil, errors.New("no response from tracepoint service")
+}
+
+func (ts *TPClient) SetTracepointPaused(ctx context.Context, req *deeppb.SetTracepointPausedRequest) (*deeppb.SetTracepointPausedResponse, error) {
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting tenant id in Tracepoint.SetTracepointPaused")
+	}
+
+	tokenFor := TokenFor(tenantID)
+
+	get, err := ts.ring.Get(tokenFor, ring.Read, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	doResults, err := get.Do(ctx, 0, func(funCtx context.Context, desc *ring.InstanceDesc) (interface{}, error) {
+		client, err := ts.pool.GetClientFor(desc.Addr)
+		if err != nil {
+			return nil, err
+		}
+
+		return client.(*tpClient).SetTracepointPaused(funCtx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range doResults {
+		response := result.(*deeppb.SetTracepointPausedResponse)
+		if response != nil {
+			return response, nil
+		}
+	}
+
+	return nil, errors.New("no response from tracepoint service")
+}
+
+// WatchTracepoints will open a stream that receives a response each time the tracepoints for the tenant change.
+// All tracepoint instances for the tenant receive every change, so we only need to watch one of them.
+func (ts *TPClient) WatchTracepoints(ctx context.Context, req *deeppb.WatchTracepointsRequest) (deeppb.TracepointConfigService_WatchTracepointsClient, error) {
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting tenant id in Tracepoint.WatchTracepoints")
+	}
+
+	tokenFor := TokenFor(tenantID)
+
+	get, err := ts.ring.Get(tokenFor, ring.Read, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for _, instance := range get.Instances {
+		client, err := ts.pool.GetClientFor(instance.Addr)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		stream, err := client.(*tpClient).WatchTracepoints(ctx, req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return stream, nil
+	}
+
+	if lastErr == nil {
+		lastErr = errors.New("no tracepoint instances available")
+	}
+	return nil, lastErr
 }
 
 func (ts *TPClient) LoadTracepoints(ctx context.Context, req *deeppb.LoadTracepointRequest) (*deeppb.LoadTracepointResponse, error) {
@@ -45,6 +45,12 @@ type Config struct {
 	MaxBlockBytes        uint64        `yaml:"max_block_bytes"`
 	CompleteBlockTimeout time.Duration `yaml:"complete_block_timeout"`
 	OverrideRingKey      string        `yaml:"override_ring_key"`
+	RetireInterval       time.Duration `yaml:"retire_interval"`
+	ReloadInterval       time.Duration `yaml:"reload_interval"`
+	// InstallStatusRetention is how long the install status reported by an agent is kept if it is not reported again
+	InstallStatusRetention time.Duration `yaml:"install_status_retention"`
+	// AgentRetention is how long an agent reported by the distributors is kept if it is not reported again
+	AgentRetention time.Duration `yaml:"agent_retention"`
 
 	Client client.Config `yaml:"client"`
 
@@ -62,6 +68,10 @@ func (cfg *Config) RegisterFlagsAndApplyDefaults(prefix string, f *flag.FlagSet)
 	f.DurationVar(&cfg.MaxBlockDuration, prefix+".max-block-duration", 30*time.Minute, "Maximum duration which the head block can be appended to before cutting it.")
 	f.Uint64Var(&cfg.MaxBlockBytes, prefix+".max-block-bytes", 500*1024*1024, "Maximum size of the head block before cutting it.")
 	f.DurationVar(&cfg.CompleteBlockTimeout, prefix+".complete-block-timeout", 3*deepdb.DefaultBlocklistPoll, "Duration to keep blocks in the ingester after they have been flushed.")
+	f.DurationVar(&cfg.RetireInterval, prefix+".retire-interval", 30*time.Second, "How often to check for tracepoints that have expired or received their max snapshots.")
+	f.DurationVar(&cfg.ReloadInterval, prefix+".reload-interval", 5*time.Second, "How often to check storage for tracepoints changed by other replicas.")
+	f.DurationVar(&cfg.InstallStatusRetention, prefix+".install-status-retention", 24*time.Hour, "How long to keep the install status reported by an agent that has not reported it again.")
+	f.DurationVar(&cfg.AgentRetention, prefix+".agent-retention", 15*time.Minute, "How long to keep an agent reported by the distributors that has not been reported again.")
 
 	hostname, err := os.Hostname()
 	if err != nil {
@@ -76,6 +86,7 @@ func (cfg *Config) RegisterFlagsAndApplyDefaults(prefix string, f *flag.FlagSet)
 	cfg.Client.GRPCClientConfig.GRPCCompression = "snappy"
 
 	cfg.API.LoadTracepoint.Timeout = 1 * time.Minute
+	cfg.API.ActorHeaders = []string{"X-Deep-User", "X-Grafana-User", "X-Forwarded-User"}
 	cfg.API.Worker = worker.TPWorkerConfig{
 		Config: pkg_worker.Config{
 			MatchMaxConcurrency:   true,
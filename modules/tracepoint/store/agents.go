@@ -0,0 +1,69 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package store
+
+import (
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/intergral/deep/pkg/deeppb"
+)
+
+// agents are the agents reported by the distributors by agent ID. An agent can be reported by more than one
+// distributor, e.g. after it reconnects to another distributor, so the latest report of each agent is kept. This is
+// only kept in memory, so it is lost when the tracepoint service restarts.
+type agents map[string]*deeppb.AgentInfo
+
+// record keeps each agent, unless we already have a later report of the agent
+func (a agents) record(reported []*deeppb.AgentInfo) {
+	for _, agent := range reported {
+		existing, ok := a[agent.ID]
+		if ok && existing.LastSeenNanos > agent.LastSeenNanos {
+			continue
+		}
+
+		agent = proto.Clone(agent).(*deeppb.AgentInfo)
+		if ok && existing.FirstSeenNanos < agent.FirstSeenNanos {
+			agent.FirstSeenNanos = existing.FirstSeenNanos
+		}
+		a[agent.ID] = agent
+	}
+}
+
+// list returns the agents ordered by ID
+func (a agents) list() []*deeppb.AgentInfo {
+	list := make([]*deeppb.AgentInfo, 0, len(a))
+	for _, agent := range a {
+		list = append(list, agent)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	return list
+}
+
+// prune removes the agents that have not been seen since the given time
+func (a agents) prune(before time.Time) {
+	beforeNanos := uint64(before.UnixNano())
+	for id, agent := range a {
+		if agent.LastSeenNanos < beforeNanos {
+			delete(a, id)
+		}
+	}
+}
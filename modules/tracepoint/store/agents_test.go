@@ -0,0 +1,49 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/intergral/deep/pkg/deeppb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAgentsKeepLatestReport(t *testing.T) {
+	now := uint64(time.Unix(1000, 0).UnixNano())
+	reported := agents{}
+
+	reported.record([]*deeppb.AgentInfo{
+		{ID: "pod-2", FirstSeenNanos: now, LastSeenNanos: now},
+		{ID: "pod-1", FirstSeenNanos: now, LastSeenNanos: now, AppliedHash: "old"},
+	})
+	// the agent has moved to another distributor, which has only just seen it
+	reported.record([]*deeppb.AgentInfo{{ID: "pod-1", FirstSeenNanos: now + 10, LastSeenNanos: now + 10, AppliedHash: "new"}})
+	// an older report from the previous distributor is ignored
+	reported.record([]*deeppb.AgentInfo{{ID: "pod-1", FirstSeenNanos: now, LastSeenNanos: now + 5, AppliedHash: "old"}})
+
+	list := reported.list()
+	assert.Len(t, list, 2)
+	assert.Equal(t, &deeppb.AgentInfo{ID: "pod-1", FirstSeenNanos: now, LastSeenNanos: now + 10, AppliedHash: "new"}, list[0])
+	assert.Equal(t, "pod-2", list[1].ID)
+
+	reported.prune(time.Unix(0, int64(now+5)))
+	assert.Len(t, reported.list(), 1)
+	assert.Equal(t, "pod-1", reported.list()[0].ID)
+}
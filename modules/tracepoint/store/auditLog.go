@@ -0,0 +1,143 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package store
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/intergral/deep/pkg/deepdb"
+	"github.com/intergral/deep/pkg/deepdb/backend"
+	"github.com/intergral/deep/pkg/deeppb"
+	deepIO "github.com/intergral/deep/pkg/io"
+)
+
+// maxAuditEvents is the number of events kept for each tenant, the oldest events are removed first. This bounds the
+// size of the log when the retention is 0.
+const maxAuditEvents = 10_000
+
+// AuditLog is an append only record of the changes made to the tracepoints of each tenant.
+// The log for a tenant is stored as a single object next to the tracepoint block. The log is read from storage before
+// each change, so events appended by other replicas are kept.
+type AuditLog struct {
+	reader deepdb.TracepointReader
+	writer deepdb.TracepointWriter
+
+	mu sync.Mutex
+}
+
+// NewAuditLog will create a new audit log that is persisted using the given reader and writer
+func NewAuditLog(reader deepdb.TracepointReader, writer deepdb.TracepointWriter) *AuditLog {
+	return &AuditLog{
+		reader: reader,
+		writer: writer,
+	}
+}
+
+// Record will append the event to the audit log for the tenant, and write the log to storage.
+// Any events older than retention are removed, a retention of 0 will keep up to maxAuditEvents events.
+func (a *AuditLog) Record(ctx context.Context, tenantID string, event *deeppb.TracepointAuditEvent, retention time.Duration) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	log, err := a.load(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	events := append(prune(log.Events, time.Unix(0, int64(event.TimestampNanos)), retention), event)
+	updated := &deeppb.TracepointAuditLog{Events: events}
+
+	data, err := proto.Marshal(updated)
+	if err != nil {
+		return err
+	}
+
+	return a.writer.WriteTracepointAudit(ctx, tenantID, bytes.NewReader(data), int64(len(data)))
+}
+
+// History returns the events for the given tracepoint in the order they happened
+func (a *AuditLog) History(ctx context.Context, tenantID string, tpID string, now time.Time, retention time.Duration) ([]*deeppb.TracepointAuditEvent, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	log, err := a.load(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*deeppb.TracepointAuditEvent
+	for _, event := range prune(log.Events, now, retention) {
+		if event.TracepointID == tpID {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// load will read the audit log for the tenant from storage, the log is not cached as other replicas can append to it
+func (a *AuditLog) load(ctx context.Context, tenantID string) (*deeppb.TracepointAuditLog, error) {
+	log := &deeppb.TracepointAuditLog{}
+	reader, size, err := a.reader.ReadTracepointAudit(ctx, tenantID)
+	if err != nil {
+		if err != backend.ErrDoesNotExist {
+			return nil, err
+		}
+		return log, nil
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	data, err := deepIO.ReadAllWithEstimate(reader, size)
+	if err != nil {
+		return nil, err
+	}
+
+	err = proto.Unmarshal(data, log)
+	if err != nil {
+		return nil, err
+	}
+
+	return log, nil
+}
+
+// prune returns the events that are within the retention period, limited to the newest maxAuditEvents events. The
+// given events are not modified.
+func prune(events []*deeppb.TracepointAuditEvent, now time.Time, retention time.Duration) []*deeppb.TracepointAuditEvent {
+	// keep room for the event being appended
+	if len(events) >= maxAuditEvents {
+		events = events[len(events)-maxAuditEvents+1:]
+	}
+
+	if retention <= 0 {
+		return events[:len(events):len(events)]
+	}
+
+	cutoff := uint64(now.Add(-retention).UnixNano())
+	// events are appended in order, so we only need to find the first event to keep
+	for i, event := range events {
+		if event.TimestampNanos >= cutoff {
+			return events[i:len(events):len(events)]
+		}
+	}
+	return nil
+}
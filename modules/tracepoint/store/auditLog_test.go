@@ -0,0 +1,147 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/intergral/deep/pkg/deepdb/backend"
+	"github.com/intergral/deep/pkg/deepdb/backend/local"
+	"github.com/intergral/deep/pkg/deeppb"
+	tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func createAuditLog(t *testing.T, tempDir string) *AuditLog {
+	r, w, _, err := local.New(&local.Config{
+		Path: tempDir,
+	})
+	assert.NoError(t, err)
+
+	return NewAuditLog(backend.NewReader(r), backend.NewWriter(w))
+}
+
+func auditEvent(tpID string, action deeppb.TracepointAuditEvent_ActionType, ts time.Time) *deeppb.TracepointAuditEvent {
+	event := &deeppb.TracepointAuditEvent{
+		TracepointID:   tpID,
+		Action:         action,
+		Actor:          "test-user",
+		TimestampNanos: uint64(ts.UnixNano()),
+	}
+	if action != deeppb.TracepointAuditEvent_CREATE {
+		event.Before = &tp.TracePointConfig{ID: tpID, Path: "before.py"}
+	}
+	if action != deeppb.TracepointAuditEvent_DELETE {
+		event.After = &tp.TracePointConfig{ID: tpID, Path: "after.py"}
+	}
+	return event
+}
+
+func TestAuditLogHistory(t *testing.T) {
+	tempDir := t.TempDir()
+	auditLog := createAuditLog(t, tempDir)
+	now := time.Now()
+
+	assert.NoError(t, auditLog.Record(context.Background(), "test-id", auditEvent("tp-1", deeppb.TracepointAuditEvent_CREATE, now), 0))
+	assert.NoError(t, auditLog.Record(context.Background(), "test-id", auditEvent("tp-2", deeppb.TracepointAuditEvent_CREATE, now), 0))
+	assert.NoError(t, auditLog.Record(context.Background(), "test-id", auditEvent("tp-1", deeppb.TracepointAuditEvent_UPDATE, now), 0))
+	assert.NoError(t, auditLog.Record(context.Background(), "test-id", auditEvent("tp-1", deeppb.TracepointAuditEvent_DELETE, now), 0))
+	assert.NoError(t, auditLog.Record(context.Background(), "other-id", auditEvent("tp-1", deeppb.TracepointAuditEvent_CREATE, now), 0))
+
+	history, err := auditLog.History(context.Background(), "test-id", "tp-1", now, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(history))
+	assert.Equal(t, deeppb.TracepointAuditEvent_CREATE, history[0].Action)
+	assert.Equal(t, deeppb.TracepointAuditEvent_UPDATE, history[1].Action)
+	assert.Equal(t, deeppb.TracepointAuditEvent_DELETE, history[2].Action)
+	assert.Nil(t, history[0].Before)
+	assert.Equal(t, "before.py", history[2].Before.Path)
+	assert.Nil(t, history[2].After)
+	assert.Equal(t, "test-user", history[2].Actor)
+
+	// a new audit log must read the events from storage
+	reloaded := createAuditLog(t, tempDir)
+	history, err = reloaded.History(context.Background(), "test-id", "tp-1", now, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(history))
+
+	history, err = reloaded.History(context.Background(), "other-id", "tp-1", now, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(history))
+
+	history, err = reloaded.History(context.Background(), "missing-id", "tp-1", now, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(history))
+}
+
+func TestAuditLogRetention(t *testing.T) {
+	tempDir := t.TempDir()
+	auditLog := createAuditLog(t, tempDir)
+	now := time.Now()
+
+	assert.NoError(t, auditLog.Record(context.Background(), "test-id", auditEvent("tp-1", deeppb.TracepointAuditEvent_CREATE, now.Add(-3*time.Hour)), 0))
+	assert.NoError(t, auditLog.Record(context.Background(), "test-id", auditEvent("tp-1", deeppb.TracepointAuditEvent_UPDATE, now.Add(-90*time.Minute)), 0))
+
+	// events outside the retention are not returned
+	history, err := auditLog.History(context.Background(), "test-id", "tp-1", now, 2*time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(history))
+	assert.Equal(t, deeppb.TracepointAuditEvent_UPDATE, history[0].Action)
+
+	// recording a new event removes the expired events from storage
+	assert.NoError(t, auditLog.Record(context.Background(), "test-id", auditEvent("tp-1", deeppb.TracepointAuditEvent_DELETE, now), 2*time.Hour))
+
+	reloaded := createAuditLog(t, tempDir)
+	history, err = reloaded.History(context.Background(), "test-id", "tp-1", now, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(history))
+	assert.Equal(t, deeppb.TracepointAuditEvent_UPDATE, history[0].Action)
+	assert.Equal(t, deeppb.TracepointAuditEvent_DELETE, history[1].Action)
+}
+
+func TestAuditLogKeepsEventsFromOtherReplicas(t *testing.T) {
+	tempDir := t.TempDir()
+	replicaA := createAuditLog(t, tempDir)
+	replicaB := createAuditLog(t, tempDir)
+	now := time.Now()
+	ctx := context.Background()
+
+	assert.NoError(t, replicaA.Record(ctx, "test-org", auditEvent("1", deeppb.TracepointAuditEvent_CREATE, now), 0))
+	// replica A has now read the log, the event from replica B must not be lost when A appends again
+	assert.NoError(t, replicaB.Record(ctx, "test-org", auditEvent("1", deeppb.TracepointAuditEvent_UPDATE, now.Add(time.Second)), 0))
+	assert.NoError(t, replicaA.Record(ctx, "test-org", auditEvent("1", deeppb.TracepointAuditEvent_DELETE, now.Add(2*time.Second)), 0))
+
+	events, err := replicaB.History(ctx, "test-org", "1", now, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(events))
+	assert.Equal(t, deeppb.TracepointAuditEvent_UPDATE, events[1].Action)
+}
+
+func TestAuditLogIsBoundedWithoutRetention(t *testing.T) {
+	now := time.Now()
+	events := make([]*deeppb.TracepointAuditEvent, maxAuditEvents+10)
+	for i := range events {
+		events[i] = auditEvent("1", deeppb.TracepointAuditEvent_UPDATE, now.Add(time.Duration(i)))
+	}
+
+	pruned := prune(events, now, 0)
+	assert.Equal(t, maxAuditEvents-1, len(pruned))
+	assert.Same(t, events[len(events)-1], pruned[len(pruned)-1])
+}
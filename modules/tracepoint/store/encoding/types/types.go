@@ -19,21 +19,67 @@ package types
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"github.com/intergral/deep/pkg/deeppb"
 	cp "github.com/intergral/deep/pkg/deeppb/common/v1"
 	deeptp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
 )
 
+var (
+	// ErrTracepointNotFound is returned when trying to modify a tracepoint that does not exist
+	ErrTracepointNotFound = errors.New("tracepoint not found")
+	// ErrVersionConflict is returned when an update is based on an older version of the tracepoint
+	ErrVersionConflict = errors.New("tracepoint version conflict")
+	// ErrTracepointExists is returned when trying to add a tracepoint with an ID that is already used
+	ErrTracepointExists = errors.New("tracepoint already exists")
+	// ErrBlockChanged is returned when flushing a block that has been changed in storage since it was loaded
+	ErrBlockChanged = errors.New("tracepoint block has been changed by another replica")
+)
+
 type TPBlock interface {
 	ForResource(resource []*cp.KeyValue) ([]*deeptp.TracePointConfig, error)
 	TenantID() string
 	Tps() []*deeptp.TracePointConfig
-	Flushed()
+	// Flushed is called once the block has been written to storage, with the version that was written
+	Flushed(version uint64)
+	// Version is the version of the block in storage that this block was loaded from, or last flushed to
+	Version() uint64
+	// Dirty returns true if the tracepoints have been changed since the block was loaded or flushed. Snapshot
+	// counts are not included, as they are not flushed after each change.
+	Dirty() bool
+	// Clone returns a copy of the block, so changes can be made to one without affecting the other
+	Clone() TPBlock
 	AddTracepoint(config *deeptp.TracePointConfig)
-	DeleteTracepoint(id string)
+	// DeleteTracepoint will remove the tracepoint, ErrTracepointNotFound is returned if it does not exist
+	DeleteTracepoint(id string) error
+	// UpdateTracepoint will replace the tracepoint with the same ID, if version is not 0 it must match the current version
+	UpdateTracepoint(config *deeptp.TracePointConfig, version uint64) (*deeppb.TracepointMetadata, error)
+	// Metadata returns the metadata for the tracepoint, or nil if the tracepoint does not exist
+	Metadata(id string) *deeppb.TracepointMetadata
+	// AllMetadata returns the metadata for all the tracepoints in this block
+	AllMetadata() map[string]*deeppb.TracepointMetadata
+	// SetLimits will replace the limits used to retire the tracepoint
+	SetLimits(id string, limits *deeppb.TracepointLimits) error
+	// SetLabels will replace the labels of the tracepoint
+	SetLabels(id string, labels map[string]string) error
+	// SetPaused will change the paused state of the tracepoint
+	SetPaused(id string, paused bool) error
+	// MatchLabels returns the tracepoints that have all the given labels
+	MatchLabels(labels map[string]string) []*deeptp.TracePointConfig
+	// RecordSnapshots will add the counts to the snapshot count of each tracepoint
+	RecordSnapshots(counts map[string]uint64)
+	// Retired returns the IDs of the tracepoints that have expired or received their max snapshots
+	Retired(now time.Time) []string
 }
 
 type TPBackend interface {
+	// Flush will write the block to storage, if the block in storage is not the version the block was loaded from
+	// then ErrBlockChanged is returned and nothing is written
 	Flush(ctx context.Context, block TPBlock) error
 	LoadBlock(ctx context.Context, tenantID string) (TPBlock, error)
+	// LoadBlockIfChanged will load the block for the tenant, unless the version of the block in storage is the given
+	// version, in which case nil is returned
+	LoadBlockIfChanged(ctx context.Context, tenantID string, version uint64) (TPBlock, error)
 }
@@ -0,0 +1,179 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package v1
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	cp "github.com/intergral/deep/pkg/deeppb/common/v1"
+	deep_tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/intergral/deep/pkg/deepql"
+)
+
+// TargetingExpressionKey is the targeting key that can be used to provide a deepql targeting expression, rather than
+// an exact match on a resource attribute.
+//
+// e.g. deepql: { resource.k8s.pod.name =~ "^checkout-.*" && resource.deployment != "canary" }
+const TargetingExpressionKey = "deepql"
+
+// maxCachedExpressions is the number of parsed targeting expressions we keep before we reset the cache
+const maxCachedExpressions = 1000
+
+var (
+	expressionCache   = map[string]*deepql.TargetingExpr{}
+	expressionCacheMu sync.RWMutex
+)
+
+// ValidateTargeting will ensure that any targeting expression on the tracepoint can be parsed
+func ValidateTargeting(tp *deep_tp.TracePointConfig) error {
+	for _, value := range tp.Targeting {
+		if value.Key != TargetingExpressionKey {
+			continue
+		}
+
+		if _, ok := value.Value.GetValue().(*cp.AnyValue_StringValue); !ok {
+			return fmt.Errorf("targeting expression must be a string")
+		}
+
+		_, err := targetingExpression(value.Value.GetStringValue())
+		if err != nil {
+			return fmt.Errorf("invalid targeting expression: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ResourceMatches returns true if the resource satisfies all the targeting of the tracepoint. Targeting keys are
+// matched exactly against the resource attribute of the same name, unless the key is TargetingExpressionKey in which
+// case the value is evaluated as a deepql targeting expression.
+func ResourceMatches(tp *deep_tp.TracePointConfig, resource []*cp.KeyValue) bool {
+	// if the resource targeting is empty then we match all
+	if len(resource) == 0 {
+		return true
+	}
+
+	// the tp targeting is not set so match all
+	if tp.Targeting == nil || len(tp.Targeting) == 0 {
+		return true
+	}
+
+	attributes := resourceAttributes(resource)
+
+	for _, value := range tp.Targeting {
+		if value.Key == TargetingExpressionKey {
+			expr, err := targetingExpression(value.Value.GetStringValue())
+			if err != nil {
+				return false
+			}
+			matches, err := expr.Matches(attributes)
+			if err != nil || !matches {
+				return false
+			}
+			continue
+		}
+
+		val, ok := attributes[deepql.NewScopedAttribute(deepql.AttributeScopeResource, false, value.Key)]
+		if !ok {
+			return false
+		}
+		target, ok := anyValueToStatic(value.Value)
+		if !ok || !staticsMatch(val, target) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// targetingExpression returns the parsed expression from the cache, or parses it if it has not been seen before
+func targetingExpression(expression string) (*deepql.TargetingExpr, error) {
+	expressionCacheMu.RLock()
+	expr, ok := expressionCache[expression]
+	expressionCacheMu.RUnlock()
+	if ok {
+		return expr, nil
+	}
+
+	expr, err := deepql.ParseTargeting(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	expressionCacheMu.Lock()
+	defer expressionCacheMu.Unlock()
+	// we do not track usage of the expressions, so rather than growing forever we just start again
+	if len(expressionCache) >= maxCachedExpressions {
+		expressionCache = map[string]*deepql.TargetingExpr{}
+	}
+	expressionCache[expression] = expr
+
+	return expr, nil
+}
+
+// resourceAttributes converts the resource into the attributes used by deepql
+func resourceAttributes(resource []*cp.KeyValue) map[deepql.Attribute]deepql.Static {
+	attributes := make(map[deepql.Attribute]deepql.Static, len(resource))
+	for _, value := range resource {
+		static, ok := anyValueToStatic(value.Value)
+		if !ok {
+			continue
+		}
+		attributes[deepql.NewScopedAttribute(deepql.AttributeScopeResource, false, value.Key)] = static
+	}
+	return attributes
+}
+
+// anyValueToStatic converts the scalar values to a deepql.Static, arrays, maps and bytes are not supported
+func anyValueToStatic(value *cp.AnyValue) (deepql.Static, bool) {
+	switch v := value.GetValue().(type) {
+	case *cp.AnyValue_StringValue:
+		return deepql.NewStaticString(v.StringValue), true
+	case *cp.AnyValue_BoolValue:
+		return deepql.NewStaticBool(v.BoolValue), true
+	case *cp.AnyValue_IntValue:
+		return deepql.NewStaticInt(int(v.IntValue)), true
+	case *cp.AnyValue_DoubleValue:
+		return deepql.NewStaticFloat(v.DoubleValue), true
+	}
+
+	return deepql.NewStaticNil(), false
+}
+
+// staticsMatch compares the values, if the types are different (e.g. the targeting is "2" and the resource is 2) then
+// we compare the string forms.
+func staticsMatch(val, target deepql.Static) bool {
+	if val.Equals(target) {
+		return true
+	}
+
+	if val.Type == target.Type {
+		return false
+	}
+
+	return staticString(val) == staticString(target)
+}
+
+func staticString(s deepql.Static) string {
+	if s.Type == deepql.TypeFloat {
+		return strconv.FormatFloat(s.F, 'f', -1, 64)
+	}
+	return s.EncodeToString(false)
+}
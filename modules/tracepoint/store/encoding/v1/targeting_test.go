@@ -0,0 +1,181 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package v1
+
+import (
+	"testing"
+
+	cp "github.com/intergral/deep/pkg/deeppb/common/v1"
+	deeptp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func stringValue(val string) *cp.AnyValue {
+	return &cp.AnyValue{Value: &cp.AnyValue_StringValue{StringValue: val}}
+}
+
+func expression(expr string) []*cp.KeyValue {
+	return []*cp.KeyValue{{Key: TargetingExpressionKey, Value: stringValue(expr)}}
+}
+
+func TestTargetingExpression(t *testing.T) {
+	resource := []*cp.KeyValue{
+		{Key: "service.name", Value: stringValue("checkout")},
+		{Key: "k8s.pod.name", Value: stringValue("checkout-7f9c-abcde")},
+		{Key: "deployment", Value: stringValue("canary")},
+		{Key: "version", Value: stringValue("2.3")},
+		{Key: "minor.version", Value: stringValue("2.10")},
+		{Key: "patch.version", Value: stringValue("2.10.1")},
+		{Key: "tag.version", Value: stringValue("v2.10.1")},
+		{Key: "replicas", Value: &cp.AnyValue{Value: &cp.AnyValue_IntValue{IntValue: 3}}},
+		{Key: "load", Value: &cp.AnyValue{Value: &cp.AnyValue_DoubleValue{DoubleValue: 0.75}}},
+		{Key: "debug", Value: &cp.AnyValue{Value: &cp.AnyValue_BoolValue{BoolValue: true}}},
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		expected   bool
+	}{
+		{name: "empty", expression: `{ }`, expected: true},
+		{name: "equal", expression: `{ resource.service.name = "checkout" }`, expected: true},
+		{name: "equal no match", expression: `{ resource.service.name = "cart" }`, expected: false},
+		{name: "unscoped", expression: `{ .service.name = "checkout" }`, expected: true},
+		{name: "not equal", expression: `{ resource.service.name != "cart" }`, expected: true},
+		{name: "not equal no match", expression: `{ resource.deployment != "canary" }`, expected: false},
+		{name: "not equal missing", expression: `{ resource.region != "eu" }`, expected: true},
+		{name: "equal missing", expression: `{ resource.region = "eu" }`, expected: false},
+		{name: "nil missing", expression: `{ resource.region = nil }`, expected: true},
+		{name: "not nil", expression: `{ resource.deployment != nil }`, expected: true},
+		{name: "regex", expression: `{ resource.k8s.pod.name =~ "^checkout-.*" }`, expected: true},
+		{name: "regex no match", expression: `{ resource.k8s.pod.name =~ "^cart-.*" }`, expected: false},
+		{name: "not regex", expression: `{ resource.k8s.pod.name !~ "^cart-.*" }`, expected: true},
+		{name: "not regex no match", expression: `{ resource.k8s.pod.name !~ "^checkout-.*" }`, expected: false},
+		{name: "not regex missing", expression: `{ resource.region !~ "eu-.*" }`, expected: true},
+		{name: "regex int", expression: `{ resource.replicas =~ "[0-9]" }`, expected: true},
+		{name: "set", expression: `{ resource.deployment =~ "^(canary|stable)$" }`, expected: true},
+		{name: "set no match", expression: `{ resource.deployment =~ "^(blue|green)$" }`, expected: false},
+		{name: "or set", expression: `{ resource.deployment = "blue" || resource.deployment = "canary" }`, expected: true},
+		{name: "greater string", expression: `{ resource.version > 2.2 }`, expected: true},
+		{name: "greater equal string", expression: `{ resource.version >= 2.3 }`, expected: true},
+		{name: "greater equal string no match", expression: `{ resource.version >= 2.4 }`, expected: false},
+		{name: "less string", expression: `{ resource.version < 3 }`, expected: true},
+		{name: "less equal string", expression: `{ resource.version <= 2.3 }`, expected: true},
+		{name: "less equal string no match", expression: `{ resource.version <= 2.2 }`, expected: false},
+		{name: "greater version", expression: `{ resource.minor.version >= 2.3 }`, expected: true},
+		{name: "less version no match", expression: `{ resource.minor.version < 2.3 }`, expected: false},
+		{name: "greater version string", expression: `{ resource.minor.version > "2.9" }`, expected: true},
+		{name: "equal version trailing zero", expression: `{ resource.minor.version >= "2.10.0" }`, expected: true},
+		{name: "greater patch version", expression: `{ resource.patch.version >= 2.3 }`, expected: true},
+		{name: "less patch version", expression: `{ resource.patch.version < "2.10.2" }`, expected: true},
+		{name: "less patch version no match", expression: `{ resource.patch.version <= "2.10" }`, expected: false},
+		{name: "greater major version", expression: `{ resource.patch.version > 2 }`, expected: true},
+		{name: "less major version", expression: `{ resource.patch.version < 3 }`, expected: true},
+		{name: "tagged version", expression: `{ resource.tag.version >= "v2.10.1" && resource.tag.version < 2.11 }`, expected: true},
+		{name: "greater int", expression: `{ resource.replicas > 2 }`, expected: true},
+		{name: "less float", expression: `{ resource.load < 0.8 }`, expected: true},
+		{name: "equal int", expression: `{ resource.replicas = 3 }`, expected: true},
+		{name: "equal numeric string", expression: `{ resource.version = 2.3 }`, expected: true},
+		{name: "compare not numeric", expression: `{ resource.service.name > 1 }`, expected: false},
+		{name: "compare missing", expression: `{ resource.region > 1 }`, expected: false},
+		{name: "bool", expression: `{ resource.debug = true }`, expected: true},
+		{name: "bool attribute", expression: `{ resource.debug }`, expected: true},
+		{name: "not", expression: `{ !(resource.deployment = "canary") }`, expected: false},
+		{name: "not bool", expression: `{ !resource.debug }`, expected: false},
+		{name: "and", expression: `{ resource.k8s.pod.name =~ "^checkout-.*" && resource.version >= 2.3 }`, expected: true},
+		{name: "and no match", expression: `{ resource.k8s.pod.name =~ "^checkout-.*" && resource.deployment != "canary" }`, expected: false},
+		{name: "or", expression: `{ resource.deployment = "stable" || resource.replicas >= 3 }`, expected: true},
+		{name: "or no match", expression: `{ resource.deployment = "stable" || resource.replicas > 3 }`, expected: false},
+		{name: "arithmetic", expression: `{ resource.replicas * 2 = 6 }`, expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tp := &deeptp.TracePointConfig{
+				ID:        "one",
+				Targeting: expression(test.expression),
+			}
+
+			assert.NoError(t, ValidateTargeting(tp))
+			assert.Equal(t, test.expected, ResourceMatches(tp, resource))
+		})
+	}
+}
+
+func TestTargetingExpressionWithExactMatch(t *testing.T) {
+	resource := []*cp.KeyValue{
+		{Key: "service.name", Value: stringValue("checkout")},
+		{Key: "replicas", Value: &cp.AnyValue{Value: &cp.AnyValue_IntValue{IntValue: 3}}},
+	}
+
+	tp := &deeptp.TracePointConfig{
+		ID: "one",
+		Targeting: append(expression(`{ resource.replicas > 1 }`),
+			&cp.KeyValue{Key: "service.name", Value: stringValue("checkout")}),
+	}
+	assert.True(t, ResourceMatches(tp, resource))
+
+	tp.Targeting[1].Value = stringValue("cart")
+	assert.False(t, ResourceMatches(tp, resource))
+
+	// exact matches work with non string values
+	tp.Targeting = []*cp.KeyValue{{Key: "replicas", Value: &cp.AnyValue{Value: &cp.AnyValue_IntValue{IntValue: 3}}}}
+	assert.True(t, ResourceMatches(tp, resource))
+
+	tp.Targeting = []*cp.KeyValue{{Key: "replicas", Value: stringValue("3")}}
+	assert.True(t, ResourceMatches(tp, resource))
+}
+
+func TestValidateTargeting(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+	}{
+		{name: "syntax", expression: `{ resource.name = `},
+		{name: "not a filter", expression: `{ } | count() > 1`},
+		{name: "multiple filters", expression: `{ resource.a = 1 } && { resource.b = 2 }`},
+		{name: "not boolean", expression: `{ resource.replicas + 1 }`},
+		{name: "invalid regex", expression: `{ resource.name =~ "(" }`},
+		{name: "regex attribute", expression: `{ resource.name =~ resource.other }`},
+		{name: "regex int", expression: `{ resource.name =~ 1 }`},
+		{name: "intrinsic", expression: `{ duration > 1s }`},
+		{name: "illegal types", expression: `{ resource.name = "a" && 1 }`},
+		{name: "string compare", expression: `{ resource.name > "a" }`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tp := &deeptp.TracePointConfig{
+				ID:        "one",
+				Targeting: expression(test.expression),
+			}
+
+			assert.Error(t, ValidateTargeting(tp))
+			assert.False(t, ResourceMatches(tp, []*cp.KeyValue{{Key: "name", Value: stringValue("a")}}))
+		})
+	}
+
+	// the expression must be a string
+	assert.Error(t, ValidateTargeting(&deeptp.TracePointConfig{
+		Targeting: []*cp.KeyValue{{Key: TargetingExpressionKey, Value: &cp.AnyValue{Value: &cp.AnyValue_IntValue{IntValue: 1}}}},
+	}))
+	// exact match targeting is always valid
+	assert.NoError(t, ValidateTargeting(&deeptp.TracePointConfig{
+		Targeting: []*cp.KeyValue{{Key: "service.name", Value: stringValue("checkout")}},
+	}))
+}
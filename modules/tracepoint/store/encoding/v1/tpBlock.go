@@ -20,14 +20,21 @@ package v1
 import (
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/intergral/deep/modules/tracepoint/store/encoding/types"
+	"github.com/intergral/deep/pkg/deeppb"
 	cp "github.com/intergral/deep/pkg/deeppb/common/v1"
 	deep_tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
 )
 
 type tpBlock struct {
 	tps       []*deep_tp.TracePointConfig
+	metadata  map[string]*deeppb.TracepointMetadata
 	tenantID  string
 	lastFlush int64
+	// version is the version of the block in storage, 0 if the block has never been written
+	version uint64
+	dirty   bool
 }
 
 func (t *tpBlock) ForResource(resource []*cp.KeyValue) ([]*deep_tp.TracePointConfig, error) {
@@ -50,15 +57,179 @@ func (t *tpBlock) Tps() []*deep_tp.TracePointConfig {
 	return t.tps
 }
 
-func (t *tpBlock) Flushed() {
+func (t *tpBlock) Flushed(version uint64) {
 	t.lastFlush = time.Now().UnixMilli()
+	t.version = version
+	t.dirty = false
+}
+
+func (t *tpBlock) Version() uint64 {
+	return t.version
+}
+
+func (t *tpBlock) Dirty() bool {
+	return t.dirty
+}
+
+func (t *tpBlock) Clone() types.TPBlock {
+	clone := *t
+	clone.tps = make([]*deep_tp.TracePointConfig, len(t.tps))
+	copy(clone.tps, t.tps)
+	// the metadata is never modified in place, so the values can be shared
+	clone.metadata = make(map[string]*deeppb.TracepointMetadata, len(t.metadata))
+	for id, metadata := range t.metadata {
+		clone.metadata[id] = metadata
+	}
+	return &clone
 }
 
 func (t *tpBlock) AddTracepoint(tp *deep_tp.TracePointConfig) {
+	t.dirty = true
 	t.tps = append(t.tps, tp)
+	t.setMetadata(tp.ID, &deeppb.TracepointMetadata{Version: 1})
+}
+
+func (t *tpBlock) UpdateTracepoint(tp *deep_tp.TracePointConfig, version uint64) (*deeppb.TracepointMetadata, error) {
+	for i, config := range t.tps {
+		if config.ID != tp.ID {
+			continue
+		}
+
+		metadata := t.Metadata(tp.ID)
+		if version != 0 && version != metadata.Version {
+			return nil, types.ErrVersionConflict
+		}
+
+		// metadata is shared with responses, so we never modify it in place
+		metadata = proto.Clone(metadata).(*deeppb.TracepointMetadata)
+		metadata.Version++
+
+		t.tps[i] = tp
+		t.setMetadata(tp.ID, metadata)
+		t.dirty = true
+		return metadata, nil
+	}
+	return nil, types.ErrTracepointNotFound
+}
+
+func (t *tpBlock) Metadata(id string) *deeppb.TracepointMetadata {
+	metadata, ok := t.metadata[id]
+	if ok {
+		return metadata
+	}
+
+	// blocks written before we tracked metadata will not have any, so create it for known tracepoints
+	for _, config := range t.tps {
+		if config.ID == id {
+			metadata = &deeppb.TracepointMetadata{Version: 1}
+			t.setMetadata(id, metadata)
+			return metadata
+		}
+	}
+	return nil
 }
 
-func (t *tpBlock) DeleteTracepoint(tpID string) {
+func (t *tpBlock) AllMetadata() map[string]*deeppb.TracepointMetadata {
+	metadata := make(map[string]*deeppb.TracepointMetadata, len(t.tps))
+	for _, config := range t.tps {
+		metadata[config.ID] = t.Metadata(config.ID)
+	}
+	return metadata
+}
+
+func (t *tpBlock) SetLimits(id string, limits *deeppb.TracepointLimits) error {
+	metadata := t.Metadata(id)
+	if metadata == nil {
+		return types.ErrTracepointNotFound
+	}
+
+	metadata = proto.Clone(metadata).(*deeppb.TracepointMetadata)
+	metadata.Limits = limits
+	t.setMetadata(id, metadata)
+	t.dirty = true
+	return nil
+}
+
+func (t *tpBlock) SetLabels(id string, labels map[string]string) error {
+	metadata := t.Metadata(id)
+	if metadata == nil {
+		return types.ErrTracepointNotFound
+	}
+
+	metadata = proto.Clone(metadata).(*deeppb.TracepointMetadata)
+	metadata.Labels = labels
+	t.setMetadata(id, metadata)
+	t.dirty = true
+	return nil
+}
+
+func (t *tpBlock) SetPaused(id string, paused bool) error {
+	metadata := t.Metadata(id)
+	if metadata == nil {
+		return types.ErrTracepointNotFound
+	}
+
+	metadata = proto.Clone(metadata).(*deeppb.TracepointMetadata)
+	metadata.Paused = paused
+	t.setMetadata(id, metadata)
+	t.dirty = true
+	return nil
+}
+
+func (t *tpBlock) MatchLabels(labels map[string]string) []*deep_tp.TracePointConfig {
+	var matched []*deep_tp.TracePointConfig
+	for _, config := range t.tps {
+		if labelsMatch(t.Metadata(config.ID).GetLabels(), labels) {
+			matched = append(matched, config)
+		}
+	}
+	return matched
+}
+
+func (t *tpBlock) RecordSnapshots(counts map[string]uint64) {
+	for id, count := range counts {
+		metadata := t.Metadata(id)
+		if metadata == nil {
+			// the tracepoint has been removed, but agents can still be sending snapshots for it
+			continue
+		}
+
+		metadata = proto.Clone(metadata).(*deeppb.TracepointMetadata)
+		metadata.SnapshotCount += count
+		t.setMetadata(id, metadata)
+	}
+}
+
+func (t *tpBlock) Retired(now time.Time) []string {
+	var retired []string
+	nowNanos := uint64(now.UnixNano())
+	for _, config := range t.tps {
+		metadata := t.Metadata(config.ID)
+		limits := metadata.GetLimits()
+		if limits == nil {
+			continue
+		}
+
+		if limits.ExpiresAtNanos != 0 && limits.ExpiresAtNanos <= nowNanos {
+			retired = append(retired, config.ID)
+			continue
+		}
+
+		if limits.MaxSnapshots != 0 && metadata.SnapshotCount >= limits.MaxSnapshots {
+			retired = append(retired, config.ID)
+		}
+	}
+	return retired
+}
+
+func (t *tpBlock) setMetadata(id string, metadata *deeppb.TracepointMetadata) {
+	if t.metadata == nil {
+		t.metadata = map[string]*deeppb.TracepointMetadata{}
+	}
+	t.metadata[id] = metadata
+}
+
+func (t *tpBlock) DeleteTracepoint(tpID string) error {
 	tpToRemoveIndex := -1
 	for i, config := range t.tps {
 		if config.ID == tpID {
@@ -68,11 +239,13 @@ func (t *tpBlock) DeleteTracepoint(tpID string) {
 	}
 
 	if tpToRemoveIndex == -1 {
-		// todo return error?
-		return
+		return types.ErrTracepointNotFound
 	}
 
 	t.tps = t.remove(t.tps, tpToRemoveIndex)
+	delete(t.metadata, tpID)
+	t.dirty = true
+	return nil
 }
 
 func (t *tpBlock) matches(tp *deep_tp.TracePointConfig, resource []*cp.KeyValue) bool {
@@ -85,35 +258,12 @@ func (t *tpBlock) remove(tps []*deep_tp.TracePointConfig, i int) []*deep_tp.Trac
 	return tps[:len(tps)-1]
 }
 
-func ResourceMatches(tp *deep_tp.TracePointConfig, resource []*cp.KeyValue) bool {
-	// if the resource targeting is empty then we match all
-	if len(resource) == 0 {
-		return true
-	}
-
-	// the tp targeting is not set so match all
-	if tp.Targeting == nil || len(tp.Targeting) == 0 {
-		return true
-	}
-
-	targeting := make(map[string]string, len(tp.Targeting))
-
-	for _, value := range resource {
-		key := value.Key
-		val := value.Value.GetStringValue()
-		targeting[key] = val
-	}
-
-	for _, value := range tp.Targeting {
-		key := value.Key
-		val, ok := targeting[key]
-		if !ok {
-			return false
-		}
-		if val != value.Value.GetStringValue() {
+// labelsMatch returns true if the tracepoint labels contain all the selector labels
+func labelsMatch(tpLabels map[string]string, selector map[string]string) bool {
+	for key, value := range selector {
+		if tpValue, ok := tpLabels[key]; !ok || tpValue != value {
 			return false
 		}
 	}
-
 	return true
 }
@@ -19,7 +19,9 @@ package v1
 
 import (
 	"testing"
+	"time"
 
+	"github.com/intergral/deep/pkg/deeppb"
 	cp "github.com/intergral/deep/pkg/deeppb/common/v1"
 	deeptp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
 	"github.com/stretchr/testify/assert"
@@ -115,3 +117,58 @@ func TestTargeting(t *testing.T) {
 		})
 	}
 }
+
+func TestRetired(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		limits   *deeppb.TracepointLimits
+		count    uint64
+		expected bool
+	}{
+		{
+			name:     "No limits",
+			limits:   nil,
+			count:    100,
+			expected: false,
+		},
+		{
+			name:     "Not expired",
+			limits:   &deeppb.TracepointLimits{ExpiresAtNanos: uint64(now.Add(time.Minute).UnixNano())},
+			expected: false,
+		},
+		{
+			name:     "Expired",
+			limits:   &deeppb.TracepointLimits{ExpiresAtNanos: uint64(now.Add(-time.Minute).UnixNano())},
+			expected: true,
+		},
+		{
+			name:     "Under max snapshots",
+			limits:   &deeppb.TracepointLimits{MaxSnapshots: 10},
+			count:    9,
+			expected: false,
+		},
+		{
+			name:     "Max snapshots",
+			limits:   &deeppb.TracepointLimits{MaxSnapshots: 10},
+			count:    10,
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			block := &tpBlock{}
+			block.AddTracepoint(&deeptp.TracePointConfig{ID: "one"})
+
+			err := block.SetLimits("one", test.limits)
+			assert.NoError(t, err)
+
+			block.RecordSnapshots(map[string]uint64{"one": test.count, "unknown": 1})
+
+			retired := block.Retired(now)
+			assert.Equal(t, test.expected, len(retired) == 1)
+		})
+	}
+}
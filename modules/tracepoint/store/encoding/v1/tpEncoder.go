@@ -20,11 +20,14 @@ package v1
 import (
 	"bytes"
 	"context"
+	"hash/fnv"
+	"io"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/intergral/deep/modules/tracepoint/store/encoding/types"
 	"github.com/intergral/deep/pkg/deepdb"
 	"github.com/intergral/deep/pkg/deepdb/backend"
+	"github.com/intergral/deep/pkg/deeppb"
 	deeptp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
 )
 
@@ -34,6 +37,15 @@ type TPEncoder struct {
 }
 
 func (t *TPEncoder) Flush(ctx context.Context, block types.TPBlock) error {
+	// another replica may have written the block since we loaded it, writing over it would lose their changes
+	stored, err := t.readBlock(ctx, block.TenantID())
+	if err != nil {
+		return err
+	}
+	if storedVersion(stored) != block.Version() {
+		return types.ErrBlockChanged
+	}
+
 	var positons []byte
 	var tpBytes []byte
 
@@ -58,36 +70,96 @@ func (t *TPEncoder) Flush(ctx context.Context, block types.TPBlock) error {
 	// append the tps
 	data = append(data, tpBytes...)
 
+	// the metadata is appended after the tps, so older versions will just ignore it
+	metadata, err := proto.Marshal(&deeppb.TracepointBlockMetadata{Tracepoints: block.AllMetadata()})
+	if err != nil {
+		return err
+	}
+	data = append(data, metadata...)
+
 	reader := bytes.NewReader(data)
 
-	err := t.Writer.WriteTracepointBlock(ctx, block.TenantID(), reader, int64(len(data)))
+	err = t.Writer.WriteTracepointBlock(ctx, block.TenantID(), reader, int64(len(data)))
 	if err != nil {
 		return err
 	}
-	block.Flushed()
+	block.Flushed(blockVersion(data))
 
 	return nil
 }
 
 func (t *TPEncoder) LoadBlock(ctx context.Context, tenantID string) (types.TPBlock, error) {
+	block, err := t.loadBlock(ctx, tenantID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// LoadBlockIfChanged will load the block for the tenant, unless the version of the block in storage is the given
+// version, in which case nil is returned. A tenant without a block in storage has version 0.
+func (t *TPEncoder) LoadBlockIfChanged(ctx context.Context, tenantID string, version uint64) (types.TPBlock, error) {
+	block, err := t.loadBlock(ctx, tenantID, &version)
+	if err != nil || block == nil {
+		// avoid returning a typed nil
+		return nil, err
+	}
+	return block, nil
+}
+
+// loadBlock reads the block for the tenant, if unchanged is set and the block in storage has that version, then nil
+// is returned without decoding the block
+func (t *TPEncoder) loadBlock(ctx context.Context, tenantID string, unchanged *uint64) (*tpBlock, error) {
+	data, err := t.readBlock(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	version := storedVersion(data)
+	if unchanged != nil && *unchanged == version {
+		return nil, nil
+	}
+
+	if data == nil {
+		// block doesn't exist so create new
+		return &tpBlock{
+			tps:      []*deeptp.TracePointConfig{},
+			tenantID: tenantID,
+		}, nil
+	}
+
+	block, err := t.decode(tenantID, data)
+	if err != nil {
+		return nil, err
+	}
+	block.version = version
+
+	return block, nil
+}
+
+// readBlock reads the data of the block for the tenant, nil is returned if there is no block in storage
+func (t *TPEncoder) readBlock(ctx context.Context, tenantID string) ([]byte, error) {
 	read, i, err := t.Reader.ReadTracepointBlock(ctx, tenantID)
 	if err != nil {
 		if err == backend.ErrDoesNotExist {
-			// block doesn't exist so create new
-			return &tpBlock{
-				tps:      []*deeptp.TracePointConfig{},
-				tenantID: tenantID,
-			}, nil
+			return nil, nil
 		}
 		return nil, err
 	}
+	defer func() {
+		_ = read.Close()
+	}()
 
 	data := make([]byte, i)
-	_, err = read.Read(data)
+	_, err = io.ReadFull(read, data)
 	if err != nil {
 		return nil, err
 	}
+	return data, nil
+}
 
+// decode will read the tracepoints and metadata from the block data
+func (t *TPEncoder) decode(tenantID string, data []byte) (*tpBlock, error) {
 	reader := bytes.NewReader(data)
 	// read the first byte as the length of the position array
 	positionSize, err := reader.ReadByte()
@@ -136,8 +208,39 @@ func (t *TPEncoder) LoadBlock(ctx context.Context, tenantID string) (types.TPBlo
 		tps[i] = tp
 	}
 
+	// anything left is the metadata for the tps, this will not exist for blocks from older versions
+	metadata := &deeppb.TracepointBlockMetadata{}
+	if reader.Len() > 0 {
+		metadataBytes := make([]byte, reader.Len())
+		_, err = reader.Read(metadataBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		err = proto.Unmarshal(metadataBytes, metadata)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &tpBlock{
 		tenantID: tenantID,
 		tps:      tps,
+		metadata: metadata.Tracepoints,
 	}, nil
 }
+
+// storedVersion is the version of the block data read from storage, a tenant without a block in storage has version 0
+func storedVersion(data []byte) uint64 {
+	if data == nil {
+		return 0
+	}
+	return blockVersion(data)
+}
+
+// blockVersion identifies the content of a block, so replicas can tell when another replica has changed it
+func blockVersion(data []byte) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(data)
+	return h.Sum64()
+}
@@ -25,6 +25,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/intergral/deep/pkg/deepdb"
 	"github.com/intergral/deep/pkg/deepdb/backend"
 	"github.com/intergral/deep/pkg/deepdb/backend/local"
@@ -93,3 +94,74 @@ func TestFlush(t *testing.T) {
 	assert.Equal(t, blockOut.Tps()[0].Path, "/some/test/file.path")
 	assert.Equal(t, blockOut.Tps()[0].LineNumber, uint32(10))
 }
+
+func TestLoadBlockWithoutMetadata(t *testing.T) {
+	r, w, _, err := local.New(&local.Config{
+		Path: t.TempDir(),
+	})
+	assert.NoError(t, err)
+
+	rw := mockTracepointReaderWriter{
+		r: r,
+		w: w,
+	}
+
+	encoder := TPEncoder{
+		Reader: rw,
+		Writer: rw,
+	}
+
+	// write a block in the format used before the metadata was added
+	marshal, err := proto.Marshal(&deeptp.TracePointConfig{ID: "iamatest"})
+	assert.NoError(t, err)
+	data := []byte{1, byte(len(marshal)), byte(len(marshal))}
+	data = append(data, marshal...)
+
+	err = rw.WriteTracepointBlock(context.Background(), "test-id", bytes.NewReader(data), int64(len(data)))
+	assert.NoError(t, err)
+
+	blockOut, err := encoder.LoadBlock(context.Background(), "test-id")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(blockOut.Tps()))
+	assert.Equal(t, uint64(1), blockOut.Metadata("iamatest").Version)
+}
+
+func TestLoadBlockIfChanged(t *testing.T) {
+	r, w, _, err := local.New(&local.Config{
+		Path: t.TempDir(),
+	})
+	assert.NoError(t, err)
+
+	rw := mockTracepointReaderWriter{
+		r: r,
+		w: w,
+	}
+
+	encoder := TPEncoder{
+		Reader: rw,
+		Writer: rw,
+	}
+
+	// a tenant without a block has version 0
+	blockOut, err := encoder.LoadBlockIfChanged(context.Background(), "test-id", 0)
+	assert.NoError(t, err)
+	assert.Nil(t, blockOut)
+
+	block := &tpBlock{tenantID: "test-id"}
+	block.AddTracepoint(&deeptp.TracePointConfig{ID: "iamatest"})
+	assert.True(t, block.Dirty())
+
+	err = encoder.Flush(context.Background(), block)
+	assert.NoError(t, err)
+	assert.False(t, block.Dirty())
+	assert.NotZero(t, block.Version())
+
+	blockOut, err = encoder.LoadBlockIfChanged(context.Background(), "test-id", block.Version())
+	assert.NoError(t, err)
+	assert.Nil(t, blockOut)
+
+	blockOut, err = encoder.LoadBlockIfChanged(context.Background(), "test-id", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, block.Version(), blockOut.Version())
+	assert.Equal(t, 1, len(blockOut.Tps()))
+}
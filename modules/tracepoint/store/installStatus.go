@@ -0,0 +1,111 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package store
+
+import (
+	"sort"
+	"time"
+
+	"github.com/intergral/deep/pkg/deeppb"
+)
+
+// maxInstallErrors is the number of distinct errors included in the install summary of a tracepoint
+const maxInstallErrors = 5
+
+// agentInstallStatus is the latest install status of a tracepoint reported by a single agent
+type agentInstallStatus struct {
+	installed bool
+	err       string
+	reported  time.Time
+}
+
+// installStatus is the latest install status reported by each agent, by tracepoint ID and then agent ID. This is only
+// kept in memory, so it is lost when the tracepoint service restarts.
+type installStatus map[string]map[string]agentInstallStatus
+
+// record replaces the status of each tracepoint for the agent
+func (s installStatus) record(agentID string, statuses []*deeppb.TracepointInstallStatus, now time.Time) {
+	for _, status := range statuses {
+		agents, ok := s[status.TracepointId]
+		if !ok {
+			agents = map[string]agentInstallStatus{}
+			s[status.TracepointId] = agents
+		}
+		agents[agentID] = agentInstallStatus{installed: status.Installed, err: status.Error, reported: now}
+	}
+}
+
+// summary counts the agents that have installed, or failed to install, the tracepoint. Returns nil if no agent has
+// reported the tracepoint.
+func (s installStatus) summary(tpID string) *deeppb.TracepointInstallSummary {
+	agents := s[tpID]
+	if len(agents) == 0 {
+		return nil
+	}
+
+	summary := &deeppb.TracepointInstallSummary{}
+	var failed []agentInstallStatus
+	for _, status := range agents {
+		if status.installed {
+			summary.Installed++
+			continue
+		}
+		summary.Failed++
+		failed = append(failed, status)
+	}
+
+	sort.Slice(failed, func(i, j int) bool {
+		if failed[i].reported.Equal(failed[j].reported) {
+			return failed[i].err < failed[j].err
+		}
+		return failed[i].reported.After(failed[j].reported)
+	})
+
+	seen := map[string]struct{}{}
+	for _, status := range failed {
+		if _, ok := seen[status.err]; ok || status.err == "" {
+			continue
+		}
+		seen[status.err] = struct{}{}
+		summary.Errors = append(summary.Errors, status.err)
+		if len(summary.Errors) == maxInstallErrors {
+			break
+		}
+	}
+
+	return summary
+}
+
+// prune removes the statuses reported before the given time, and the statuses of tracepoints that no longer exist
+func (s installStatus) prune(before time.Time, exists func(tpID string) bool) {
+	for tpID, agents := range s {
+		if !exists(tpID) {
+			delete(s, tpID)
+			continue
+		}
+
+		for agentID, status := range agents {
+			if status.reported.Before(before) {
+				delete(agents, agentID)
+			}
+		}
+		if len(agents) == 0 {
+			delete(s, tpID)
+		}
+	}
+}
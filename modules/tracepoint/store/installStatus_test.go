@@ -0,0 +1,81 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package store
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/intergral/deep/pkg/deeppb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstallStatusSummary(t *testing.T) {
+	now := time.Unix(1000, 0)
+	status := installStatus{}
+
+	status.record("agent-1", []*deeppb.TracepointInstallStatus{{TracepointId: "1", Installed: true}}, now)
+	status.record("agent-2", []*deeppb.TracepointInstallStatus{{TracepointId: "1", Error: "line 10 is not executable"}}, now.Add(time.Second))
+	status.record("agent-3", []*deeppb.TracepointInstallStatus{{TracepointId: "1", Error: "file not found"}}, now.Add(2*time.Second))
+	status.record("agent-4", []*deeppb.TracepointInstallStatus{{TracepointId: "1", Error: "line 10 is not executable"}}, now.Add(3*time.Second))
+
+	// errors are distinct and newest first
+	assert.Equal(t, &deeppb.TracepointInstallSummary{
+		Installed: 1,
+		Failed:    3,
+		Errors:    []string{"line 10 is not executable", "file not found"},
+	}, status.summary("1"))
+
+	// a new report replaces the previous status of the agent
+	status.record("agent-2", []*deeppb.TracepointInstallStatus{{TracepointId: "1", Installed: true}}, now.Add(4*time.Second))
+	assert.Equal(t, uint32(2), status.summary("1").Installed)
+	assert.Equal(t, uint32(2), status.summary("1").Failed)
+
+	assert.Nil(t, status.summary("2"))
+}
+
+func TestInstallStatusSummaryLimitsErrors(t *testing.T) {
+	now := time.Unix(1000, 0)
+	status := installStatus{}
+	for i := 0; i < maxInstallErrors+2; i++ {
+		status.record(fmt.Sprintf("agent-%d", i), []*deeppb.TracepointInstallStatus{{TracepointId: "1", Error: fmt.Sprintf("error %d", i)}}, now.Add(time.Duration(i)*time.Second))
+	}
+
+	summary := status.summary("1")
+	assert.Equal(t, uint32(maxInstallErrors+2), summary.Failed)
+	assert.Equal(t, []string{"error 6", "error 5", "error 4", "error 3", "error 2"}, summary.Errors)
+}
+
+func TestInstallStatusPrune(t *testing.T) {
+	now := time.Unix(1000, 0)
+	status := installStatus{}
+	status.record("old", []*deeppb.TracepointInstallStatus{{TracepointId: "1", Installed: true}}, now)
+	status.record("new", []*deeppb.TracepointInstallStatus{{TracepointId: "1", Installed: true}}, now.Add(time.Hour))
+	status.record("old", []*deeppb.TracepointInstallStatus{{TracepointId: "2", Installed: true}}, now)
+	status.record("new", []*deeppb.TracepointInstallStatus{{TracepointId: "3", Installed: true}}, now.Add(time.Hour))
+
+	status.prune(now.Add(time.Minute), func(tpID string) bool {
+		return tpID != "3"
+	})
+
+	assert.Equal(t, &deeppb.TracepointInstallSummary{Installed: 1}, status.summary("1"))
+	assert.Nil(t, status.summary("2"))
+	assert.Nil(t, status.summary("3"))
+	assert.Len(t, status, 1)
+}
@@ -19,12 +19,16 @@ package store
 
 import (
 	"context"
+	"fmt"
 	"hash/fnv"
 	"sort"
 	"strconv"
 	"sync"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
 	"github.com/intergral/deep/modules/storage"
 	"github.com/intergral/deep/modules/tracepoint/store/encoding"
 	"github.com/intergral/deep/modules/tracepoint/store/encoding/types"
@@ -33,23 +37,52 @@ import (
 	cp "github.com/intergral/deep/pkg/deeppb/common/v1"
 	pb "github.com/intergral/deep/pkg/deeppb/poll/v1"
 	tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/intergral/deep/pkg/util"
 )
 
+// maxUpdateAttempts is the number of times an update is made before giving up, when the block keeps being changed by
+// other replicas
+const maxUpdateAttempts = 3
+
+var metricReloads = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "deep",
+	Subsystem: "tracepoint",
+	Name:      "reloads_total",
+	Help:      "The total number of times the tracepoints of a tenant have been reloaded after being changed by another replica.",
+}, []string{"tenant"})
+
 type TPStore struct {
 	orgStores map[string]*orgStore
 	backend   types.TPBackend
+	mu        sync.RWMutex
 }
 
 type ResourceTPStore interface {
 	ProcessRequest(req *deeppb.LoadTracepointRequest) (*deeppb.LoadTracepointResponse, error)
 	AddTracepoint(tp *tp.TracePointConfig) error
 	DeleteTracepoint(tpID string) error
+	UpdateTracepoint(tp *tp.TracePointConfig) error
 }
 
 type OrgTPStore interface {
 	DeleteTracepoint(tpID string) error
 	forResource(resource []*cp.KeyValue) (ResourceTPStore, error)
 	AddTracepoint(tracepoint *tp.TracePointConfig) error
+	AddTracepoints(requests []*deeppb.CreateTracepointRequest) ([]*deeppb.TracepointMetadata, error)
+	DeleteTracepoints(labels map[string]string) []*tp.TracePointConfig
+	SetPaused(labels map[string]string, paused bool) []*tp.TracePointConfig
+	SetTracepointPaused(tpID string, paused bool) (bool, error)
+	SetLabels(tpID string, labels map[string]string) error
+	UpdateTracepoint(tracepoint *tp.TracePointConfig, version uint64) (*deeppb.TracepointMetadata, error)
+	Tracepoint(tpID string) *tp.TracePointConfig
+	Metadata(tpID string) *deeppb.TracepointMetadata
+	SetLimits(tpID string, limits *deeppb.TracepointLimits) error
+	RecordSnapshots(counts map[string]uint64) []string
+	RecordInstallStatus(agentID string, statuses []*deeppb.TracepointInstallStatus)
+	RecordAgents(agents []*deeppb.AgentInfo)
+	Agents() []*deeppb.AgentInfo
+	RemoveRetired(now time.Time) []string
+	Watch() (<-chan struct{}, func())
 }
 
 // NewStore will create a new store to handle reading and writing to disk
@@ -64,9 +97,12 @@ func NewStore(store storage.Store) (*TPStore, error) {
 	}, nil
 }
 
+// FlushAll will sync the in memory changes of every org to disk, including the snapshot counts
 func (s *TPStore) FlushAll(ctx context.Context) error {
-	for _, store := range s.orgStores {
-		err := s.Flush(ctx, store)
+	for _, store := range s.orgs() {
+		store.writeMu.Lock()
+		err := s.update(ctx, store, func() error { return nil }, true)
+		store.writeMu.Unlock()
 		if err != nil {
 			return err
 		}
@@ -74,13 +110,68 @@ func (s *TPStore) FlushAll(ctx context.Context) error {
 	return nil
 }
 
-// Flush will sync the in memory changes to disk
+// Flush will sync the in memory changes to disk. The block is written without holding the lock, so the tracepoints
+// can still be read while it is written.
 func (s *TPStore) Flush(ctx context.Context, store OrgTPStore) error {
 	o := store.(*orgStore)
+	o.mu.Lock()
+	block := o.block.Clone()
+	o.mu.Unlock()
+
+	err := s.backend.Flush(ctx, block)
+	if err != nil {
+		return err
+	}
+
 	o.mu.Lock()
 	defer o.mu.Unlock()
+	o.block.Flushed(block.Version())
+	return nil
+}
+
+// Update will refresh the org from storage, apply the change and then flush it to storage. Updates to an org are
+// made one at a time, so the change is always made to the latest tracepoints. If another replica changes the block
+// before we flush, the change is made again to their block. If either the change or the flush fails, the org is
+// restored to how it was before the change, so a failed request never leaves part of a change behind. The org is only
+// flushed if the change modified the tracepoints.
+func (s *TPStore) Update(ctx context.Context, store OrgTPStore, change func() error) error {
+	o := store.(*orgStore)
+	o.writeMu.Lock()
+	defer o.writeMu.Unlock()
+
+	return s.update(ctx, o, change, false)
+}
+
+// update is Update, always will flush the org even if the change does not modify the tracepoints. This must be called
+// while holding the write lock.
+func (s *TPStore) update(ctx context.Context, o *orgStore, change func() error, always bool) error {
+	var err error
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		_, err = s.refresh(ctx, o)
+		if err != nil {
+			return err
+		}
 
-	return s.backend.Flush(ctx, o.block)
+		previous := o.snapshot()
+		err = change()
+		if err != nil {
+			o.restore(previous)
+			return err
+		}
+		if !always && !o.dirty() {
+			return nil
+		}
+
+		err = s.Flush(ctx, o)
+		if err == nil {
+			return nil
+		}
+		o.restore(previous)
+		if err != types.ErrBlockChanged {
+			return err
+		}
+	}
+	return err
 }
 
 // ForResource will find or create a new in memory store for the defined resource
@@ -97,9 +188,19 @@ func (s *TPStore) ForResource(ctx context.Context, id string, resource []*cp.Key
 // ForOrg will find or create a in memory store for the given org id
 // this will load the org block from storage, if we do not already have a copy
 func (s *TPStore) ForOrg(ctx context.Context, id string) (OrgTPStore, error) {
+	s.mu.RLock()
+	store := s.orgStores[id]
+	s.mu.RUnlock()
+	if store != nil {
+		return store, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if s.orgStores[id] != nil {
 		return s.orgStores[id], nil
 	}
+
 	block, err := s.backend.LoadBlock(ctx, id)
 	if err != nil {
 		return nil, err
@@ -110,6 +211,108 @@ func (s *TPStore) ForOrg(ctx context.Context, id string) (OrgTPStore, error) {
 	return s.orgStores[id], nil
 }
 
+// RemoveRetired will remove any tracepoints that have expired, or received their max snapshots, from all the orgs
+// any org that is changed is then flushed to storage
+func (s *TPStore) RemoveRetired(ctx context.Context, now time.Time) (map[string][]string, error) {
+	removed := map[string][]string{}
+	for tenantID, store := range s.orgs() {
+		var retired []string
+		err := s.Update(ctx, store, func() error {
+			retired = store.RemoveRetired(now)
+			return nil
+		})
+		if err != nil {
+			return removed, err
+		}
+		if len(retired) > 0 {
+			removed[tenantID] = retired
+		}
+	}
+	return removed, nil
+}
+
+// PruneInstallStatus will remove the install status reported by agents before the given time from all the orgs, so
+// agents that have gone away are no longer counted
+func (s *TPStore) PruneInstallStatus(before time.Time) {
+	for _, store := range s.orgs() {
+		store.pruneInstallStatus(before)
+	}
+}
+
+// PruneAgents will remove the agents that have not been seen since before
+func (s *TPStore) PruneAgents(before time.Time) {
+	for _, store := range s.orgs() {
+		store.pruneAgents(before)
+	}
+}
+
+// Refresh will reload the tracepoints of the org if the block in storage has been changed by another replica. Any
+// changes that have not been flushed are kept, so the next flush is not lost. Returns true if the org was reloaded.
+func (s *TPStore) Refresh(ctx context.Context, store OrgTPStore) (bool, error) {
+	o := store.(*orgStore)
+	o.writeMu.Lock()
+	defer o.writeMu.Unlock()
+
+	return s.refresh(ctx, o)
+}
+
+// refresh is Refresh, the block is read without holding the lock so the tracepoints can still be read while it is
+// loaded. This must be called while holding the write lock.
+func (s *TPStore) refresh(ctx context.Context, o *orgStore) (bool, error) {
+	o.mu.Lock()
+	dirty, version := o.block.Dirty(), o.block.Version()
+	o.mu.Unlock()
+	if dirty {
+		return false, nil
+	}
+
+	block, err := s.backend.LoadBlockIfChanged(ctx, o.tenantID, version)
+	if err != nil || block == nil {
+		return false, err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	// the org may have been changed while the block was loading
+	if o.block.Dirty() || o.block.Version() != version {
+		return false, nil
+	}
+	err = o.reload(block)
+	if err != nil {
+		return false, err
+	}
+	metricReloads.WithLabelValues(o.tenantID).Inc()
+	return true, nil
+}
+
+// RefreshAll will reload any orgs that have been changed in storage by another replica, the IDs of the reloaded
+// tenants are returned
+func (s *TPStore) RefreshAll(ctx context.Context) ([]string, error) {
+	var reloaded []string
+	for tenantID, store := range s.orgs() {
+		changed, err := s.Refresh(ctx, store)
+		if err != nil {
+			return reloaded, err
+		}
+		if changed {
+			reloaded = append(reloaded, tenantID)
+		}
+	}
+	return reloaded, nil
+}
+
+// orgs returns a copy of the org stores, so they can be read from and written to storage without holding the lock
+func (s *TPStore) orgs() map[string]*orgStore {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	orgs := make(map[string]*orgStore, len(s.orgStores))
+	for tenantID, store := range s.orgStores {
+		orgs[tenantID] = store
+	}
+	return orgs
+}
+
 // orgStore is the link to the block in storage
 // this is what is read and written to storage when needed
 type orgStore struct {
@@ -117,6 +320,14 @@ type orgStore struct {
 	userStores map[string]*resourceStore
 	block      types.TPBlock
 	mu         sync.Mutex
+	// writeMu is held while the org is refreshed, changed and flushed, so changes are made one at a time
+	writeMu sync.Mutex
+	// watchers are notified each time the tracepoints change, see Watch
+	watchers map[chan struct{}]struct{}
+	// installStatus is the install status reported by the agents, see RecordInstallStatus
+	installStatus installStatus
+	// agents are the agents reported by the distributors, see RecordAgents
+	agents agents
 }
 
 // AddTracepoint will add a tracepoint to the org and any matching resource stores
@@ -125,6 +336,7 @@ func (os *orgStore) AddTracepoint(tp *tp.TracePointConfig) error {
 	defer os.mu.Unlock()
 
 	os.block.AddTracepoint(tp)
+	defer os.notify()
 
 	for _, store := range os.userStores {
 		if v1.ResourceMatches(tp, store.resource) {
@@ -138,21 +350,387 @@ func (os *orgStore) AddTracepoint(tp *tp.TracePointConfig) error {
 	return nil
 }
 
-// DeleteTracepoint will remove a tracepoint from the org and any matching resource stores
+// AddTracepoints will add all the tracepoints to the org and any matching resource stores. The changes are made
+// under a single lock, so the resource stores will never return only some of the tracepoints. The limits of each
+// request are used as is, so they must already have been applied. If any of the IDs are already in use then
+// ErrTracepointExists is returned and none of the tracepoints are added.
+func (os *orgStore) AddTracepoints(requests []*deeppb.CreateTracepointRequest) ([]*deeppb.TracepointMetadata, error) {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+
+	ids := make(map[string]struct{}, len(requests))
+	for _, req := range requests {
+		if _, ok := ids[req.Tracepoint.ID]; ok || os.block.Metadata(req.Tracepoint.ID) != nil {
+			return nil, types.ErrTracepointExists
+		}
+		ids[req.Tracepoint.ID] = struct{}{}
+	}
+
+	metadata := make([]*deeppb.TracepointMetadata, len(requests))
+	for i, req := range requests {
+		// the tracepoint has just been added, so setting the limits, labels and paused state cannot fail
+		os.block.AddTracepoint(req.Tracepoint)
+		if req.Limits != nil {
+			_ = os.block.SetLimits(req.Tracepoint.ID, req.Limits)
+		}
+		if len(req.Labels) > 0 {
+			_ = os.block.SetLabels(req.Tracepoint.ID, req.Labels)
+		}
+		if req.Paused {
+			_ = os.block.SetPaused(req.Tracepoint.ID, true)
+		}
+		metadata[i] = os.block.Metadata(req.Tracepoint.ID)
+
+		for _, store := range os.userStores {
+			if v1.ResourceMatches(req.Tracepoint, store.resource) {
+				store.tps = append(store.tps, req.Tracepoint)
+			}
+		}
+	}
+
+	for _, store := range os.userStores {
+		store.rehash()
+	}
+	os.notify()
+
+	return metadata, nil
+}
+
+// DeleteTracepoints will remove all the tracepoints that have the given labels, the removed tracepoints are returned
+func (os *orgStore) DeleteTracepoints(labels map[string]string) []*tp.TracePointConfig {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+
+	matched := os.block.MatchLabels(labels)
+	for _, config := range matched {
+		for _, store := range os.userStores {
+			_ = store.DeleteTracepoint(config.ID)
+		}
+		_ = os.block.DeleteTracepoint(config.ID)
+	}
+	if len(matched) > 0 {
+		os.notify()
+	}
+
+	return matched
+}
+
+// SetPaused will change the paused state of all the tracepoints that have the given labels, the tracepoints that
+// have been changed are returned
+func (os *orgStore) SetPaused(labels map[string]string, paused bool) []*tp.TracePointConfig {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+
+	var changed []*tp.TracePointConfig
+	for _, config := range os.block.MatchLabels(labels) {
+		if os.block.Metadata(config.ID).GetPaused() == paused {
+			continue
+		}
+		_ = os.block.SetPaused(config.ID, paused)
+		changed = append(changed, config)
+	}
+
+	// paused tracepoints are not sent to the clients, so the resource hashes need to change
+	if len(changed) > 0 {
+		for _, store := range os.userStores {
+			store.rehash()
+		}
+		os.notify()
+	}
+
+	return changed
+}
+
+// SetTracepointPaused will change the paused state of a single tracepoint, returning true if the state was changed
+func (os *orgStore) SetTracepointPaused(tpID string, paused bool) (bool, error) {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+
+	metadata := os.block.Metadata(tpID)
+	if metadata == nil {
+		return false, types.ErrTracepointNotFound
+	}
+	if metadata.Paused == paused {
+		return false, nil
+	}
+
+	err := os.block.SetPaused(tpID, paused)
+	if err != nil {
+		return false, err
+	}
+
+	for _, store := range os.userStores {
+		store.rehash()
+	}
+	os.notify()
+
+	return true, nil
+}
+
+// SetLabels will replace the labels of the tracepoint
+func (os *orgStore) SetLabels(tpID string, labels map[string]string) error {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+
+	return os.block.SetLabels(tpID, labels)
+}
+
+// DeleteTracepoint will remove a tracepoint from the org and any matching resource stores, if the org does not have
+// the tracepoint then types.ErrTracepointNotFound is returned
 func (os *orgStore) DeleteTracepoint(tpID string) error {
 	os.mu.Lock()
 	defer os.mu.Unlock()
+
+	err := os.block.DeleteTracepoint(tpID)
+	if err != nil {
+		return err
+	}
 	for _, store := range os.userStores {
 		_ = store.DeleteTracepoint(tpID)
 	}
-	os.block.DeleteTracepoint(tpID)
+	os.notify()
+	return nil
+}
+
+// UpdateTracepoint will replace the tracepoint in the org, and update any resource stores that are affected by the change.
+// If version is not 0 then it must match the current version of the tracepoint.
+func (os *orgStore) UpdateTracepoint(tp *tp.TracePointConfig, version uint64) (*deeppb.TracepointMetadata, error) {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+
+	metadata, err := os.block.UpdateTracepoint(tp, version)
+	if err != nil {
+		return nil, err
+	}
+	defer os.notify()
+
+	// the agents will install the updated tracepoint again, so the status of the previous version no longer applies
+	delete(os.installStatus, tp.ID)
+
+	for _, store := range os.userStores {
+		// the targeting might have changed, so we need to remove the tracepoint from resources that no longer match
+		if v1.ResourceMatches(tp, store.resource) {
+			err := store.UpdateTracepoint(tp)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			_ = store.DeleteTracepoint(tp.ID)
+		}
+	}
+
+	return metadata, nil
+}
+
+// SetLimits will replace the limits that are used to retire the tracepoint
+func (os *orgStore) SetLimits(tpID string, limits *deeppb.TracepointLimits) error {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+
+	return os.block.SetLimits(tpID, limits)
+}
+
+// RecordSnapshots will add the snapshot counts to the tracepoints, the IDs of any tracepoints that have now received
+// their max snapshots are returned. The tracepoints are not removed, as the removal has to be flushed, see
+// RemoveRetired.
+func (os *orgStore) RecordSnapshots(counts map[string]uint64) []string {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+
+	os.block.RecordSnapshots(counts)
+
+	return os.block.Retired(time.Now())
+}
+
+// RecordInstallStatus will replace the install status reported by the agent for each tracepoint. Statuses for
+// tracepoints that do not exist are ignored, as agents can report a tracepoint that has just been removed.
+func (os *orgStore) RecordInstallStatus(agentID string, statuses []*deeppb.TracepointInstallStatus) {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+
+	known := make([]*deeppb.TracepointInstallStatus, 0, len(statuses))
+	for _, status := range statuses {
+		if os.block.Metadata(status.TracepointId) != nil {
+			known = append(known, status)
+		}
+	}
+
+	if os.installStatus == nil {
+		os.installStatus = installStatus{}
+	}
+	os.installStatus.record(agentID, known, time.Now())
+}
+
+// RecordAgents will keep the agents reported by a distributor, replacing any earlier report of each agent
+func (os *orgStore) RecordAgents(reported []*deeppb.AgentInfo) {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+
+	if os.agents == nil {
+		os.agents = agents{}
+	}
+	os.agents.record(reported)
+}
+
+// Agents returns the agents reported by all the distributors, ordered by ID
+func (os *orgStore) Agents() []*deeppb.AgentInfo {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+
+	return os.agents.list()
+}
+
+func (os *orgStore) pruneAgents(before time.Time) {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+
+	os.agents.prune(before)
+}
+
+func (os *orgStore) pruneInstallStatus(before time.Time) {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+
+	os.installStatus.prune(before, func(tpID string) bool {
+		return os.block.Metadata(tpID) != nil
+	})
+}
+
+// RemoveRetired will remove any tracepoints that have expired or received their max snapshots, the IDs of the
+// removed tracepoints are returned
+func (os *orgStore) RemoveRetired(now time.Time) []string {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+
+	return os.removeRetired(now)
+}
+
+func (os *orgStore) removeRetired(now time.Time) []string {
+	retired := os.block.Retired(now)
+	for _, tpID := range retired {
+		for _, store := range os.userStores {
+			_ = store.DeleteTracepoint(tpID)
+		}
+		_ = os.block.DeleteTracepoint(tpID)
+	}
+	if len(retired) > 0 {
+		os.notify()
+	}
+	return retired
+}
+
+// reload will replace the block with one loaded from storage (or a snapshot), and rebuild the resource stores from it. The snapshot
+// counts are not flushed after each change, so any counts we have recorded that are not in the new block are kept.
+// This must be called while holding the lock.
+func (os *orgStore) reload(block types.TPBlock) error {
+	counts := map[string]uint64{}
+	for _, config := range block.Tps() {
+		current := os.block.Metadata(config.ID).GetSnapshotCount()
+		if stored := block.Metadata(config.ID).GetSnapshotCount(); current > stored {
+			counts[config.ID] = current - stored
+		}
+	}
+	block.RecordSnapshots(counts)
+
+	os.block = block
+	for _, store := range os.userStores {
+		tps, err := block.ForResource(store.resource)
+		if err != nil {
+			return err
+		}
+		store.tps = tps
+		store.rehash()
+	}
+	os.notify()
+
 	return nil
 }
 
+// dirty returns true if the tracepoints have been changed since they were loaded or flushed
+func (os *orgStore) dirty() bool {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+
+	return os.block.Dirty()
+}
+
+// snapshot returns a copy of the block, that can be given to restore to undo any changes made after this call
+func (os *orgStore) snapshot() types.TPBlock {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+
+	return os.block.Clone()
+}
+
+// restore will replace the block with one from snapshot, undoing any changes made since. Snapshot counts recorded in
+// the meantime are kept.
+func (os *orgStore) restore(block types.TPBlock) {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+
+	// the block was valid when it was taken, so rebuilding the resource stores cannot fail
+	_ = os.reload(block)
+}
+
+// Watch returns a channel that receives a value each time the tracepoints in the org change. Changes that happen
+// before the value is read are coalesced, so the watcher should reload the tracepoints rather than count changes.
+// The returned func must be called to stop watching.
+func (os *orgStore) Watch() (<-chan struct{}, func()) {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+
+	ch := make(chan struct{}, 1)
+	if os.watchers == nil {
+		os.watchers = map[chan struct{}]struct{}{}
+	}
+	os.watchers[ch] = struct{}{}
+
+	return ch, func() {
+		os.mu.Lock()
+		defer os.mu.Unlock()
+		delete(os.watchers, ch)
+	}
+}
+
+// notify the watchers that the tracepoints have changed, this must be called while holding the lock
+func (os *orgStore) notify() {
+	for ch := range os.watchers {
+		select {
+		case ch <- struct{}{}:
+		default:
+			// the watcher already has a pending notification
+		}
+	}
+}
+
+// Tracepoint will return the config for the tracepoint, or nil if the tracepoint does not exist
+func (os *orgStore) Tracepoint(tpID string) *tp.TracePointConfig {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+
+	for _, config := range os.block.Tps() {
+		if config.ID == tpID {
+			return config
+		}
+	}
+	return nil
+}
+
+// Metadata will return the metadata for the tracepoint, or nil if the tracepoint does not exist
+func (os *orgStore) Metadata(tpID string) *deeppb.TracepointMetadata {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+
+	return os.block.Metadata(tpID)
+}
+
 // forResource will create a representation of the tracepoints based on the resource.
 // this simple creates a sublist of the org tracepoints that have targeting that affect ths resource provided
 // the resourceStore is not persisted to disk
 func (os *orgStore) forResource(resource []*cp.KeyValue) (ResourceTPStore, error) {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+
 	key := os.keyForResource(os.tenantID, resource)
 	if os.userStores[key] != nil {
 		return os.userStores[key], nil
@@ -180,7 +758,10 @@ func (os *orgStore) keyForResource(id string, resource []*cp.KeyValue) string {
 		values := make(map[string]string, len(resource))
 		for i, attr := range resource {
 			keys[i] = attr.Key
-			values[attr.Key] = attr.Value.GetStringValue()
+			// the type is included so values that stringify the same (e.g. 1 and "1") are not treated as equal
+			if attr.Value != nil {
+				values[attr.Key] = fmt.Sprintf("%T:%s", attr.Value.Value, util.StringifyAnyValue(attr.Value))
+			}
 		}
 
 		// we need to sort the keys to ensure we always hash the same way
@@ -188,7 +769,9 @@ func (os *orgStore) keyForResource(id string, resource []*cp.KeyValue) string {
 
 		for _, key := range keys {
 			_, _ = h.Write([]byte(key))
+			_, _ = h.Write([]byte{0})
 			_, _ = h.Write([]byte(values[key]))
+			_, _ = h.Write([]byte{0})
 		}
 	}
 
@@ -209,19 +792,48 @@ type resourceStore struct {
 }
 
 // ProcessRequest will process a request to load the tracepoints for a resource
+// paused tracepoints are only included if requested, as they should not be sent to the agents
 func (us *resourceStore) ProcessRequest(req *deeppb.LoadTracepointRequest) (*deeppb.LoadTracepointResponse, error) {
+	// we lock the org, so we never see a partially applied change
+	us.os.mu.Lock()
+	defer us.os.mu.Unlock()
+
 	responseType := pb.ResponseType_UPDATE
 	// if the incoming hash is the same has the hash we have then there is no change between the client and us
 	if req.Request.CurrentHash != "" && req.Request.CurrentHash == us.currentHash {
 		responseType = pb.ResponseType_NO_CHANGE
 	}
 
-	return &deeppb.LoadTracepointResponse{Response: &pb.PollResponse{
-		TsNanos:      uint64(time.Now().UnixNano()),
-		CurrentHash:  us.currentHash,
-		Response:     us.tps,
-		ResponseType: responseType,
-	}}, nil
+	tps := make([]*tp.TracePointConfig, 0, len(us.tps))
+	metadata := make(map[string]*deeppb.TracepointMetadata, len(us.tps))
+	var installStatus map[string]*deeppb.TracepointInstallSummary
+	if req.IncludeStatus {
+		installStatus = map[string]*deeppb.TracepointInstallSummary{}
+	}
+	for _, config := range us.tps {
+		tpMetadata := us.os.block.Metadata(config.ID)
+		if tpMetadata.GetPaused() && !req.IncludePaused {
+			continue
+		}
+		tps = append(tps, config)
+		metadata[config.ID] = tpMetadata
+		if req.IncludeStatus {
+			if summary := us.os.installStatus.summary(config.ID); summary != nil {
+				installStatus[config.ID] = summary
+			}
+		}
+	}
+
+	return &deeppb.LoadTracepointResponse{
+		Response: &pb.PollResponse{
+			TsNanos:      uint64(time.Now().UnixNano()),
+			CurrentHash:  us.currentHash,
+			Response:     tps,
+			ResponseType: responseType,
+		},
+		Metadata: metadata,
+		Status:   installStatus,
+	}, nil
 }
 
 // AddTracepoint to this resource
@@ -231,6 +843,18 @@ func (us *resourceStore) AddTracepoint(tp *tp.TracePointConfig) error {
 	return nil
 }
 
+// UpdateTracepoint in this resource, if the tracepoint is not already in this resource it is added
+func (us *resourceStore) UpdateTracepoint(tp *tp.TracePointConfig) error {
+	for i, config := range us.tps {
+		if config.ID == tp.ID {
+			us.tps[i] = tp
+			us.rehash()
+			return nil
+		}
+	}
+	return us.AddTracepoint(tp)
+}
+
 // DeleteTracepoint from this resource
 func (us *resourceStore) DeleteTracepoint(tpID string) error {
 	tpToRemoveIndex := -1
@@ -252,10 +876,19 @@ func (us *resourceStore) DeleteTracepoint(tpID string) error {
 }
 
 // rehash the resource and set our currentHash
+// we include the version of each tracepoint so that updates are seen as a change by the clients, paused tracepoints
+// are not sent to the clients so are not included
 func (us *resourceStore) rehash() {
 	h := fnv.New32()
 	for _, config := range us.tps {
+		metadata := us.os.block.Metadata(config.ID)
+		if metadata.GetPaused() {
+			continue
+		}
 		_, _ = h.Write([]byte(config.ID))
+		if metadata != nil {
+			_, _ = h.Write([]byte(strconv.FormatUint(metadata.Version, 10)))
+		}
 	}
 	us.currentHash = strconv.Itoa(int(h.Sum32()))
 }
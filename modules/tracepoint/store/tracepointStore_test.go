@@ -19,9 +19,12 @@ package store
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/intergral/deep/modules/storage"
+	"github.com/intergral/deep/modules/tracepoint/store/encoding/types"
 	"github.com/intergral/deep/pkg/deepdb"
 	"github.com/intergral/deep/pkg/deepdb/backend"
 	"github.com/intergral/deep/pkg/deepdb/backend/local"
@@ -29,6 +32,7 @@ import (
 	"github.com/intergral/deep/pkg/deepdb/encoding/common"
 	"github.com/intergral/deep/pkg/deepdb/wal"
 	"github.com/intergral/deep/pkg/deeppb"
+	cp "github.com/intergral/deep/pkg/deeppb/common/v1"
 	deeppb_poll "github.com/intergral/deep/pkg/deeppb/poll/v1"
 	tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
 	"github.com/stretchr/testify/assert"
@@ -247,3 +251,676 @@ func TestGetNewTracepointsOnPoll(t *testing.T) {
 		assert.Equal(t, response.Response.ResponseType, deeppb_poll.ResponseType_NO_CHANGE)
 	}
 }
+
+func TestUpdateTracepoint(t *testing.T) {
+	tpStore := createStore(t, "")
+
+	org, _ := tpStore.ForOrg(context.Background(), "test-org")
+
+	_ = org.AddTracepoint(&tp.TracePointConfig{
+		ID:         "1",
+		LineNumber: 10,
+	})
+
+	resource, _ := org.forResource(nil)
+	response, _ := resource.ProcessRequest(&deeppb.LoadTracepointRequest{
+		Request: &deeppb_poll.PollRequest{},
+	})
+	currentHash := response.Response.CurrentHash
+	assert.Equal(t, uint64(1), response.Metadata["1"].Version)
+
+	metadata, err := org.UpdateTracepoint(&tp.TracePointConfig{
+		ID:         "1",
+		LineNumber: 12,
+	}, 1)
+	assert.NoError(t, err, "cannot update tp")
+	assert.Equal(t, uint64(2), metadata.Version)
+
+	// the update should be seen as a change by the client
+	response, _ = resource.ProcessRequest(&deeppb.LoadTracepointRequest{
+		Request: &deeppb_poll.PollRequest{
+			CurrentHash: currentHash,
+		},
+	})
+	assert.Equal(t, deeppb_poll.ResponseType_UPDATE, response.Response.ResponseType)
+	assert.NotEqual(t, currentHash, response.Response.CurrentHash)
+	assert.Equal(t, 1, len(response.Response.Response))
+	assert.Equal(t, uint32(12), response.Response.Response[0].LineNumber)
+	assert.Equal(t, uint64(2), response.Metadata["1"].Version)
+}
+
+func TestUpdateTracepointVersionConflict(t *testing.T) {
+	tpStore := createStore(t, "")
+
+	org, _ := tpStore.ForOrg(context.Background(), "test-org")
+
+	_ = org.AddTracepoint(&tp.TracePointConfig{
+		ID: "1",
+	})
+
+	_, err := org.UpdateTracepoint(&tp.TracePointConfig{ID: "1", LineNumber: 12}, 1)
+	assert.NoError(t, err)
+
+	// this update is based on version 1, but we are now at version 2
+	_, err = org.UpdateTracepoint(&tp.TracePointConfig{ID: "1", LineNumber: 14}, 1)
+	assert.ErrorIs(t, err, types.ErrVersionConflict)
+
+	// version 0 always updates
+	metadata, err := org.UpdateTracepoint(&tp.TracePointConfig{ID: "1", LineNumber: 14}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), metadata.Version)
+
+	_, err = org.UpdateTracepoint(&tp.TracePointConfig{ID: "2"}, 0)
+	assert.ErrorIs(t, err, types.ErrTracepointNotFound)
+}
+
+func TestUpdateTracepointTargeting(t *testing.T) {
+	tpStore := createStore(t, "")
+
+	org, _ := tpStore.ForOrg(context.Background(), "test-org")
+
+	resourceAttrs := []*cp.KeyValue{{
+		Key:   "service.name",
+		Value: &cp.AnyValue{Value: &cp.AnyValue_StringValue{StringValue: "test"}},
+	}}
+
+	_ = org.AddTracepoint(&tp.TracePointConfig{
+		ID: "1",
+	})
+
+	resource, _ := org.forResource(resourceAttrs)
+	response, _ := resource.ProcessRequest(&deeppb.LoadTracepointRequest{
+		Request: &deeppb_poll.PollRequest{},
+	})
+	assert.Equal(t, 1, len(response.Response.Response))
+
+	// change the targeting so the resource no longer matches
+	_, err := org.UpdateTracepoint(&tp.TracePointConfig{
+		ID: "1",
+		Targeting: []*cp.KeyValue{{
+			Key:   "service.name",
+			Value: &cp.AnyValue{Value: &cp.AnyValue_StringValue{StringValue: "other"}},
+		}},
+	}, 0)
+	assert.NoError(t, err)
+
+	response, _ = resource.ProcessRequest(&deeppb.LoadTracepointRequest{
+		Request: &deeppb_poll.PollRequest{},
+	})
+	assert.Equal(t, 0, len(response.Response.Response))
+}
+
+func TestVersionAfterFlush(t *testing.T) {
+	dir := t.TempDir()
+	{
+		tpStore := createStore(t, dir)
+
+		org, _ := tpStore.ForOrg(context.Background(), "test-org")
+
+		_ = org.AddTracepoint(&tp.TracePointConfig{
+			ID: "1",
+		})
+		_, err := org.UpdateTracepoint(&tp.TracePointConfig{ID: "1", LineNumber: 12}, 1)
+		assert.NoError(t, err)
+
+		err = tpStore.FlushAll(context.Background())
+		assert.NoError(t, err, "failed to flush")
+	}
+	{
+		tpStore := createStore(t, dir)
+		org, _ := tpStore.ForOrg(context.Background(), "test-org")
+
+		assert.Equal(t, uint64(2), org.Metadata("1").Version)
+	}
+}
+
+func TestRecordSnapshotsRetiresTracepoint(t *testing.T) {
+	tpStore := createStore(t, "")
+
+	org, _ := tpStore.ForOrg(context.Background(), "test-org")
+
+	_ = org.AddTracepoint(&tp.TracePointConfig{ID: "1"})
+	_ = org.AddTracepoint(&tp.TracePointConfig{ID: "2"})
+	err := org.SetLimits("1", &deeppb.TracepointLimits{MaxSnapshots: 2})
+	assert.NoError(t, err)
+
+	resource, _ := org.forResource(nil)
+
+	retired := org.RecordSnapshots(map[string]uint64{"1": 1, "2": 5})
+	assert.Equal(t, 0, len(retired))
+
+	retired = org.RecordSnapshots(map[string]uint64{"1": 1})
+	assert.Equal(t, []string{"1"}, retired)
+	assert.Equal(t, []string{"1"}, org.RemoveRetired(time.Now()))
+
+	response, _ := resource.ProcessRequest(&deeppb.LoadTracepointRequest{
+		Request: &deeppb_poll.PollRequest{},
+	})
+	assert.Equal(t, 1, len(response.Response.Response))
+	assert.Equal(t, "2", response.Response.Response[0].ID)
+}
+
+func TestRemoveExpiredTracepoints(t *testing.T) {
+	tpStore := createStore(t, "")
+
+	org, _ := tpStore.ForOrg(context.Background(), "test-org")
+
+	now := time.Now()
+	_ = org.AddTracepoint(&tp.TracePointConfig{ID: "1"})
+	_ = org.SetLimits("1", &deeppb.TracepointLimits{ExpiresAtNanos: uint64(now.Add(time.Minute).UnixNano())})
+
+	removed, err := tpStore.RemoveRetired(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(removed))
+
+	removed, err = tpStore.RemoveRetired(context.Background(), now.Add(2*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1"}, removed["test-org"])
+	assert.Nil(t, org.Metadata("1"))
+}
+
+func TestBulkCreateAndDeleteTracepoints(t *testing.T) {
+	tpStore := createStore(t, "")
+
+	org, _ := tpStore.ForOrg(context.Background(), "test-org")
+	resource, _ := org.forResource(nil)
+
+	metadata, err := org.AddTracepoints([]*deeppb.CreateTracepointRequest{
+		{Tracepoint: &tp.TracePointConfig{ID: "1"}, Labels: map[string]string{"group": "checkout"}},
+		{Tracepoint: &tp.TracePointConfig{ID: "2"}, Labels: map[string]string{"group": "checkout", "team": "a"}},
+		{Tracepoint: &tp.TracePointConfig{ID: "3"}, Labels: map[string]string{"group": "billing"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(metadata))
+	assert.Equal(t, "checkout", metadata[0].Labels["group"])
+
+	response, _ := resource.ProcessRequest(&deeppb.LoadTracepointRequest{
+		Request: &deeppb_poll.PollRequest{},
+	})
+	assert.Equal(t, 3, len(response.Response.Response))
+
+	deleted := org.DeleteTracepoints(map[string]string{"group": "checkout"})
+	assert.Equal(t, 2, len(deleted))
+
+	response, _ = resource.ProcessRequest(&deeppb.LoadTracepointRequest{
+		Request: &deeppb_poll.PollRequest{},
+	})
+	assert.Equal(t, 1, len(response.Response.Response))
+	assert.Equal(t, "3", response.Response.Response[0].ID)
+
+	// no tracepoints match so nothing should be removed
+	deleted = org.DeleteTracepoints(map[string]string{"group": "billing", "team": "a"})
+	assert.Equal(t, 0, len(deleted))
+}
+
+func TestBulkCreateWithExistingIDAddsNothing(t *testing.T) {
+	tpStore := createStore(t, "")
+
+	org, _ := tpStore.ForOrg(context.Background(), "test-org")
+	resource, _ := org.forResource(nil)
+
+	_, err := org.AddTracepoints([]*deeppb.CreateTracepointRequest{{Tracepoint: &tp.TracePointConfig{ID: "1"}}})
+	assert.NoError(t, err)
+
+	_, err = org.AddTracepoints([]*deeppb.CreateTracepointRequest{
+		{Tracepoint: &tp.TracePointConfig{ID: "2"}},
+		{Tracepoint: &tp.TracePointConfig{ID: "1"}},
+	})
+	assert.Equal(t, types.ErrTracepointExists, err)
+
+	_, err = org.AddTracepoints([]*deeppb.CreateTracepointRequest{
+		{Tracepoint: &tp.TracePointConfig{ID: "3"}},
+		{Tracepoint: &tp.TracePointConfig{ID: "3"}},
+	})
+	assert.Equal(t, types.ErrTracepointExists, err)
+
+	response, _ := resource.ProcessRequest(&deeppb.LoadTracepointRequest{
+		Request: &deeppb_poll.PollRequest{},
+	})
+	assert.Equal(t, 1, len(response.Response.Response))
+	assert.Equal(t, "1", response.Response.Response[0].ID)
+}
+
+// failingBackend fails every flush
+type failingBackend struct {
+	types.TPBackend
+}
+
+func (f failingBackend) Flush(context.Context, types.TPBlock) error {
+	return errors.New("flush failed")
+}
+
+func TestUpdateIsRestoredWhenFlushFails(t *testing.T) {
+	tpStore := createStore(t, "")
+
+	org, _ := tpStore.ForOrg(context.Background(), "test-org")
+	resource, _ := org.forResource(nil)
+
+	err := tpStore.Update(context.Background(), org, func() error {
+		_, err := org.AddTracepoints([]*deeppb.CreateTracepointRequest{{Tracepoint: &tp.TracePointConfig{ID: "1"}}})
+		return err
+	})
+	assert.NoError(t, err)
+
+	tpStore.backend = failingBackend{TPBackend: tpStore.backend}
+	err = tpStore.Update(context.Background(), org, func() error {
+		_, err := org.AddTracepoints([]*deeppb.CreateTracepointRequest{{Tracepoint: &tp.TracePointConfig{ID: "2"}}})
+		if err != nil {
+			return err
+		}
+		return org.SetLabels("1", map[string]string{"group": "checkout"})
+	})
+	assert.Error(t, err)
+
+	response, _ := resource.ProcessRequest(&deeppb.LoadTracepointRequest{
+		Request: &deeppb_poll.PollRequest{},
+	})
+	assert.Equal(t, 1, len(response.Response.Response))
+	assert.Equal(t, "1", response.Response.Response[0].ID)
+	assert.Nil(t, org.Metadata("1").Labels)
+	assert.Nil(t, org.Metadata("2"))
+}
+
+func TestUpdateIsRestoredWhenChangeFails(t *testing.T) {
+	tpStore := createStore(t, "")
+
+	org, _ := tpStore.ForOrg(context.Background(), "test-org")
+
+	err := tpStore.Update(context.Background(), org, func() error {
+		_, err := org.AddTracepoints([]*deeppb.CreateTracepointRequest{{Tracepoint: &tp.TracePointConfig{ID: "1"}}})
+		if err != nil {
+			return err
+		}
+		return org.SetLimits("missing", &deeppb.TracepointLimits{MaxSnapshots: 1})
+	})
+	assert.Equal(t, types.ErrTracepointNotFound, err)
+	assert.Nil(t, org.Tracepoint("1"))
+}
+
+func TestPausedTracepointsAreNotSent(t *testing.T) {
+	tpStore := createStore(t, "")
+
+	org, _ := tpStore.ForOrg(context.Background(), "test-org")
+	resource, _ := org.forResource(nil)
+
+	_, err := org.AddTracepoints([]*deeppb.CreateTracepointRequest{
+		{Tracepoint: &tp.TracePointConfig{ID: "1"}, Labels: map[string]string{"group": "checkout"}},
+		{Tracepoint: &tp.TracePointConfig{ID: "2"}},
+	})
+	assert.NoError(t, err)
+
+	response, _ := resource.ProcessRequest(&deeppb.LoadTracepointRequest{
+		Request: &deeppb_poll.PollRequest{},
+	})
+	currentHash := response.Response.CurrentHash
+
+	changed := org.SetPaused(map[string]string{"group": "checkout"}, true)
+	assert.Equal(t, 1, len(changed))
+	assert.Equal(t, "1", changed[0].ID)
+
+	// pausing again should not change anything
+	changed = org.SetPaused(map[string]string{"group": "checkout"}, true)
+	assert.Equal(t, 0, len(changed))
+
+	response, _ = resource.ProcessRequest(&deeppb.LoadTracepointRequest{
+		Request: &deeppb_poll.PollRequest{CurrentHash: currentHash},
+	})
+	assert.Equal(t, deeppb_poll.ResponseType_UPDATE, response.Response.ResponseType)
+	assert.Equal(t, 1, len(response.Response.Response))
+	assert.Equal(t, "2", response.Response.Response[0].ID)
+
+	response, _ = resource.ProcessRequest(&deeppb.LoadTracepointRequest{
+		Request:       &deeppb_poll.PollRequest{},
+		IncludePaused: true,
+	})
+	assert.Equal(t, 2, len(response.Response.Response))
+	assert.True(t, response.Metadata["1"].Paused)
+
+	changed = org.SetPaused(map[string]string{"group": "checkout"}, false)
+	assert.Equal(t, 1, len(changed))
+
+	response, _ = resource.ProcessRequest(&deeppb.LoadTracepointRequest{
+		Request: &deeppb_poll.PollRequest{},
+	})
+	assert.Equal(t, 2, len(response.Response.Response))
+	assert.Equal(t, currentHash, response.Response.CurrentHash)
+}
+
+func TestPauseAndResumeTracepoint(t *testing.T) {
+	tpStore := createStore(t, "")
+
+	org, _ := tpStore.ForOrg(context.Background(), "test-org")
+	resource, _ := org.forResource(nil)
+
+	_ = org.AddTracepoint(&tp.TracePointConfig{ID: "1"})
+
+	changed, err := org.SetTracepointPaused("1", true)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	changed, err = org.SetTracepointPaused("1", true)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	response, _ := resource.ProcessRequest(&deeppb.LoadTracepointRequest{
+		Request: &deeppb_poll.PollRequest{},
+	})
+	assert.Equal(t, 0, len(response.Response.Response))
+
+	// the config is kept while paused, so it can be resumed with the same ID
+	assert.NotNil(t, org.Tracepoint("1"))
+	assert.True(t, org.Metadata("1").Paused)
+
+	changed, err = org.SetTracepointPaused("1", false)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	response, _ = resource.ProcessRequest(&deeppb.LoadTracepointRequest{
+		Request: &deeppb_poll.PollRequest{},
+	})
+	assert.Equal(t, 1, len(response.Response.Response))
+	assert.Equal(t, "1", response.Response.Response[0].ID)
+
+	_, err = org.SetTracepointPaused("2", true)
+	assert.Equal(t, types.ErrTracepointNotFound, err)
+}
+
+func TestPausedStateIsPersisted(t *testing.T) {
+	tempDir := t.TempDir()
+	{
+		tpStore := createStore(t, tempDir)
+		org, _ := tpStore.ForOrg(context.Background(), "test-org")
+		_ = org.AddTracepoint(&tp.TracePointConfig{ID: "1"})
+		_, _ = org.SetTracepointPaused("1", true)
+		assert.NoError(t, tpStore.Flush(context.Background(), org))
+	}
+	{
+		tpStore := createStore(t, tempDir)
+		org, _ := tpStore.ForOrg(context.Background(), "test-org")
+		assert.True(t, org.Metadata("1").Paused)
+
+		resource, _ := org.forResource(nil)
+		response, _ := resource.ProcessRequest(&deeppb.LoadTracepointRequest{
+			Request: &deeppb_poll.PollRequest{},
+		})
+		assert.Equal(t, 0, len(response.Response.Response))
+	}
+}
+
+func TestWatchIsNotifiedOfChanges(t *testing.T) {
+	tpStore := createStore(t, "")
+
+	org, _ := tpStore.ForOrg(context.Background(), "test-org")
+
+	changes, cancel := org.Watch()
+
+	_ = org.AddTracepoint(&tp.TracePointConfig{ID: "1"})
+	_ = org.DeleteTracepoint("1")
+
+	// changes are coalesced, so we only see one notification
+	assert.Equal(t, 1, len(changes))
+	<-changes
+
+	cancel()
+	_ = org.AddTracepoint(&tp.TracePointConfig{ID: "2"})
+	assert.Equal(t, 0, len(changes))
+}
+
+func TestDeleteMissingTracepoint(t *testing.T) {
+	tpStore := createStore(t, "")
+
+	org, _ := tpStore.ForOrg(context.Background(), "test-org")
+	_ = org.AddTracepoint(&tp.TracePointConfig{ID: "1"})
+
+	changes, cancel := org.Watch()
+	defer cancel()
+
+	err := org.DeleteTracepoint("missing")
+	assert.Equal(t, types.ErrTracepointNotFound, err)
+	// nothing changed, so the watchers are not notified
+	assert.Equal(t, 0, len(changes))
+	assert.NotNil(t, org.Tracepoint("1"))
+}
+
+func TestRefreshLoadsChangesFromOtherReplicas(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	storeA := createStore(t, dir)
+	storeB := createStore(t, dir)
+
+	orgA, _ := storeA.ForOrg(ctx, "test-org")
+	orgB, _ := storeB.ForOrg(ctx, "test-org")
+	resourceB, _ := orgB.forResource(nil)
+	changes, cancel := orgB.Watch()
+	defer cancel()
+
+	// nothing has been written yet
+	reloaded, err := storeB.RefreshAll(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, reloaded)
+
+	_ = orgA.AddTracepoint(&tp.TracePointConfig{ID: "1"})
+	assert.NoError(t, storeA.Flush(ctx, orgA))
+
+	reloaded, err = storeB.RefreshAll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"test-org"}, reloaded)
+	assert.Equal(t, 1, len(changes))
+
+	response, _ := resourceB.ProcessRequest(&deeppb.LoadTracepointRequest{Request: &deeppb_poll.PollRequest{}})
+	assert.Equal(t, 1, len(response.Response.Response))
+	assert.Equal(t, "1", response.Response.Response[0].ID)
+
+	// the block has not changed again
+	reloaded, err = storeB.RefreshAll(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, reloaded)
+
+	// our own flush does not cause a reload
+	_ = orgB.DeleteTracepoint("1")
+	assert.NoError(t, storeB.Flush(ctx, orgB))
+	changed, err := storeB.Refresh(ctx, orgB)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	changed, err = storeA.Refresh(ctx, orgA)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Nil(t, orgA.Tracepoint("1"))
+}
+
+func TestRefreshKeepsLocalChanges(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	storeA := createStore(t, dir)
+	storeB := createStore(t, dir)
+
+	orgA, _ := storeA.ForOrg(ctx, "test-org")
+	orgB, _ := storeB.ForOrg(ctx, "test-org")
+
+	_ = orgA.AddTracepoint(&tp.TracePointConfig{ID: "1"})
+	assert.NoError(t, storeA.Flush(ctx, orgA))
+
+	// changes that have not been flushed are not replaced
+	_ = orgB.AddTracepoint(&tp.TracePointConfig{ID: "2"})
+	changed, err := storeB.Refresh(ctx, orgB)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	assert.NotNil(t, orgB.Tracepoint("2"))
+}
+
+func TestRefreshKeepsSnapshotCounts(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	storeA := createStore(t, dir)
+	storeB := createStore(t, dir)
+
+	orgA, _ := storeA.ForOrg(ctx, "test-org")
+	_ = orgA.AddTracepoint(&tp.TracePointConfig{ID: "1"})
+	assert.NoError(t, storeA.Flush(ctx, orgA))
+
+	orgB, _ := storeB.ForOrg(ctx, "test-org")
+	orgB.RecordSnapshots(map[string]uint64{"1": 3})
+
+	_ = orgA.AddTracepoint(&tp.TracePointConfig{ID: "2"})
+	assert.NoError(t, storeA.Flush(ctx, orgA))
+
+	changed, err := storeB.Refresh(ctx, orgB)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.NotNil(t, orgB.Tracepoint("2"))
+	assert.Equal(t, uint64(3), orgB.Metadata("1").SnapshotCount)
+}
+
+func TestFlushRejectsStaleBlock(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	storeA := createStore(t, dir)
+	storeB := createStore(t, dir)
+
+	orgA, _ := storeA.ForOrg(ctx, "test-org")
+	orgB, _ := storeB.ForOrg(ctx, "test-org")
+
+	_ = orgA.AddTracepoint(&tp.TracePointConfig{ID: "1"})
+	assert.NoError(t, storeA.Flush(ctx, orgA))
+
+	// writing the block B loaded before A flushed would lose the tracepoint from A
+	_ = orgB.AddTracepoint(&tp.TracePointConfig{ID: "2"})
+	assert.Equal(t, types.ErrBlockChanged, storeB.Flush(ctx, orgB))
+
+	changed, err := storeA.Refresh(ctx, orgA)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	assert.Nil(t, orgA.Tracepoint("2"))
+}
+
+func TestUpdateAppliesChangeOverOtherReplicas(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	storeA := createStore(t, dir)
+	storeB := createStore(t, dir)
+
+	orgA, _ := storeA.ForOrg(ctx, "test-org")
+	orgB, _ := storeB.ForOrg(ctx, "test-org")
+
+	err := storeA.Update(ctx, orgA, func() error {
+		return orgA.AddTracepoint(&tp.TracePointConfig{ID: "1"})
+	})
+	assert.NoError(t, err)
+
+	// B has not seen the tracepoint from A, the update must load it before applying the change
+	err = storeB.Update(ctx, orgB, func() error {
+		return orgB.AddTracepoint(&tp.TracePointConfig{ID: "2"})
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, orgB.Tracepoint("1"))
+
+	changed, err := storeA.Refresh(ctx, orgA)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.NotNil(t, orgA.Tracepoint("1"))
+	assert.NotNil(t, orgA.Tracepoint("2"))
+}
+
+// racingBackend writes a change from another replica before the first flush
+type racingBackend struct {
+	types.TPBackend
+	race func()
+}
+
+func (r *racingBackend) Flush(ctx context.Context, block types.TPBlock) error {
+	if r.race != nil {
+		r.race()
+		r.race = nil
+	}
+	return r.TPBackend.Flush(ctx, block)
+}
+
+func TestUpdateRetriesWhenBlockChanged(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	storeA := createStore(t, dir)
+	storeB := createStore(t, dir)
+
+	orgA, _ := storeA.ForOrg(ctx, "test-org")
+	orgB, _ := storeB.ForOrg(ctx, "test-org")
+
+	storeB.backend = &racingBackend{TPBackend: storeB.backend, race: func() {
+		_ = storeA.Update(ctx, orgA, func() error {
+			return orgA.AddTracepoint(&tp.TracePointConfig{ID: "1"})
+		})
+	}}
+
+	calls := 0
+	err := storeB.Update(ctx, orgB, func() error {
+		calls++
+		return orgB.AddTracepoint(&tp.TracePointConfig{ID: "2"})
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.NotNil(t, orgB.Tracepoint("1"))
+	assert.NotNil(t, orgB.Tracepoint("2"))
+
+	_, _ = storeA.Refresh(ctx, orgA)
+	assert.NotNil(t, orgA.Tracepoint("2"))
+}
+
+func TestRecordInstallStatus(t *testing.T) {
+	tpStore := createStore(t, "")
+
+	org, _ := tpStore.ForOrg(context.Background(), "test-org")
+
+	_ = org.AddTracepoint(&tp.TracePointConfig{ID: "1"})
+	_ = org.AddTracepoint(&tp.TracePointConfig{ID: "2"})
+
+	org.RecordInstallStatus("agent-1", []*deeppb.TracepointInstallStatus{
+		{TracepointId: "1", Installed: true},
+		{TracepointId: "2", Error: "file not found"},
+		// unknown tracepoints are ignored
+		{TracepointId: "3", Installed: true},
+	})
+	org.RecordInstallStatus("agent-2", []*deeppb.TracepointInstallStatus{{TracepointId: "1", Installed: true}})
+
+	resource, _ := org.forResource(nil)
+
+	// the status is only included if requested
+	response, _ := resource.ProcessRequest(&deeppb.LoadTracepointRequest{Request: &deeppb_poll.PollRequest{}})
+	assert.Nil(t, response.Status)
+
+	response, _ = resource.ProcessRequest(&deeppb.LoadTracepointRequest{Request: &deeppb_poll.PollRequest{}, IncludeStatus: true})
+	assert.Equal(t, map[string]*deeppb.TracepointInstallSummary{
+		"1": {Installed: 2},
+		"2": {Failed: 1, Errors: []string{"file not found"}},
+	}, response.Status)
+
+	// the agents install the tracepoint again after an update
+	_, err := org.UpdateTracepoint(&tp.TracePointConfig{ID: "2", LineNumber: 12}, 0)
+	assert.NoError(t, err)
+
+	response, _ = resource.ProcessRequest(&deeppb.LoadTracepointRequest{Request: &deeppb_poll.PollRequest{}, IncludeStatus: true})
+	assert.Equal(t, map[string]*deeppb.TracepointInstallSummary{"1": {Installed: 2}}, response.Status)
+
+	tpStore.PruneInstallStatus(time.Now().Add(time.Minute))
+
+	response, _ = resource.ProcessRequest(&deeppb.LoadTracepointRequest{Request: &deeppb_poll.PollRequest{}, IncludeStatus: true})
+	assert.Empty(t, response.Status)
+}
+
+func TestResourcesWithDifferentTypedValuesAreNotShared(t *testing.T) {
+	tpStore := createStore(t, "")
+
+	org, _ := tpStore.ForOrg(context.Background(), "test-org")
+
+	intResource := []*cp.KeyValue{{Key: "port", Value: &cp.AnyValue{Value: &cp.AnyValue_IntValue{IntValue: 8080}}}}
+	otherIntResource := []*cp.KeyValue{{Key: "port", Value: &cp.AnyValue{Value: &cp.AnyValue_IntValue{IntValue: 9090}}}}
+	stringResource := []*cp.KeyValue{{Key: "port", Value: &cp.AnyValue{Value: &cp.AnyValue_StringValue{StringValue: "8080"}}}}
+
+	first, _ := org.forResource(intResource)
+	second, _ := org.forResource(otherIntResource)
+	third, _ := org.forResource(stringResource)
+	again, _ := org.forResource(intResource)
+
+	assert.NotSame(t, first, second)
+	assert.NotSame(t, first, third)
+	assert.Same(t, first, again)
+}
@@ -20,20 +20,31 @@ package tracepoint
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	gkLog "github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/grafana/dskit/ring"
 	"github.com/grafana/dskit/services"
+	"github.com/intergral/deep/modules/overrides"
 	"github.com/intergral/deep/modules/storage"
 	tp_store "github.com/intergral/deep/modules/tracepoint/store"
+	"github.com/intergral/deep/modules/tracepoint/store/encoding/types"
+	v1 "github.com/intergral/deep/modules/tracepoint/store/encoding/v1"
+	"github.com/intergral/deep/modules/tracepoint/validation"
 	"github.com/intergral/deep/pkg/deeppb"
 	cp "github.com/intergral/deep/pkg/deeppb/common/v1"
+	tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
 	"github.com/intergral/deep/pkg/util"
 	"github.com/intergral/deep/pkg/util/log"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // ErrReadOnly is returned when we are shutting down
@@ -43,6 +54,27 @@ const (
 	tracepointRingKey = "tpRing"
 )
 
+var (
+	metricRetiredTracepoints = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "deep",
+		Subsystem: "tracepoint",
+		Name:      "retired_tracepoints_total",
+		Help:      "The total number of tracepoints removed as they have expired or received their max snapshots.",
+	}, []string{"tenant"})
+	metricAuditFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "deep",
+		Subsystem: "tracepoint",
+		Name:      "audit_failures_total",
+		Help:      "The total number of tracepoint changes that could not be recorded in the audit log.",
+	}, []string{"tenant"})
+	metricInstallFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "deep",
+		Subsystem: "tracepoint",
+		Name:      "install_failures_total",
+		Help:      "The total number of times an agent has reported that it could not install a tracepoint.",
+	}, []string{"tenant"})
+)
+
 type TPService struct {
 	services.Service
 	deeppb.UnimplementedTracepointConfigServiceServer
@@ -52,8 +84,13 @@ type TPService struct {
 	cfg        Config
 	lifecycler *ring.Lifecycler
 	store      *tp_store.TPStore
+	audit      *tp_store.AuditLog
+	overrides  *overrides.Overrides
+	validator  *validation.Registry
 	log        gkLog.Logger
 	readonly   bool
+	// stopped is closed when the service stops, to end any open watch streams
+	stopped chan struct{}
 }
 
 func (ts *TPService) Flush() {
@@ -68,16 +105,20 @@ func (ts *TPService) TransferOut(context.Context) error {
 }
 
 // New will create a new TPService that handles reading and writing tracepoint changes to disk
-func New(cfg Config, store storage.Store, logger gkLog.Logger, reg prometheus.Registerer) (*TPService, error) {
+func New(cfg Config, store storage.Store, overrides *overrides.Overrides, logger gkLog.Logger, reg prometheus.Registerer) (*TPService, error) {
 	newStore, err := tp_store.NewStore(store)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create new tracepoint store %w", err)
 	}
 
 	service := &TPService{
-		cfg:   cfg,
-		store: newStore,
-		log:   logger,
+		cfg:       cfg,
+		store:     newStore,
+		audit:     tp_store.NewAuditLog(store, store),
+		overrides: overrides,
+		validator: validation.NewRegistry(),
+		log:       logger,
+		stopped:   make(chan struct{}),
 	}
 
 	service.Service = services.NewBasicService(service.starting, service.running, service.stopping)
@@ -104,9 +145,55 @@ func (ts *TPService) starting(ctx context.Context) error {
 }
 
 func (ts *TPService) running(ctx context.Context) error {
-	select {
-	case <-ctx.Done():
-		return nil
+	ticker := time.NewTicker(ts.cfg.RetireInterval)
+	defer ticker.Stop()
+
+	reloadTicker := time.NewTicker(ts.cfg.ReloadInterval)
+	defer reloadTicker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ts.removeRetired(ctx)
+			ts.store.PruneInstallStatus(time.Now().Add(-ts.cfg.InstallStatusRetention))
+			ts.store.PruneAgents(time.Now().Add(-ts.cfg.AgentRetention))
+		case <-reloadTicker.C:
+			ts.reload(ctx)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// reload will reload the tracepoints of any tenant that has been changed by another replica
+func (ts *TPService) reload(ctx context.Context) {
+	reloaded, err := ts.store.RefreshAll(ctx)
+	for _, tenantID := range reloaded {
+		level.Debug(ts.log).Log("msg", "reloaded tracepoints changed by another replica", "tenant", tenantID)
+	}
+	if err != nil {
+		level.Error(ts.log).Log("msg", "error reloading tracepoints", "err", err)
+	}
+}
+
+// updateError converts an error from updating the store, a block that keeps being changed by other replicas is
+// reported as aborted so the caller can try again
+func updateError(err error) error {
+	if err == types.ErrBlockChanged {
+		return status.Error(codes.Aborted, "the tracepoints have been changed by another replica, try again")
+	}
+	return err
+}
+
+// removeRetired will remove all the tracepoints that have expired or received their max snapshots
+func (ts *TPService) removeRetired(ctx context.Context) {
+	removed, err := ts.store.RemoveRetired(ctx, time.Now())
+	for tenantID, tpIDs := range removed {
+		metricRetiredTracepoints.WithLabelValues(tenantID).Add(float64(len(tpIDs)))
+		level.Debug(ts.log).Log("msg", "retired tracepoints", "tenant", tenantID, "tracepoints", strings.Join(tpIDs, ","))
+	}
+	if err != nil {
+		level.Error(ts.log).Log("msg", "error flushing retired tracepoints", "err", err)
 	}
 }
 
@@ -116,6 +203,7 @@ func (ts *TPService) stopping(_ error) error {
 	}
 
 	ts.setReadOnly()
+	close(ts.stopped)
 	return nil
 }
 
@@ -145,6 +233,38 @@ func (ts *TPService) LoadTracepoints(ctx context.Context, req *deeppb.LoadTracep
 	return tpStore.ProcessRequest(req)
 }
 
+// WatchTracepoints will send a response each time the tracepoints for the tenant change, this is used by the
+// distributors to push changes to subscribed agents
+func (ts *TPService) WatchTracepoints(_ *deeppb.WatchTracepointsRequest, stream deeppb.TracepointConfigService_WatchTracepointsServer) error {
+	ctx := stream.Context()
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error extracting tenant id in Tracepoint.WatchTracepoints")
+	}
+
+	tpStore, err := ts.store.ForOrg(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	changes, cancel := tpStore.Watch()
+	defer cancel()
+
+	for {
+		select {
+		case <-changes:
+			err := stream.Send(&deeppb.WatchTracepointsResponse{TsNanos: uint64(time.Now().UnixNano())})
+			if err != nil {
+				return err
+			}
+		case <-ts.stopped:
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
 func (ts *TPService) CreateTracepoint(ctx context.Context, req *deeppb.CreateTracepointRequest) (*deeppb.CreateTracepointResponse, error) {
 	if ts.readonly {
 		return nil, ErrReadOnly
@@ -154,16 +274,106 @@ func (ts *TPService) CreateTracepoint(ctx context.Context, req *deeppb.CreateTra
 		return nil, errors.Wrap(err, "error extracting tenant id in Tracepoint.LoadTracepoints")
 	}
 
+	if err := ts.validator.Validate(req.Tracepoint); err != nil {
+		return nil, err
+	}
+	if err := v1.ValidateTargeting(req.Tracepoint); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	tpStore, err := ts.store.ForOrg(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	create := &deeppb.CreateTracepointRequest{
+		Tracepoint: req.Tracepoint,
+		Limits:     applyLimits(req.Tracepoint, req.Limits, time.Now()),
+		Labels:     req.Labels,
+		Paused:     req.Paused,
+	}
+
+	var metadata []*deeppb.TracepointMetadata
+	err = ts.store.Update(ctx, tpStore, func() error {
+		metadata, err = tpStore.AddTracepoints([]*deeppb.CreateTracepointRequest{create})
+		return err
+	})
+	if err != nil {
+		if err == types.ErrTracepointExists {
+			return nil, status.Errorf(codes.AlreadyExists, "tracepoint %s already exists", req.Tracepoint.ID)
+		}
+		return nil, updateError(err)
+	}
+
+	ts.recordAudit(ctx, tenantID, deeppb.TracepointAuditEvent_CREATE, req.Actor, req.Tracepoint.ID, nil, req.Tracepoint)
+
+	return &deeppb.CreateTracepointResponse{Tracepoint: req.Tracepoint, Metadata: metadata[0]}, nil
+}
+
+func (ts *TPService) UpdateTracepoint(ctx context.Context, req *deeppb.UpdateTracepointRequest) (*deeppb.UpdateTracepointResponse, error) {
+	if ts.readonly {
+		return nil, ErrReadOnly
+	}
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting tenant id in Tracepoint.UpdateTracepoint")
+	}
+
+	if req.Tracepoint == nil || req.Tracepoint.ID == "" {
+		return nil, status.Error(codes.InvalidArgument, "tracepoint ID is required to update a tracepoint")
+	}
+
+	if err := ts.validator.Validate(req.Tracepoint); err != nil {
+		return nil, err
+	}
+	if err := v1.ValidateTargeting(req.Tracepoint); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	tpStore, err := ts.store.ForOrg(ctx, tenantID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = tpStore.AddTracepoint(req.Tracepoint)
+	limits := applyLimits(req.Tracepoint, req.Limits, time.Now())
+
+	var before *tp.TracePointConfig
+	var metadata *deeppb.TracepointMetadata
+	err = ts.store.Update(ctx, tpStore, func() error {
+		before = tpStore.Tracepoint(req.Tracepoint.ID)
+		metadata, err = tpStore.UpdateTracepoint(req.Tracepoint, req.Version)
+		if err != nil {
+			return err
+		}
+
+		if limits != nil {
+			err = tpStore.SetLimits(req.Tracepoint.ID, limits)
+			if err != nil {
+				return err
+			}
+		}
+		if len(req.Labels) > 0 {
+			err = tpStore.SetLabels(req.Tracepoint.ID, req.Labels)
+			if err != nil {
+				return err
+			}
+		}
+		metadata = tpStore.Metadata(req.Tracepoint.ID)
+		return nil
+	})
+	if err != nil {
+		switch err {
+		case types.ErrTracepointNotFound:
+			return nil, status.Errorf(codes.NotFound, "tracepoint %s not found", req.Tracepoint.ID)
+		case types.ErrVersionConflict:
+			return nil, status.Errorf(codes.Aborted, "tracepoint %s has been modified, expected version %d", req.Tracepoint.ID, req.Version)
+		}
+		return nil, updateError(err)
+	}
 
-	err = ts.store.Flush(ctx, tpStore)
+	ts.recordAudit(ctx, tenantID, deeppb.TracepointAuditEvent_UPDATE, req.Actor, req.Tracepoint.ID, before, req.Tracepoint)
 
-	return &deeppb.CreateTracepointResponse{}, nil
+	return &deeppb.UpdateTracepointResponse{Tracepoint: req.Tracepoint, Metadata: metadata}, nil
 }
 
 func (ts *TPService) DeleteTracepoint(ctx context.Context, req *deeppb.DeleteTracepointRequest) (*deeppb.DeleteTracepointResponse, error) {
@@ -180,9 +390,373 @@ func (ts *TPService) DeleteTracepoint(ctx context.Context, req *deeppb.DeleteTra
 		return nil, err
 	}
 
-	err = tpStore.DeleteTracepoint(req.TracepointID)
+	var before *tp.TracePointConfig
+	err = ts.store.Update(ctx, tpStore, func() error {
+		before = tpStore.Tracepoint(req.TracepointID)
+		return tpStore.DeleteTracepoint(req.TracepointID)
+	})
+	if err != nil {
+		switch err {
+		case types.ErrTracepointNotFound:
+			return nil, status.Errorf(codes.NotFound, "tracepoint %s not found", req.TracepointID)
+		case types.ErrBlockChanged:
+			return nil, updateError(err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete tracepoint %s: %v", req.TracepointID, err)
+	}
 
-	err = ts.store.Flush(ctx, tpStore)
+	ts.recordAudit(ctx, tenantID, deeppb.TracepointAuditEvent_DELETE, req.Actor, req.TracepointID, before, nil)
 
 	return &deeppb.DeleteTracepointResponse{}, nil
 }
+
+// BulkCreateTracepoints will create all the tracepoints together, if any are not valid then none are created
+func (ts *TPService) BulkCreateTracepoints(ctx context.Context, req *deeppb.BulkCreateTracepointsRequest) (*deeppb.BulkCreateTracepointsResponse, error) {
+	if ts.readonly {
+		return nil, ErrReadOnly
+	}
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting tenant id in Tracepoint.BulkCreateTracepoints")
+	}
+
+	now := time.Now()
+	requests := make([]*deeppb.CreateTracepointRequest, len(req.Tracepoints))
+	for i, create := range req.Tracepoints {
+		if create.Tracepoint == nil || create.Tracepoint.ID == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "tracepoint %d: tracepoint ID is required", i)
+		}
+		if err := ts.validator.Validate(create.Tracepoint); err != nil {
+			if fieldErrors, ok := err.(validation.Errors); ok {
+				return nil, fieldErrors.WithPrefix(fmt.Sprintf("tracepoints[%d]", i))
+			}
+			return nil, err
+		}
+		if err := v1.ValidateTargeting(create.Tracepoint); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "tracepoint %d: %s", i, err)
+		}
+
+		requests[i] = &deeppb.CreateTracepointRequest{
+			Tracepoint: create.Tracepoint,
+			Limits:     applyLimits(create.Tracepoint, create.Limits, now),
+			Labels:     create.Labels,
+			Paused:     create.Paused,
+		}
+	}
+
+	tpStore, err := ts.store.ForOrg(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata []*deeppb.TracepointMetadata
+	err = ts.store.Update(ctx, tpStore, func() error {
+		metadata, err = tpStore.AddTracepoints(requests)
+		return err
+	})
+	if err != nil {
+		if err == types.ErrTracepointExists {
+			return nil, status.Error(codes.AlreadyExists, "tracepoint IDs must be unique and not already exist")
+		}
+		return nil, updateError(err)
+	}
+
+	response := &deeppb.BulkCreateTracepointsResponse{Tracepoints: make([]*deeppb.CreateTracepointResponse, len(requests))}
+	for i, create := range requests {
+		ts.recordAudit(ctx, tenantID, deeppb.TracepointAuditEvent_CREATE, req.Actor, create.Tracepoint.ID, nil, create.Tracepoint)
+		response.Tracepoints[i] = &deeppb.CreateTracepointResponse{Tracepoint: create.Tracepoint, Metadata: metadata[i]}
+	}
+
+	return response, nil
+}
+
+// BulkDeleteTracepoints will delete all the tracepoints that have the requested labels
+func (ts *TPService) BulkDeleteTracepoints(ctx context.Context, req *deeppb.BulkDeleteTracepointsRequest) (*deeppb.BulkDeleteTracepointsResponse, error) {
+	if ts.readonly {
+		return nil, ErrReadOnly
+	}
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting tenant id in Tracepoint.BulkDeleteTracepoints")
+	}
+
+	// we do not want an empty selector to remove every tracepoint
+	if len(req.Labels) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "labels are required to bulk delete tracepoints")
+	}
+
+	tpStore, err := ts.store.ForOrg(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []*tp.TracePointConfig
+	err = ts.store.Update(ctx, tpStore, func() error {
+		deleted = tpStore.DeleteTracepoints(req.Labels)
+		return nil
+	})
+	if err != nil {
+		return nil, updateError(err)
+	}
+
+	response := &deeppb.BulkDeleteTracepointsResponse{TracepointIDs: make([]string, len(deleted))}
+	for i, config := range deleted {
+		ts.recordAudit(ctx, tenantID, deeppb.TracepointAuditEvent_DELETE, req.Actor, config.ID, config, nil)
+		response.TracepointIDs[i] = config.ID
+	}
+
+	return response, nil
+}
+
+// SetTracepointsPaused will pause or resume all the tracepoints that have the requested labels
+func (ts *TPService) SetTracepointsPaused(ctx context.Context, req *deeppb.SetTracepointsPausedRequest) (*deeppb.SetTracepointsPausedResponse, error) {
+	if ts.readonly {
+		return nil, ErrReadOnly
+	}
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting tenant id in Tracepoint.SetTracepointsPaused")
+	}
+
+	if len(req.Labels) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "labels are required to pause or resume tracepoints")
+	}
+
+	tpStore, err := ts.store.ForOrg(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []*tp.TracePointConfig
+	err = ts.store.Update(ctx, tpStore, func() error {
+		changed = tpStore.SetPaused(req.Labels, req.Paused)
+		return nil
+	})
+	if err != nil {
+		return nil, updateError(err)
+	}
+
+	action := deeppb.TracepointAuditEvent_RESUME
+	if req.Paused {
+		action = deeppb.TracepointAuditEvent_PAUSE
+	}
+
+	response := &deeppb.SetTracepointsPausedResponse{TracepointIDs: make([]string, len(changed))}
+	for i, config := range changed {
+		ts.recordAudit(ctx, tenantID, action, req.Actor, config.ID, config, config)
+		response.TracepointIDs[i] = config.ID
+	}
+
+	return response, nil
+}
+
+// SetTracepointPaused will pause or resume a single tracepoint, paused tracepoints are kept but are not sent to the agents
+func (ts *TPService) SetTracepointPaused(ctx context.Context, req *deeppb.SetTracepointPausedRequest) (*deeppb.SetTracepointPausedResponse, error) {
+	if ts.readonly {
+		return nil, ErrReadOnly
+	}
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting tenant id in Tracepoint.SetTracepointPaused")
+	}
+
+	if req.TracepointID == "" {
+		return nil, status.Error(codes.InvalidArgument, "tracepoint ID is required to pause or resume a tracepoint")
+	}
+
+	tpStore, err := ts.store.ForOrg(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed bool
+	err = ts.store.Update(ctx, tpStore, func() error {
+		changed, err = tpStore.SetTracepointPaused(req.TracepointID, req.Paused)
+		return err
+	})
+	if err != nil {
+		if err == types.ErrTracepointNotFound {
+			return nil, status.Errorf(codes.NotFound, "tracepoint %s not found", req.TracepointID)
+		}
+		return nil, updateError(err)
+	}
+
+	config := tpStore.Tracepoint(req.TracepointID)
+	if changed {
+		action := deeppb.TracepointAuditEvent_RESUME
+		if req.Paused {
+			action = deeppb.TracepointAuditEvent_PAUSE
+		}
+		ts.recordAudit(ctx, tenantID, action, req.Actor, req.TracepointID, config, config)
+	}
+
+	return &deeppb.SetTracepointPausedResponse{Tracepoint: config, Metadata: tpStore.Metadata(req.TracepointID)}, nil
+}
+
+// TracepointHistory will return the audit events for the tracepoint, in the order they happened
+func (ts *TPService) TracepointHistory(ctx context.Context, req *deeppb.TracepointHistoryRequest) (*deeppb.TracepointHistoryResponse, error) {
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting tenant id in Tracepoint.TracepointHistory")
+	}
+
+	if req.TracepointID == "" {
+		return nil, status.Error(codes.InvalidArgument, "tracepoint ID is required to get the history of a tracepoint")
+	}
+
+	events, err := ts.audit.History(ctx, tenantID, req.TracepointID, time.Now(), ts.overrides.TracepointAuditRetention(tenantID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &deeppb.TracepointHistoryResponse{Events: events}, nil
+}
+
+// recordAudit will add the change to the audit log of the tenant. As the change has already been applied, any error is
+// logged rather than returned to the caller.
+func (ts *TPService) recordAudit(ctx context.Context, tenantID string, action deeppb.TracepointAuditEvent_ActionType, actor string, tpID string, before *tp.TracePointConfig, after *tp.TracePointConfig) {
+	event := &deeppb.TracepointAuditEvent{
+		TracepointID:   tpID,
+		Action:         action,
+		Actor:          actor,
+		TimestampNanos: uint64(time.Now().UnixNano()),
+		Before:         before,
+		After:          after,
+	}
+
+	err := ts.audit.Record(ctx, tenantID, event, ts.overrides.TracepointAuditRetention(tenantID))
+	if err != nil {
+		metricAuditFailures.WithLabelValues(tenantID).Inc()
+		level.Error(ts.log).Log("msg", "failed to record tracepoint audit event", "tenant", tenantID, "tracepoint", tpID, "action", action, "err", err)
+	}
+}
+
+// RecordSnapshots is called by the distributors to tell us how many snapshots have been received for each tracepoint
+func (ts *TPService) RecordSnapshots(ctx context.Context, req *deeppb.RecordSnapshotsRequest) (*deeppb.RecordSnapshotsResponse, error) {
+	if ts.readonly {
+		return nil, ErrReadOnly
+	}
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting tenant id in Tracepoint.RecordSnapshots")
+	}
+
+	tpStore, err := ts.store.ForOrg(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the counts are kept in memory, so only the removal of any retired tracepoints needs to be flushed
+	if len(tpStore.RecordSnapshots(req.Counts)) > 0 {
+		var retired []string
+		err = ts.store.Update(ctx, tpStore, func() error {
+			retired = tpStore.RemoveRetired(time.Now())
+			return nil
+		})
+		if err != nil {
+			return nil, updateError(err)
+		}
+		metricRetiredTracepoints.WithLabelValues(tenantID).Add(float64(len(retired)))
+	}
+
+	return &deeppb.RecordSnapshotsResponse{}, nil
+}
+
+// RecordInstallStatus is called by the distributors with the install status an agent has reported for its tracepoints
+func (ts *TPService) RecordInstallStatus(ctx context.Context, req *deeppb.RecordInstallStatusRequest) (*deeppb.RecordInstallStatusResponse, error) {
+	if ts.readonly {
+		return nil, ErrReadOnly
+	}
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting tenant id in Tracepoint.RecordInstallStatus")
+	}
+
+	tpStore, err := ts.store.ForOrg(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	tpStore.RecordInstallStatus(req.AgentID, req.Status)
+
+	for _, installStatus := range req.Status {
+		if !installStatus.Installed {
+			metricInstallFailures.WithLabelValues(tenantID).Inc()
+		}
+	}
+
+	return &deeppb.RecordInstallStatusResponse{}, nil
+}
+
+// RecordAgents is called by each distributor with the agents that have polled or subscribed to it
+func (ts *TPService) RecordAgents(ctx context.Context, req *deeppb.RecordAgentsRequest) (*deeppb.RecordAgentsResponse, error) {
+	if ts.readonly {
+		return nil, ErrReadOnly
+	}
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting tenant id in Tracepoint.RecordAgents")
+	}
+
+	tpStore, err := ts.store.ForOrg(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	tpStore.RecordAgents(req.Agents)
+
+	return &deeppb.RecordAgentsResponse{}, nil
+}
+
+// LoadAgents returns the agents reported by all the distributors
+func (ts *TPService) LoadAgents(ctx context.Context, _ *deeppb.LoadAgentsRequest) (*deeppb.LoadAgentsResponse, error) {
+	tenantID, err := util.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting tenant id in Tracepoint.LoadAgents")
+	}
+
+	tpStore, err := ts.store.ForOrg(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deeppb.LoadAgentsResponse{Agents: tpStore.Agents()}, nil
+}
+
+// applyLimits will convert the TTL of the limits into an expiry time. If a max snapshot count is set and the
+// tracepoint does not have a fire count, then the fire count is set so the agents can also enforce the limit. Any
+// rate limits are set as args, so the agents can throttle locally and the distributors can enforce them.
+func applyLimits(tracepoint *tp.TracePointConfig, limits *deeppb.TracepointLimits, now time.Time) *deeppb.TracepointLimits {
+	if limits == nil {
+		return nil
+	}
+
+	if limits.TTLSeconds != 0 {
+		limits.ExpiresAtNanos = uint64(now.Add(time.Duration(limits.TTLSeconds) * time.Second).UnixNano())
+	}
+
+	if limits.MaxSnapshots != 0 {
+		if tracepoint.Args == nil {
+			tracepoint.Args = map[string]string{}
+		}
+		if _, ok := tracepoint.Args[util.ArgFireCount]; !ok {
+			tracepoint.Args[util.ArgFireCount] = strconv.FormatUint(limits.MaxSnapshots, 10)
+		}
+	}
+
+	if limits.SnapshotsPerSecond != 0 {
+		if tracepoint.Args == nil {
+			tracepoint.Args = map[string]string{}
+		}
+		tracepoint.Args[util.ArgSnapshotRateLimit] = strconv.FormatFloat(limits.SnapshotsPerSecond, 'f', -1, 64)
+	}
+
+	if limits.BytesPerSecond != 0 {
+		if tracepoint.Args == nil {
+			tracepoint.Args = map[string]string{}
+		}
+		tracepoint.Args[util.ArgBytesRateLimit] = strconv.FormatUint(limits.BytesPerSecond, 10)
+	}
+
+	return limits
+}
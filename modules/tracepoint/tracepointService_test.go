@@ -0,0 +1,283 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package tracepoint
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/intergral/deep/modules/overrides"
+	"github.com/intergral/deep/modules/storage"
+	tp_store "github.com/intergral/deep/modules/tracepoint/store"
+	"github.com/intergral/deep/modules/tracepoint/validation"
+	"github.com/intergral/deep/pkg/deepdb"
+	"github.com/intergral/deep/pkg/deepdb/backend"
+	"github.com/intergral/deep/pkg/deepdb/backend/local"
+	"github.com/intergral/deep/pkg/deepdb/encoding"
+	"github.com/intergral/deep/pkg/deepdb/encoding/common"
+	"github.com/intergral/deep/pkg/deepdb/wal"
+	"github.com/intergral/deep/pkg/deeppb"
+	pb "github.com/intergral/deep/pkg/deeppb/poll/v1"
+	tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/intergral/deep/pkg/util"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newTestReplicas creates tracepoint services that share the same local backend, without joining a ring
+func newTestReplicas(t *testing.T, count int) []*TPService {
+	return newTestReplicasIn(t, t.TempDir(), count)
+}
+
+// newTestReplicasIn creates tracepoint services that share a local backend in the given directory
+func newTestReplicasIn(t *testing.T, dir string, count int) []*TPService {
+	o, err := overrides.NewOverrides(overrides.Limits{})
+	require.NoError(t, err)
+
+	replicas := make([]*TPService, count)
+	for i := range replicas {
+		store, err := storage.NewStore(storage.Config{
+			TracePoint: deepdb.Config{
+				Backend: "local",
+				Local: &local.Config{
+					Path: dir,
+				},
+				Block: &common.BlockConfig{
+					BloomFP:             0.01,
+					BloomShardSizeBytes: 100_000,
+					Version:             encoding.DefaultEncoding().Version(),
+					Encoding:            backend.EncLZ4_1M,
+				},
+				WAL: &wal.Config{
+					Filepath: dir,
+				},
+			},
+		}, nil)
+		require.NoError(t, err)
+
+		tpStore, err := tp_store.NewStore(store)
+		require.NoError(t, err)
+
+		replicas[i] = &TPService{
+			store:     tpStore,
+			audit:     tp_store.NewAuditLog(store, store),
+			overrides: o,
+			validator: validation.NewRegistry(),
+			log:       log.NewNopLogger(),
+			stopped:   make(chan struct{}),
+		}
+	}
+	return replicas
+}
+
+func loadTracepointIDs(ctx context.Context, t *testing.T, ts *TPService) []string {
+	response, err := ts.LoadTracepoints(ctx, &deeppb.LoadTracepointRequest{Request: &pb.PollRequest{}})
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(response.Response.Response))
+	for _, config := range response.Response.Response {
+		ids = append(ids, config.ID)
+	}
+	return ids
+}
+
+func TestReplicasConvergeOnReload(t *testing.T) {
+	ctx := util.InjectTenantID(context.Background(), "test-org")
+	replicas := newTestReplicas(t, 3)
+
+	// load the tenant on all the replicas, so they have a copy in memory
+	for _, replica := range replicas {
+		assert.Empty(t, loadTracepointIDs(ctx, t, replica))
+	}
+
+	_, err := replicas[0].CreateTracepoint(ctx, &deeppb.CreateTracepointRequest{Tracepoint: &tp.TracePointConfig{ID: "1", Path: "file.py", LineNumber: 10}})
+	require.NoError(t, err)
+
+	for _, replica := range replicas {
+		replica.reload(ctx)
+		assert.Equal(t, []string{"1"}, loadTracepointIDs(ctx, t, replica))
+	}
+
+	// a write on another replica is based on the latest tracepoints, so the first create is not lost
+	_, err = replicas[1].CreateTracepoint(ctx, &deeppb.CreateTracepointRequest{Tracepoint: &tp.TracePointConfig{ID: "2", Path: "file.py", LineNumber: 20}})
+	require.NoError(t, err)
+	_, err = replicas[2].DeleteTracepoint(ctx, &deeppb.DeleteTracepointRequest{TracepointID: "1"})
+	require.NoError(t, err)
+
+	for _, replica := range replicas {
+		replica.reload(ctx)
+		assert.Equal(t, []string{"2"}, loadTracepointIDs(ctx, t, replica))
+	}
+}
+
+func TestWatchIsNotifiedOfReload(t *testing.T) {
+	ctx := util.InjectTenantID(context.Background(), "test-org")
+	replicas := newTestReplicas(t, 2)
+
+	org, err := replicas[1].store.ForOrg(ctx, "test-org")
+	require.NoError(t, err)
+	changes, cancel := org.Watch()
+	defer cancel()
+
+	_, err = replicas[0].CreateTracepoint(ctx, &deeppb.CreateTracepointRequest{Tracepoint: &tp.TracePointConfig{ID: "1", Path: "file.py", LineNumber: 10}})
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(changes))
+
+	replicas[1].reload(ctx)
+	assert.Equal(t, 1, len(changes))
+}
+
+func TestCreateRejectsInvalidTracepoint(t *testing.T) {
+	ctx := util.InjectTenantID(context.Background(), "test-org")
+	ts := newTestReplicas(t, 1)[0]
+
+	_, err := ts.CreateTracepoint(ctx, &deeppb.CreateTracepointRequest{Tracepoint: &tp.TracePointConfig{
+		ID:      "1",
+		Args:    map[string]string{"condition": "a ==", "unknown": "value"},
+		Watches: []string{"len(a"},
+	}})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	fieldErrors, ok := validation.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, []string{"path", "line_number", "args.condition", "args.unknown", "watches[0]"}, fieldNames(fieldErrors))
+
+	assert.Empty(t, loadTracepointIDs(ctx, t, ts))
+}
+
+func TestBulkCreateRejectsInvalidTracepoint(t *testing.T) {
+	ctx := util.InjectTenantID(context.Background(), "test-org")
+	ts := newTestReplicas(t, 1)[0]
+
+	_, err := ts.BulkCreateTracepoints(ctx, &deeppb.BulkCreateTracepointsRequest{Tracepoints: []*deeppb.CreateTracepointRequest{
+		{Tracepoint: &tp.TracePointConfig{ID: "1", Path: "file.py", LineNumber: 10}},
+		{Tracepoint: &tp.TracePointConfig{ID: "2", Path: "file.py"}},
+	}})
+	require.Error(t, err)
+
+	fieldErrors, ok := validation.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, []string{"tracepoints[1].line_number"}, fieldNames(fieldErrors))
+
+	assert.Empty(t, loadTracepointIDs(ctx, t, ts))
+}
+
+func TestCreatePausedAndUpdateLabels(t *testing.T) {
+	ctx := util.InjectTenantID(context.Background(), "test-org")
+	ts := newTestReplicas(t, 1)[0]
+
+	created, err := ts.CreateTracepoint(ctx, &deeppb.CreateTracepointRequest{
+		Tracepoint: &tp.TracePointConfig{ID: "1", Path: "file.py", LineNumber: 10},
+		Labels:     map[string]string{"team": "payments"},
+		Paused:     true,
+	})
+	require.NoError(t, err)
+	assert.True(t, created.Metadata.Paused)
+	assert.Empty(t, loadTracepointIDs(ctx, t, ts))
+
+	updated, err := ts.UpdateTracepoint(ctx, &deeppb.UpdateTracepointRequest{
+		Tracepoint: &tp.TracePointConfig{ID: "1", Path: "file.py", LineNumber: 10},
+		Labels:     map[string]string{"team": "checkout"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "checkout"}, updated.Metadata.Labels)
+	assert.True(t, updated.Metadata.Paused)
+
+	// the labels are kept if none are sent
+	updated, err = ts.UpdateTracepoint(ctx, &deeppb.UpdateTracepointRequest{
+		Tracepoint: &tp.TracePointConfig{ID: "1", Path: "file.py", LineNumber: 20},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "checkout"}, updated.Metadata.Labels)
+}
+
+func TestDeleteTracepoint(t *testing.T) {
+	ctx := util.InjectTenantID(context.Background(), "test-org")
+	dir := t.TempDir()
+	ts := newTestReplicasIn(t, dir, 1)[0]
+
+	_, err := ts.CreateTracepoint(ctx, &deeppb.CreateTracepointRequest{Tracepoint: &tp.TracePointConfig{ID: "1", Path: "file.py", LineNumber: 10}})
+	require.NoError(t, err)
+
+	_, err = ts.DeleteTracepoint(ctx, &deeppb.DeleteTracepointRequest{TracepointID: "missing"})
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// replace the block with a directory, so it can no longer be read
+	blockPath := filepath.Join(dir, "test-org", "tracepoints")
+	require.NoError(t, os.Remove(blockPath))
+	require.NoError(t, os.Mkdir(blockPath, os.ModePerm))
+
+	_, err = ts.DeleteTracepoint(ctx, &deeppb.DeleteTracepointRequest{TracepointID: "1"})
+	require.Error(t, err)
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, []string{"1"}, loadTracepointIDs(ctx, t, ts))
+}
+
+func TestRecordInstallStatus(t *testing.T) {
+	ctx := util.InjectTenantID(context.Background(), "install-org")
+	ts := newTestReplicas(t, 1)[0]
+
+	_, err := ts.CreateTracepoint(ctx, &deeppb.CreateTracepointRequest{Tracepoint: &tp.TracePointConfig{ID: "1", Path: "file.py", LineNumber: 10}})
+	require.NoError(t, err)
+
+	_, err = ts.RecordInstallStatus(ctx, &deeppb.RecordInstallStatusRequest{AgentID: "agent-1", Status: []*deeppb.TracepointInstallStatus{{TracepointId: "1", Installed: true}}})
+	require.NoError(t, err)
+	_, err = ts.RecordInstallStatus(ctx, &deeppb.RecordInstallStatusRequest{AgentID: "agent-2", Status: []*deeppb.TracepointInstallStatus{{TracepointId: "1", Error: "file not found"}}})
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metricInstallFailures.WithLabelValues("install-org")))
+
+	response, err := ts.LoadTracepoints(ctx, &deeppb.LoadTracepointRequest{Request: &pb.PollRequest{}, IncludeStatus: true})
+	require.NoError(t, err)
+	assert.Equal(t, &deeppb.TracepointInstallSummary{Installed: 1, Failed: 1, Errors: []string{"file not found"}}, response.Status["1"])
+}
+
+func TestRecordAgents(t *testing.T) {
+	ctx := util.InjectTenantID(context.Background(), "test-org")
+	ts := newTestReplicas(t, 1)[0]
+
+	_, err := ts.RecordAgents(ctx, &deeppb.RecordAgentsRequest{Agents: []*deeppb.AgentInfo{{ID: "pod-2", LastSeenNanos: 10}, {ID: "pod-1", LastSeenNanos: 10}}})
+	require.NoError(t, err)
+	_, err = ts.RecordAgents(ctx, &deeppb.RecordAgentsRequest{Agents: []*deeppb.AgentInfo{{ID: "pod-1", LastSeenNanos: 20, AppliedHash: "abc"}}})
+	require.NoError(t, err)
+
+	response, err := ts.LoadAgents(ctx, &deeppb.LoadAgentsRequest{})
+	require.NoError(t, err)
+	require.Len(t, response.Agents, 2)
+	assert.Equal(t, "pod-1", response.Agents[0].ID)
+	assert.Equal(t, "abc", response.Agents[0].AppliedHash)
+
+	response, err = ts.LoadAgents(util.InjectTenantID(context.Background(), "other-org"), &deeppb.LoadAgentsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, response.Agents)
+}
+
+func fieldNames(fieldErrors validation.Errors) []string {
+	names := make([]string, len(fieldErrors))
+	for i, fieldError := range fieldErrors {
+		names[i] = fieldError.Field
+	}
+	return names
+}
@@ -0,0 +1,211 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// genericValidator only checks the structure that is shared by the expressions of every agent: strings must be
+// closed, brackets must be balanced and the expression cannot end with an operator.
+type genericValidator struct{}
+
+func (genericValidator) ValidateExpression(expression string) error {
+	return checkStructure(expression)
+}
+
+func (genericValidator) Args() []string {
+	return nil
+}
+
+// pythonValidator checks expressions are python expressions rather than statements
+type pythonValidator struct{}
+
+func (pythonValidator) ValidateExpression(expression string) error {
+	if err := checkStructure(expression); err != nil {
+		return err
+	}
+
+	code := stripStrings(expression)
+	if strings.Contains(code, "&&") || strings.Contains(code, "||") {
+		return fmt.Errorf("use 'and' and 'or' instead of '&&' and '||'")
+	}
+	if hasAssignment(code, ":") {
+		return fmt.Errorf("assignment is not allowed in an expression, use '==' to compare")
+	}
+	return nil
+}
+
+func (pythonValidator) Args() []string {
+	return nil
+}
+
+// javaValidator checks expressions do not contain assignments
+type javaValidator struct{}
+
+func (javaValidator) ValidateExpression(expression string) error {
+	if err := checkStructure(expression); err != nil {
+		return err
+	}
+
+	if hasAssignment(stripStrings(expression), "") {
+		return fmt.Errorf("assignment is not allowed in an expression, use '==' to compare")
+	}
+	return nil
+}
+
+func (javaValidator) Args() []string {
+	return nil
+}
+
+// validateLogMessage checks the expressions interpolated into the log message with {}
+func validateLogMessage(message string, validator Validator) error {
+	depth := 0
+	start := 0
+	for i, c := range message {
+		switch c {
+		case '{':
+			if depth == 0 {
+				start = i + 1
+			}
+			depth++
+		case '}':
+			if depth == 0 {
+				return fmt.Errorf("unexpected '}' at position %d", i)
+			}
+			depth--
+			if depth == 0 {
+				if err := validateExpression(message[start:i], validator); err != nil {
+					return fmt.Errorf("invalid expression {%s}: %w", message[start:i], err)
+				}
+			}
+		}
+	}
+	if depth != 0 {
+		return fmt.Errorf("unclosed '{' at position %d", start-1)
+	}
+	return nil
+}
+
+var closing = map[rune]rune{'(': ')', '[': ']', '{': '}'}
+
+// checkStructure checks the strings and brackets of the expression
+func checkStructure(expression string) error {
+	var stack []rune
+	var quote rune
+	escaped := false
+
+	for i, c := range expression {
+		if quote != 0 {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == quote:
+				quote = 0
+			}
+			continue
+		}
+
+		switch c {
+		case '"', '\'':
+			quote = c
+		case '(', '[', '{':
+			stack = append(stack, closing[c])
+		case ')', ']', '}':
+			if len(stack) == 0 || stack[len(stack)-1] != c {
+				return fmt.Errorf("unexpected '%c' at position %d", c, i)
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	if quote != 0 {
+		return fmt.Errorf("unterminated string")
+	}
+	if len(stack) != 0 {
+		return fmt.Errorf("missing '%c'", stack[len(stack)-1])
+	}
+
+	trimmed := strings.TrimSpace(expression)
+	if trimmed == "" {
+		return fmt.Errorf("expression is empty")
+	}
+	if strings.ContainsAny(trimmed[len(trimmed)-1:], "+-*/%=<>!&|.,") {
+		return fmt.Errorf("expression is incomplete")
+	}
+	return nil
+}
+
+// stripStrings replaces the contents of the string literals, so operators in strings are ignored
+func stripStrings(expression string) string {
+	var b strings.Builder
+	var quote rune
+	escaped := false
+
+	for _, c := range expression {
+		if quote != 0 {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == quote:
+				quote = 0
+				b.WriteRune(c)
+			}
+			continue
+		}
+		if c == '"' || c == '\'' {
+			quote = c
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// hasAssignment returns true if the code contains a single '=' that is not part of a comparison. The allowed runes
+// can also precede an '=', such as ':' for the python walrus operator.
+func hasAssignment(code string, allowed string) bool {
+	for i := 0; i < len(code); i++ {
+		if code[i] != '=' {
+			continue
+		}
+		if i+1 < len(code) && code[i+1] == '=' {
+			i++
+			continue
+		}
+		if i > 0 && strings.ContainsRune("=!<>"+allowed, rune(code[i-1])) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
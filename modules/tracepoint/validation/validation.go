@@ -0,0 +1,265 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package validation checks tracepoints before they are stored, so that agents are not sent tracepoints they cannot
+// install or evaluate.
+package validation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	deep_tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/intergral/deep/pkg/util"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// LanguageKey is the targeting key used to select the validator for a tracepoint
+	LanguageKey = "telemetry.sdk.language"
+
+	ArgCondition  = "condition"
+	ArgLogMessage = "log_msg"
+	ArgFirePeriod = "fire_period"
+	ArgSpan       = "span"
+	ArgSnapshot   = "snapshot"
+
+	// argMetricPrefix is the prefix of the args that define metrics, these are validated by the metrics generator
+	argMetricPrefix = "metric."
+)
+
+// commonArgs are the args supported by the agents of every language
+var commonArgs = map[string]struct{}{
+	ArgCondition:              {},
+	ArgLogMessage:             {},
+	ArgFirePeriod:             {},
+	ArgSpan:                   {},
+	ArgSnapshot:               {},
+	util.ArgFireCount:         {},
+	util.ArgSnapshotRateLimit: {},
+	util.ArgBytesRateLimit:    {},
+}
+
+// Validator checks the parts of a tracepoint that depend on the language of the agent
+type Validator interface {
+	// ValidateExpression returns an error if the agent cannot evaluate the expression
+	ValidateExpression(expression string) error
+	// Args returns the args supported by the agent, in addition to the args supported by every agent
+	Args() []string
+}
+
+// FieldError is a single invalid field of a tracepoint
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// Errors are the invalid fields of a tracepoint
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldError := range e {
+		messages[i] = fieldError.Error()
+	}
+	return "invalid tracepoint: " + strings.Join(messages, "; ")
+}
+
+// GRPCStatus returns the errors as an invalid argument status, with the fields as bad request details
+func (e Errors) GRPCStatus() *status.Status {
+	st := status.New(codes.InvalidArgument, e.Error())
+
+	details := &errdetails.BadRequest{}
+	for _, fieldError := range e {
+		details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       fieldError.Field,
+			Description: fieldError.Message,
+		})
+	}
+
+	withDetails, err := st.WithDetails(details)
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
+// WithPrefix returns the errors with the prefix added to each field, used to identify the tracepoint in bulk requests
+func (e Errors) WithPrefix(prefix string) Errors {
+	prefixed := make(Errors, len(e))
+	for i, fieldError := range e {
+		prefixed[i] = FieldError{Field: prefix + "." + fieldError.Field, Message: fieldError.Message}
+	}
+	return prefixed
+}
+
+// FromError returns the field errors from an error returned by Validate, including when it has been sent over grpc
+func FromError(err error) (Errors, bool) {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		return nil, false
+	}
+
+	var fieldErrors Errors
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.FieldViolations {
+				fieldErrors = append(fieldErrors, FieldError{Field: violation.Field, Message: violation.Description})
+			}
+		}
+	}
+
+	return fieldErrors, len(fieldErrors) > 0
+}
+
+// Registry holds the validators for each agent language
+type Registry struct {
+	mtx        sync.RWMutex
+	validators map[string]Validator
+	fallback   Validator
+}
+
+// NewRegistry returns a registry with the validators for the languages of the deep agents. Tracepoints that do not
+// target a registered language are checked with a validator that only accepts expressions every agent can parse.
+func NewRegistry() *Registry {
+	r := &Registry{
+		validators: map[string]Validator{},
+		fallback:   genericValidator{},
+	}
+	r.Register("python", pythonValidator{})
+	r.Register("java", javaValidator{})
+	return r
+}
+
+// Register sets the validator for the language, replacing any existing validator
+func (r *Registry) Register(language string, validator Validator) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.validators[strings.ToLower(language)] = validator
+}
+
+// validatorFor returns the validator for the language targeted by the tracepoint
+func (r *Registry) validatorFor(config *deep_tp.TracePointConfig) Validator {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	for _, kv := range config.Targeting {
+		if kv.Key != LanguageKey {
+			continue
+		}
+		if validator, ok := r.validators[strings.ToLower(kv.Value.GetStringValue())]; ok {
+			return validator
+		}
+	}
+	return r.fallback
+}
+
+// Validate checks the tracepoint, returning Errors with every invalid field
+func (r *Registry) Validate(config *deep_tp.TracePointConfig) error {
+	if config == nil {
+		return Errors{{Field: "tracepoint", Message: "is required"}}
+	}
+
+	validator := r.validatorFor(config)
+
+	var errs Errors
+	if strings.TrimSpace(config.Path) == "" {
+		errs = append(errs, FieldError{Field: "path", Message: "is required"})
+	}
+	if config.LineNumber == 0 {
+		errs = append(errs, FieldError{Field: "line_number", Message: "must be greater than 0"})
+	}
+
+	errs = append(errs, validateArgs(config.Args, validator)...)
+
+	for i, watch := range config.Watches {
+		if err := validateExpression(watch, validator); err != nil {
+			errs = append(errs, FieldError{Field: fmt.Sprintf("watches[%d]", i), Message: err.Error()})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func validateArgs(args map[string]string, validator Validator) Errors {
+	supported := map[string]struct{}{}
+	for _, arg := range validator.Args() {
+		supported[arg] = struct{}{}
+	}
+
+	var errs Errors
+	for _, key := range sortedKeys(args) {
+		value := args[key]
+		field := "args." + key
+
+		_, common := commonArgs[key]
+		_, language := supported[key]
+		if !common && !language && !strings.HasPrefix(key, argMetricPrefix) {
+			errs = append(errs, FieldError{Field: field, Message: "is not a supported arg"})
+			continue
+		}
+
+		var err error
+		switch key {
+		case ArgCondition:
+			err = validateExpression(value, validator)
+		case ArgLogMessage:
+			err = validateLogMessage(value, validator)
+		case util.ArgFireCount:
+			// -1 is used by the agents to fire without limit
+			if count, parseErr := strconv.Atoi(value); parseErr != nil || count < -1 || count == 0 {
+				err = fmt.Errorf("must be a positive integer or -1, got %q", value)
+			}
+		case ArgFirePeriod:
+			if period, parseErr := strconv.ParseUint(value, 10, 64); parseErr != nil || period == 0 {
+				err = fmt.Errorf("must be a positive number of milliseconds, got %q", value)
+			}
+		case util.ArgSnapshotRateLimit:
+			if limit, parseErr := strconv.ParseFloat(value, 64); parseErr != nil || limit <= 0 {
+				err = fmt.Errorf("must be a positive number, got %q", value)
+			}
+		case util.ArgBytesRateLimit:
+			if limit, parseErr := strconv.ParseUint(value, 10, 64); parseErr != nil || limit == 0 {
+				err = fmt.Errorf("must be a positive integer, got %q", value)
+			}
+		}
+		if err != nil {
+			errs = append(errs, FieldError{Field: field, Message: err.Error()})
+		}
+	}
+	return errs
+}
+
+// validateExpression checks the expression is not empty before passing it to the validator
+func validateExpression(expression string, validator Validator) error {
+	if strings.TrimSpace(expression) == "" {
+		return fmt.Errorf("expression is empty")
+	}
+	return validator.ValidateExpression(expression)
+}
@@ -0,0 +1,168 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	cp "github.com/intergral/deep/pkg/deeppb/common/v1"
+	deep_tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func tracepoint(language string, args map[string]string, watches ...string) *deep_tp.TracePointConfig {
+	config := &deep_tp.TracePointConfig{ID: "1", Path: "file.py", LineNumber: 10, Args: args, Watches: watches}
+	if language != "" {
+		config.Targeting = []*cp.KeyValue{{Key: LanguageKey, Value: &cp.AnyValue{Value: &cp.AnyValue_StringValue{StringValue: language}}}}
+	}
+	return config
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *deep_tp.TracePointConfig
+		errors Errors
+	}{
+		{
+			name:   "valid",
+			config: tracepoint("", map[string]string{"condition": "a == 'b)'", "fire_count": "-1", "log_msg": "value is {a[0]}", "metric.count.type": "counter"}, "a.b", "len(c)"),
+		},
+		{
+			name:   "missing path and line",
+			config: &deep_tp.TracePointConfig{Path: " "},
+			errors: Errors{{Field: "path", Message: "is required"}, {Field: "line_number", Message: "must be greater than 0"}},
+		},
+		{
+			name:   "unknown arg",
+			config: tracepoint("", map[string]string{"fire_cuont": "1"}),
+			errors: Errors{{Field: "args.fire_cuont", Message: "is not a supported arg"}},
+		},
+		{
+			name: "invalid numbers",
+			config: tracepoint("", map[string]string{
+				"fire_count":                "0",
+				"fire_period":               "soon",
+				"snapshot_rate_limit":       "-1",
+				"snapshot_bytes_rate_limit": "1.5",
+			}),
+			errors: Errors{
+				{Field: "args.fire_count", Message: `must be a positive integer or -1, got "0"`},
+				{Field: "args.fire_period", Message: `must be a positive number of milliseconds, got "soon"`},
+				{Field: "args.snapshot_bytes_rate_limit", Message: `must be a positive integer, got "1.5"`},
+				{Field: "args.snapshot_rate_limit", Message: `must be a positive number, got "-1"`},
+			},
+		},
+		{
+			name:   "invalid condition",
+			config: tracepoint("", map[string]string{"condition": "len(a"}),
+			errors: Errors{{Field: "args.condition", Message: "missing ')'"}},
+		},
+		{
+			name:   "invalid log message",
+			config: tracepoint("", map[string]string{"log_msg": "value is {a"}),
+			errors: Errors{{Field: "args.log_msg", Message: "unclosed '{' at position 9"}},
+		},
+		{
+			name:   "invalid log message expression",
+			config: tracepoint("", map[string]string{"log_msg": "value is {}"}),
+			errors: Errors{{Field: "args.log_msg", Message: "invalid expression {}: expression is empty"}},
+		},
+		{
+			name:   "invalid watches",
+			config: tracepoint("", nil, "a.b", "", "'abc", "a +"),
+			errors: Errors{
+				{Field: "watches[1]", Message: "expression is empty"},
+				{Field: "watches[2]", Message: "unterminated string"},
+				{Field: "watches[3]", Message: "expression is incomplete"},
+			},
+		},
+		{
+			name:   "python operators",
+			config: tracepoint("python", map[string]string{"condition": "a && b"}, "x = 1", "(y := 2)", "a != 'x = y'"),
+			errors: Errors{
+				{Field: "args.condition", Message: "use 'and' and 'or' instead of '&&' and '||'"},
+				{Field: "watches[0]", Message: "assignment is not allowed in an expression, use '==' to compare"},
+			},
+		},
+		{
+			name:   "java",
+			config: tracepoint("Java", map[string]string{"condition": "a >= 1 && b != null"}, "x += 1"),
+			errors: Errors{{Field: "watches[0]", Message: "assignment is not allowed in an expression, use '==' to compare"}},
+		},
+	}
+
+	registry := NewRegistry()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := registry.Validate(tt.config)
+			if tt.errors == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, tt.errors, err)
+		})
+	}
+}
+
+type rubyValidator struct{}
+
+func (rubyValidator) ValidateExpression(expression string) error {
+	if expression == "nil?" {
+		return nil
+	}
+	return fmt.Errorf("not ruby")
+}
+
+func (rubyValidator) Args() []string {
+	return []string{"gem"}
+}
+
+func TestRegister(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("ruby", rubyValidator{})
+
+	assert.NoError(t, registry.Validate(tracepoint("ruby", map[string]string{"gem": "rails", "condition": "nil?"})))
+	assert.Equal(t, Errors{{Field: "args.condition", Message: "not ruby"}}, registry.Validate(tracepoint("ruby", map[string]string{"condition": "a"})))
+
+	// the language args are not supported by the other agents
+	assert.Equal(t, Errors{{Field: "args.gem", Message: "is not a supported arg"}}, registry.Validate(tracepoint("python", map[string]string{"gem": "rails"})))
+}
+
+func TestFromError(t *testing.T) {
+	errs := Errors{{Field: "path", Message: "is required"}}
+
+	st := status.Convert(errs.WithPrefix("tracepoints[1]"))
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "invalid tracepoint: tracepoints[1].path: is required", st.Message())
+
+	// the details are kept when the status is sent over grpc
+	fieldErrors, ok := FromError(st.Err())
+	require.True(t, ok)
+	assert.Equal(t, Errors{{Field: "tracepoints[1].path", Message: "is required"}}, fieldErrors)
+
+	_, ok = FromError(status.Error(codes.InvalidArgument, "invalid targeting expression"))
+	assert.False(t, ok)
+	_, ok = FromError(errors.New("failed"))
+	assert.False(t, ok)
+}
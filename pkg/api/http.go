@@ -39,8 +39,15 @@ import (
 )
 
 const (
-	URLParamSnapshotID   = "snapshotID"
-	URLParamTracepointID = "tpID"
+	URLParamSnapshotID      = "snapshotID"
+	URLParamTracepointID    = "tpID"
+	URLParamTracepointState = "state"
+	URLParamTraceID         = "traceID"
+	// agents
+	URLParamTracepoint = "tracepoint"
+	// snapshot diff
+	urlParamSnapshotA = "a"
+	urlParamSnapshotB = "b"
 	// search
 	urlParamQuery       = "q"
 	urlParamTags        = "tags"
@@ -49,6 +56,11 @@ const (
 	urlParamLimit       = "limit"
 	urlParamStart       = "start"
 	urlParamEnd         = "end"
+	urlParamStep        = "step"
+	// export
+	urlParamFormat = "format"
+	// trace snapshots
+	urlParamSpanID = "spanID"
 
 	// backend search (querier/serverless)
 	urlParamStartPage     = "startPage"
@@ -73,19 +85,29 @@ const (
 	PathPrefixQuerier = "/querier"
 
 	PathSnapshots       = "/api/snapshots/{snapshotID}"
+	PathSnapshotDiff    = "/api/snapshots/diff"
 	PathSearch          = "/api/search"
 	PathSearchTags      = "/api/search/tags"
+	PathSearchTail      = "/api/search/tail"
+	PathSearchExport    = "/api/search/export"
 	PathSearchTagValues = "/api/search/tag/{tagName}/values"
+	PathTraceSnapshots  = "/api/traces/{traceID}/snapshots"
 	PathEcho            = "/api/echo"
 	PathUsageStats      = "/status/usage-stats"
 
 	PathPrefixTracepoints = "/tracepoints"
 
-	PathTracepoints      = "/api/tracepoints"
-	PathDeleteTracepoint = "/api/tracepoints/{tpID}"
+	PathTracepoints          = "/api/tracepoints"
+	PathDeleteTracepoint     = "/api/tracepoints/{tpID}"
+	PathTracepointHistory    = "/api/tracepoints/{tpID}/history"
+	PathTracepointState      = "/api/tracepoints/{tpID}/{state:enable|disable}"
+	PathBulkTracepoints      = "/api/tracepoints/bulk"
+	PathBulkTracepointsState = "/api/tracepoints/bulk/{state}"
 
 	PathSearchTagValuesV2 = "/api/v2/search/tag/{tagName}/values"
 
+	PathAgents = "/api/agents"
+
 	QueryModeKey       = "mode"
 	QueryModeIngesters = "ingesters"
 	QueryModeBlocks    = "blocks"
@@ -93,6 +115,9 @@ const (
 	BlockStartKey      = "blockStart"
 	BlockEndKey        = "blockEnd"
 
+	ExportFormatNDJSON  = "ndjson"
+	ExportFormatParquet = "parquet"
+
 	defaultLimit = 20
 )
 
@@ -111,6 +136,29 @@ func ParseSnapshotID(r *http.Request) ([]byte, error) {
 	return byteID, nil
 }
 
+// ParseSnapshotDiffRequest returns the ids of the two snapshots to compare
+func ParseSnapshotDiffRequest(r *http.Request) ([]byte, []byte, error) {
+	a, ok := extractQueryParam(r, urlParamSnapshotA)
+	if !ok {
+		return nil, nil, fmt.Errorf("please provide snapshot a")
+	}
+	b, ok := extractQueryParam(r, urlParamSnapshotB)
+	if !ok {
+		return nil, nil, fmt.Errorf("please provide snapshot b")
+	}
+
+	aID, err := util.HexStringToSnapshotID(a)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid snapshot a: %w", err)
+	}
+	bID, err := util.HexStringToSnapshotID(b)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid snapshot b: %w", err)
+	}
+
+	return aID, bID, nil
+}
+
 // ParseSearchRequest takes an http.Request and decodes query params to create a deeppb.SearchRequest
 func ParseSearchRequest(r *http.Request) (*deeppb.SearchRequest, error) {
 	req := &deeppb.SearchRequest{
@@ -134,14 +182,26 @@ func ParseSearchRequest(r *http.Request) (*deeppb.SearchRequest, error) {
 		req.End = uint32(end)
 	}
 
+	if s, ok := extractQueryParam(r, urlParamStep); ok {
+		step, err := parseStep(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid step: %w", err)
+		}
+		req.Step = step
+	}
+
 	query, queryFound := extractQueryParam(r, urlParamQuery)
 	if queryFound {
 		// TODO hacky fix: we don't validate {} since this isn't handled correctly yet
 		if query != "{}" {
-			_, err := deepql.Parse(query)
+			rootExpr, err := deepql.Parse(query)
 			if err != nil {
 				return nil, fmt.Errorf("invalid DeepQL query: %w", err)
 			}
+			// metrics queries build a series over the requested range, so they require one
+			if rootExpr.Metrics != nil && req.End == 0 {
+				return nil, errors.New("invalid DeepQL query: range functions require start and end")
+			}
 		}
 		req.Query = query
 	}
@@ -182,7 +242,7 @@ func ParseSearchRequest(r *http.Request) (*deeppb.SearchRequest, error) {
 		// As Grafana gets updated and/or versions using this get old we can remove this section.
 		for k, v := range r.URL.Query() {
 			// Skip reserved keywords
-			if k == urlParamQuery || k == urlParamTags || k == urlParamMinDuration || k == urlParamMaxDuration || k == urlParamLimit {
+			if k == urlParamQuery || k == urlParamTags || k == urlParamMinDuration || k == urlParamMaxDuration || k == urlParamLimit || k == urlParamStep {
 				continue
 			}
 
@@ -235,6 +295,92 @@ func ParseSearchRequest(r *http.Request) (*deeppb.SearchRequest, error) {
 	return req, nil
 }
 
+// ParseTailRequest takes an http.Request and decodes query params to create a deeppb.TailSnapshotsRequest
+func ParseTailRequest(r *http.Request) (*deeppb.TailSnapshotsRequest, error) {
+	query, ok := extractQueryParam(r, urlParamQuery)
+	if !ok {
+		return nil, errors.New("query required")
+	}
+
+	return &deeppb.TailSnapshotsRequest{Query: query}, nil
+}
+
+// ParseExportRequest parses the search to export and the format to write the snapshots in. Unlike a search, an
+// export requires start and end, and the limit is left at 0 when it is not set, so the frontend can apply its own.
+func ParseExportRequest(r *http.Request) (*deeppb.SearchRequest, string, error) {
+	searchReq, err := ParseSearchRequest(r)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if searchReq.Start == 0 || searchReq.End == 0 {
+		return nil, "", errors.New("start and end required")
+	}
+
+	if searchReq.Query != "" && searchReq.Query != "{}" {
+		rootExpr, err := deepql.Parse(searchReq.Query)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid DeepQL query: %w", err)
+		}
+		if rootExpr.Metrics != nil {
+			return nil, "", errors.New("invalid DeepQL query: range functions can not be exported")
+		}
+	}
+
+	if _, ok := extractQueryParam(r, urlParamLimit); !ok {
+		searchReq.Limit = 0
+	}
+
+	format := ExportFormatNDJSON
+	if s, ok := extractQueryParam(r, urlParamFormat); ok {
+		switch s {
+		case ExportFormatNDJSON, ExportFormatParquet:
+			format = s
+		default:
+			return nil, "", fmt.Errorf("invalid format: %s, must be one of %s or %s", s, ExportFormatNDJSON, ExportFormatParquet)
+		}
+	}
+
+	return searchReq, format, nil
+}
+
+// ParseTraceSnapshotsRequest returns the search for the snapshots taken in a trace, or in a single span of the trace
+// when spanID is set. The IDs are normalized so 64 and 128 bit IDs match the indexed form.
+func ParseTraceSnapshotsRequest(r *http.Request) (*deeppb.SearchRequest, error) {
+	traceID, ok := mux.Vars(r)[URLParamTraceID]
+	if !ok {
+		return nil, errors.New("please provide a traceID")
+	}
+	traceID, err := util.NormalizeTraceID(traceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := extractQueryParam(r, urlParamQuery); ok {
+		return nil, errors.New("q is not supported, the query is built from the trace and span ID")
+	}
+	if _, ok := extractQueryParam(r, urlParamTags); ok {
+		return nil, errors.New("tags is not supported, the query is built from the trace and span ID")
+	}
+
+	searchReq, err := ParseSearchRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	searchReq.Tags = map[string]string{}
+	searchReq.Query = fmt.Sprintf("{ %s = %q }", deepql.IntrinsicTraceID, traceID)
+
+	if spanID, ok := extractQueryParam(r, urlParamSpanID); ok {
+		spanID, err = util.NormalizeSpanID(spanID)
+		if err != nil {
+			return nil, err
+		}
+		searchReq.Query = fmt.Sprintf("{ %s = %q && %s = %q }", deepql.IntrinsicTraceID, traceID, deepql.IntrinsicSpanID, spanID)
+	}
+
+	return searchReq, nil
+}
+
 // ParseSearchBlockRequest parses all http parameters necessary to perform a block search.
 func ParseSearchBlockRequest(r *http.Request) (*deeppb.SearchBlockRequest, error) {
 	searchReq, err := ParseSearchRequest(r)
@@ -346,6 +492,7 @@ func BuildIngesterSearchRequest(req *http.Request, searchReq *deeppb.SearchReque
 	q := request.URL.Query()
 	q.Set(urlParamStart, strconv.FormatUint(uint64(start), 10))
 	q.Set(urlParamEnd, strconv.FormatUint(uint64(end), 10))
+	request.URL.RawQuery = q.Encode()
 
 	return request, nil
 }
@@ -376,6 +523,10 @@ func BuildSearchRequest(req *http.Request, searchReq *deeppb.SearchRequest) (*ht
 		q.Set(urlParamMinDuration, strconv.FormatUint(uint64(searchReq.MinDurationMs), 10)+"ms")
 	}
 
+	if searchReq.Step != 0 {
+		q.Set(urlParamStep, strconv.FormatUint(uint64(searchReq.Step), 10))
+	}
+
 	if len(searchReq.Query) > 0 {
 		q.Set(urlParamQuery, searchReq.Query)
 	}
@@ -466,6 +617,25 @@ func extractQueryParam(r *http.Request, param string) (string, bool) {
 	return value, value != ""
 }
 
+// parseStep accepts the step as a number of seconds, as sent by Grafana, or as a duration e.g. 30s
+func parseStep(s string) (uint32, error) {
+	if seconds, err := strconv.ParseFloat(s, 64); err == nil {
+		if seconds < 1 {
+			return 0, errors.New("must be at least 1s")
+		}
+		return uint32(seconds), nil
+	}
+
+	dur, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if dur < time.Second {
+		return 0, errors.New("must be at least 1s")
+	}
+	return uint32(dur.Seconds()), nil
+}
+
 // ValidateAndSanitizeRequest validates params for snapshot by id api
 // return values are (blockStart, blockEnd, queryMode, start, end, error)
 func ValidateAndSanitizeRequest(r *http.Request) (string, string, string, int64, int64, error) {
@@ -24,7 +24,9 @@ import (
 	"net/url"
 	"testing"
 
+	"github.com/gorilla/mux"
 	"github.com/intergral/deep/pkg/deeppb"
+	"github.com/intergral/deep/pkg/util"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -113,6 +115,28 @@ func TestQuerierParseSearchRequest(t *testing.T) {
 			urlQuery: "q=" + url.QueryEscape(`{ .foo="bar" `),
 			err:      "invalid DeepQL query: parse error at line 1, col 14: syntax error: unexpected $end",
 		},
+		{
+			name:     "deepql metrics query with step",
+			urlQuery: "q=" + url.QueryEscape(`count_over_time({ .foo="bar" }[5m]) by(.service)`) + "&start=10&end=20&step=30s",
+			expected: &deeppb.SearchRequest{
+				Query: `count_over_time({ .foo="bar" }[5m]) by(.service)`,
+				Tags:  map[string]string{},
+				Start: 10,
+				End:   20,
+				Step:  30,
+				Limit: defaultLimit,
+			},
+		},
+		{
+			name:     "deepql metrics query without range",
+			urlQuery: "q=" + url.QueryEscape(`rate({ .foo="bar" }[5m])`),
+			err:      "invalid DeepQL query: range functions require start and end",
+		},
+		{
+			name:     "invalid step",
+			urlQuery: "step=500ms",
+			err:      "invalid step: must be at least 1s",
+		},
 		{
 			name:     "deepql query and tags",
 			urlQuery: "q=" + url.QueryEscape(`{ .foo="bar" }`) + "&tags=" + url.QueryEscape("service.name=foo"),
@@ -556,6 +580,24 @@ func TestBuildSearchRequest(t *testing.T) {
 	}
 }
 
+func TestBuildIngesterSearchRequest(t *testing.T) {
+	req := &deeppb.SearchRequest{
+		Start: 10,
+		End:   100,
+		Limit: 50,
+	}
+
+	// the ingesters are only searched for part of the range, so the start and end of the request are replaced
+	actualURL, err := BuildIngesterSearchRequest(nil, req, 60, 90)
+	assert.NoError(t, err)
+	assert.Equal(t, "?end=90&limit=50&start=60", actualURL.URL.String())
+
+	// without a search request, the http request is not changed
+	actualURL, err = BuildIngesterSearchRequest(nil, nil, 60, 90)
+	assert.NoError(t, err)
+	assert.Nil(t, actualURL)
+}
+
 func TestAddServerlessParams(t *testing.T) {
 	actualURL := AddServerlessParams(nil, 10)
 	assert.Equal(t, "?maxBytes=10", actualURL.URL.String())
@@ -582,3 +624,82 @@ func TestExtractServerlessParam(t *testing.T) {
 	_, err = ExtractServerlessParams(r)
 	assert.Error(t, err)
 }
+
+func TestParseTailRequest(t *testing.T) {
+	r := httptest.NewRequest("GET", "http://example.com/api/search/tail", nil)
+	_, err := ParseTailRequest(r)
+	assert.Error(t, err)
+
+	r = httptest.NewRequest("GET", "http://example.com/api/search/tail?q="+url.QueryEscape(`{ .foo = "bar" }`), nil)
+	req, err := ParseTailRequest(r)
+	require.NoError(t, err)
+	assert.Equal(t, `{ .foo = "bar" }`, req.Query)
+}
+
+func TestParseSnapshotDiffRequest(t *testing.T) {
+	for _, query := range []string{"", "a=1234", "b=1234", "a=zz&b=1234", "a=1234&b=zz"} {
+		r := httptest.NewRequest("GET", "http://example.com/api/snapshots/diff?"+query, nil)
+		_, _, err := ParseSnapshotDiffRequest(r)
+		assert.Error(t, err, query)
+	}
+
+	r := httptest.NewRequest("GET", "http://example.com/api/snapshots/diff?a=1234&b=abcd", nil)
+	a, b, err := ParseSnapshotDiffRequest(r)
+	require.NoError(t, err)
+	assert.Equal(t, "1234", util.SnapshotIDToHexString(a))
+	assert.Equal(t, "abcd", util.SnapshotIDToHexString(b))
+}
+
+func TestParseExportRequest(t *testing.T) {
+	for _, query := range []string{
+		"",
+		"start=10",
+		"start=10&end=20&format=csv",
+		"start=10&end=20&q=" + url.QueryEscape(`count_over_time({}[1m])`),
+	} {
+		r := httptest.NewRequest("GET", "http://example.com/api/search/export?"+query, nil)
+		_, _, err := ParseExportRequest(r)
+		assert.Error(t, err, query)
+	}
+
+	r := httptest.NewRequest("GET", "http://example.com/api/search/export?start=10&end=20&q="+url.QueryEscape(`{ .foo = "bar" }`), nil)
+	req, format, err := ParseExportRequest(r)
+	require.NoError(t, err)
+	assert.Equal(t, ExportFormatNDJSON, format)
+	assert.Equal(t, uint32(0), req.Limit)
+	assert.Equal(t, `{ .foo = "bar" }`, req.Query)
+
+	r = httptest.NewRequest("GET", "http://example.com/api/search/export?start=10&end=20&limit=5&format=parquet", nil)
+	req, format, err = ParseExportRequest(r)
+	require.NoError(t, err)
+	assert.Equal(t, ExportFormatParquet, format)
+	assert.Equal(t, uint32(5), req.Limit)
+}
+
+func TestParseTraceSnapshotsRequest(t *testing.T) {
+	traceSnapshotsRequest := func(traceID, query string) *http.Request {
+		r := httptest.NewRequest("GET", "http://example.com/api/traces/"+traceID+"/snapshots?"+query, nil)
+		return mux.SetURLVars(r, map[string]string{URLParamTraceID: traceID})
+	}
+
+	for _, tc := range []struct{ traceID, query string }{
+		{traceID: "not-hex"},
+		{traceID: "1234", query: "spanID=zz"},
+		{traceID: "1234", query: "q=" + url.QueryEscape("{}")},
+		{traceID: "1234", query: "tags=foo%3Dbar"},
+	} {
+		_, err := ParseTraceSnapshotsRequest(traceSnapshotsRequest(tc.traceID, tc.query))
+		assert.Error(t, err, tc)
+	}
+
+	req, err := ParseTraceSnapshotsRequest(traceSnapshotsRequest("ABCDEF", "start=10&end=20&limit=5"))
+	require.NoError(t, err)
+	assert.Equal(t, `{ traceID = "00000000000000000000000000abcdef" }`, req.Query)
+	assert.Equal(t, uint32(10), req.Start)
+	assert.Equal(t, uint32(20), req.End)
+	assert.Equal(t, uint32(5), req.Limit)
+
+	req, err = ParseTraceSnapshotsRequest(traceSnapshotsRequest("abcdef", "spanID=12"))
+	require.NoError(t, err)
+	assert.Equal(t, `{ traceID = "00000000000000000000000000abcdef" && spanID = "0000000000000012" }`, req.Query)
+}
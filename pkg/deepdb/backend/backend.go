@@ -52,6 +52,8 @@ type Writer interface {
 	WriteTenantIndex(ctx context.Context, tenantID string, meta []*BlockMeta, compactedMeta []*CompactedBlockMeta) error
 	// WriteTracepointBlock writes the tracepoint block for the given tenantID
 	WriteTracepointBlock(ctx context.Context, tenantID string, data *bytes.Reader, size int64) error
+	// WriteTracepointAudit writes the tracepoint audit log for the given tenantID
+	WriteTracepointAudit(ctx context.Context, tenantID string, data *bytes.Reader, size int64) error
 }
 
 // Reader is a collection of methods to read data from deepdb backends
@@ -76,6 +78,8 @@ type Reader interface {
 	Shutdown()
 	// ReadTracepointBlock reads the tracepoint block for the given tenantID
 	ReadTracepointBlock(ctx context.Context, tenantID string) (io.ReadCloser, int64, error)
+	// ReadTracepointAudit reads the tracepoint audit log for the given tenantID
+	ReadTracepointAudit(ctx context.Context, tenantID string) (io.ReadCloser, int64, error)
 }
 
 // Compactor is a collection of methods to interact with compacted elements of a deepdb block
@@ -47,6 +47,8 @@ type BlockMeta struct {
 	DataEncoding    string    `json:"dataEncoding"`    // DataEncoding is a string provided externally, but tracked by deepdb that indicates the way the bytes are encoded
 	BloomShardCount uint16    `json:"bloomShards"`     // Number of bloom filter shards
 	FooterSize      uint32    `json:"footerSize"`      // Size of data file footer (parquet)
+
+	TraceBloomShardCount uint16 `json:"traceBloomShards,omitempty"` // Number of trace id bloom filter shards, 0 for blocks written without them
 }
 
 func NewBlockMeta(tenantID string, blockID uuid.UUID, version string, encoding Encoding, dataEncoding string) *BlockMeta {
@@ -125,6 +125,11 @@ func (m *MockReader) ReadTracepointBlock(ctx context.Context, name string) (io.R
 	panic("implement me")
 }
 
+func (m *MockReader) ReadTracepointAudit(ctx context.Context, name string) (io.ReadCloser, int64, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
 func (m *MockReader) Tenants(ctx context.Context) ([]string, error) {
 	return m.T, nil
 }
@@ -183,6 +188,10 @@ func (m *MockWriter) WriteTracepointBlock(ctx context.Context, name string, data
 	return nil
 }
 
+func (m *MockWriter) WriteTracepointAudit(ctx context.Context, name string, data *bytes.Reader, size int64) error {
+	return nil
+}
+
 func (m *MockWriter) Write(ctx context.Context, name string, blockID uuid.UUID, tenantID string, buffer []byte, shouldCache bool) error {
 	return nil
 }
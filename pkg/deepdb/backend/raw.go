@@ -79,6 +79,10 @@ func (w *writer) WriteTracepointBlock(ctx context.Context, tenantID string, data
 	return w.w.Write(ctx, "tracepoints", []string{tenantID}, data, size, false)
 }
 
+func (w *writer) WriteTracepointAudit(ctx context.Context, tenantID string, data *bytes.Reader, size int64) error {
+	return w.w.Write(ctx, "tracepoints_audit", []string{tenantID}, data, size, false)
+}
+
 func (w *writer) Write(ctx context.Context, name string, blockID uuid.UUID, tenantID string, buffer []byte, shouldCache bool) error {
 	return w.w.Write(ctx, name, KeyPathForBlock(blockID, tenantID), bytes.NewReader(buffer), int64(len(buffer)), shouldCache)
 }
@@ -138,6 +142,10 @@ func (r *reader) ReadTracepointBlock(ctx context.Context, tenantID string) (io.R
 	return r.r.Read(ctx, "tracepoints", []string{tenantID}, false)
 }
 
+func (r *reader) ReadTracepointAudit(ctx context.Context, tenantID string) (io.ReadCloser, int64, error) {
+	return r.r.Read(ctx, "tracepoints_audit", []string{tenantID}, false)
+}
+
 func (r *reader) Read(ctx context.Context, name string, blockID uuid.UUID, tenantID string, shouldCache bool) ([]byte, error) {
 	objReader, size, err := r.r.Read(ctx, name, KeyPathForBlock(blockID, tenantID), shouldCache)
 	if err != nil {
@@ -91,6 +91,7 @@ var (
 
 type TracepointWriter interface {
 	WriteTracepointBlock(ctx context.Context, tenantID string, reader *bytes.Reader, size int64) error
+	WriteTracepointAudit(ctx context.Context, tenantID string, reader *bytes.Reader, size int64) error
 }
 
 type Writer interface {
@@ -104,6 +105,7 @@ type IterateObjectCallback func(id common.ID, obj []byte) bool
 
 type TracepointReader interface {
 	ReadTracepointBlock(ctx context.Context, tenantID string) (io.ReadCloser, int64, error)
+	ReadTracepointAudit(ctx context.Context, tenantID string) (io.ReadCloser, int64, error)
 }
 
 type Reader interface {
@@ -236,6 +238,10 @@ func (rw *readerWriter) WriteTracepointBlock(ctx context.Context, tenantID strin
 	return rw.tw.WriteTracepointBlock(ctx, tenantID, data, size)
 }
 
+func (rw *readerWriter) WriteTracepointAudit(ctx context.Context, tenantID string, data *bytes.Reader, size int64) error {
+	return rw.tw.WriteTracepointAudit(ctx, tenantID, data, size)
+}
+
 func (rw *readerWriter) WriteBlock(ctx context.Context, c WriteableBlock) error {
 	w := rw.getWriterForBlock(c.BlockMeta(), time.Now())
 	return c.Write(ctx, w)
@@ -307,6 +313,10 @@ func (rw *readerWriter) ReadTracepointBlock(ctx context.Context, tenantID string
 	return rw.tr.ReadTracepointBlock(ctx, tenantID)
 }
 
+func (rw *readerWriter) ReadTracepointAudit(ctx context.Context, tenantID string) (io.ReadCloser, int64, error) {
+	return rw.tr.ReadTracepointAudit(ctx, tenantID)
+}
+
 func (rw *readerWriter) FindSnapshot(ctx context.Context, tenantID string, id common.ID, blockStart string, blockEnd string, timeStart int64, timeEnd int64) (*deepTP.Snapshot, []error, error) {
 	// tracing instrumentation
 	logger := log.WithContext(ctx, log.Logger)
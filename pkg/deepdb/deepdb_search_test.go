@@ -139,7 +139,7 @@ func testAdvancedDeepQLCompleteBlock(t *testing.T, blockVersion string) {
 			fmt.Sprintf(".name=`%v`", test.RandomString()),
 			fmt.Sprintf("duration>%dh", rand.Intn(10)+1),
 		}
-		totalSpans := 0
+		totalSnapshots := meta.TotalObjects
 		trueAttrC, falseAttrC := conditionsForAttributes(wantedSnapshot.Attributes, "")
 		falseConditions = append(falseConditions, falseAttrC...)
 		trueConditions = append(trueConditions, trueAttrC)
@@ -169,11 +169,13 @@ func testAdvancedDeepQLCompleteBlock(t *testing.T, blockVersion string) {
 			{Query: fmt.Sprintf("({%s} | count() > 0) && ({%s} | count() > 0)", rando(trueConditions[0]), rando(trueConditions[1]))},
 			{Query: fmt.Sprintf("({%s} | count() > 0) || ({%s} | count() > 0)", rando(trueConditions[0]), rando(falseConditions))},
 			// counts
-			{Query: fmt.Sprintf("{} | count() = %d", totalSpans)},
-			{Query: fmt.Sprintf("{} | count() != %d", totalSpans+1)},
-			{Query: fmt.Sprintf("{ true } && { true } | count() = %d", totalSpans)},
-			{Query: fmt.Sprintf("{ true } || { true } | count() = %d", totalSpans)},
-			{Query: fmt.Sprintf("{ %s && %s } | count() = 1", rando(trueConditions[0]), rando(trueConditions[0]))},
+			{Query: fmt.Sprintf("{} | count() = %d", totalSnapshots)},
+			{Query: fmt.Sprintf("{} | count() != %d", totalSnapshots+1)},
+			{Query: fmt.Sprintf("{ true } && { true } | count() = %d", totalSnapshots)},
+			{Query: fmt.Sprintf("{ true } || { true } | count() = %d", totalSnapshots)},
+			{Query: fmt.Sprintf("{ %s && %s && duration = %dns } | count() = 1", rando(trueConditions[0]), rando(trueConditions[0]), wantedSnapshot.DurationNanos)},
+			// groups
+			{Query: fmt.Sprintf("{} | by(resource.service.name) | coalesce() | count() = %d", totalSnapshots)},
 			// avgs/min/max/sum
 			//{Query: fmt.Sprintf("{ %s && %s } && { %s && %s } | avg(duration) = %dns",
 			//	rando(trueConditions[0]), rando(trueConditions[0]),
@@ -202,6 +204,9 @@ func testAdvancedDeepQLCompleteBlock(t *testing.T, blockVersion string) {
 			//// conditions
 			{Query: fmt.Sprintf("{%s && %s}", rando(trueConditions[0]), rando(falseConditions))},
 			{Query: fmt.Sprintf("{%s || %s}", rando(falseConditions), rando(falseConditions))},
+			// counts
+			{Query: fmt.Sprintf("{} | count() > %d", totalSnapshots)},
+			{Query: fmt.Sprintf("{} | by(.%s) | count() > %d", wantedSnapshot.Attributes[0].Key, totalSnapshots)},
 			//{Query: fmt.Sprintf("{%s && (%s || %s)}", rando(falseConditions), rando(falseConditions), rando(trueConditions[0]))},
 			//// snapshots
 			//{Query: fmt.Sprintf("{%s} && {%s}", rando(trueConditions[0]), rando(falseConditions))},
@@ -232,7 +237,7 @@ func testAdvancedDeepQLCompleteBlock(t *testing.T, blockVersion string) {
 				return r.Fetch(ctx, meta, req, common.DefaultSearchOptions())
 			})
 
-			res, err := e.Execute(ctx, req, fetcher)
+			res, err := executeAndEvaluate(ctx, e, req, fetcher)
 			require.NoError(t, err, "search request: %+v", req)
 			actual := actualForExpectedMeta(wantMeta, res)
 			require.NotNil(t, actual, "search request: %v", req)
@@ -244,13 +249,36 @@ func testAdvancedDeepQLCompleteBlock(t *testing.T, blockVersion string) {
 				return r.Fetch(ctx, meta, req, common.DefaultSearchOptions())
 			})
 
-			res, err := e.Execute(ctx, req, fetcher)
+			res, err := executeAndEvaluate(ctx, e, req, fetcher)
 			require.NoError(t, err, "search request: %+v", req)
 			require.Nil(t, actualForExpectedMeta(wantMeta, res), "search request: %v", req)
 		}
+
+		// grouped snapshots expose the values they are grouped by
+		fetcher := deepql.NewSnapshotResultFetcherWrapper(func(ctx context.Context, req deepql.FetchSnapshotRequest) (deepql.FetchSnapshotResponse, error) {
+			return r.Fetch(ctx, meta, req, common.DefaultSearchOptions())
+		})
+		groupBy := wantedSnapshot.Attributes[0]
+		req := &deeppb.SearchRequest{Query: fmt.Sprintf("{ %s } | by(.%s) | count() >= 1", rando(trueConditions[0]), groupBy.Key)}
+		res, err := executeAndEvaluate(ctx, e, req, fetcher)
+		require.NoError(t, err, "search request: %+v", req)
+		actual := actualForExpectedMeta(wantMeta, res)
+		require.NotNil(t, actual, "search request: %v", req)
+		require.Equal(t, map[string]string{groupBy.Key: groupBy.GetValue().GetStringValue()}, actual.Group, "search request: %v", req)
 	})
 }
 
+// executeAndEvaluate searches the block, then evaluates the pipeline as it is once the results of all the blocks are
+// combined
+func executeAndEvaluate(ctx context.Context, e *deepql.Engine, req *deeppb.SearchRequest, fetcher deepql.SnapshotResultFetcher) (*deeppb.SearchResponse, error) {
+	res, err := e.Execute(ctx, req, fetcher)
+	if err != nil {
+		return nil, err
+	}
+	res.Snapshots, err = deepql.EvaluatePipeline(req, res.Snapshots)
+	return res, err
+}
+
 func conditionsForAttributes(atts []*v1_common.KeyValue, scope string) ([]string, []string) {
 	trueConditions := []string{}
 	falseConditions := []string{}
@@ -31,9 +31,16 @@ const (
 	NameIndex = "index"
 	// nameBloomPrefix is the prefix used to build the bloom shards
 	nameBloomPrefix = "bloom-"
+	// nameTraceBloomPrefix is the prefix used to build the bloom shards of the trace ids
+	nameTraceBloomPrefix = "bloom-trace-"
 )
 
 // BloomName returns the backend bloom name for the given shard
 func BloomName(shard int) string {
 	return nameBloomPrefix + strconv.Itoa(shard)
 }
+
+// TraceBloomName returns the backend trace id bloom name for the given shard
+func TraceBloomName(shard int) string {
+	return nameTraceBloomPrefix + strconv.Itoa(shard)
+}
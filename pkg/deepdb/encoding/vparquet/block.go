@@ -66,6 +66,20 @@ func (b *backendBlock) Fetch(ctx context.Context, req deepql.FetchSnapshotReques
 		return deepql.FetchSnapshotResponse{}, errors.Wrap(err, "conditions invalid")
 	}
 
+	// if every snapshot has to be in a trace that is not in the bloom, then the block can be skipped
+	if traceID, ok := traceIDCondition(req); ok {
+		found, err := b.checkTraceBloom(ctx, traceID)
+		if err != nil {
+			return deepql.FetchSnapshotResponse{}, err
+		}
+		if !found {
+			return deepql.FetchSnapshotResponse{
+				Results: &mergeSnapshotIterator{},
+				Bytes:   func() uint64 { return 0 },
+			}, nil
+		}
+	}
+
 	pf, rr, err := b.openForSearch(ctx, opts)
 	if err != nil {
 		return deepql.FetchSnapshotResponse{}, err
@@ -82,6 +96,24 @@ func (b *backendBlock) Fetch(ctx context.Context, req deepql.FetchSnapshotReques
 	}, nil
 }
 
+// traceIDCondition returns the trace id that all snapshots matching the request must have, if there is one.
+func traceIDCondition(req deepql.FetchSnapshotRequest) (string, bool) {
+	if !req.AllConditions && len(req.Conditions) > 1 {
+		return "", false
+	}
+
+	for _, cond := range req.Conditions {
+		if cond.Attribute.Intrinsic != deepql.IntrinsicTraceID || cond.Op != deepql.OpEqual || len(cond.Operands) != 1 {
+			continue
+		}
+		if cond.Operands[0].Type == deepql.TypeString {
+			return cond.Operands[0].S, true
+		}
+	}
+
+	return "", false
+}
+
 func checkConditions(conditions []deepql.Condition) error {
 	for _, cond := range conditions {
 		opCount := len(cond.Operands)
@@ -96,7 +128,7 @@ func checkConditions(conditions []deepql.Condition) error {
 		case deepql.OpEqual, deepql.OpNotEqual,
 			deepql.OpGreater, deepql.OpGreaterEqual,
 			deepql.OpLess, deepql.OpLessEqual,
-			deepql.OpRegex:
+			deepql.OpRegex, deepql.OpNotRegex:
 			if opCount != 1 {
 				return fmt.Errorf("operation %v must have exactly 1 argument. condition: %+v", cond.Op, cond)
 			}
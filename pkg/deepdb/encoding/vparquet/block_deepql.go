@@ -19,6 +19,8 @@ package vparquet
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/intergral/deep/pkg/deepdb/encoding/common"
 	"github.com/intergral/deep/pkg/deepql"
@@ -32,7 +34,16 @@ var intrinsicColumnLookups = map[deepql.Intrinsic]struct {
 	typ        deepql.StaticType
 	columnPath string
 }{
-	deepql.IntrinsicDuration: {deepql.AttributeScopeSnapshot, deepql.TypeDuration, ""},
+	deepql.IntrinsicDuration:   {deepql.AttributeScopeSnapshot, deepql.TypeDuration, columnPathDurationNanos},
+	deepql.IntrinsicTracepoint: {deepql.AttributeScopeSnapshot, deepql.TypeString, columnPathTracepointID},
+	deepql.IntrinsicPath:       {deepql.AttributeScopeSnapshot, deepql.TypeString, columnPathTracepointFile},
+	deepql.IntrinsicLine:       {deepql.AttributeScopeSnapshot, deepql.TypeInt, columnPathTracepointLine},
+	deepql.IntrinsicMethod:     {deepql.AttributeScopeSnapshot, deepql.TypeString, columnPathFrameMethodName},
+	deepql.IntrinsicClass:      {deepql.AttributeScopeSnapshot, deepql.TypeString, columnPathFrameClassName},
+	deepql.IntrinsicLog:        {deepql.AttributeScopeSnapshot, deepql.TypeString, columnPathLogMsg},
+	deepql.IntrinsicFrames:     {deepql.AttributeScopeSnapshot, deepql.TypeInt, columnPathFrameMethodName},
+	deepql.IntrinsicTraceID:    {deepql.AttributeScopeSnapshot, deepql.TypeString, columnPathTraceID},
+	deepql.IntrinsicSpanID:     {deepql.AttributeScopeSnapshot, deepql.TypeString, columnPathSpanID},
 }
 
 // Lookup table of all well-known attributes with dedicated columns
@@ -58,11 +69,17 @@ const (
 	columnPathSnapshotIDText    = "IDText"
 	columnPathStartTimeUnixNano = "TsNanos"
 	columnPathDurationNanos     = "DurationNanos"
+	columnPathLogMsg            = "LogMsg"
+	columnPathTraceID           = "TraceID"
+	columnPathSpanID            = "SpanID"
 
 	columnPathTracepointID   = "tp.ID"
 	columnPathTracepointFile = "tp.Path"
 	columnPathTracepointLine = "tp.LineNumber"
 
+	columnPathFrameMethodName = "Frames.MethodName"
+	columnPathFrameClassName  = "Frames.ClassName"
+
 	columnPathAttrKey         = "attr.Key"
 	columnPathAttrValueString = "attr.Value"
 	columnPathAttrValueInt    = "attr.ValueInt"
@@ -92,10 +109,16 @@ type makeIterFn func(columnName string, predicate parquetquery.Predicate, select
 
 func fetch(ctx context.Context, req deepql.FetchSnapshotRequest, pf *parquet.File, opts common.SearchOptions) (*snapshotMetadataIterator, error) {
 	var (
+		timeIterator        parquetquery.Iterator
 		conditionIterators  []parquetquery.Iterator
 		attributeConditions []deepql.Condition
+		capturedConditions  []deepql.Condition
+		capturedIterators   []parquetquery.Iterator
+		fetchAttributes     []string
+		fetchIterators      []parquetquery.Iterator
 		columnPredicates    = map[string][]parquetquery.Predicate{}
 		columnSelectAs      = map[string]string{}
+		missingColumn       bool
 	)
 
 	rgs := rowGroupsFromFile(pf, opts)
@@ -103,16 +126,16 @@ func fetch(ctx context.Context, req deepql.FetchSnapshotRequest, pf *parquet.Fil
 
 	// add start time filter
 	if req.StartTimeUnixNanos > 0 && req.EndTimeUnixNanos > 0 {
-		conditionIterators = append(conditionIterators, makeIter(columnPathStartTimeUnixNano, parquetquery.NewIntBetweenPredicate(int64(req.StartTimeUnixNanos), int64(req.EndTimeUnixNanos)), columnPathStartTimeUnixNano))
+		timeIterator = makeIter(columnPathStartTimeUnixNano, parquetquery.NewIntBetweenPredicate(int64(req.StartTimeUnixNanos), int64(req.EndTimeUnixNanos)), columnPathStartTimeUnixNano)
 	}
 
 	if len(req.Conditions) == 0 {
 		// we have no conditions so iterate the ids
-		if len(conditionIterators) == 0 {
-			return createSnapshotMetaIterator(makeIter, makeIter(columnPathSnapshotID, nil, ""))
+		if timeIterator == nil {
+			return createSnapshotMetaIterator(makeIter, makeIter(columnPathSnapshotID, nil, ""), nil)
 		}
 		// we are a time only request
-		return createSnapshotMetaIterator(makeIter, conditionIterators[0])
+		return createSnapshotMetaIterator(makeIter, timeIterator, nil)
 	}
 
 	addPredicate := func(columnPath string, p parquetquery.Predicate) {
@@ -120,13 +143,40 @@ func fetch(ctx context.Context, req deepql.FetchSnapshotRequest, pf *parquet.Fil
 	}
 
 	for _, condition := range req.Conditions {
-		if condition.Attribute.Intrinsic == deepql.IntrinsicDuration {
+		if deepql.IsCapturedAttribute(condition.Attribute) {
+			capturedConditions = append(capturedConditions, condition)
+			if condition.Op != deepql.OpNone {
+				capturedIterators = append(capturedIterators, createCapturedIterator(makeIter, condition))
+			}
+			continue
+		}
 
-			predicate, err := createIntPredicate(condition.Op, condition.Operands)
+		if condition.Attribute.Intrinsic != deepql.IntrinsicNone {
+			// blocks written before the intrinsic was added do not have its column, so none of their snapshots
+			// have a value for it and the condition cannot match
+			if entry, ok := intrinsicColumnLookups[condition.Attribute.Intrinsic]; ok && !hasColumn(pf, entry.columnPath) {
+				if condition.Op == deepql.OpNone {
+					continue
+				}
+				if req.AllConditions {
+					return newSnapshotMetadataIterator(&rowNumberIterator{}, nil), nil
+				}
+				missingColumn = true
+				continue
+			}
+			iter, err := createIntrinsicIterator(makeIter, condition)
 			if err != nil {
 				return nil, err
 			}
-			conditionIterators = append(conditionIterators, makeIter(columnPathDurationNanos, predicate, columnPathDurationNanos))
+			if iter == nil {
+				continue
+			}
+			if condition.Op == deepql.OpNone {
+				// the intrinsic is only needed by the engine, e.g. by(method), so fetch the value without filtering
+				fetchIterators = append(fetchIterators, iter)
+			} else {
+				conditionIterators = append(conditionIterators, iter)
+			}
 		} else {
 			if entry, ok := wellKnownColumnLookups[condition.Attribute.Name]; ok {
 				if condition.Op == deepql.OpNone {
@@ -145,12 +195,20 @@ func fetch(ctx context.Context, req deepql.FetchSnapshotRequest, pf *parquet.Fil
 					columnSelectAs[entry.columnPath] = condition.Attribute.Name
 					continue
 				}
+			} else if condition.Op == deepql.OpNone {
+				// the attribute is only needed by the engine, e.g. by(.foo), so fetch the value without filtering
+				fetchAttributes = append(fetchAttributes, condition.Attribute.Name)
 			} else {
 				attributeConditions = append(attributeConditions, condition)
 			}
 		}
 	}
 
+	// the only conditions that could match need columns the block does not have
+	if missingColumn && len(conditionIterators)+len(columnPredicates)+len(attributeConditions)+len(capturedIterators) == 0 {
+		return newSnapshotMetadataIterator(&rowNumberIterator{}, nil), nil
+	}
+
 	for columnPath, predicates := range columnPredicates {
 		conditionIterators = append(conditionIterators, makeIter(columnPath, parquetquery.NewOrPredicate(predicates...), columnSelectAs[columnPath]))
 	}
@@ -167,16 +225,120 @@ func fetch(ctx context.Context, req deepql.FetchSnapshotRequest, pf *parquet.Fil
 		conditionIterators = append(conditionIterators, parquetquery.NewUnionIterator(DefinitionLevelSnapshot, []parquetquery.Iterator{attrIter, resIter}, nil))
 	}
 
-	if !req.AllConditions {
-		conditionIterators = []parquetquery.Iterator{parquetquery.NewUnionIterator(DefinitionLevelSnapshot, conditionIterators, nil)}
+	if req.AllConditions {
+		conditionIterators = append(conditionIterators, capturedIterators...)
+	} else if len(conditionIterators)+len(capturedIterators) > 0 {
+		if len(conditionIterators) > 0 && len(capturedConditions) > 0 {
+			conditionIterators = []parquetquery.Iterator{parquetquery.NewJoinIterator(DefinitionLevelSnapshot, []parquetquery.Iterator{
+				parquetquery.NewUnionIterator(DefinitionLevelSnapshot, conditionIterators, nil),
+			}, &conditionMatchedMarker{})}
+		}
+		conditionIterators = []parquetquery.Iterator{parquetquery.NewUnionIterator(DefinitionLevelSnapshot, append(conditionIterators, capturedIterators...), nil)}
 	}
 
-	iterator := parquetquery.NewJoinIterator(DefinitionLevelSnapshot, conditionIterators, nil)
+	// all conditions only fetch attribute values
+	if len(conditionIterators) == 0 {
+		conditionIterators = append(conditionIterators, makeIter(columnPathSnapshotID, nil, ""))
+	}
 
-	return createSnapshotMetaIterator(makeIter, iterator)
+	// the time range always applies, regardless of how the conditions are combined
+	if timeIterator != nil {
+		conditionIterators = append(conditionIterators, timeIterator)
+	}
+
+	var iterator parquetquery.Iterator = parquetquery.NewJoinIterator(DefinitionLevelSnapshot, conditionIterators, nil)
+
+	if len(fetchAttributes) > 0 {
+		fetchIterators = append(fetchIterators,
+			createAttributeCollectorIterator(makeIter, fetchAttributes, deepql.AttributeScopeSnapshot, DefinitionLevelSnapshotAttrs, columnPathAttrKey, columnPathAttrValueString, columnPathAttrValueInt, columnPathAttrValueDouble, columnPathAttrValueBool),
+			createAttributeCollectorIterator(makeIter, fetchAttributes, deepql.AttributeScopeResource, DefinitionLevelResourceAttrs, columnPathResourceAttrKey, columnPathResourceAttrValueString, columnPathResourceAttrValueInt, columnPathResourceAttrValueDouble, columnPathResourceAttrValueBool),
+		)
+	}
+
+	if len(fetchIterators) > 0 {
+		iterator = parquetquery.NewLeftJoinIterator(DefinitionLevelSnapshot, []parquetquery.Iterator{iterator}, fetchIterators, nil)
+	}
+
+	var captured *capturedEvaluator
+	if len(capturedConditions) > 0 {
+		captured = newCapturedEvaluator(pf, opts, capturedConditions, req.AllConditions)
+	}
+
+	return createSnapshotMetaIterator(makeIter, iterator, captured)
 }
 
-func createSnapshotMetaIterator(makeIter makeIterFn, snapshotIter parquetquery.Iterator) (*snapshotMetadataIterator, error) {
+// hasColumn returns true if the parquet file has the column
+func hasColumn(pf *parquet.File, columnPath string) bool {
+	index, _ := parquetquery.GetColumnIndexByPath(pf, columnPath)
+	return index != -1
+}
+
+// createIntrinsicIterator returns an iterator that filters the snapshots by the condition on the intrinsic. The
+// values are collected into the OtherEntries of the result by intrinsicCollector. Nil is returned if there is nothing
+// to filter and the intrinsic is always returned with the snapshot.
+func createIntrinsicIterator(makeIter makeIterFn, condition deepql.Condition) (parquetquery.Iterator, error) {
+	intrinsic := condition.Attribute.Intrinsic
+	entry, ok := intrinsicColumnLookups[intrinsic]
+	if !ok {
+		return nil, fmt.Errorf("intrinsic not supported: %s", intrinsic)
+	}
+
+	var predicate parquetquery.Predicate
+	var err error
+	if entry.typ == deepql.TypeString {
+		predicate, err = createStringPredicate(condition.Op, condition.Operands)
+	} else {
+		predicate, err = createIntPredicate(condition.Op, condition.Operands)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "creating intrinsic predicate")
+	}
+
+	switch intrinsic {
+	case deepql.IntrinsicDuration, deepql.IntrinsicPath, deepql.IntrinsicLine:
+		if predicate == nil {
+			return nil, nil
+		}
+	case deepql.IntrinsicFrames:
+		// there is no column for the number of frames, so count the values of a required frame column
+		return parquetquery.NewJoinIterator(DefinitionLevelSnapshot, []parquetquery.Iterator{makeIter(entry.columnPath, nil, intrinsic.String())}, &frameCounter{
+			attribute: condition.Attribute,
+			predicate: predicate,
+		}), nil
+	case deepql.IntrinsicMethod, deepql.IntrinsicClass:
+		// only the frame the tracepoint fired in is matched
+		return parquetquery.NewJoinIterator(DefinitionLevelSnapshotFrames, []parquetquery.Iterator{makeIter(entry.columnPath, predicate, intrinsic.String())}, &intrinsicCollector{
+			attribute:  condition.Attribute,
+			typ:        entry.typ,
+			firstFrame: true,
+		}), nil
+	}
+
+	return parquetquery.NewJoinIterator(DefinitionLevelSnapshot, []parquetquery.Iterator{makeIter(entry.columnPath, predicate, intrinsic.String())}, &intrinsicCollector{
+		attribute: condition.Attribute,
+		typ:       entry.typ,
+	}), nil
+}
+
+// createAttributeCollectorIterator returns the values of the named attributes without filtering the snapshots.
+// The values are collected into the OtherEntries of the result by attributeCollector.
+func createAttributeCollectorIterator(makeIter makeIterFn, names []string, scope deepql.AttributeScope,
+	definitionLevel int,
+	keyPath, strPath, intPath, floatPath, boolPath string,
+) parquetquery.Iterator {
+	return parquetquery.NewLeftJoinIterator(definitionLevel,
+		[]parquetquery.Iterator{makeIter(keyPath, parquetquery.NewStringInPredicate(names), "key")},
+		[]parquetquery.Iterator{
+			makeIter(strPath, nil, "string"),
+			makeIter(intPath, nil, "int"),
+			makeIter(floatPath, nil, "float"),
+			makeIter(boolPath, nil, "bool"),
+		},
+		&attributeCollector{scope: scope},
+	)
+}
+
+func createSnapshotMetaIterator(makeIter makeIterFn, snapshotIter parquetquery.Iterator, captured *capturedEvaluator) (*snapshotMetadataIterator, error) {
 	snapshotIterators := []parquetquery.Iterator{
 		snapshotIter,
 		// Add static columns that are always return
@@ -188,7 +350,7 @@ func createSnapshotMetaIterator(makeIter makeIterFn, snapshotIter parquetquery.I
 		makeIter(columnPathTracepointLine, nil, columnPathTracepointLine),
 	}
 
-	return newSnapshotMetadataIterator(parquetquery.NewJoinIterator(DefinitionLevelSnapshot, snapshotIterators, nil)), nil
+	return newSnapshotMetadataIterator(parquetquery.NewJoinIterator(DefinitionLevelSnapshot, snapshotIterators, nil), captured), nil
 }
 
 func operandType(operands deepql.Operands) deepql.StaticType {
@@ -232,7 +394,15 @@ func createAttributeIterator(makeIter makeIterFn, conditions []deepql.Condition,
 	return parquetquery.NewUnionIterator(definitionLevel, ittrs, nil), nil
 }
 
-type attributeCollector struct{}
+// collectedAttribute is the value of an attribute collected by attributeCollector
+type collectedAttribute struct {
+	attribute deepql.Attribute
+	value     deepql.Static
+}
+
+type attributeCollector struct {
+	scope deepql.AttributeScope
+}
 
 var _ parquetquery.GroupPredicate = (*attributeCollector)(nil)
 
@@ -267,7 +437,97 @@ func (c *attributeCollector) KeepGroup(res *parquetquery.IteratorResult) bool {
 
 	res.Entries = res.Entries[:0]
 	res.OtherEntries = res.OtherEntries[:0]
-	res.AppendOtherValue(key, val)
+	res.AppendOtherValue(key, collectedAttribute{
+		attribute: deepql.NewScopedAttribute(c.scope, false, key),
+		value:     val,
+	})
+
+	return true
+}
+
+// intrinsicCollector collects the value of an intrinsic into the OtherEntries of the result, so it is available
+// to the engine as an attribute of the snapshot.
+type intrinsicCollector struct {
+	attribute deepql.Attribute
+	typ       deepql.StaticType
+	// firstFrame limits the values to the frame the tracepoint fired in
+	firstFrame bool
+}
+
+var _ parquetquery.GroupPredicate = (*intrinsicCollector)(nil)
+
+func (c *intrinsicCollector) String() string {
+	return fmt.Sprintf("intrinsicCollector{%s}", c.attribute)
+}
+
+func (c *intrinsicCollector) KeepGroup(res *parquetquery.IteratorResult) bool {
+	if c.firstFrame && res.RowNumber[DefinitionLevelSnapshotFrames] != 0 {
+		return false
+	}
+
+	var val *deepql.Static
+	for _, e := range res.Entries {
+		// snapshots without a value do not have the intrinsic, e.g. no log message
+		if e.Value.Kind() < 0 {
+			continue
+		}
+
+		var v deepql.Static
+		switch c.typ {
+		case deepql.TypeString:
+			v = deepql.NewStaticString(e.Value.String())
+		case deepql.TypeDuration:
+			v = deepql.NewStaticDuration(time.Duration(e.Value.Int64()))
+		default:
+			v = deepql.NewStaticInt(int(e.Value.Int64()))
+		}
+		val = &v
+	}
+	if val == nil {
+		return false
+	}
+
+	res.Entries = res.Entries[:0]
+	res.OtherEntries = res.OtherEntries[:0]
+	res.AppendOtherValue(c.attribute.String(), collectedAttribute{
+		attribute: c.attribute,
+		value:     *val,
+	})
+
+	return true
+}
+
+// frameCounter counts the frames of each snapshot and keeps the snapshots where the count matches the predicate.
+type frameCounter struct {
+	attribute deepql.Attribute
+	predicate parquetquery.Predicate
+}
+
+var _ parquetquery.GroupPredicate = (*frameCounter)(nil)
+
+func (c *frameCounter) String() string {
+	return fmt.Sprintf("frameCounter{%v}", c.predicate)
+}
+
+func (c *frameCounter) KeepGroup(res *parquetquery.IteratorResult) bool {
+	count := 0
+	for _, e := range res.Entries {
+		// a snapshot without frames has a single null value
+		if e.Value.Kind() >= 0 {
+			count++
+		}
+	}
+
+	if c.predicate != nil && !c.predicate.KeepValue(parquet.ValueOf(int64(count))) {
+		return false
+	}
+
+	res.Entries = res.Entries[:0]
+	res.OtherEntries = res.OtherEntries[:0]
+	res.AppendOtherValue(c.attribute.String(), collectedAttribute{
+		attribute: c.attribute,
+		value:     deepql.NewStaticInt(count),
+	})
 
 	return true
 }
@@ -0,0 +1,141 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package vparquet
+
+import (
+	"strings"
+
+	"github.com/intergral/deep/pkg/deepdb/encoding/common"
+	"github.com/intergral/deep/pkg/deepql"
+	"github.com/intergral/deep/pkg/parquetquery"
+	"github.com/pkg/errors"
+	"github.com/segmentio/parquet-go"
+)
+
+const (
+	columnPathFrameVariableName = "Frames.Variables.Name"
+	columnPathWatchExpression   = "Watches.Expression"
+
+	otherEntryConditionMatched = "conditionMatched"
+)
+
+// createCapturedIterator returns an iterator over the snapshots that could match a condition on a variable or
+// watch. It only checks that the snapshot captured a top level variable with the first name in the path, or a
+// watch with the expression. The condition itself is evaluated by capturedEvaluator.
+func createCapturedIterator(makeIter makeIterFn, condition deepql.Condition) parquetquery.Iterator {
+	if condition.Attribute.Scope == deepql.AttributeScopeWatch {
+		return makeIter(columnPathWatchExpression, parquetquery.NewStringInPredicate([]string{condition.Attribute.Name}), "")
+	}
+
+	root, _, _ := strings.Cut(condition.Attribute.Name, ".")
+	return makeIter(columnPathFrameVariableName, parquetquery.NewStringInPredicate([]string{root}), "")
+}
+
+// conditionMatchedMarker marks snapshots that matched one of the column conditions. When the conditions are not
+// all required these snapshots are kept without evaluating the captured conditions.
+type conditionMatchedMarker struct{}
+
+var _ parquetquery.GroupPredicate = (*conditionMatchedMarker)(nil)
+
+func (m *conditionMatchedMarker) String() string {
+	return "conditionMatchedMarker{}"
+}
+
+func (m *conditionMatchedMarker) KeepGroup(res *parquetquery.IteratorResult) bool {
+	res.AppendOtherValue(otherEntryConditionMatched, true)
+	return true
+}
+
+// capturedEvaluator evaluates conditions on the variables and watch results of a snapshot. This data is nested
+// too deeply to be filtered by the column iterators, so the rows that could match are read in full and the
+// conditions are evaluated in memory.
+type capturedEvaluator struct {
+	conditions    []deepql.Condition
+	allConditions bool
+
+	reader *parquet.Reader
+	// rowOffset is the number of rows in the row groups before those being searched
+	rowOffset int64
+}
+
+func newCapturedEvaluator(pf *parquet.File, opts common.SearchOptions, conditions []deepql.Condition, allConditions bool) *capturedEvaluator {
+	var rowOffset int64
+	if opts.TotalPages > 0 {
+		for _, rg := range pf.RowGroups()[:opts.StartPage] {
+			rowOffset += rg.NumRows()
+		}
+	}
+
+	return &capturedEvaluator{
+		conditions:    conditions,
+		allConditions: allConditions,
+		reader:        parquet.NewReader(pf),
+		rowOffset:     rowOffset,
+	}
+}
+
+// evaluate reads the snapshot for the result and resolves the captured attributes. The bool is false if the
+// snapshot does not match the conditions.
+func (e *capturedEvaluator) evaluate(res *parquetquery.IteratorResult) (map[deepql.Attribute]deepql.Static, bool, error) {
+	if err := e.reader.SeekToRow(e.rowOffset + res.RowNumber[0]); err != nil {
+		return nil, false, errors.Wrap(err, "seek to row")
+	}
+
+	row := new(Snapshot)
+	if err := e.reader.Read(row); err != nil {
+		return nil, false, errors.Wrap(err, "error reading row")
+	}
+	snapshot := parquetToDeepSnapshot(row)
+
+	attributes := make(map[deepql.Attribute]deepql.Static, len(e.conditions))
+	filters, matched := 0, 0
+	for _, condition := range e.conditions {
+		value, ok := deepql.ResolveCapturedAttribute(snapshot, condition.Attribute)
+		if ok {
+			attributes[condition.Attribute] = value
+		}
+
+		if condition.Op == deepql.OpNone {
+			continue
+		}
+
+		filters++
+		if !ok {
+			continue
+		}
+		match, err := condition.Matches(value)
+		if err != nil {
+			return nil, false, err
+		}
+		if match {
+			matched++
+		}
+	}
+
+	if filters == 0 {
+		return attributes, true, nil
+	}
+	if e.allConditions {
+		return attributes, matched == filters, nil
+	}
+	return attributes, matched > 0 || res.OtherValueFromKey(otherEntryConditionMatched) != nil, nil
+}
+
+func (e *capturedEvaluator) close() {
+	_ = e.reader.Close()
+}
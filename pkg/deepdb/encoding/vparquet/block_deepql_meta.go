@@ -19,6 +19,8 @@ package vparquet
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/intergral/deep/pkg/deepql"
 
@@ -27,6 +29,8 @@ import (
 
 type snapshotMetadataIterator struct {
 	iter parquetquery.Iterator
+	// captured is set when the request has conditions on variables or watches
+	captured *capturedEvaluator
 }
 
 const (
@@ -40,31 +44,121 @@ const (
 
 var _ deepql.SnapshotResultIterator = (*snapshotMetadataIterator)(nil)
 
-func newSnapshotMetadataIterator(iter parquetquery.Iterator) *snapshotMetadataIterator {
+func newSnapshotMetadataIterator(iter parquetquery.Iterator, captured *capturedEvaluator) *snapshotMetadataIterator {
 	return &snapshotMetadataIterator{
-		iter: iter,
+		iter:     iter,
+		captured: captured,
 	}
 }
 
 func (i *snapshotMetadataIterator) Next(context.Context) (*deepql.SnapshotResult, error) {
-	res, err := i.iter.Next()
-	if err != nil {
-		return nil, err
-	}
-	if res == nil {
-		return nil, nil
+	var capturedAttributes map[deepql.Attribute]deepql.Static
+	for {
+		res, err := i.iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			return nil, nil
+		}
+
+		if i.captured != nil {
+			var keep bool
+			capturedAttributes, keep, err = i.captured.evaluate(res)
+			if err != nil {
+				return nil, err
+			}
+			if !keep {
+				continue
+			}
+		}
+
+		return newSnapshotResult(res, capturedAttributes), nil
 	}
+}
+
+func newSnapshotResult(res *parquetquery.IteratorResult, capturedAttributes map[deepql.Attribute]deepql.Static) *deepql.SnapshotResult {
 	entries := len(res.Entries)
-	return &deepql.SnapshotResult{
+	result := &deepql.SnapshotResult{
 		SnapshotID:         res.Entries[entries-resEntriesIDOffset].Value.Bytes(),
 		StartTimeUnixNanos: res.Entries[entries-resEntriesTSOffset].Value.Uint64(),
 		DurationNanos:      res.Entries[entries-resEntriesDurationOffset].Value.Uint64(),
 		ServiceName:        res.Entries[entries-resEntriesServiceNameOffset].Value.String(),
 		FilePath:           res.Entries[entries-resEntriesPathOffset].Value.String(),
 		LineNo:             res.Entries[entries-resEntriesLineNoOffset].Value.Uint32(),
-	}, err
+	}
+	snapshot := newSnapshot(result, res)
+	for attribute, value := range capturedAttributes {
+		snapshot.attributes[attribute] = value
+	}
+	result.Snapshot = snapshot
+	return result
+}
+
+// snapshot holds the attributes fetched for the conditions of a deepql.FetchSnapshotRequest, these are
+// used by the engine to group and aggregate the snapshots.
+type snapshot struct {
+	id         []byte
+	startTime  uint64
+	duration   uint64
+	attributes map[deepql.Attribute]deepql.Static
+}
+
+var _ deepql.Snapshot = (*snapshot)(nil)
+
+func newSnapshot(result *deepql.SnapshotResult, res *parquetquery.IteratorResult) *snapshot {
+	s := &snapshot{
+		id:        result.SnapshotID,
+		startTime: result.StartTimeUnixNanos,
+		duration:  result.DurationNanos,
+		attributes: map[deepql.Attribute]deepql.Static{
+			deepql.NewIntrinsic(deepql.IntrinsicDuration): deepql.NewStaticDuration(time.Duration(result.DurationNanos)),
+			deepql.NewIntrinsic(deepql.IntrinsicPath):     deepql.NewStaticString(result.FilePath),
+			deepql.NewIntrinsic(deepql.IntrinsicLine):     deepql.NewStaticInt(int(result.LineNo)),
+		},
+	}
+
+	// well known columns are selected as the attribute name
+	for _, e := range res.Entries[:len(res.Entries)-resEntriesIDOffset] {
+		lookup, ok := wellKnownColumnLookups[e.Key]
+		if !ok || e.Value.Kind() < 0 {
+			continue
+		}
+		scope := deepql.AttributeScopeSnapshot
+		if strings.HasPrefix(lookup.columnPath, "rs.") {
+			scope = deepql.AttributeScopeResource
+		}
+		s.attributes[deepql.NewScopedAttribute(scope, false, e.Key)] = deepql.NewStaticString(e.Value.String())
+	}
+
+	for _, e := range res.OtherEntries {
+		if attr, ok := e.Value.(collectedAttribute); ok {
+			s.attributes[attr.attribute] = attr.value
+		}
+	}
+
+	return s
+}
+
+func (s *snapshot) Attributes() map[deepql.Attribute]deepql.Static {
+	return s.attributes
+}
+
+func (s *snapshot) ID() []byte {
+	return s.id
+}
+
+func (s *snapshot) StartTimeUnixNanos() uint64 {
+	return s.startTime
+}
+
+func (s *snapshot) EndTimeUnixNanos() uint64 {
+	return s.startTime + s.duration
 }
 
 func (i *snapshotMetadataIterator) Close() {
 	i.iter.Close()
+	if i.captured != nil {
+		i.captured.close()
+	}
 }
@@ -20,6 +20,7 @@ package vparquet
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/intergral/deep/pkg/deepdb/encoding/common"
 	"github.com/intergral/deep/pkg/deepql"
@@ -27,12 +28,32 @@ import (
 )
 
 func TestBackendBlockSearchFetchMetaData(t *testing.T) {
-	snapshot := fullyPopulatedTestSnapshot(nil)
-	b := makeBackendBlockWithSnapshots(t, []*Snapshot{snapshot})
+	wantSnapshot := fullyPopulatedTestSnapshot(nil)
+	b := makeBackendBlockWithSnapshots(t, []*Snapshot{wantSnapshot})
 	ctx := context.Background()
 
 	// Helper functions to make requests
 
+	result := func(attributes map[deepql.Attribute]deepql.Static) *deepql.SnapshotResult {
+		attributes[deepql.NewIntrinsic(deepql.IntrinsicDuration)] = deepql.NewStaticDuration(time.Duration(wantSnapshot.DurationNanos))
+		attributes[deepql.NewIntrinsic(deepql.IntrinsicPath)] = deepql.NewStaticString(wantSnapshot.Tracepoint.Path)
+		attributes[deepql.NewIntrinsic(deepql.IntrinsicLine)] = deepql.NewStaticInt(int(wantSnapshot.Tracepoint.LineNumber))
+		return &deepql.SnapshotResult{
+			SnapshotID:         wantSnapshot.ID,
+			ServiceName:        "test-service-name",
+			FilePath:           wantSnapshot.Tracepoint.Path,
+			LineNo:             wantSnapshot.Tracepoint.LineNumber,
+			StartTimeUnixNanos: wantSnapshot.TsNanos,
+			DurationNanos:      wantSnapshot.DurationNanos,
+			Snapshot: &snapshot{
+				id:         wantSnapshot.ID,
+				startTime:  wantSnapshot.TsNanos,
+				duration:   wantSnapshot.DurationNanos,
+				attributes: attributes,
+			},
+		}
+	}
+
 	testCases := []struct {
 		req             deepql.FetchSnapshotRequest
 		expectedResults []*deepql.SnapshotResult
@@ -40,20 +61,22 @@ func TestBackendBlockSearchFetchMetaData(t *testing.T) {
 		{
 			// Empty request returns 1 snapshot with all spans
 			deepql.FetchSnapshotRequest{},
-			[]*deepql.SnapshotResult{{
-				SnapshotID:         snapshot.ID,
-				ServiceName:        "test-service-name",
-				FilePath:           snapshot.Tracepoint.Path,
-				LineNo:             snapshot.Tracepoint.LineNumber,
-				StartTimeUnixNanos: snapshot.TsNanos,
-				DurationNanos:      snapshot.DurationNanos,
-			}},
+			[]*deepql.SnapshotResult{result(map[deepql.Attribute]deepql.Static{})},
 		},
 		{
 			// doesn't match anything
 			makeReq(parse(t, `{.xyz = "xyz"}`)),
 			nil,
 		},
+		{
+			// attributes that are only fetched don't filter, and are returned for both scopes
+			deepql.MustExtractFetchSnapshotRequest(`{} | by(.foo) | by(.xyz) | by(resource.cluster)`),
+			[]*deepql.SnapshotResult{result(map[deepql.Attribute]deepql.Static{
+				deepql.NewScopedAttribute(deepql.AttributeScopeSnapshot, false, "foo"):     deepql.NewStaticString("def"),
+				deepql.NewScopedAttribute(deepql.AttributeScopeResource, false, "foo"):     deepql.NewStaticString("abc"),
+				deepql.NewScopedAttribute(deepql.AttributeScopeResource, false, "cluster"): deepql.NewStaticString("cluster"),
+			})},
+		},
 	}
 
 	for _, tc := range testCases {
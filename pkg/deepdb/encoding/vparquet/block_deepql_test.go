@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"math/rand"
 	"path"
+	"strconv"
 	"testing"
 	"time"
 
@@ -34,6 +35,9 @@ import (
 	"github.com/intergral/deep/pkg/deepdb/backend"
 	"github.com/intergral/deep/pkg/deepdb/backend/local"
 	"github.com/intergral/deep/pkg/deepdb/encoding/common"
+	"github.com/intergral/deep/pkg/deeppb"
+	tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/intergral/deep/pkg/util"
 	"github.com/intergral/deep/pkg/util/test"
 )
 
@@ -348,3 +352,313 @@ func BenchmarkBackendBlockdeepql(b *testing.B) {
 		})
 	}
 }
+
+func TestBackendBlockSearchDeepqlCaptured(t *testing.T) {
+	wanted := capturedTestSnapshot(42, "149.99", "alice", "wanted")
+	other := capturedTestSnapshot(7, "10.5", "bob", "other")
+	b := makeBackendBlockWithSnapshots(t, []*Snapshot{other, wanted})
+	ctx := context.Background()
+
+	fetchIDs := func(req deepql.FetchSnapshotRequest) [][]byte {
+		resp, err := b.Fetch(ctx, req, common.DefaultSearchOptions())
+		require.NoError(t, err, "search request:", req)
+		defer resp.Results.Close()
+
+		var ids [][]byte
+		for {
+			snap, err := resp.Results.Next(ctx)
+			require.NoError(t, err, "search request:", req)
+			if snap == nil {
+				return ids
+			}
+			ids = append(ids, snap.SnapshotID)
+		}
+	}
+
+	searchesThatMatch := []string{
+		`{ var.user.id = 42 }`,
+		`{ var.user.id > 40 }`,
+		`{ var.user.name = "alice" }`,
+		`{ var.user.name =~ "ali.*" }`,
+		`{ watch."order.total" > 100 }`,
+		`{ watch.order.total = 149.99 }`,
+		`{ var.user.id = 42 && .foo = "wanted" }`,
+		`{ var.user.id = 42 && watch."order.total" > 100 }`,
+		`{ var.user.id = 1 || .foo = "wanted" }`,
+		`{ var.user.id = 42 || .foo = "nope" }`,
+	}
+	for _, q := range searchesThatMatch {
+		require.Equal(t, [][]byte{wanted.ID}, fetchIDs(deepql.MustExtractFetchSnapshotRequest(q)), "query:", q)
+	}
+
+	searchesThatDontMatch := []string{
+		`{ var.user.id = 43 }`,
+		`{ var.user.missing = 42 }`,
+		`{ var.missing = 42 }`,
+		`{ watch."order.missing" = "error" }`,
+		`{ watch."order.total" > 1000 }`,
+		`{ var.user.id = 42 && .foo = "other" }`,
+		`{ var.user.id = 1 || .foo = "nope" }`,
+	}
+	for _, q := range searchesThatDontMatch {
+		require.Empty(t, fetchIDs(deepql.MustExtractFetchSnapshotRequest(q)), "query:", q)
+	}
+
+	// the time range applies to all conditions when they are not all required
+	for _, q := range []string{`{ .foo = "wanted" || .foo = "other" }`, `{ .foo = "wanted" || var.user.id = 7 }`} {
+		req := deepql.MustExtractFetchSnapshotRequest(q)
+		req.StartTimeUnixNanos = uint64(1000 * time.Second)
+		req.EndTimeUnixNanos = uint64(2000 * time.Second)
+		require.Empty(t, fetchIDs(req), "query:", q)
+	}
+
+	// captured values are returned so they can be grouped by
+	resp, err := b.Fetch(ctx, deepql.MustExtractFetchSnapshotRequest(`{ var.user.id = 42 } | by(var.user.name)`), common.DefaultSearchOptions())
+	require.NoError(t, err)
+	snap, err := resp.Results.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[deepql.Attribute]deepql.Static{
+		deepql.NewIntrinsic(deepql.IntrinsicDuration):                                deepql.NewStaticDuration(time.Duration(wanted.DurationNanos)),
+		deepql.NewIntrinsic(deepql.IntrinsicPath):                                    deepql.NewStaticString(wanted.Tracepoint.Path),
+		deepql.NewIntrinsic(deepql.IntrinsicLine):                                    deepql.NewStaticInt(int(wanted.Tracepoint.LineNumber)),
+		deepql.NewScopedAttribute(deepql.AttributeScopeVariable, false, "user.id"):   deepql.NewStaticInt(42),
+		deepql.NewScopedAttribute(deepql.AttributeScopeVariable, false, "user.name"): deepql.NewStaticString("alice"),
+	}, snap.Snapshot.Attributes())
+}
+
+// capturedTestSnapshot creates a snapshot with a user variable in the top frame and a watch on the order total
+func capturedTestSnapshot(userID int, orderTotal, userName, foo string) *Snapshot {
+	id := test.ValidSnapshotID(nil)
+	snapshot := test.GenerateSnapshot(0, &test.GenerateOptions{Id: id, ServiceName: "test-service", Attrs: map[string]string{"foo": foo}})
+	snapshot.Frames = []*tp.StackFrame{
+		{
+			FileName:   "/app/checkout.py",
+			MethodName: "checkout",
+			LineNumber: 12,
+			Variables:  []*tp.VariableID{{ID: "1", Name: "user"}, {ID: "4", Name: "order"}},
+		},
+	}
+	snapshot.VarLookup = map[string]*tp.Variable{
+		"1": {Type: "User", Value: "User", Children: []*tp.VariableID{{ID: "2", Name: "id"}, {ID: "3", Name: "name"}}},
+		"2": {Type: "int", Value: strconv.Itoa(userID)},
+		"3": {Type: "str", Value: userName},
+		"4": {Type: "Order", Value: "Order"},
+		"5": {Type: "float", Value: orderTotal},
+	}
+	snapshot.Watches = []*tp.WatchResult{
+		{Expression: "order.total", Result: &tp.WatchResult_GoodResult{GoodResult: &tp.VariableID{ID: "5", Name: "order.total"}}},
+		{Expression: "order.missing", Result: &tp.WatchResult_ErrorResult{ErrorResult: "error"}},
+	}
+
+	return snapshotToParquet(id, snapshot, nil)
+}
+
+func TestBackendBlockSearchDeepqlIntrinsics(t *testing.T) {
+	logMsg := "checkout complete"
+	wanted := intrinsicsTestSnapshot("tp-1", "/app/checkout.py", 42, []*tp.StackFrame{
+		{MethodName: "checkout", ClassName: &[]string{"Cart"}[0]},
+		{MethodName: "handle", ClassName: &[]string{"Server"}[0]},
+		{MethodName: "main"},
+	}, &logMsg)
+	other := intrinsicsTestSnapshot("tp-2", "/app/server.py", 12, []*tp.StackFrame{
+		{MethodName: "handle", ClassName: &[]string{"Server"}[0]},
+	}, nil)
+	noFrames := intrinsicsTestSnapshot("tp-2", "/app/server.py", 12, nil, nil)
+	b := makeBackendBlockWithSnapshots(t, []*Snapshot{other, wanted, noFrames})
+	ctx := context.Background()
+
+	fetchIDs := func(q string) [][]byte {
+		resp, err := b.Fetch(ctx, deepql.MustExtractFetchSnapshotRequest(q), common.DefaultSearchOptions())
+		require.NoError(t, err, "query:", q)
+		defer resp.Results.Close()
+
+		var ids [][]byte
+		for {
+			snap, err := resp.Results.Next(ctx)
+			require.NoError(t, err, "query:", q)
+			if snap == nil {
+				return ids
+			}
+			ids = append(ids, snap.SnapshotID)
+		}
+	}
+
+	searchesThatMatch := []string{
+		`{ tracepoint = "tp-1" }`,
+		`{ path = "/app/checkout.py" }`,
+		`{ path =~ ".*checkout.*" }`,
+		`{ path !~ ".*server.*" }`,
+		`{ line = 42 }`,
+		`{ line > 20 }`,
+		`{ method = "checkout" }`,
+		`{ class = "Cart" }`,
+		`{ log = "checkout complete" }`,
+		`{ log =~ "checkout.*" }`,
+		`{ frames = 3 }`,
+		`{ frames > 1 }`,
+		`{ path =~ ".*checkout.*" && line = 42 }`,
+		`{ method = "checkout" || line = 1 }`,
+	}
+	for _, q := range searchesThatMatch {
+		require.Equal(t, [][]byte{wanted.ID}, fetchIDs(q), "query:", q)
+	}
+
+	searchesThatDontMatch := []string{
+		`{ tracepoint = "tp-3" }`,
+		`{ path =~ ".*cart.*" }`,
+		`{ line = 43 }`,
+		`{ method = "main" }`,
+		`{ class = "Cart" && line = 12 }`,
+		`{ log = "checkout failed" }`,
+		`{ frames > 3 }`,
+		`{ method = "main" || line = 1 }`,
+	}
+	for _, q := range searchesThatDontMatch {
+		require.Empty(t, fetchIDs(q), "query:", q)
+	}
+
+	require.Equal(t, [][]byte{noFrames.ID}, fetchIDs(`{ frames = 0 }`))
+	require.ElementsMatch(t, [][]byte{other.ID, noFrames.ID}, fetchIDs(`{ tracepoint = "tp-2" }`))
+
+	// the intrinsics are returned so they can be grouped by
+	groupedBy := map[deepql.Intrinsic]deepql.Static{
+		deepql.IntrinsicPath:       deepql.NewStaticString("/app/checkout.py"),
+		deepql.IntrinsicLine:       deepql.NewStaticInt(42),
+		deepql.IntrinsicTracepoint: deepql.NewStaticString("tp-1"),
+		deepql.IntrinsicMethod:     deepql.NewStaticString("checkout"),
+		deepql.IntrinsicClass:      deepql.NewStaticString("Cart"),
+		deepql.IntrinsicLog:        deepql.NewStaticString("checkout complete"),
+		deepql.IntrinsicFrames:     deepql.NewStaticInt(3),
+	}
+	for intrinsic, value := range groupedBy {
+		q := `{ line = 42 } | by(` + intrinsic.String() + `)`
+		resp, err := b.Fetch(ctx, deepql.MustExtractFetchSnapshotRequest(q), common.DefaultSearchOptions())
+		require.NoError(t, err, "query:", q)
+		snap, err := resp.Results.Next(ctx)
+		require.NoError(t, err, "query:", q)
+		require.Equal(t, value, snap.Snapshot.Attributes()[deepql.NewIntrinsic(intrinsic)], "query:", q)
+		snap, err = resp.Results.Next(ctx)
+		require.NoError(t, err, "query:", q)
+		require.Nil(t, snap, "query:", q)
+		resp.Results.Close()
+	}
+}
+
+// intrinsicsTestSnapshot creates a snapshot for the given tracepoint with the given frames and log message
+func intrinsicsTestSnapshot(tracepointID, path string, line uint32, frames []*tp.StackFrame, logMsg *string) *Snapshot {
+	id := test.ValidSnapshotID(nil)
+	snapshot := test.GenerateSnapshot(0, &test.GenerateOptions{Id: id, ServiceName: "test-service"})
+	snapshot.Tracepoint = &tp.TracePointConfig{ID: tracepointID, Path: path, LineNumber: line}
+	snapshot.Frames = frames
+	snapshot.LogMsg = logMsg
+
+	return snapshotToParquet(id, snapshot, nil)
+}
+
+func TestBackendBlockSearchDeepqlTraceID(t *testing.T) {
+	wanted := traceTestSnapshot("ABCDEF", "12")
+	other := traceTestSnapshot("123456", "34")
+	noTrace := traceTestSnapshot("", "")
+	b := makeBackendBlockWithSnapshots(t, []*Snapshot{other, wanted, noTrace})
+	ctx := context.Background()
+	require.NotZero(t, b.meta.TraceBloomShardCount)
+
+	fetchIDs := func(q string) ([][]byte, uint64) {
+		resp, err := b.Fetch(ctx, deepql.MustExtractFetchSnapshotRequest(q), common.DefaultSearchOptions())
+		require.NoError(t, err, "query:", q)
+		defer resp.Results.Close()
+
+		var ids [][]byte
+		for {
+			snap, err := resp.Results.Next(ctx)
+			require.NoError(t, err, "query:", q)
+			if snap == nil {
+				return ids, resp.Bytes()
+			}
+			ids = append(ids, snap.SnapshotID)
+		}
+	}
+
+	searchesThatMatch := []string{
+		`{ traceID = "00000000000000000000000000abcdef" }`,
+		`{ spanID = "0000000000000012" }`,
+		`{ traceID = "00000000000000000000000000abcdef" && spanID = "0000000000000012" }`,
+		`{ traceID =~ ".*abcdef" }`,
+	}
+	for _, q := range searchesThatMatch {
+		ids, _ := fetchIDs(q)
+		require.Equal(t, [][]byte{wanted.ID}, ids, "query:", q)
+	}
+
+	ids, _ := fetchIDs(`{ traceID != "00000000000000000000000000abcdef" }`)
+	require.Equal(t, [][]byte{other.ID}, ids)
+
+	// the block is not read when the trace is not in the bloom
+	ids, bytesRead := fetchIDs(`{ traceID = "0000000000000000000000000000ffff" }`)
+	require.Empty(t, ids)
+	require.Zero(t, bytesRead)
+
+	// unless the trace id is not required
+	ids, bytesRead = fetchIDs(`{ traceID = "0000000000000000000000000000ffff" || spanID = "0000000000000034" }`)
+	require.Equal(t, [][]byte{other.ID}, ids)
+	require.NotZero(t, bytesRead)
+
+	// blocks written before trace ids were indexed have no trace bloom, so they are not read
+	b.meta.TraceBloomShardCount = 0
+	ids, bytesRead = fetchIDs(`{ traceID = "00000000000000000000000000abcdef" }`)
+	require.Empty(t, ids)
+	require.Zero(t, bytesRead)
+}
+
+// TestBackendBlockSearchDeepqlTraceIDBeforeIndexed searches a block written before trace ids were indexed, which has
+// no TraceID or SpanID columns
+func TestBackendBlockSearchDeepqlTraceIDBeforeIndexed(t *testing.T) {
+	rawR, _, _, err := local.New(&local.Config{
+		Path: "./test-data",
+	})
+	require.NoError(t, err)
+
+	r := backend.NewReader(rawR)
+	ctx := context.Background()
+
+	blocks, err := r.Blocks(ctx, "single-tenant")
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+
+	meta, err := r.BlockMeta(ctx, blocks[0], "single-tenant")
+	require.NoError(t, err)
+	require.Zero(t, meta.TraceBloomShardCount)
+
+	b := newBackendBlock(meta, r)
+
+	fetcher := deepql.NewSnapshotResultFetcherWrapper(func(ctx context.Context, req deepql.FetchSnapshotRequest) (deepql.FetchSnapshotResponse, error) {
+		return b.Fetch(ctx, req, common.DefaultSearchOptions())
+	})
+	fetchCount := func(q string) int {
+		resp, err := deepql.NewEngine().Execute(ctx, &deeppb.SearchRequest{Query: q}, fetcher)
+		require.NoError(t, err, "query:", q)
+		return len(resp.Snapshots)
+	}
+
+	require.Zero(t, fetchCount(`{ traceID = "00000000000000000000000000abcdef" }`))
+	require.Zero(t, fetchCount(`{ spanID = "0000000000000012" }`))
+	require.Zero(t, fetchCount(`{ spanID =~ ".*" && duration > 0 }`))
+	// the other conditions still match
+	require.Equal(t, int(meta.TotalObjects), fetchCount(`{ spanID = "0000000000000012" || duration >= 0 }`))
+}
+
+// traceTestSnapshot creates a snapshot with the given trace_id and span_id attributes, empty ids are not set
+func traceTestSnapshot(traceID, spanID string) *Snapshot {
+	attrs := map[string]string{}
+	if traceID != "" {
+		attrs[util.AttributeTraceID] = traceID
+	}
+	if spanID != "" {
+		attrs[util.AttributeSpanID] = spanID
+	}
+
+	id := test.ValidSnapshotID(nil)
+	snapshot := test.GenerateSnapshot(0, &test.GenerateOptions{Id: id, ServiceName: "test-service", Attrs: attrs})
+
+	return snapshotToParquet(id, snapshot, nil)
+}
@@ -57,7 +57,32 @@ func (b *backendBlock) checkBloom(ctx context.Context, id common.ID) (found bool
 	nameBloom := common.BloomName(shardKey)
 	span.SetTag("bloom", nameBloom)
 
-	bloomBytes, err := b.r.Read(derivedCtx, nameBloom, b.meta.BlockID, b.meta.TenantID, true)
+	return b.testBloom(derivedCtx, nameBloom, id)
+}
+
+// checkTraceBloom returns false if the block has no snapshots for the normalized trace id. Blocks written before
+// trace ids were indexed do not have a trace bloom or the TraceID column, so they have no snapshots for any trace id.
+func (b *backendBlock) checkTraceBloom(ctx context.Context, traceID string) (found bool, err error) {
+	if b.meta.TraceBloomShardCount == 0 {
+		return false, nil
+	}
+
+	span, derivedCtx := opentracing.StartSpanFromContext(ctx, "parquet.backendBlock.checkTraceBloom",
+		opentracing.Tags{
+			"blockID":  b.meta.BlockID,
+			"tenantID": b.meta.TenantID,
+		})
+	defer span.Finish()
+
+	shardKey := common.ShardKeyForSnapshotID([]byte(traceID), int(b.meta.TraceBloomShardCount))
+	nameBloom := common.TraceBloomName(shardKey)
+	span.SetTag("bloom", nameBloom)
+
+	return b.testBloom(derivedCtx, nameBloom, []byte(traceID))
+}
+
+func (b *backendBlock) testBloom(ctx context.Context, nameBloom string, key []byte) (bool, error) {
+	bloomBytes, err := b.r.Read(ctx, nameBloom, b.meta.BlockID, b.meta.TenantID, true)
 	if err != nil {
 		return false, fmt.Errorf("error retrieving bloom %s (%s, %s): %w", nameBloom, b.meta.TenantID, b.meta.BlockID, err)
 	}
@@ -68,7 +93,7 @@ func (b *backendBlock) checkBloom(ctx context.Context, id common.ID) (found bool
 		return false, fmt.Errorf("error parsing bloom (%s, %s): %w", b.meta.TenantID, b.meta.BlockID, err)
 	}
 
-	return filter.Test(id), nil
+	return filter.Test(key), nil
 }
 
 // FindSnapshotByID scan the block for the snapshot with the given ID
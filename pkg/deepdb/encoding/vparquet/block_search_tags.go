@@ -209,7 +209,7 @@ func (b *backendBlock) SearchTagValuesV2(ctx context.Context, tag deepql.Attribu
 }
 
 func searchTagValues(ctx context.Context, tag deepql.Attribute, cb common.TagCallbackV2, pf *parquet.File) error {
-	// Special handling for intrinsics - currently we only have a duration intrinsic which is not a tag so skip it
+	// Special handling for intrinsics - these have dedicated columns and are not tags so skip them
 	if tag.Intrinsic != deepql.IntrinsicNone {
 		return nil
 	}
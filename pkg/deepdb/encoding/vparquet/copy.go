@@ -66,23 +66,30 @@ func CopyBlock(ctx context.Context, fromMeta, toMeta *backend.BlockMeta, from ba
 		}
 	}
 
+	// Trace bloom, blocks written before trace ids were indexed do not have one
+	for i := 0; i < int(fromMeta.TraceBloomShardCount); i++ {
+		err = copyFunc(common.TraceBloomName(i))
+		if err != nil {
+			return err
+		}
+	}
+
 	// Meta
 	err = to.WriteBlockMeta(ctx, toMeta)
 	return err
 }
 
-func writeBlockMeta(ctx context.Context, w backend.Writer, meta *backend.BlockMeta, bloom *common.ShardedBloomFilter) error {
+func writeBlockMeta(ctx context.Context, w backend.Writer, meta *backend.BlockMeta, bloom, traceBloom *common.ShardedBloomFilter) error {
 	// bloom
-	blooms, err := bloom.Marshal()
+	err := writeBloom(ctx, w, meta, bloom, common.BloomName)
 	if err != nil {
 		return err
 	}
-	for i, bloom := range blooms {
-		nameBloom := common.BloomName(i)
-		err := w.Write(ctx, nameBloom, meta.BlockID, meta.TenantID, bloom, true)
-		if err != nil {
-			return fmt.Errorf("unexpected error writing bloom-%d %w", i, err)
-		}
+
+	// trace bloom
+	err = writeBloom(ctx, w, meta, traceBloom, common.TraceBloomName)
+	if err != nil {
+		return err
 	}
 
 	// meta
@@ -93,3 +100,18 @@ func writeBlockMeta(ctx context.Context, w backend.Writer, meta *backend.BlockMe
 
 	return nil
 }
+
+func writeBloom(ctx context.Context, w backend.Writer, meta *backend.BlockMeta, bloom *common.ShardedBloomFilter, name func(shard int) string) error {
+	blooms, err := bloom.Marshal()
+	if err != nil {
+		return err
+	}
+	for i, bloom := range blooms {
+		nameBloom := name(i)
+		err := w.Write(ctx, nameBloom, meta.BlockID, meta.TenantID, bloom, true)
+		if err != nil {
+			return fmt.Errorf("unexpected error writing %s %w", nameBloom, err)
+		}
+	}
+	return nil
+}
@@ -108,15 +108,23 @@ func CreateBlock(ctx context.Context, cfg *common.BlockConfig, meta *backend.Blo
 	return s.meta, nil
 }
 
+// traceIDColumnIndex is the index of the TraceID column in the rows of a block, it is used to add the trace ids of
+// raw rows to the trace bloom
+var traceIDColumnIndex = func() int {
+	leaf, _ := parquet.SchemaOf(&Snapshot{}).Lookup(columnPathTraceID)
+	return leaf.ColumnIndex
+}()
+
 type streamingBlock struct {
-	ctx   context.Context
-	bloom *common.ShardedBloomFilter
-	meta  *backend.BlockMeta
-	bw    deepIO.BufferedWriteFlusher
-	pw    *parquet.GenericWriter[*Snapshot]
-	w     *backendWriter
-	r     backend.Reader
-	to    backend.Writer
+	ctx        context.Context
+	bloom      *common.ShardedBloomFilter
+	traceBloom *common.ShardedBloomFilter
+	meta       *backend.BlockMeta
+	bw         deepIO.BufferedWriteFlusher
+	pw         *parquet.GenericWriter[*Snapshot]
+	w          *backendWriter
+	r          backend.Reader
+	to         backend.Writer
 
 	currentBufferedSnapshots int
 	currentBufferedBytes     int
@@ -130,20 +138,22 @@ func newStreamingBlock(ctx context.Context, cfg *common.BlockConfig, meta *backe
 	// TotalObjects is used here as an estimated count for the bloom filter.
 	// The real number of objects is tracked below.
 	bloom := common.NewBloom(cfg.BloomFP, uint(cfg.BloomShardSizeBytes), uint(meta.TotalObjects))
+	traceBloom := common.NewBloom(cfg.BloomFP, uint(cfg.BloomShardSizeBytes), uint(meta.TotalObjects))
 
 	w := &backendWriter{ctx, to, DataFileName, meta.BlockID, meta.TenantID, nil}
 	bw := createBufferedWriter(w)
 	pw := parquet.NewGenericWriter[*Snapshot](bw)
 
 	return &streamingBlock{
-		ctx:   ctx,
-		meta:  newMeta,
-		bloom: bloom,
-		bw:    bw,
-		pw:    pw,
-		w:     w,
-		r:     r,
-		to:    to,
+		ctx:        ctx,
+		meta:       newMeta,
+		bloom:      bloom,
+		traceBloom: traceBloom,
+		bw:         bw,
+		pw:         pw,
+		w:          w,
+		r:          r,
+		to:         to,
 	}
 }
 
@@ -155,6 +165,9 @@ func (b *streamingBlock) Add(tr *Snapshot, start uint32) error {
 	id := tr.ID
 
 	b.bloom.Add(id)
+	if tr.TraceID != nil {
+		b.traceBloom.Add([]byte(*tr.TraceID))
+	}
 	b.meta.ObjectAdded(id, start)
 	b.currentBufferedSnapshots++
 	b.currentBufferedBytes += estimateMarshalledSizeFromSnapshot(tr)
@@ -169,6 +182,11 @@ func (b *streamingBlock) AddRaw(id []byte, row parquet.Row, start uint32) error
 	}
 
 	b.bloom.Add(id)
+	for _, v := range row {
+		if v.Column() == traceIDColumnIndex && !v.IsNull() {
+			b.traceBloom.Add(v.ByteArray())
+		}
+	}
 	b.meta.ObjectAdded(id, start)
 	b.currentBufferedSnapshots++
 	b.currentBufferedBytes += estimateMarshalledSizeFromParquetRow(row)
@@ -245,8 +263,9 @@ func (b *streamingBlock) Complete() (int, error) {
 	b.meta.FooterSize = binary.LittleEndian.Uint32(buf[0:4])
 
 	b.meta.BloomShardCount = uint16(b.bloom.GetShardCount())
+	b.meta.TraceBloomShardCount = uint16(b.traceBloom.GetShardCount())
 
-	return n, writeBlockMeta(b.ctx, b.to, b.meta, b.bloom)
+	return n, writeBlockMeta(b.ctx, b.to, b.meta, b.bloom, b.traceBloom)
 }
 
 // estimateMarshalledSizeFromSnapshot attempts to estimate the size of Snapshot in bytes. This is used to make choose
@@ -66,6 +66,43 @@ func TestCreateBlockHonorsSnapshotStartEndTimesFromWalMeta(t *testing.T) {
 	require.Equal(t, 305, int(outMeta.EndTime.Unix()))
 }
 
+func TestCreateBlockAddsTraceIDsToBloom(t *testing.T) {
+	ctx := context.Background()
+
+	rawR, rawW, _, err := local.New(&local.Config{
+		Path: t.TempDir(),
+	})
+	require.NoError(t, err)
+
+	r := backend.NewReader(rawR)
+	w := backend.NewWriter(rawW)
+
+	iter := newTestIterator()
+	iter.Add(test.GenerateSnapshot(1, &test.GenerateOptions{Attrs: map[string]string{"trace_id": "ABCDEF"}}), 100, 401)
+	iter.Add(test.GenerateSnapshot(2, nil), 101, 402)
+
+	cfg := &common.BlockConfig{
+		BloomFP:             0.01,
+		BloomShardSizeBytes: 100 * 1024,
+	}
+
+	meta := backend.NewBlockMeta("fake", uuid.New(), VersionString, backend.EncNone, "")
+	meta.TotalObjects = 1
+
+	outMeta, err := CreateBlock(ctx, cfg, meta, iter, r, w)
+	require.NoError(t, err)
+	require.NotZero(t, outMeta.TraceBloomShardCount)
+
+	b := newBackendBlock(outMeta, r)
+	found, err := b.checkTraceBloom(ctx, "00000000000000000000000000abcdef")
+	require.NoError(t, err)
+	require.True(t, found)
+
+	found, err = b.checkTraceBloom(ctx, "0000000000000000000000000000ffff")
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
 type testIterator struct {
 	snapshots []*deep_tp.Snapshot
 }
@@ -19,6 +19,7 @@ package vparquet
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/intergral/deep/pkg/deepql"
 	"github.com/intergral/deep/pkg/parquetquery"
@@ -121,6 +122,23 @@ func createStringPredicate(op deepql.Operator, operands deepql.Operands) (parque
 	case deepql.OpRegex:
 		return parquetquery.NewRegexInPredicate([]string{s})
 
+	case deepql.OpNotRegex:
+		r, err := regexp.Compile(s)
+		if err != nil {
+			return nil, err
+		}
+		return parquetquery.NewGenericPredicate(
+			func(v string) bool {
+				return !r.MatchString(v)
+			},
+			func(min, max string) bool {
+				return true
+			},
+			func(v parquet.Value) string {
+				return v.String()
+			},
+		), nil
+
 	default:
 		return nil, fmt.Errorf("operand not supported for strings: %+v", op)
 	}
@@ -0,0 +1,56 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package vparquet
+
+import (
+	"io"
+
+	"github.com/segmentio/parquet-go"
+
+	deepTP "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+)
+
+// SnapshotWriter writes snapshots to a standalone parquet file that uses the same schema as a block, so it can be
+// read with the same tools.
+type SnapshotWriter struct {
+	pw *parquet.GenericWriter[*Snapshot]
+}
+
+// NewSnapshotWriter creates a writer that writes the parquet file to w. The file is not complete until Close is
+// called.
+func NewSnapshotWriter(w io.Writer) *SnapshotWriter {
+	return &SnapshotWriter{
+		pw: parquet.NewGenericWriter[*Snapshot](w),
+	}
+}
+
+// Write adds the snapshot to the file
+func (s *SnapshotWriter) Write(snapshot *deepTP.Snapshot) error {
+	_, err := s.pw.Write([]*Snapshot{snapshotToParquet(snapshot.ID, snapshot, nil)})
+	return err
+}
+
+// Flush writes the buffered snapshots as a row group
+func (s *SnapshotWriter) Flush() error {
+	return s.pw.Flush()
+}
+
+// Close writes any buffered snapshots and the footer of the file
+func (s *SnapshotWriter) Close() error {
+	return s.pw.Close()
+}
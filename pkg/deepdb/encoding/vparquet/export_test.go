@@ -0,0 +1,70 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package vparquet
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/segmentio/parquet-go"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	deeptp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/intergral/deep/pkg/util/test"
+)
+
+func TestSnapshotWriterRoundTrip(t *testing.T) {
+	expected := make([]*deeptp.Snapshot, 0, 10)
+	for i := 0; i < 10; i++ {
+		expected = append(expected, test.GenerateSnapshot(i, &test.GenerateOptions{Id: test.ValidSnapshotID(nil)}))
+	}
+
+	buf := &bytes.Buffer{}
+	w := NewSnapshotWriter(buf)
+	for i, snapshot := range expected {
+		require.NoError(t, w.Write(snapshot))
+		// write more than one row group
+		if i == 4 {
+			require.NoError(t, w.Flush())
+		}
+	}
+	require.NoError(t, w.Close())
+
+	r := parquet.NewGenericReader[*Snapshot](bytes.NewReader(buf.Bytes()))
+	defer r.Close()
+	require.Equal(t, int64(len(expected)), r.NumRows())
+
+	rows := make([]*Snapshot, len(expected))
+	read := 0
+	for read < len(rows) {
+		n, err := r.Read(rows[read:])
+		read += n
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+	require.Equal(t, len(expected), read)
+
+	for i, row := range rows {
+		// parquet reads empty repeated fields as empty rather than nil, which proto.Equal treats the same
+		require.True(t, proto.Equal(expected[i], parquetToDeepSnapshot(row)))
+	}
+}
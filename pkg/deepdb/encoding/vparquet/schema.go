@@ -46,9 +46,10 @@ const (
 
 // These definition levels match the schema below
 const (
-	DefinitionLevelSnapshot      = 0
-	DefinitionLevelResourceAttrs = 1
-	DefinitionLevelSnapshotAttrs = 1
+	DefinitionLevelSnapshot       = 0
+	DefinitionLevelResourceAttrs  = 1
+	DefinitionLevelSnapshotAttrs  = 1
+	DefinitionLevelSnapshotFrames = 1
 
 	FieldResourceAttrKey       = "rs.Attrs.Key"
 	FieldResourceAttrVal       = "rs.Attrs.Value"
@@ -164,6 +165,11 @@ type Snapshot struct {
 	DurationNanos uint64              `parquet:",delta"`
 	Resource      Resource            `parquet:"rs"`
 	LogMsg        *string             `parquet:",snappy,optional"`
+
+	// TraceID and SpanID are the normalized ids from the trace_id and span_id attributes, the attributes are kept
+	// as they were sent
+	TraceID *string `parquet:",snappy,optional"`
+	SpanID  *string `parquet:",snappy,optional"`
 }
 
 func attrToParquet(a *deepCommon.KeyValue, p *Attribute) {
@@ -215,6 +221,16 @@ func snapshotToParquet(id common.ID, snapshot *deepTP.Snapshot, sp *Snapshot) *S
 
 	sp.LogMsg = snapshot.LogMsg
 
+	sp.TraceID = nil
+	sp.SpanID = nil
+	traceID, spanID := util.TraceIDsFromAttributes(snapshot.Attributes)
+	if traceID != "" {
+		sp.TraceID = &traceID
+	}
+	if spanID != "" {
+		sp.SpanID = &spanID
+	}
+
 	sp.Resource.ServiceName = ""
 	sp.Resource.Cluster = nil
 	sp.Resource.Namespace = nil
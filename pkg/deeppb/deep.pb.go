@@ -23,7 +23,8 @@
 package deeppb
 
 import (
-	v11 "github.com/intergral/deep/pkg/deeppb/poll/v1"
+	v12 "github.com/intergral/deep/pkg/deeppb/poll/v1"
+	v11 "github.com/intergral/deep/pkg/deeppb/resource/v1"
 	v1 "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -38,6 +39,61 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type TracepointAuditEvent_ActionType int32
+
+const (
+	TracepointAuditEvent_CREATE TracepointAuditEvent_ActionType = 0
+	TracepointAuditEvent_UPDATE TracepointAuditEvent_ActionType = 1
+	TracepointAuditEvent_DELETE TracepointAuditEvent_ActionType = 2
+	TracepointAuditEvent_PAUSE  TracepointAuditEvent_ActionType = 3
+	TracepointAuditEvent_RESUME TracepointAuditEvent_ActionType = 4
+)
+
+// Enum value maps for TracepointAuditEvent_ActionType.
+var (
+	TracepointAuditEvent_ActionType_name = map[int32]string{
+		0: "CREATE",
+		1: "UPDATE",
+		2: "DELETE",
+		3: "PAUSE",
+		4: "RESUME",
+	}
+	TracepointAuditEvent_ActionType_value = map[string]int32{
+		"CREATE": 0,
+		"UPDATE": 1,
+		"DELETE": 2,
+		"PAUSE":  3,
+		"RESUME": 4,
+	}
+)
+
+func (x TracepointAuditEvent_ActionType) Enum() *TracepointAuditEvent_ActionType {
+	p := new(TracepointAuditEvent_ActionType)
+	*p = x
+	return p
+}
+
+func (x TracepointAuditEvent_ActionType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TracepointAuditEvent_ActionType) Descriptor() protoreflect.EnumDescriptor {
+	return file_deep_proto_enumTypes[0].Descriptor()
+}
+
+func (TracepointAuditEvent_ActionType) Type() protoreflect.EnumType {
+	return &file_deep_proto_enumTypes[0]
+}
+
+func (x TracepointAuditEvent_ActionType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TracepointAuditEvent_ActionType.Descriptor instead.
+func (TracepointAuditEvent_ActionType) EnumDescriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{46, 0}
+}
+
 type SearchRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -52,6 +108,8 @@ type SearchRequest struct {
 	End           uint32            `protobuf:"varint,6,opt,name=end,proto3" json:"end,omitempty"`
 	// DeepQL query
 	Query string `protobuf:"bytes,8,opt,name=Query,proto3" json:"Query,omitempty"`
+	// resolution in seconds of the series returned for DeepQL metrics queries
+	Step uint32 `protobuf:"varint,9,opt,name=step,proto3" json:"step,omitempty"`
 }
 
 func (x *SearchRequest) Reset() {
@@ -135,6 +193,13 @@ func (x *SearchRequest) GetQuery() string {
 	return ""
 }
 
+func (x *SearchRequest) GetStep() uint32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
 // SearchBlockRequest takes SearchRequest parameters as well as all information necessary
 // to search a block in the backend.
 type SearchBlockRequest struct {
@@ -271,6 +336,8 @@ type SearchResponse struct {
 
 	Snapshots []*SnapshotSearchMetadata `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
 	Metrics   *SearchMetrics            `protobuf:"bytes,2,opt,name=metrics,proto3" json:"metrics,omitempty"`
+	// only set for DeepQL metrics queries, e.g. count_over_time({}[5m])
+	Series []*TimeSeries `protobuf:"bytes,3,rep,name=series,proto3" json:"series,omitempty"`
 }
 
 func (x *SearchResponse) Reset() {
@@ -319,6 +386,13 @@ func (x *SearchResponse) GetMetrics() *SearchMetrics {
 	return nil
 }
 
+func (x *SearchResponse) GetSeries() []*TimeSeries {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
 type SnapshotSearchMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -330,6 +404,13 @@ type SnapshotSearchMetadata struct {
 	LineNo            uint32 `protobuf:"varint,4,opt,name=lineNo,proto3" json:"lineNo,omitempty"`
 	StartTimeUnixNano uint64 `protobuf:"varint,5,opt,name=startTimeUnixNano,proto3" json:"startTimeUnixNano,omitempty"`
 	DurationNano      uint64 `protobuf:"varint,6,opt,name=durationNano,proto3" json:"durationNano,omitempty"`
+	// the values of the by() grouping of a DeepQL metrics query
+	SeriesLabels map[string]string `protobuf:"bytes,7,rep,name=seriesLabels,proto3" json:"seriesLabels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// the values of the by() operations in the DeepQL pipeline that this snapshot was grouped by
+	Group map[string]string `protobuf:"bytes,8,rep,name=group,proto3" json:"group,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// the values of the attributes used to group or aggregate the snapshots in the DeepQL pipeline, these are used to
+	// evaluate the pipeline once the results of all shards are combined
+	PipelineValues map[string]*DeepQLValue `protobuf:"bytes,9,rep,name=pipelineValues,proto3" json:"pipelineValues,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *SnapshotSearchMetadata) Reset() {
@@ -406,21 +487,42 @@ func (x *SnapshotSearchMetadata) GetDurationNano() uint64 {
 	return 0
 }
 
-type SearchMetrics struct {
+func (x *SnapshotSearchMetadata) GetSeriesLabels() map[string]string {
+	if x != nil {
+		return x.SeriesLabels
+	}
+	return nil
+}
+
+func (x *SnapshotSearchMetadata) GetGroup() map[string]string {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+func (x *SnapshotSearchMetadata) GetPipelineValues() map[string]*DeepQLValue {
+	if x != nil {
+		return x.PipelineValues
+	}
+	return nil
+}
+
+// DeepQLValue is a typed value used by a DeepQL query
+type DeepQLValue struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	InspectedSnapshots uint32 `protobuf:"varint,1,opt,name=inspectedSnapshots,proto3" json:"inspectedSnapshots,omitempty"`
-	InspectedBytes     uint64 `protobuf:"varint,2,opt,name=inspectedBytes,proto3" json:"inspectedBytes,omitempty"`
-	InspectedBlocks    uint32 `protobuf:"varint,3,opt,name=inspectedBlocks,proto3" json:"inspectedBlocks,omitempty"`
-	SkippedBlocks      uint32 `protobuf:"varint,4,opt,name=skippedBlocks,proto3" json:"skippedBlocks,omitempty"`
-	SkippedSnapshots   uint32 `protobuf:"varint,5,opt,name=skippedSnapshots,proto3" json:"skippedSnapshots,omitempty"`
-	TotalBlockBytes    uint64 `protobuf:"varint,6,opt,name=totalBlockBytes,proto3" json:"totalBlockBytes,omitempty"`
+	Type        int32   `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	IntValue    int64   `protobuf:"varint,2,opt,name=int_value,json=intValue,proto3" json:"int_value,omitempty"`
+	FloatValue  float64 `protobuf:"fixed64,3,opt,name=float_value,json=floatValue,proto3" json:"float_value,omitempty"`
+	StringValue string  `protobuf:"bytes,4,opt,name=string_value,json=stringValue,proto3" json:"string_value,omitempty"`
+	BoolValue   bool    `protobuf:"varint,5,opt,name=bool_value,json=boolValue,proto3" json:"bool_value,omitempty"`
 }
 
-func (x *SearchMetrics) Reset() {
-	*x = SearchMetrics{}
+func (x *DeepQLValue) Reset() {
+	*x = DeepQLValue{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_deep_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -428,13 +530,13 @@ func (x *SearchMetrics) Reset() {
 	}
 }
 
-func (x *SearchMetrics) String() string {
+func (x *DeepQLValue) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchMetrics) ProtoMessage() {}
+func (*DeepQLValue) ProtoMessage() {}
 
-func (x *SearchMetrics) ProtoReflect() protoreflect.Message {
+func (x *DeepQLValue) ProtoReflect() protoreflect.Message {
 	mi := &file_deep_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -446,61 +548,58 @@ func (x *SearchMetrics) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchMetrics.ProtoReflect.Descriptor instead.
-func (*SearchMetrics) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeepQLValue.ProtoReflect.Descriptor instead.
+func (*DeepQLValue) Descriptor() ([]byte, []int) {
 	return file_deep_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *SearchMetrics) GetInspectedSnapshots() uint32 {
-	if x != nil {
-		return x.InspectedSnapshots
-	}
-	return 0
-}
-
-func (x *SearchMetrics) GetInspectedBytes() uint64 {
+func (x *DeepQLValue) GetType() int32 {
 	if x != nil {
-		return x.InspectedBytes
+		return x.Type
 	}
 	return 0
 }
 
-func (x *SearchMetrics) GetInspectedBlocks() uint32 {
+func (x *DeepQLValue) GetIntValue() int64 {
 	if x != nil {
-		return x.InspectedBlocks
+		return x.IntValue
 	}
 	return 0
 }
 
-func (x *SearchMetrics) GetSkippedBlocks() uint32 {
+func (x *DeepQLValue) GetFloatValue() float64 {
 	if x != nil {
-		return x.SkippedBlocks
+		return x.FloatValue
 	}
 	return 0
 }
 
-func (x *SearchMetrics) GetSkippedSnapshots() uint32 {
+func (x *DeepQLValue) GetStringValue() string {
 	if x != nil {
-		return x.SkippedSnapshots
+		return x.StringValue
 	}
-	return 0
+	return ""
 }
 
-func (x *SearchMetrics) GetTotalBlockBytes() uint64 {
+func (x *DeepQLValue) GetBoolValue() bool {
 	if x != nil {
-		return x.TotalBlockBytes
+		return x.BoolValue
 	}
-	return 0
+	return false
 }
 
-type SearchTagsRequest struct {
+// TailSnapshotsRequest follows the snapshots received by the ingesters
+type TailSnapshotsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// DeepQL query, only snapshot filters are supported e.g. { .foo = "bar" }
+	Query string `protobuf:"bytes,1,opt,name=Query,proto3" json:"Query,omitempty"`
 }
 
-func (x *SearchTagsRequest) Reset() {
-	*x = SearchTagsRequest{}
+func (x *TailSnapshotsRequest) Reset() {
+	*x = TailSnapshotsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_deep_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -508,13 +607,13 @@ func (x *SearchTagsRequest) Reset() {
 	}
 }
 
-func (x *SearchTagsRequest) String() string {
+func (x *TailSnapshotsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchTagsRequest) ProtoMessage() {}
+func (*TailSnapshotsRequest) ProtoMessage() {}
 
-func (x *SearchTagsRequest) ProtoReflect() protoreflect.Message {
+func (x *TailSnapshotsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_deep_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -526,21 +625,30 @@ func (x *SearchTagsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchTagsRequest.ProtoReflect.Descriptor instead.
-func (*SearchTagsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TailSnapshotsRequest.ProtoReflect.Descriptor instead.
+func (*TailSnapshotsRequest) Descriptor() ([]byte, []int) {
 	return file_deep_proto_rawDescGZIP(), []int{5}
 }
 
-type SearchTagsResponse struct {
+func (x *TailSnapshotsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+type TailSnapshotsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TagNames []string `protobuf:"bytes,1,rep,name=tagNames,proto3" json:"tagNames,omitempty"`
+	Snapshots []*SnapshotSearchMetadata `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+	// the number of matching snapshots that were not sent as the client could not keep up
+	DroppedSnapshots uint32 `protobuf:"varint,2,opt,name=droppedSnapshots,proto3" json:"droppedSnapshots,omitempty"`
 }
 
-func (x *SearchTagsResponse) Reset() {
-	*x = SearchTagsResponse{}
+func (x *TailSnapshotsResponse) Reset() {
+	*x = TailSnapshotsResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_deep_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -548,13 +656,13 @@ func (x *SearchTagsResponse) Reset() {
 	}
 }
 
-func (x *SearchTagsResponse) String() string {
+func (x *TailSnapshotsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchTagsResponse) ProtoMessage() {}
+func (*TailSnapshotsResponse) ProtoMessage() {}
 
-func (x *SearchTagsResponse) ProtoReflect() protoreflect.Message {
+func (x *TailSnapshotsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_deep_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -566,28 +674,36 @@ func (x *SearchTagsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchTagsResponse.ProtoReflect.Descriptor instead.
-func (*SearchTagsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use TailSnapshotsResponse.ProtoReflect.Descriptor instead.
+func (*TailSnapshotsResponse) Descriptor() ([]byte, []int) {
 	return file_deep_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *SearchTagsResponse) GetTagNames() []string {
+func (x *TailSnapshotsResponse) GetSnapshots() []*SnapshotSearchMetadata {
 	if x != nil {
-		return x.TagNames
+		return x.Snapshots
 	}
 	return nil
 }
 
-type SearchTagValuesRequest struct {
+func (x *TailSnapshotsResponse) GetDroppedSnapshots() uint32 {
+	if x != nil {
+		return x.DroppedSnapshots
+	}
+	return 0
+}
+
+type TimeSeries struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TagName string `protobuf:"bytes,1,opt,name=tagName,proto3" json:"tagName,omitempty"`
+	Labels  map[string]string `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Samples []*Sample         `protobuf:"bytes,2,rep,name=samples,proto3" json:"samples,omitempty"`
 }
 
-func (x *SearchTagValuesRequest) Reset() {
-	*x = SearchTagValuesRequest{}
+func (x *TimeSeries) Reset() {
+	*x = TimeSeries{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_deep_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -595,13 +711,13 @@ func (x *SearchTagValuesRequest) Reset() {
 	}
 }
 
-func (x *SearchTagValuesRequest) String() string {
+func (x *TimeSeries) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchTagValuesRequest) ProtoMessage() {}
+func (*TimeSeries) ProtoMessage() {}
 
-func (x *SearchTagValuesRequest) ProtoReflect() protoreflect.Message {
+func (x *TimeSeries) ProtoReflect() protoreflect.Message {
 	mi := &file_deep_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -613,28 +729,36 @@ func (x *SearchTagValuesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchTagValuesRequest.ProtoReflect.Descriptor instead.
-func (*SearchTagValuesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TimeSeries.ProtoReflect.Descriptor instead.
+func (*TimeSeries) Descriptor() ([]byte, []int) {
 	return file_deep_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *SearchTagValuesRequest) GetTagName() string {
+func (x *TimeSeries) GetLabels() map[string]string {
 	if x != nil {
-		return x.TagName
+		return x.Labels
 	}
-	return ""
+	return nil
 }
 
-type SearchTagValuesResponse struct {
+func (x *TimeSeries) GetSamples() []*Sample {
+	if x != nil {
+		return x.Samples
+	}
+	return nil
+}
+
+type Sample struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TagValues []string `protobuf:"bytes,1,rep,name=tagValues,proto3" json:"tagValues,omitempty"`
+	TimestampMs int64   `protobuf:"varint,1,opt,name=timestampMs,proto3" json:"timestampMs,omitempty"`
+	Value       float64 `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-func (x *SearchTagValuesResponse) Reset() {
-	*x = SearchTagValuesResponse{}
+func (x *Sample) Reset() {
+	*x = Sample{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_deep_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -642,13 +766,13 @@ func (x *SearchTagValuesResponse) Reset() {
 	}
 }
 
-func (x *SearchTagValuesResponse) String() string {
+func (x *Sample) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchTagValuesResponse) ProtoMessage() {}
+func (*Sample) ProtoMessage() {}
 
-func (x *SearchTagValuesResponse) ProtoReflect() protoreflect.Message {
+func (x *Sample) ProtoReflect() protoreflect.Message {
 	mi := &file_deep_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -660,29 +784,40 @@ func (x *SearchTagValuesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchTagValuesResponse.ProtoReflect.Descriptor instead.
-func (*SearchTagValuesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Sample.ProtoReflect.Descriptor instead.
+func (*Sample) Descriptor() ([]byte, []int) {
 	return file_deep_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *SearchTagValuesResponse) GetTagValues() []string {
+func (x *Sample) GetTimestampMs() int64 {
 	if x != nil {
-		return x.TagValues
+		return x.TimestampMs
 	}
-	return nil
+	return 0
 }
 
-type TagValue struct {
+func (x *Sample) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type SearchMetrics struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type  string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	InspectedSnapshots uint32 `protobuf:"varint,1,opt,name=inspectedSnapshots,proto3" json:"inspectedSnapshots,omitempty"`
+	InspectedBytes     uint64 `protobuf:"varint,2,opt,name=inspectedBytes,proto3" json:"inspectedBytes,omitempty"`
+	InspectedBlocks    uint32 `protobuf:"varint,3,opt,name=inspectedBlocks,proto3" json:"inspectedBlocks,omitempty"`
+	SkippedBlocks      uint32 `protobuf:"varint,4,opt,name=skippedBlocks,proto3" json:"skippedBlocks,omitempty"`
+	SkippedSnapshots   uint32 `protobuf:"varint,5,opt,name=skippedSnapshots,proto3" json:"skippedSnapshots,omitempty"`
+	TotalBlockBytes    uint64 `protobuf:"varint,6,opt,name=totalBlockBytes,proto3" json:"totalBlockBytes,omitempty"`
 }
 
-func (x *TagValue) Reset() {
-	*x = TagValue{}
+func (x *SearchMetrics) Reset() {
+	*x = SearchMetrics{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_deep_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -690,13 +825,13 @@ func (x *TagValue) Reset() {
 	}
 }
 
-func (x *TagValue) String() string {
+func (x *SearchMetrics) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TagValue) ProtoMessage() {}
+func (*SearchMetrics) ProtoMessage() {}
 
-func (x *TagValue) ProtoReflect() protoreflect.Message {
+func (x *SearchMetrics) ProtoReflect() protoreflect.Message {
 	mi := &file_deep_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -708,35 +843,61 @@ func (x *TagValue) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TagValue.ProtoReflect.Descriptor instead.
-func (*TagValue) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchMetrics.ProtoReflect.Descriptor instead.
+func (*SearchMetrics) Descriptor() ([]byte, []int) {
 	return file_deep_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *TagValue) GetType() string {
+func (x *SearchMetrics) GetInspectedSnapshots() uint32 {
 	if x != nil {
-		return x.Type
+		return x.InspectedSnapshots
 	}
-	return ""
+	return 0
 }
 
-func (x *TagValue) GetValue() string {
+func (x *SearchMetrics) GetInspectedBytes() uint64 {
 	if x != nil {
-		return x.Value
+		return x.InspectedBytes
 	}
-	return ""
+	return 0
 }
 
-type SearchTagValuesV2Response struct {
+func (x *SearchMetrics) GetInspectedBlocks() uint32 {
+	if x != nil {
+		return x.InspectedBlocks
+	}
+	return 0
+}
+
+func (x *SearchMetrics) GetSkippedBlocks() uint32 {
+	if x != nil {
+		return x.SkippedBlocks
+	}
+	return 0
+}
+
+func (x *SearchMetrics) GetSkippedSnapshots() uint32 {
+	if x != nil {
+		return x.SkippedSnapshots
+	}
+	return 0
+}
+
+func (x *SearchMetrics) GetTotalBlockBytes() uint64 {
+	if x != nil {
+		return x.TotalBlockBytes
+	}
+	return 0
+}
+
+type SearchTagsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	TagValues []*TagValue `protobuf:"bytes,1,rep,name=tagValues,proto3" json:"tagValues,omitempty"`
 }
 
-func (x *SearchTagValuesV2Response) Reset() {
-	*x = SearchTagValuesV2Response{}
+func (x *SearchTagsRequest) Reset() {
+	*x = SearchTagsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_deep_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -744,13 +905,13 @@ func (x *SearchTagValuesV2Response) Reset() {
 	}
 }
 
-func (x *SearchTagValuesV2Response) String() string {
+func (x *SearchTagsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchTagValuesV2Response) ProtoMessage() {}
+func (*SearchTagsRequest) ProtoMessage() {}
 
-func (x *SearchTagValuesV2Response) ProtoReflect() protoreflect.Message {
+func (x *SearchTagsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_deep_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -762,31 +923,21 @@ func (x *SearchTagValuesV2Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchTagValuesV2Response.ProtoReflect.Descriptor instead.
-func (*SearchTagValuesV2Response) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchTagsRequest.ProtoReflect.Descriptor instead.
+func (*SearchTagsRequest) Descriptor() ([]byte, []int) {
 	return file_deep_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *SearchTagValuesV2Response) GetTagValues() []*TagValue {
-	if x != nil {
-		return x.TagValues
-	}
-	return nil
-}
-
-type SnapshotByIDRequest struct {
+type SearchTagsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ID         []byte `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	BlockStart string `protobuf:"bytes,2,opt,name=blockStart,proto3" json:"blockStart,omitempty"`
-	BlockEnd   string `protobuf:"bytes,3,opt,name=blockEnd,proto3" json:"blockEnd,omitempty"`
-	QueryMode  string `protobuf:"bytes,5,opt,name=queryMode,proto3" json:"queryMode,omitempty"`
+	TagNames []string `protobuf:"bytes,1,rep,name=tagNames,proto3" json:"tagNames,omitempty"`
 }
 
-func (x *SnapshotByIDRequest) Reset() {
-	*x = SnapshotByIDRequest{}
+func (x *SearchTagsResponse) Reset() {
+	*x = SearchTagsResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_deep_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -794,13 +945,13 @@ func (x *SnapshotByIDRequest) Reset() {
 	}
 }
 
-func (x *SnapshotByIDRequest) String() string {
+func (x *SearchTagsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SnapshotByIDRequest) ProtoMessage() {}
+func (*SearchTagsResponse) ProtoMessage() {}
 
-func (x *SnapshotByIDRequest) ProtoReflect() protoreflect.Message {
+func (x *SearchTagsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_deep_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -812,50 +963,28 @@ func (x *SnapshotByIDRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SnapshotByIDRequest.ProtoReflect.Descriptor instead.
-func (*SnapshotByIDRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchTagsResponse.ProtoReflect.Descriptor instead.
+func (*SearchTagsResponse) Descriptor() ([]byte, []int) {
 	return file_deep_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *SnapshotByIDRequest) GetID() []byte {
+func (x *SearchTagsResponse) GetTagNames() []string {
 	if x != nil {
-		return x.ID
+		return x.TagNames
 	}
 	return nil
 }
 
-func (x *SnapshotByIDRequest) GetBlockStart() string {
-	if x != nil {
-		return x.BlockStart
-	}
-	return ""
-}
-
-func (x *SnapshotByIDRequest) GetBlockEnd() string {
-	if x != nil {
-		return x.BlockEnd
-	}
-	return ""
-}
-
-func (x *SnapshotByIDRequest) GetQueryMode() string {
-	if x != nil {
-		return x.QueryMode
-	}
-	return ""
-}
-
-type SnapshotByIDResponse struct {
+type SearchTagValuesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Snapshot *v1.Snapshot         `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
-	Metrics  *SnapshotByIDMetrics `protobuf:"bytes,2,opt,name=metrics,proto3" json:"metrics,omitempty"`
+	TagName string `protobuf:"bytes,1,opt,name=tagName,proto3" json:"tagName,omitempty"`
 }
 
-func (x *SnapshotByIDResponse) Reset() {
-	*x = SnapshotByIDResponse{}
+func (x *SearchTagValuesRequest) Reset() {
+	*x = SearchTagValuesRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_deep_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -863,13 +992,13 @@ func (x *SnapshotByIDResponse) Reset() {
 	}
 }
 
-func (x *SnapshotByIDResponse) String() string {
+func (x *SearchTagValuesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SnapshotByIDResponse) ProtoMessage() {}
+func (*SearchTagValuesRequest) ProtoMessage() {}
 
-func (x *SnapshotByIDResponse) ProtoReflect() protoreflect.Message {
+func (x *SearchTagValuesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_deep_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -881,35 +1010,28 @@ func (x *SnapshotByIDResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SnapshotByIDResponse.ProtoReflect.Descriptor instead.
-func (*SnapshotByIDResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchTagValuesRequest.ProtoReflect.Descriptor instead.
+func (*SearchTagValuesRequest) Descriptor() ([]byte, []int) {
 	return file_deep_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *SnapshotByIDResponse) GetSnapshot() *v1.Snapshot {
-	if x != nil {
-		return x.Snapshot
-	}
-	return nil
-}
-
-func (x *SnapshotByIDResponse) GetMetrics() *SnapshotByIDMetrics {
+func (x *SearchTagValuesRequest) GetTagName() string {
 	if x != nil {
-		return x.Metrics
+		return x.TagName
 	}
-	return nil
+	return ""
 }
 
-type SnapshotByIDMetrics struct {
+type SearchTagValuesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	FailedBlocks uint32 `protobuf:"varint,1,opt,name=failedBlocks,proto3" json:"failedBlocks,omitempty"`
+	TagValues []string `protobuf:"bytes,1,rep,name=tagValues,proto3" json:"tagValues,omitempty"`
 }
 
-func (x *SnapshotByIDMetrics) Reset() {
-	*x = SnapshotByIDMetrics{}
+func (x *SearchTagValuesResponse) Reset() {
+	*x = SearchTagValuesResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_deep_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -917,13 +1039,13 @@ func (x *SnapshotByIDMetrics) Reset() {
 	}
 }
 
-func (x *SnapshotByIDMetrics) String() string {
+func (x *SearchTagValuesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SnapshotByIDMetrics) ProtoMessage() {}
+func (*SearchTagValuesResponse) ProtoMessage() {}
 
-func (x *SnapshotByIDMetrics) ProtoReflect() protoreflect.Message {
+func (x *SearchTagValuesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_deep_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -935,28 +1057,29 @@ func (x *SnapshotByIDMetrics) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SnapshotByIDMetrics.ProtoReflect.Descriptor instead.
-func (*SnapshotByIDMetrics) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchTagValuesResponse.ProtoReflect.Descriptor instead.
+func (*SearchTagValuesResponse) Descriptor() ([]byte, []int) {
 	return file_deep_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *SnapshotByIDMetrics) GetFailedBlocks() uint32 {
+func (x *SearchTagValuesResponse) GetTagValues() []string {
 	if x != nil {
-		return x.FailedBlocks
+		return x.TagValues
 	}
-	return 0
+	return nil
 }
 
-type PushSnapshotRequest struct {
+type TagValue struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Snapshot *v1.Snapshot `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	Type  string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-func (x *PushSnapshotRequest) Reset() {
-	*x = PushSnapshotRequest{}
+func (x *TagValue) Reset() {
+	*x = TagValue{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_deep_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -964,13 +1087,13 @@ func (x *PushSnapshotRequest) Reset() {
 	}
 }
 
-func (x *PushSnapshotRequest) String() string {
+func (x *TagValue) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PushSnapshotRequest) ProtoMessage() {}
+func (*TagValue) ProtoMessage() {}
 
-func (x *PushSnapshotRequest) ProtoReflect() protoreflect.Message {
+func (x *TagValue) ProtoReflect() protoreflect.Message {
 	mi := &file_deep_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -982,27 +1105,35 @@ func (x *PushSnapshotRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PushSnapshotRequest.ProtoReflect.Descriptor instead.
-func (*PushSnapshotRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TagValue.ProtoReflect.Descriptor instead.
+func (*TagValue) Descriptor() ([]byte, []int) {
 	return file_deep_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *PushSnapshotRequest) GetSnapshot() *v1.Snapshot {
+func (x *TagValue) GetType() string {
 	if x != nil {
-		return x.Snapshot
+		return x.Type
 	}
-	return nil
+	return ""
 }
 
-// Write
-type PushSnapshotResponse struct {
+func (x *TagValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type SearchTagValuesV2Response struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	TagValues []*TagValue `protobuf:"bytes,1,rep,name=tagValues,proto3" json:"tagValues,omitempty"`
 }
 
-func (x *PushSnapshotResponse) Reset() {
-	*x = PushSnapshotResponse{}
+func (x *SearchTagValuesV2Response) Reset() {
+	*x = SearchTagValuesV2Response{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_deep_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1010,13 +1141,13 @@ func (x *PushSnapshotResponse) Reset() {
 	}
 }
 
-func (x *PushSnapshotResponse) String() string {
+func (x *SearchTagValuesV2Response) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PushSnapshotResponse) ProtoMessage() {}
+func (*SearchTagValuesV2Response) ProtoMessage() {}
 
-func (x *PushSnapshotResponse) ProtoReflect() protoreflect.Message {
+func (x *SearchTagValuesV2Response) ProtoReflect() protoreflect.Message {
 	mi := &file_deep_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1028,22 +1159,31 @@ func (x *PushSnapshotResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PushSnapshotResponse.ProtoReflect.Descriptor instead.
-func (*PushSnapshotResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchTagValuesV2Response.ProtoReflect.Descriptor instead.
+func (*SearchTagValuesV2Response) Descriptor() ([]byte, []int) {
 	return file_deep_proto_rawDescGZIP(), []int{15}
 }
 
-type PushBytesRequest struct {
+func (x *SearchTagValuesV2Response) GetTagValues() []*TagValue {
+	if x != nil {
+		return x.TagValues
+	}
+	return nil
+}
+
+type SnapshotByIDRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Snapshot []byte `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
-	ID       []byte `protobuf:"bytes,2,opt,name=ID,proto3" json:"ID,omitempty"`
+	ID         []byte `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	BlockStart string `protobuf:"bytes,2,opt,name=blockStart,proto3" json:"blockStart,omitempty"`
+	BlockEnd   string `protobuf:"bytes,3,opt,name=blockEnd,proto3" json:"blockEnd,omitempty"`
+	QueryMode  string `protobuf:"bytes,5,opt,name=queryMode,proto3" json:"queryMode,omitempty"`
 }
 
-func (x *PushBytesRequest) Reset() {
-	*x = PushBytesRequest{}
+func (x *SnapshotByIDRequest) Reset() {
+	*x = SnapshotByIDRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_deep_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1051,13 +1191,13 @@ func (x *PushBytesRequest) Reset() {
 	}
 }
 
-func (x *PushBytesRequest) String() string {
+func (x *SnapshotByIDRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PushBytesRequest) ProtoMessage() {}
+func (*SnapshotByIDRequest) ProtoMessage() {}
 
-func (x *PushBytesRequest) ProtoReflect() protoreflect.Message {
+func (x *SnapshotByIDRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_deep_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1069,33 +1209,50 @@ func (x *PushBytesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PushBytesRequest.ProtoReflect.Descriptor instead.
-func (*PushBytesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SnapshotByIDRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotByIDRequest) Descriptor() ([]byte, []int) {
 	return file_deep_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *PushBytesRequest) GetSnapshot() []byte {
+func (x *SnapshotByIDRequest) GetID() []byte {
 	if x != nil {
-		return x.Snapshot
+		return x.ID
 	}
 	return nil
 }
 
-func (x *PushBytesRequest) GetID() []byte {
+func (x *SnapshotByIDRequest) GetBlockStart() string {
 	if x != nil {
-		return x.ID
+		return x.BlockStart
 	}
-	return nil
+	return ""
 }
 
-type PushBytesResponse struct {
+func (x *SnapshotByIDRequest) GetBlockEnd() string {
+	if x != nil {
+		return x.BlockEnd
+	}
+	return ""
+}
+
+func (x *SnapshotByIDRequest) GetQueryMode() string {
+	if x != nil {
+		return x.QueryMode
+	}
+	return ""
+}
+
+type SnapshotByIDResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Snapshot *v1.Snapshot         `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	Metrics  *SnapshotByIDMetrics `protobuf:"bytes,2,opt,name=metrics,proto3" json:"metrics,omitempty"`
 }
 
-func (x *PushBytesResponse) Reset() {
-	*x = PushBytesResponse{}
+func (x *SnapshotByIDResponse) Reset() {
+	*x = SnapshotByIDResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_deep_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1103,13 +1260,13 @@ func (x *PushBytesResponse) Reset() {
 	}
 }
 
-func (x *PushBytesResponse) String() string {
+func (x *SnapshotByIDResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PushBytesResponse) ProtoMessage() {}
+func (*SnapshotByIDResponse) ProtoMessage() {}
 
-func (x *PushBytesResponse) ProtoReflect() protoreflect.Message {
+func (x *SnapshotByIDResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_deep_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1121,21 +1278,35 @@ func (x *PushBytesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PushBytesResponse.ProtoReflect.Descriptor instead.
-func (*PushBytesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SnapshotByIDResponse.ProtoReflect.Descriptor instead.
+func (*SnapshotByIDResponse) Descriptor() ([]byte, []int) {
 	return file_deep_proto_rawDescGZIP(), []int{17}
 }
 
-type LoadTracepointRequest struct {
+func (x *SnapshotByIDResponse) GetSnapshot() *v1.Snapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (x *SnapshotByIDResponse) GetMetrics() *SnapshotByIDMetrics {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+type SnapshotByIDMetrics struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Request *v11.PollRequest `protobuf:"bytes,1,opt,name=Request,proto3,oneof" json:"Request,omitempty"`
+	FailedBlocks uint32 `protobuf:"varint,1,opt,name=failedBlocks,proto3" json:"failedBlocks,omitempty"`
 }
 
-func (x *LoadTracepointRequest) Reset() {
-	*x = LoadTracepointRequest{}
+func (x *SnapshotByIDMetrics) Reset() {
+	*x = SnapshotByIDMetrics{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_deep_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1143,13 +1314,13 @@ func (x *LoadTracepointRequest) Reset() {
 	}
 }
 
-func (x *LoadTracepointRequest) String() string {
+func (x *SnapshotByIDMetrics) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoadTracepointRequest) ProtoMessage() {}
+func (*SnapshotByIDMetrics) ProtoMessage() {}
 
-func (x *LoadTracepointRequest) ProtoReflect() protoreflect.Message {
+func (x *SnapshotByIDMetrics) ProtoReflect() protoreflect.Message {
 	mi := &file_deep_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1161,28 +1332,33 @@ func (x *LoadTracepointRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoadTracepointRequest.ProtoReflect.Descriptor instead.
-func (*LoadTracepointRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SnapshotByIDMetrics.ProtoReflect.Descriptor instead.
+func (*SnapshotByIDMetrics) Descriptor() ([]byte, []int) {
 	return file_deep_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *LoadTracepointRequest) GetRequest() *v11.PollRequest {
+func (x *SnapshotByIDMetrics) GetFailedBlocks() uint32 {
 	if x != nil {
-		return x.Request
+		return x.FailedBlocks
 	}
-	return nil
+	return 0
 }
 
-type LoadTracepointResponse struct {
+// SnapshotDiffResponse describes what changed from snapshot a to snapshot b
+type SnapshotDiffResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Response *v11.PollResponse `protobuf:"bytes,1,opt,name=Response,proto3" json:"Response,omitempty"`
+	SnapshotA string          `protobuf:"bytes,1,opt,name=snapshotA,proto3" json:"snapshotA,omitempty"`
+	SnapshotB string          `protobuf:"bytes,2,opt,name=snapshotB,proto3" json:"snapshotB,omitempty"`
+	Variables []*VariableDiff `protobuf:"bytes,3,rep,name=variables,proto3" json:"variables,omitempty"`
+	Frames    []*FrameDiff    `protobuf:"bytes,4,rep,name=frames,proto3" json:"frames,omitempty"`
+	Watches   []*WatchDiff    `protobuf:"bytes,5,rep,name=watches,proto3" json:"watches,omitempty"`
 }
 
-func (x *LoadTracepointResponse) Reset() {
-	*x = LoadTracepointResponse{}
+func (x *SnapshotDiffResponse) Reset() {
+	*x = SnapshotDiffResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_deep_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1190,13 +1366,13 @@ func (x *LoadTracepointResponse) Reset() {
 	}
 }
 
-func (x *LoadTracepointResponse) String() string {
+func (x *SnapshotDiffResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoadTracepointResponse) ProtoMessage() {}
+func (*SnapshotDiffResponse) ProtoMessage() {}
 
-func (x *LoadTracepointResponse) ProtoReflect() protoreflect.Message {
+func (x *SnapshotDiffResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_deep_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1208,28 +1384,61 @@ func (x *LoadTracepointResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoadTracepointResponse.ProtoReflect.Descriptor instead.
-func (*LoadTracepointResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SnapshotDiffResponse.ProtoReflect.Descriptor instead.
+func (*SnapshotDiffResponse) Descriptor() ([]byte, []int) {
 	return file_deep_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *LoadTracepointResponse) GetResponse() *v11.PollResponse {
+func (x *SnapshotDiffResponse) GetSnapshotA() string {
 	if x != nil {
-		return x.Response
+		return x.SnapshotA
 	}
-	return nil
+	return ""
 }
 
-type CreateTracepointRequest struct {
+func (x *SnapshotDiffResponse) GetSnapshotB() string {
+	if x != nil {
+		return x.SnapshotB
+	}
+	return ""
+}
+
+func (x *SnapshotDiffResponse) GetVariables() []*VariableDiff {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *SnapshotDiffResponse) GetFrames() []*FrameDiff {
+	if x != nil {
+		return x.Frames
+	}
+	return nil
+}
+
+func (x *SnapshotDiffResponse) GetWatches() []*WatchDiff {
+	if x != nil {
+		return x.Watches
+	}
+	return nil
+}
+
+type VariableDiff struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Tracepoint *v1.TracePointConfig `protobuf:"bytes,1,opt,name=Tracepoint,proto3" json:"Tracepoint,omitempty"`
+	// the path of the variable from the frame or watch, e.g. frames[0].user.name
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// one of added, removed or changed
+	Change string     `protobuf:"bytes,2,opt,name=change,proto3" json:"change,omitempty"`
+	A      *DiffValue `protobuf:"bytes,3,opt,name=a,proto3" json:"a,omitempty"`
+	B      *DiffValue `protobuf:"bytes,4,opt,name=b,proto3" json:"b,omitempty"`
 }
 
-func (x *CreateTracepointRequest) Reset() {
-	*x = CreateTracepointRequest{}
+func (x *VariableDiff) Reset() {
+	*x = VariableDiff{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_deep_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1237,13 +1446,13 @@ func (x *CreateTracepointRequest) Reset() {
 	}
 }
 
-func (x *CreateTracepointRequest) String() string {
+func (x *VariableDiff) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTracepointRequest) ProtoMessage() {}
+func (*VariableDiff) ProtoMessage() {}
 
-func (x *CreateTracepointRequest) ProtoReflect() protoreflect.Message {
+func (x *VariableDiff) ProtoReflect() protoreflect.Message {
 	mi := &file_deep_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1255,26 +1464,54 @@ func (x *CreateTracepointRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTracepointRequest.ProtoReflect.Descriptor instead.
-func (*CreateTracepointRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use VariableDiff.ProtoReflect.Descriptor instead.
+func (*VariableDiff) Descriptor() ([]byte, []int) {
 	return file_deep_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *CreateTracepointRequest) GetTracepoint() *v1.TracePointConfig {
+func (x *VariableDiff) GetPath() string {
 	if x != nil {
-		return x.Tracepoint
+		return x.Path
+	}
+	return ""
+}
+
+func (x *VariableDiff) GetChange() string {
+	if x != nil {
+		return x.Change
+	}
+	return ""
+}
+
+func (x *VariableDiff) GetA() *DiffValue {
+	if x != nil {
+		return x.A
 	}
 	return nil
 }
 
-type CreateTracepointResponse struct {
+func (x *VariableDiff) GetB() *DiffValue {
+	if x != nil {
+		return x.B
+	}
+	return nil
+}
+
+type FrameDiff struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// the index of the frame, from the top of the stack
+	Index uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// one of added, removed or changed
+	Change string `protobuf:"bytes,2,opt,name=change,proto3" json:"change,omitempty"`
+	A      string `protobuf:"bytes,3,opt,name=a,proto3" json:"a,omitempty"`
+	B      string `protobuf:"bytes,4,opt,name=b,proto3" json:"b,omitempty"`
 }
 
-func (x *CreateTracepointResponse) Reset() {
-	*x = CreateTracepointResponse{}
+func (x *FrameDiff) Reset() {
+	*x = FrameDiff{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_deep_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1282,438 +1519,4079 @@ func (x *CreateTracepointResponse) Reset() {
 	}
 }
 
-func (x *CreateTracepointResponse) String() string {
+func (x *FrameDiff) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTracepointResponse) ProtoMessage() {}
+func (*FrameDiff) ProtoMessage() {}
 
-func (x *CreateTracepointResponse) ProtoReflect() protoreflect.Message {
+func (x *FrameDiff) ProtoReflect() protoreflect.Message {
 	mi := &file_deep_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use CreateTracepointResponse.ProtoReflect.Descriptor instead.
-func (*CreateTracepointResponse) Descriptor() ([]byte, []int) {
-	return file_deep_proto_rawDescGZIP(), []int{21}
-}
-
-type DeleteTracepointRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	TracepointID string `protobuf:"bytes,1,opt,name=TracepointID,proto3" json:"TracepointID,omitempty"`
-}
-
-func (x *DeleteTracepointRequest) Reset() {
-	*x = DeleteTracepointRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_deep_proto_msgTypes[22]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *DeleteTracepointRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*DeleteTracepointRequest) ProtoMessage() {}
-
-func (x *DeleteTracepointRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_deep_proto_msgTypes[22]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameDiff.ProtoReflect.Descriptor instead.
+func (*FrameDiff) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *FrameDiff) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *FrameDiff) GetChange() string {
+	if x != nil {
+		return x.Change
+	}
+	return ""
+}
+
+func (x *FrameDiff) GetA() string {
+	if x != nil {
+		return x.A
+	}
+	return ""
+}
+
+func (x *FrameDiff) GetB() string {
+	if x != nil {
+		return x.B
+	}
+	return ""
+}
+
+type WatchDiff struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Expression string `protobuf:"bytes,1,opt,name=expression,proto3" json:"expression,omitempty"`
+	// one of added, removed or changed
+	Change string     `protobuf:"bytes,2,opt,name=change,proto3" json:"change,omitempty"`
+	A      *DiffValue `protobuf:"bytes,3,opt,name=a,proto3" json:"a,omitempty"`
+	B      *DiffValue `protobuf:"bytes,4,opt,name=b,proto3" json:"b,omitempty"`
+}
+
+func (x *WatchDiff) Reset() {
+	*x = WatchDiff{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchDiff) ProtoMessage() {}
+
+func (x *WatchDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchDiff.ProtoReflect.Descriptor instead.
+func (*WatchDiff) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WatchDiff) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+func (x *WatchDiff) GetChange() string {
+	if x != nil {
+		return x.Change
+	}
+	return ""
+}
+
+func (x *WatchDiff) GetA() *DiffValue {
+	if x != nil {
+		return x.A
+	}
+	return nil
+}
+
+func (x *WatchDiff) GetB() *DiffValue {
+	if x != nil {
+		return x.B
+	}
+	return nil
+}
+
+type DiffValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type  string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *DiffValue) Reset() {
+	*x = DiffValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DiffValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffValue) ProtoMessage() {}
+
+func (x *DiffValue) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffValue.ProtoReflect.Descriptor instead.
+func (*DiffValue) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DiffValue) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DiffValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type PushSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Snapshot *v1.Snapshot `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+}
+
+func (x *PushSnapshotRequest) Reset() {
+	*x = PushSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushSnapshotRequest) ProtoMessage() {}
+
+func (x *PushSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*PushSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PushSnapshotRequest) GetSnapshot() *v1.Snapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+// Write
+type PushSnapshotResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *PushSnapshotResponse) Reset() {
+	*x = PushSnapshotResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushSnapshotResponse) ProtoMessage() {}
+
+func (x *PushSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*PushSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{25}
+}
+
+type PushBytesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Snapshot []byte `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	ID       []byte `protobuf:"bytes,2,opt,name=ID,proto3" json:"ID,omitempty"`
+}
+
+func (x *PushBytesRequest) Reset() {
+	*x = PushBytesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushBytesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushBytesRequest) ProtoMessage() {}
+
+func (x *PushBytesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushBytesRequest.ProtoReflect.Descriptor instead.
+func (*PushBytesRequest) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PushBytesRequest) GetSnapshot() []byte {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (x *PushBytesRequest) GetID() []byte {
+	if x != nil {
+		return x.ID
+	}
+	return nil
+}
+
+type PushBytesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *PushBytesResponse) Reset() {
+	*x = PushBytesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushBytesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushBytesResponse) ProtoMessage() {}
+
+func (x *PushBytesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushBytesResponse.ProtoReflect.Descriptor instead.
+func (*PushBytesResponse) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{27}
+}
+
+// ReportStatusRequest is sent by an agent after it has applied the tracepoints from a poll response. The fields use
+// the same naming as the agent protos, as this message is sent by the agents.
+type ReportStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// resource identifies the agent, this should be the resource the agent sends when polling
+	Resource *v11.Resource              `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	Status   []*TracepointInstallStatus `protobuf:"bytes,2,rep,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *ReportStatusRequest) Reset() {
+	*x = ReportStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportStatusRequest) ProtoMessage() {}
+
+func (x *ReportStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportStatusRequest.ProtoReflect.Descriptor instead.
+func (*ReportStatusRequest) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ReportStatusRequest) GetResource() *v11.Resource {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+func (x *ReportStatusRequest) GetStatus() []*TracepointInstallStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+// TracepointInstallStatus is the result of an agent installing a single tracepoint
+type TracepointInstallStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TracepointId string `protobuf:"bytes,1,opt,name=tracepoint_id,json=tracepointId,proto3" json:"tracepoint_id,omitempty"`
+	Installed    bool   `protobuf:"varint,2,opt,name=installed,proto3" json:"installed,omitempty"`
+	// error is the reason the tracepoint could not be installed, e.g. the file does not exist or the condition is invalid
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *TracepointInstallStatus) Reset() {
+	*x = TracepointInstallStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TracepointInstallStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TracepointInstallStatus) ProtoMessage() {}
+
+func (x *TracepointInstallStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TracepointInstallStatus.ProtoReflect.Descriptor instead.
+func (*TracepointInstallStatus) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *TracepointInstallStatus) GetTracepointId() string {
+	if x != nil {
+		return x.TracepointId
+	}
+	return ""
+}
+
+func (x *TracepointInstallStatus) GetInstalled() bool {
+	if x != nil {
+		return x.Installed
+	}
+	return false
+}
+
+func (x *TracepointInstallStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ReportStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ReportStatusResponse) Reset() {
+	*x = ReportStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportStatusResponse) ProtoMessage() {}
+
+func (x *ReportStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportStatusResponse.ProtoReflect.Descriptor instead.
+func (*ReportStatusResponse) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{30}
+}
+
+// TracepointMetadata is the server side state we keep for each tracepoint, this is not sent to the agents
+type TracepointMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Version is incremented each time the tracepoint is updated
+	Version uint64            `protobuf:"varint,1,opt,name=Version,proto3" json:"Version,omitempty"`
+	Limits  *TracepointLimits `protobuf:"bytes,2,opt,name=Limits,proto3" json:"Limits,omitempty"`
+	// SnapshotCount is the number of snapshots the distributors have received for this tracepoint
+	SnapshotCount uint64 `protobuf:"varint,3,opt,name=SnapshotCount,proto3" json:"SnapshotCount,omitempty"`
+	// Labels are used to group tracepoints, so they can be managed together
+	Labels map[string]string `protobuf:"bytes,4,rep,name=Labels,proto3" json:"Labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Paused tracepoints are kept, but are not sent to the agents
+	Paused bool `protobuf:"varint,5,opt,name=Paused,proto3" json:"Paused,omitempty"`
+}
+
+func (x *TracepointMetadata) Reset() {
+	*x = TracepointMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TracepointMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TracepointMetadata) ProtoMessage() {}
+
+func (x *TracepointMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TracepointMetadata.ProtoReflect.Descriptor instead.
+func (*TracepointMetadata) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *TracepointMetadata) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *TracepointMetadata) GetLimits() *TracepointLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *TracepointMetadata) GetSnapshotCount() uint64 {
+	if x != nil {
+		return x.SnapshotCount
+	}
+	return 0
+}
+
+func (x *TracepointMetadata) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *TracepointMetadata) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+// TracepointInstallSummary is the install status of a tracepoint across the agents that have reported it
+type TracepointInstallSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Installed uint32 `protobuf:"varint,1,opt,name=Installed,proto3" json:"Installed,omitempty"`
+	Failed    uint32 `protobuf:"varint,2,opt,name=Failed,proto3" json:"Failed,omitempty"`
+	// Errors are the latest distinct errors reported by the agents, newest first
+	Errors []string `protobuf:"bytes,3,rep,name=Errors,proto3" json:"Errors,omitempty"`
+}
+
+func (x *TracepointInstallSummary) Reset() {
+	*x = TracepointInstallSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TracepointInstallSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TracepointInstallSummary) ProtoMessage() {}
+
+func (x *TracepointInstallSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TracepointInstallSummary.ProtoReflect.Descriptor instead.
+func (*TracepointInstallSummary) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *TracepointInstallSummary) GetInstalled() uint32 {
+	if x != nil {
+		return x.Installed
+	}
+	return 0
+}
+
+func (x *TracepointInstallSummary) GetFailed() uint32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *TracepointInstallSummary) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// TracepointLimits define when the tracepoint service will retire (remove) a tracepoint
+type TracepointLimits struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ExpiresAtNanos is the time (as epoch nanos) after which the tracepoint is removed, 0 means it does not expire
+	ExpiresAtNanos uint64 `protobuf:"varint,1,opt,name=ExpiresAtNanos,proto3" json:"ExpiresAtNanos,omitempty"`
+	// TTLSeconds is used to set ExpiresAtNanos when the tracepoint is created or updated
+	TTLSeconds uint64 `protobuf:"varint,2,opt,name=TTLSeconds,proto3" json:"TTLSeconds,omitempty"`
+	// MaxSnapshots is the number of snapshots after which the tracepoint is removed, 0 means there is no limit
+	MaxSnapshots uint64 `protobuf:"varint,3,opt,name=MaxSnapshots,proto3" json:"MaxSnapshots,omitempty"`
+	// SnapshotsPerSecond is the rate of snapshots the distributors will accept for this tracepoint, 0 uses the tenant limit
+	SnapshotsPerSecond float64 `protobuf:"fixed64,4,opt,name=SnapshotsPerSecond,proto3" json:"SnapshotsPerSecond,omitempty"`
+	// BytesPerSecond is the rate of snapshot bytes the distributors will accept for this tracepoint, 0 uses the tenant limit
+	BytesPerSecond uint64 `protobuf:"varint,5,opt,name=BytesPerSecond,proto3" json:"BytesPerSecond,omitempty"`
+}
+
+func (x *TracepointLimits) Reset() {
+	*x = TracepointLimits{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TracepointLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TracepointLimits) ProtoMessage() {}
+
+func (x *TracepointLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TracepointLimits.ProtoReflect.Descriptor instead.
+func (*TracepointLimits) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *TracepointLimits) GetExpiresAtNanos() uint64 {
+	if x != nil {
+		return x.ExpiresAtNanos
+	}
+	return 0
+}
+
+func (x *TracepointLimits) GetTTLSeconds() uint64 {
+	if x != nil {
+		return x.TTLSeconds
+	}
+	return 0
+}
+
+func (x *TracepointLimits) GetMaxSnapshots() uint64 {
+	if x != nil {
+		return x.MaxSnapshots
+	}
+	return 0
+}
+
+func (x *TracepointLimits) GetSnapshotsPerSecond() float64 {
+	if x != nil {
+		return x.SnapshotsPerSecond
+	}
+	return 0
+}
+
+func (x *TracepointLimits) GetBytesPerSecond() uint64 {
+	if x != nil {
+		return x.BytesPerSecond
+	}
+	return 0
+}
+
+// TracepointBlockMetadata is stored at the end of the tracepoint block for a tenant
+type TracepointBlockMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tracepoints map[string]*TracepointMetadata `protobuf:"bytes,1,rep,name=Tracepoints,proto3" json:"Tracepoints,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *TracepointBlockMetadata) Reset() {
+	*x = TracepointBlockMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TracepointBlockMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TracepointBlockMetadata) ProtoMessage() {}
+
+func (x *TracepointBlockMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TracepointBlockMetadata.ProtoReflect.Descriptor instead.
+func (*TracepointBlockMetadata) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *TracepointBlockMetadata) GetTracepoints() map[string]*TracepointMetadata {
+	if x != nil {
+		return x.Tracepoints
+	}
+	return nil
+}
+
+type LoadTracepointRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Request *v12.PollRequest `protobuf:"bytes,1,opt,name=Request,proto3,oneof" json:"Request,omitempty"`
+	// IncludePaused will include paused tracepoints in the response, this is used when listing the tracepoints
+	// rather than when polling from an agent
+	IncludePaused bool `protobuf:"varint,2,opt,name=IncludePaused,proto3" json:"IncludePaused,omitempty"`
+	// IncludeStatus will include the install status reported by the agents in the response
+	IncludeStatus bool `protobuf:"varint,3,opt,name=IncludeStatus,proto3" json:"IncludeStatus,omitempty"`
+}
+
+func (x *LoadTracepointRequest) Reset() {
+	*x = LoadTracepointRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoadTracepointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadTracepointRequest) ProtoMessage() {}
+
+func (x *LoadTracepointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadTracepointRequest.ProtoReflect.Descriptor instead.
+func (*LoadTracepointRequest) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *LoadTracepointRequest) GetRequest() *v12.PollRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *LoadTracepointRequest) GetIncludePaused() bool {
+	if x != nil {
+		return x.IncludePaused
+	}
+	return false
+}
+
+func (x *LoadTracepointRequest) GetIncludeStatus() bool {
+	if x != nil {
+		return x.IncludeStatus
+	}
+	return false
+}
+
+type LoadTracepointResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Response *v12.PollResponse              `protobuf:"bytes,1,opt,name=Response,proto3" json:"Response,omitempty"`
+	Metadata map[string]*TracepointMetadata `protobuf:"bytes,2,rep,name=Metadata,proto3" json:"Metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Status is the install status of each tracepoint by tracepoint ID, this is only set if IncludeStatus is requested
+	Status map[string]*TracepointInstallSummary `protobuf:"bytes,3,rep,name=Status,proto3" json:"Status,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *LoadTracepointResponse) Reset() {
+	*x = LoadTracepointResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoadTracepointResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadTracepointResponse) ProtoMessage() {}
+
+func (x *LoadTracepointResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadTracepointResponse.ProtoReflect.Descriptor instead.
+func (*LoadTracepointResponse) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *LoadTracepointResponse) GetResponse() *v12.PollResponse {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *LoadTracepointResponse) GetMetadata() map[string]*TracepointMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *LoadTracepointResponse) GetStatus() map[string]*TracepointInstallSummary {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+// ListTracepointsResponse is returned when listing the tracepoints over http. The first fields match
+// deeppb.poll.v1.PollResponse, so clients that read the list as a PollResponse continue to work.
+type ListTracepointsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TsNanos      uint64                 `protobuf:"fixed64,1,opt,name=ts_nanos,json=tsNanos,proto3" json:"ts_nanos,omitempty"`
+	CurrentHash  string                 `protobuf:"bytes,2,opt,name=current_hash,json=currentHash,proto3" json:"current_hash,omitempty"`
+	Response     []*v1.TracePointConfig `protobuf:"bytes,3,rep,name=response,proto3" json:"response,omitempty"`
+	ResponseType v12.ResponseType       `protobuf:"varint,4,opt,name=response_type,json=responseType,proto3,enum=deeppb.poll.v1.ResponseType" json:"response_type,omitempty"`
+	// status is the install status reported by the agents, by tracepoint ID
+	Status map[string]*TracepointInstallSummary `protobuf:"bytes,5,rep,name=status,proto3" json:"status,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ListTracepointsResponse) Reset() {
+	*x = ListTracepointsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTracepointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTracepointsResponse) ProtoMessage() {}
+
+func (x *ListTracepointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTracepointsResponse.ProtoReflect.Descriptor instead.
+func (*ListTracepointsResponse) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListTracepointsResponse) GetTsNanos() uint64 {
+	if x != nil {
+		return x.TsNanos
+	}
+	return 0
+}
+
+func (x *ListTracepointsResponse) GetCurrentHash() string {
+	if x != nil {
+		return x.CurrentHash
+	}
+	return ""
+}
+
+func (x *ListTracepointsResponse) GetResponse() []*v1.TracePointConfig {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ListTracepointsResponse) GetResponseType() v12.ResponseType {
+	if x != nil {
+		return x.ResponseType
+	}
+	return v12.ResponseType_NO_CHANGE
+}
+
+func (x *ListTracepointsResponse) GetStatus() map[string]*TracepointInstallSummary {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type CreateTracepointRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tracepoint *v1.TracePointConfig `protobuf:"bytes,1,opt,name=Tracepoint,proto3" json:"Tracepoint,omitempty"`
+	Limits     *TracepointLimits    `protobuf:"bytes,2,opt,name=Limits,proto3" json:"Limits,omitempty"`
+	// Actor is the user making the change, this is recorded in the audit log
+	Actor  string            `protobuf:"bytes,3,opt,name=Actor,proto3" json:"Actor,omitempty"`
+	Labels map[string]string `protobuf:"bytes,4,rep,name=Labels,proto3" json:"Labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Paused will create the tracepoint without sending it to the agents
+	Paused bool `protobuf:"varint,5,opt,name=Paused,proto3" json:"Paused,omitempty"`
+}
+
+func (x *CreateTracepointRequest) Reset() {
+	*x = CreateTracepointRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTracepointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTracepointRequest) ProtoMessage() {}
+
+func (x *CreateTracepointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTracepointRequest.ProtoReflect.Descriptor instead.
+func (*CreateTracepointRequest) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CreateTracepointRequest) GetTracepoint() *v1.TracePointConfig {
+	if x != nil {
+		return x.Tracepoint
+	}
+	return nil
+}
+
+func (x *CreateTracepointRequest) GetLimits() *TracepointLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *CreateTracepointRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *CreateTracepointRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *CreateTracepointRequest) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+type CreateTracepointResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tracepoint *v1.TracePointConfig `protobuf:"bytes,1,opt,name=Tracepoint,proto3" json:"Tracepoint,omitempty"`
+	Metadata   *TracepointMetadata  `protobuf:"bytes,2,opt,name=Metadata,proto3" json:"Metadata,omitempty"`
+}
+
+func (x *CreateTracepointResponse) Reset() {
+	*x = CreateTracepointResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTracepointResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTracepointResponse) ProtoMessage() {}
+
+func (x *CreateTracepointResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTracepointResponse.ProtoReflect.Descriptor instead.
+func (*CreateTracepointResponse) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *CreateTracepointResponse) GetTracepoint() *v1.TracePointConfig {
+	if x != nil {
+		return x.Tracepoint
+	}
+	return nil
+}
+
+func (x *CreateTracepointResponse) GetMetadata() *TracepointMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type DeleteTracepointRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TracepointID string `protobuf:"bytes,1,opt,name=TracepointID,proto3" json:"TracepointID,omitempty"`
+	// Actor is the user making the change, this is recorded in the audit log
+	Actor string `protobuf:"bytes,2,opt,name=Actor,proto3" json:"Actor,omitempty"`
+}
+
+func (x *DeleteTracepointRequest) Reset() {
+	*x = DeleteTracepointRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteTracepointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTracepointRequest) ProtoMessage() {}
+
+func (x *DeleteTracepointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTracepointRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTracepointRequest) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *DeleteTracepointRequest) GetTracepointID() string {
+	if x != nil {
+		return x.TracepointID
+	}
+	return ""
+}
+
+func (x *DeleteTracepointRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+type DeleteTracepointResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteTracepointResponse) Reset() {
+	*x = DeleteTracepointResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteTracepointResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTracepointResponse) ProtoMessage() {}
+
+func (x *DeleteTracepointResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTracepointResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTracepointResponse) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{41}
+}
+
+type UpdateTracepointRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tracepoint *v1.TracePointConfig `protobuf:"bytes,1,opt,name=Tracepoint,proto3" json:"Tracepoint,omitempty"`
+	// Version is the version of the tracepoint this update is based on, if it does not match the stored version the
+	// update is rejected. A version of 0 will always update the tracepoint.
+	Version uint64 `protobuf:"varint,2,opt,name=Version,proto3" json:"Version,omitempty"`
+	// Limits will replace the current limits for the tracepoint, if not set the current limits are kept
+	Limits *TracepointLimits `protobuf:"bytes,3,opt,name=Limits,proto3" json:"Limits,omitempty"`
+	// Actor is the user making the change, this is recorded in the audit log
+	Actor string `protobuf:"bytes,4,opt,name=Actor,proto3" json:"Actor,omitempty"`
+	// Labels will replace the labels of the tracepoint, if not set the current labels are kept
+	Labels map[string]string `protobuf:"bytes,5,rep,name=Labels,proto3" json:"Labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *UpdateTracepointRequest) Reset() {
+	*x = UpdateTracepointRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateTracepointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTracepointRequest) ProtoMessage() {}
+
+func (x *UpdateTracepointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTracepointRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTracepointRequest) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *UpdateTracepointRequest) GetTracepoint() *v1.TracePointConfig {
+	if x != nil {
+		return x.Tracepoint
+	}
+	return nil
+}
+
+func (x *UpdateTracepointRequest) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateTracepointRequest) GetLimits() *TracepointLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *UpdateTracepointRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *UpdateTracepointRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type UpdateTracepointResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tracepoint *v1.TracePointConfig `protobuf:"bytes,1,opt,name=Tracepoint,proto3" json:"Tracepoint,omitempty"`
+	Metadata   *TracepointMetadata  `protobuf:"bytes,2,opt,name=Metadata,proto3" json:"Metadata,omitempty"`
+}
+
+func (x *UpdateTracepointResponse) Reset() {
+	*x = UpdateTracepointResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateTracepointResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTracepointResponse) ProtoMessage() {}
+
+func (x *UpdateTracepointResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTracepointResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTracepointResponse) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *UpdateTracepointResponse) GetTracepoint() *v1.TracePointConfig {
+	if x != nil {
+		return x.Tracepoint
+	}
+	return nil
+}
+
+func (x *UpdateTracepointResponse) GetMetadata() *TracepointMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type RecordSnapshotsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Counts is the number of snapshots received for each tracepoint ID
+	Counts map[string]uint64 `protobuf:"bytes,1,rep,name=Counts,proto3" json:"Counts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *RecordSnapshotsRequest) Reset() {
+	*x = RecordSnapshotsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordSnapshotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordSnapshotsRequest) ProtoMessage() {}
+
+func (x *RecordSnapshotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordSnapshotsRequest.ProtoReflect.Descriptor instead.
+func (*RecordSnapshotsRequest) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *RecordSnapshotsRequest) GetCounts() map[string]uint64 {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+type RecordSnapshotsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RecordSnapshotsResponse) Reset() {
+	*x = RecordSnapshotsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordSnapshotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordSnapshotsResponse) ProtoMessage() {}
+
+func (x *RecordSnapshotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordSnapshotsResponse.ProtoReflect.Descriptor instead.
+func (*RecordSnapshotsResponse) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{45}
+}
+
+// TracepointAuditEvent records a single change to a tracepoint
+type TracepointAuditEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TracepointID string                          `protobuf:"bytes,1,opt,name=TracepointID,proto3" json:"TracepointID,omitempty"`
+	Action       TracepointAuditEvent_ActionType `protobuf:"varint,2,opt,name=Action,proto3,enum=deeppb.TracepointAuditEvent_ActionType" json:"Action,omitempty"`
+	// Actor is the user that made the change, taken from the request headers
+	Actor          string `protobuf:"bytes,3,opt,name=Actor,proto3" json:"Actor,omitempty"`
+	TimestampNanos uint64 `protobuf:"varint,4,opt,name=TimestampNanos,proto3" json:"TimestampNanos,omitempty"`
+	// Before is the config before the change, this is not set for CREATE
+	Before *v1.TracePointConfig `protobuf:"bytes,5,opt,name=Before,proto3" json:"Before,omitempty"`
+	// After is the config after the change, this is not set for DELETE
+	After *v1.TracePointConfig `protobuf:"bytes,6,opt,name=After,proto3" json:"After,omitempty"`
+}
+
+func (x *TracepointAuditEvent) Reset() {
+	*x = TracepointAuditEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TracepointAuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TracepointAuditEvent) ProtoMessage() {}
+
+func (x *TracepointAuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TracepointAuditEvent.ProtoReflect.Descriptor instead.
+func (*TracepointAuditEvent) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *TracepointAuditEvent) GetTracepointID() string {
+	if x != nil {
+		return x.TracepointID
+	}
+	return ""
+}
+
+func (x *TracepointAuditEvent) GetAction() TracepointAuditEvent_ActionType {
+	if x != nil {
+		return x.Action
+	}
+	return TracepointAuditEvent_CREATE
+}
+
+func (x *TracepointAuditEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *TracepointAuditEvent) GetTimestampNanos() uint64 {
+	if x != nil {
+		return x.TimestampNanos
+	}
+	return 0
+}
+
+func (x *TracepointAuditEvent) GetBefore() *v1.TracePointConfig {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *TracepointAuditEvent) GetAfter() *v1.TracePointConfig {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+// TracepointAuditLog is the stored form of the audit log for a tenant, events are in the order they happened
+type TracepointAuditLog struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Events []*TracepointAuditEvent `protobuf:"bytes,1,rep,name=Events,proto3" json:"Events,omitempty"`
+}
+
+func (x *TracepointAuditLog) Reset() {
+	*x = TracepointAuditLog{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TracepointAuditLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TracepointAuditLog) ProtoMessage() {}
+
+func (x *TracepointAuditLog) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TracepointAuditLog.ProtoReflect.Descriptor instead.
+func (*TracepointAuditLog) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *TracepointAuditLog) GetEvents() []*TracepointAuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type TracepointHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TracepointID string `protobuf:"bytes,1,opt,name=TracepointID,proto3" json:"TracepointID,omitempty"`
+}
+
+func (x *TracepointHistoryRequest) Reset() {
+	*x = TracepointHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TracepointHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TracepointHistoryRequest) ProtoMessage() {}
+
+func (x *TracepointHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TracepointHistoryRequest.ProtoReflect.Descriptor instead.
+func (*TracepointHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *TracepointHistoryRequest) GetTracepointID() string {
+	if x != nil {
+		return x.TracepointID
+	}
+	return ""
+}
+
+type TracepointHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Events []*TracepointAuditEvent `protobuf:"bytes,1,rep,name=Events,proto3" json:"Events,omitempty"`
+}
+
+func (x *TracepointHistoryResponse) Reset() {
+	*x = TracepointHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TracepointHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TracepointHistoryResponse) ProtoMessage() {}
+
+func (x *TracepointHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TracepointHistoryResponse.ProtoReflect.Descriptor instead.
+func (*TracepointHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *TracepointHistoryResponse) GetEvents() []*TracepointAuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type BulkCreateTracepointsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Tracepoints are all created together, if any are invalid then none are created
+	Tracepoints []*CreateTracepointRequest `protobuf:"bytes,1,rep,name=Tracepoints,proto3" json:"Tracepoints,omitempty"`
+	// Actor is the user making the change, this is recorded in the audit log
+	Actor string `protobuf:"bytes,2,opt,name=Actor,proto3" json:"Actor,omitempty"`
+}
+
+func (x *BulkCreateTracepointsRequest) Reset() {
+	*x = BulkCreateTracepointsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkCreateTracepointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkCreateTracepointsRequest) ProtoMessage() {}
+
+func (x *BulkCreateTracepointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkCreateTracepointsRequest.ProtoReflect.Descriptor instead.
+func (*BulkCreateTracepointsRequest) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *BulkCreateTracepointsRequest) GetTracepoints() []*CreateTracepointRequest {
+	if x != nil {
+		return x.Tracepoints
+	}
+	return nil
+}
+
+func (x *BulkCreateTracepointsRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+type BulkCreateTracepointsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tracepoints []*CreateTracepointResponse `protobuf:"bytes,1,rep,name=Tracepoints,proto3" json:"Tracepoints,omitempty"`
+}
+
+func (x *BulkCreateTracepointsResponse) Reset() {
+	*x = BulkCreateTracepointsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkCreateTracepointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkCreateTracepointsResponse) ProtoMessage() {}
+
+func (x *BulkCreateTracepointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkCreateTracepointsResponse.ProtoReflect.Descriptor instead.
+func (*BulkCreateTracepointsResponse) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *BulkCreateTracepointsResponse) GetTracepoints() []*CreateTracepointResponse {
+	if x != nil {
+		return x.Tracepoints
+	}
+	return nil
+}
+
+type BulkDeleteTracepointsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Labels select the tracepoints to delete, a tracepoint must have all the labels to be selected
+	Labels map[string]string `protobuf:"bytes,1,rep,name=Labels,proto3" json:"Labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Actor is the user making the change, this is recorded in the audit log
+	Actor string `protobuf:"bytes,2,opt,name=Actor,proto3" json:"Actor,omitempty"`
+}
+
+func (x *BulkDeleteTracepointsRequest) Reset() {
+	*x = BulkDeleteTracepointsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkDeleteTracepointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkDeleteTracepointsRequest) ProtoMessage() {}
+
+func (x *BulkDeleteTracepointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkDeleteTracepointsRequest.ProtoReflect.Descriptor instead.
+func (*BulkDeleteTracepointsRequest) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *BulkDeleteTracepointsRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *BulkDeleteTracepointsRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+type BulkDeleteTracepointsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TracepointIDs []string `protobuf:"bytes,1,rep,name=TracepointIDs,proto3" json:"TracepointIDs,omitempty"`
+}
+
+func (x *BulkDeleteTracepointsResponse) Reset() {
+	*x = BulkDeleteTracepointsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkDeleteTracepointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkDeleteTracepointsResponse) ProtoMessage() {}
+
+func (x *BulkDeleteTracepointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkDeleteTracepointsResponse.ProtoReflect.Descriptor instead.
+func (*BulkDeleteTracepointsResponse) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *BulkDeleteTracepointsResponse) GetTracepointIDs() []string {
+	if x != nil {
+		return x.TracepointIDs
+	}
+	return nil
+}
+
+type SetTracepointsPausedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Labels select the tracepoints to change, a tracepoint must have all the labels to be selected
+	Labels map[string]string `protobuf:"bytes,1,rep,name=Labels,proto3" json:"Labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Paused bool              `protobuf:"varint,2,opt,name=Paused,proto3" json:"Paused,omitempty"`
+	// Actor is the user making the change, this is recorded in the audit log
+	Actor string `protobuf:"bytes,3,opt,name=Actor,proto3" json:"Actor,omitempty"`
+}
+
+func (x *SetTracepointsPausedRequest) Reset() {
+	*x = SetTracepointsPausedRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetTracepointsPausedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTracepointsPausedRequest) ProtoMessage() {}
+
+func (x *SetTracepointsPausedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTracepointsPausedRequest.ProtoReflect.Descriptor instead.
+func (*SetTracepointsPausedRequest) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *SetTracepointsPausedRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *SetTracepointsPausedRequest) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *SetTracepointsPausedRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+type SetTracepointsPausedResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// TracepointIDs are the tracepoints that have been changed, tracepoints already in the requested state are not included
+	TracepointIDs []string `protobuf:"bytes,1,rep,name=TracepointIDs,proto3" json:"TracepointIDs,omitempty"`
+}
+
+func (x *SetTracepointsPausedResponse) Reset() {
+	*x = SetTracepointsPausedResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetTracepointsPausedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTracepointsPausedResponse) ProtoMessage() {}
+
+func (x *SetTracepointsPausedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTracepointsPausedResponse.ProtoReflect.Descriptor instead.
+func (*SetTracepointsPausedResponse) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *SetTracepointsPausedResponse) GetTracepointIDs() []string {
+	if x != nil {
+		return x.TracepointIDs
+	}
+	return nil
+}
+
+type SetTracepointPausedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TracepointID string `protobuf:"bytes,1,opt,name=TracepointID,proto3" json:"TracepointID,omitempty"`
+	// Paused tracepoints are kept, but are not sent to the agents until they are resumed
+	Paused bool `protobuf:"varint,2,opt,name=Paused,proto3" json:"Paused,omitempty"`
+	// Actor is the user making the change, this is recorded in the audit log
+	Actor string `protobuf:"bytes,3,opt,name=Actor,proto3" json:"Actor,omitempty"`
+}
+
+func (x *SetTracepointPausedRequest) Reset() {
+	*x = SetTracepointPausedRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetTracepointPausedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTracepointPausedRequest) ProtoMessage() {}
+
+func (x *SetTracepointPausedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTracepointPausedRequest.ProtoReflect.Descriptor instead.
+func (*SetTracepointPausedRequest) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *SetTracepointPausedRequest) GetTracepointID() string {
+	if x != nil {
+		return x.TracepointID
+	}
+	return ""
+}
+
+func (x *SetTracepointPausedRequest) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *SetTracepointPausedRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+type SetTracepointPausedResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tracepoint *v1.TracePointConfig `protobuf:"bytes,1,opt,name=Tracepoint,proto3" json:"Tracepoint,omitempty"`
+	Metadata   *TracepointMetadata  `protobuf:"bytes,2,opt,name=Metadata,proto3" json:"Metadata,omitempty"`
+}
+
+func (x *SetTracepointPausedResponse) Reset() {
+	*x = SetTracepointPausedResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetTracepointPausedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTracepointPausedResponse) ProtoMessage() {}
+
+func (x *SetTracepointPausedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTracepointPausedResponse.ProtoReflect.Descriptor instead.
+func (*SetTracepointPausedResponse) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *SetTracepointPausedResponse) GetTracepoint() *v1.TracePointConfig {
+	if x != nil {
+		return x.Tracepoint
+	}
+	return nil
+}
+
+func (x *SetTracepointPausedResponse) GetMetadata() *TracepointMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type WatchTracepointsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *WatchTracepointsRequest) Reset() {
+	*x = WatchTracepointsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchTracepointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTracepointsRequest) ProtoMessage() {}
+
+func (x *WatchTracepointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTracepointsRequest.ProtoReflect.Descriptor instead.
+func (*WatchTracepointsRequest) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{58}
+}
+
+// WatchTracepointsResponse is sent each time the tracepoints for the tenant change
+type WatchTracepointsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TsNanos uint64 `protobuf:"varint,1,opt,name=TsNanos,proto3" json:"TsNanos,omitempty"`
+}
+
+func (x *WatchTracepointsResponse) Reset() {
+	*x = WatchTracepointsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchTracepointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTracepointsResponse) ProtoMessage() {}
+
+func (x *WatchTracepointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTracepointsResponse.ProtoReflect.Descriptor instead.
+func (*WatchTracepointsResponse) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *WatchTracepointsResponse) GetTsNanos() uint64 {
+	if x != nil {
+		return x.TsNanos
+	}
+	return 0
+}
+
+type RecordInstallStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// AgentID identifies the agent that reported the status
+	AgentID string                     `protobuf:"bytes,1,opt,name=AgentID,proto3" json:"AgentID,omitempty"`
+	Status  []*TracepointInstallStatus `protobuf:"bytes,2,rep,name=Status,proto3" json:"Status,omitempty"`
+}
+
+func (x *RecordInstallStatusRequest) Reset() {
+	*x = RecordInstallStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordInstallStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordInstallStatusRequest) ProtoMessage() {}
+
+func (x *RecordInstallStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordInstallStatusRequest.ProtoReflect.Descriptor instead.
+func (*RecordInstallStatusRequest) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *RecordInstallStatusRequest) GetAgentID() string {
+	if x != nil {
+		return x.AgentID
+	}
+	return ""
+}
+
+func (x *RecordInstallStatusRequest) GetStatus() []*TracepointInstallStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type RecordInstallStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RecordInstallStatusResponse) Reset() {
+	*x = RecordInstallStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordInstallStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordInstallStatusResponse) ProtoMessage() {}
+
+func (x *RecordInstallStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordInstallStatusResponse.ProtoReflect.Descriptor instead.
+func (*RecordInstallStatusResponse) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{61}
+}
+
+// AgentInfo is an agent that has polled or subscribed to a distributor
+type AgentInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ID is the service.instance.id of the agent, or a hash of the resource if it is not set
+	ID             string            `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Resource       map[string]string `protobuf:"bytes,2,rep,name=Resource,proto3" json:"Resource,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	FirstSeenNanos uint64            `protobuf:"varint,3,opt,name=FirstSeenNanos,proto3" json:"FirstSeenNanos,omitempty"`
+	// LastSeenNanos is the last poll of the agent, or the time the agent was reported if it has an open subscription
+	LastSeenNanos uint64 `protobuf:"varint,4,opt,name=LastSeenNanos,proto3" json:"LastSeenNanos,omitempty"`
+	Subscribed    bool   `protobuf:"varint,5,opt,name=Subscribed,proto3" json:"Subscribed,omitempty"`
+	// AppliedHash is the hash of the tracepoints the agent has installed
+	AppliedHash string `protobuf:"bytes,6,opt,name=AppliedHash,proto3" json:"AppliedHash,omitempty"`
+	// CurrentHash is the hash of the tracepoints the agent should have installed
+	CurrentHash string `protobuf:"bytes,7,opt,name=CurrentHash,proto3" json:"CurrentHash,omitempty"`
+	// Tracepoints are the IDs of the tracepoints for the resource of the agent
+	Tracepoints []string `protobuf:"bytes,8,rep,name=Tracepoints,proto3" json:"Tracepoints,omitempty"`
+}
+
+func (x *AgentInfo) Reset() {
+	*x = AgentInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AgentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentInfo) ProtoMessage() {}
+
+func (x *AgentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentInfo.ProtoReflect.Descriptor instead.
+func (*AgentInfo) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *AgentInfo) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *AgentInfo) GetResource() map[string]string {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+func (x *AgentInfo) GetFirstSeenNanos() uint64 {
+	if x != nil {
+		return x.FirstSeenNanos
+	}
+	return 0
+}
+
+func (x *AgentInfo) GetLastSeenNanos() uint64 {
+	if x != nil {
+		return x.LastSeenNanos
+	}
+	return 0
+}
+
+func (x *AgentInfo) GetSubscribed() bool {
+	if x != nil {
+		return x.Subscribed
+	}
+	return false
+}
+
+func (x *AgentInfo) GetAppliedHash() string {
+	if x != nil {
+		return x.AppliedHash
+	}
+	return ""
+}
+
+func (x *AgentInfo) GetCurrentHash() string {
+	if x != nil {
+		return x.CurrentHash
+	}
+	return ""
+}
+
+func (x *AgentInfo) GetTracepoints() []string {
+	if x != nil {
+		return x.Tracepoints
+	}
+	return nil
+}
+
+type RecordAgentsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Agents are all the agents the distributor has seen within its retention
+	Agents []*AgentInfo `protobuf:"bytes,1,rep,name=Agents,proto3" json:"Agents,omitempty"`
+}
+
+func (x *RecordAgentsRequest) Reset() {
+	*x = RecordAgentsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordAgentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordAgentsRequest) ProtoMessage() {}
+
+func (x *RecordAgentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordAgentsRequest.ProtoReflect.Descriptor instead.
+func (*RecordAgentsRequest) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *RecordAgentsRequest) GetAgents() []*AgentInfo {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+type RecordAgentsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RecordAgentsResponse) Reset() {
+	*x = RecordAgentsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordAgentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordAgentsResponse) ProtoMessage() {}
+
+func (x *RecordAgentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordAgentsResponse.ProtoReflect.Descriptor instead.
+func (*RecordAgentsResponse) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{64}
+}
+
+type LoadAgentsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *LoadAgentsRequest) Reset() {
+	*x = LoadAgentsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoadAgentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadAgentsRequest) ProtoMessage() {}
+
+func (x *LoadAgentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadAgentsRequest.ProtoReflect.Descriptor instead.
+func (*LoadAgentsRequest) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{65}
+}
+
+type LoadAgentsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Agents are the agents reported by all the distributors
+	Agents []*AgentInfo `protobuf:"bytes,1,rep,name=Agents,proto3" json:"Agents,omitempty"`
+}
+
+func (x *LoadAgentsResponse) Reset() {
+	*x = LoadAgentsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deep_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoadAgentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadAgentsResponse) ProtoMessage() {}
+
+func (x *LoadAgentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deep_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadAgentsResponse.ProtoReflect.Descriptor instead.
+func (*LoadAgentsResponse) Descriptor() ([]byte, []int) {
+	return file_deep_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *LoadAgentsResponse) GetAgents() []*AgentInfo {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+var File_deep_proto protoreflect.FileDescriptor
+
+var file_deep_proto_rawDesc = []byte{
+	0x0a, 0x0a, 0x64, 0x65, 0x65, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x64, 0x65,
+	0x65, 0x70, 0x70, 0x62, 0x1a, 0x1e, 0x74, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x2f, 0x76, 0x31, 0x2f, 0x74, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x12, 0x70, 0x6f, 0x6c, 0x6c, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x6f,
+	0x6c, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1a, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb1, 0x02, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x04, 0x54, 0x61, 0x67, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x61, 0x67, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x54, 0x61, 0x67, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x4d,
+	0x69, 0x6e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0d, 0x4d, 0x69, 0x6e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d,
+	0x73, 0x12, 0x24, 0x0a, 0x0d, 0x4d, 0x61, 0x78, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x4d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x4d, 0x61, 0x78, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x74, 0x65, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x1a,
+	0x37, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xff, 0x02, 0x0a, 0x12, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x33, 0x0a, 0x09, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x09, 0x73, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x50, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x50, 0x61, 0x67, 0x65, 0x12, 0x24, 0x0a, 0x0d,
+	0x70, 0x61, 0x67, 0x65, 0x73, 0x54, 0x6f, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0d, 0x70, 0x61, 0x67, 0x65, 0x73, 0x54, 0x6f, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x24,
+	0x0a, 0x0d, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x50, 0x61, 0x67, 0x65,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61,
+	0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x6f,
+	0x6f, 0x74, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a,
+	0x66, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x22, 0xab, 0x01, 0x0a, 0x0e, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a,
+	0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x52, 0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x07, 0x6d,
+	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x64,
+	0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x2a, 0x0a, 0x06,
+	0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x64,
+	0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73,
+	0x52, 0x06, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x22, 0xa6, 0x05, 0x0a, 0x16, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74,
+	0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74,
+	0x68, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x69, 0x6e, 0x65, 0x4e, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x06, 0x6c, 0x69, 0x6e, 0x65, 0x4e, 0x6f, 0x12, 0x2c, 0x0a, 0x11, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x55, 0x6e, 0x69, 0x78, 0x4e, 0x61, 0x6e, 0x6f, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x55,
+	0x6e, 0x69, 0x78, 0x4e, 0x61, 0x6e, 0x6f, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6e, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6e, 0x6f, 0x12, 0x54, 0x0a, 0x0c, 0x73,
+	0x65, 0x72, 0x69, 0x65, 0x73, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x30, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x2e, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x12, 0x3f, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x29, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x12, 0x5a, 0x0a, 0x0e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x64, 0x65, 0x65,
+	0x70, 0x70, 0x62, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x6c,
+	0x69, 0x6e, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0e,
+	0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x1a, 0x3f,
+	0x0a, 0x11, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
+	0x38, 0x0a, 0x0a, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x56, 0x0a, 0x13, 0x50, 0x69, 0x70,
+	0x65, 0x6c, 0x69, 0x6e, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x65, 0x70, 0x51,
+	0x4c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0xa1, 0x01, 0x0a, 0x0b, 0x44, 0x65, 0x65, 0x70, 0x51, 0x4c, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x5f, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6f, 0x6f, 0x6c, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x62, 0x6f, 0x6f, 0x6c,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x2c, 0x0a, 0x14, 0x54, 0x61, 0x69, 0x6c, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x22, 0x81, 0x01, 0x0a, 0x15, 0x54, 0x61, 0x69, 0x6c, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a,
+	0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x52, 0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x64,
+	0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x22, 0xa9, 0x01, 0x0a, 0x0a, 0x54, 0x69, 0x6d, 0x65,
+	0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x28,
+	0x0a, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52,
+	0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x40, 0x0a, 0x06, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4d, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4d, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x8d, 0x02, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x69, 0x6e, 0x73, 0x70, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x12, 0x69, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x69, 0x6e, 0x73, 0x70, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0e, 0x69, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12,
+	0x28, 0x0a, 0x0f, 0x69, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x69, 0x6e, 0x73, 0x70, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x6b, 0x69,
+	0x70, 0x70, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12,
+	0x2a, 0x0a, 0x10, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x73, 0x6b, 0x69, 0x70, 0x70,
+	0x65, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x13, 0x0a, 0x11, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54,
+	0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x30, 0x0a, 0x12, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x74, 0x61, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x08, 0x74, 0x61, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x32, 0x0a, 0x16,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x61, 0x67, 0x4e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x61, 0x67, 0x4e, 0x61, 0x6d, 0x65,
+	0x22, 0x37, 0x0a, 0x17, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74,
+	0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
+	0x74, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x34, 0x0a, 0x08, 0x54, 0x61, 0x67,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22,
+	0x4b, 0x0a, 0x19, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x56, 0x32, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x09,
+	0x74, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x52, 0x09, 0x74, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x7f, 0x0a, 0x13,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x02, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x45, 0x6e, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x45, 0x6e, 0x64, 0x12,
+	0x1c, 0x0a, 0x09, 0x71, 0x75, 0x65, 0x72, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x71, 0x75, 0x65, 0x72, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x22, 0x89, 0x01,
+	0x0a, 0x14, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70,
+	0x62, 0x2e, 0x74, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x12, 0x35, 0x0a, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x42, 0x79, 0x49, 0x44, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
+	0x52, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x22, 0x39, 0x0a, 0x13, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x42, 0x79, 0x49, 0x44, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
+	0x12, 0x22, 0x0a, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x73, 0x22, 0xde, 0x01, 0x0a, 0x14, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x41, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x41, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x42, 0x12, 0x32, 0x0a, 0x09, 0x76, 0x61, 0x72,
+	0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64,
+	0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x69,
+	0x66, 0x66, 0x52, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x29, 0x0a,
+	0x06, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x44, 0x69, 0x66, 0x66,
+	0x52, 0x06, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x07, 0x77, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x64, 0x65, 0x65, 0x70,
+	0x70, 0x62, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x44, 0x69, 0x66, 0x66, 0x52, 0x07, 0x77, 0x61,
+	0x74, 0x63, 0x68, 0x65, 0x73, 0x22, 0x7c, 0x0a, 0x0c, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c,
+	0x65, 0x44, 0x69, 0x66, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x12, 0x1f, 0x0a, 0x01, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x64,
+	0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x44, 0x69, 0x66, 0x66, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x01, 0x61, 0x12, 0x1f, 0x0a, 0x01, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x44, 0x69, 0x66, 0x66, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x01, 0x62, 0x22, 0x55, 0x0a, 0x09, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x44, 0x69, 0x66, 0x66,
+	0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x0c,
+	0x0a, 0x01, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x01, 0x61, 0x12, 0x0c, 0x0a, 0x01,
+	0x62, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x01, 0x62, 0x22, 0x85, 0x01, 0x0a, 0x09, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x44, 0x69, 0x66, 0x66, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x12, 0x1f, 0x0a, 0x01, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x64, 0x65,
+	0x65, 0x70, 0x70, 0x62, 0x2e, 0x44, 0x69, 0x66, 0x66, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x01,
+	0x61, 0x12, 0x1f, 0x0a, 0x01, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x64,
+	0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x44, 0x69, 0x66, 0x66, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x01, 0x62, 0x22, 0x35, 0x0a, 0x09, 0x44, 0x69, 0x66, 0x66, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x51, 0x0a, 0x13, 0x50, 0x75, 0x73,
+	0x68, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x3a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x74, 0x72, 0x61, 0x63,
+	0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0x16, 0x0a, 0x14,
+	0x50, 0x75, 0x73, 0x68, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x3e, 0x0a, 0x10, 0x50, 0x75, 0x73, 0x68, 0x42, 0x79, 0x74, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x02, 0x49, 0x44, 0x22, 0x13, 0x0a, 0x11, 0x50, 0x75, 0x73, 0x68, 0x42, 0x79, 0x74, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x88, 0x01, 0x0a, 0x13, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x38, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x37, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x64, 0x65,
+	0x65, 0x70, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x22, 0x72, 0x0a, 0x17, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x23, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c,
+	0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x16, 0x0a, 0x14, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x99, 0x02, 0x0a, 0x12, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x30, 0x0a, 0x06, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x06, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3e, 0x0a, 0x06, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x64, 0x65, 0x65, 0x70,
+	0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x61, 0x75,
+	0x73, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x50, 0x61, 0x75, 0x73, 0x65,
+	0x64, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x68, 0x0a, 0x18,
+	0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c,
+	0x6c, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x22, 0xd6, 0x01, 0x0a, 0x10, 0x54, 0x72, 0x61, 0x63, 0x65,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x45,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x4e, 0x61,
+	0x6e, 0x6f, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x54, 0x54, 0x4c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x54, 0x54, 0x4c, 0x53, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x4d, 0x61, 0x78, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x4d, 0x61, 0x78, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x12, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x50, 0x65,
+	0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x22,
+	0xc9, 0x01, 0x0a, 0x17, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x52, 0x0a, 0x0b, 0x54,
+	0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x30, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0b, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x1a,
+	0x5a, 0x0a, 0x10, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x54, 0x72,
+	0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xab, 0x01, 0x0a, 0x15,
+	0x4c, 0x6f, 0x61, 0x64, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e,
+	0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x88, 0x01,
+	0x01, 0x12, 0x24, 0x0a, 0x0d, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x50, 0x61, 0x75, 0x73,
+	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x49, 0x6e, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d,
+	0x49, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x0a, 0x0a,
+	0x08, 0x5f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x96, 0x03, 0x0a, 0x16, 0x4c, 0x6f,
+	0x61, 0x64, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e,
+	0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x52, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48,
+	0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2c, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x72,
+	0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x42, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70,
+	0x62, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x1a, 0x57, 0x0a, 0x0d,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x30, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5b, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x36, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x54,
+	0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c,
+	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x80, 0x03, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x63, 0x65,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19,
+	0x0a, 0x08, 0x74, 0x73, 0x5f, 0x6e, 0x61, 0x6e, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x06,
+	0x52, 0x07, 0x74, 0x73, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x42, 0x0a, 0x08,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26,
+	0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x74, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x41, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62,
+	0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x43, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x1a, 0x5b, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x36, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70,
+	0x62, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xc1, 0x02, 0x0a, 0x17, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x46, 0x0a, 0x0a, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x74,
+	0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61,
+	0x63, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0a, 0x54,
+	0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x06, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x65, 0x65, 0x70,
+	0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x52, 0x06, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x41,
+	0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x41, 0x63, 0x74, 0x6f,
+	0x72, 0x12, 0x43, 0x0a, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2b, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x1a, 0x39,
+	0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x9a, 0x01, 0x0a, 0x18, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x0a, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x64, 0x65, 0x65,
+	0x70, 0x70, 0x62, 0x2e, 0x74, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x52, 0x0a, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x36,
+	0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x53, 0x0a, 0x17, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x22, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x1a, 0x0a, 0x18, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xc3, 0x02, 0x0a, 0x17, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x46, 0x0a, 0x0a, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62,
+	0x2e, 0x74, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x54,
+	0x72, 0x61, 0x63, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
+	0x0a, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x06, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x54,
+	0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52,
+	0x06, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x41, 0x63, 0x74, 0x6f, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x43, 0x0a,
+	0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e,
+	0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61,
+	0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x9a, 0x01,
+	0x0a, 0x18, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x0a, 0x54, 0x72,
+	0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26,
+	0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x74, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0a, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x12, 0x36, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x54, 0x72,
+	0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x52, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x97, 0x01, 0x0a, 0x16, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x42, 0x0a, 0x06, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x06, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x19, 0x0a, 0x17, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x80, 0x03, 0x0a, 0x14, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x41, 0x75,
+	0x64, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x63,
+	0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x3f, 0x0a, 0x06,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x64,
+	0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a,
+	0x05, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x41, 0x63,
+	0x74, 0x6f, 0x72, 0x12, 0x26, 0x0a, 0x0e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x12, 0x3e, 0x0a, 0x06, 0x42,
+	0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x64, 0x65,
+	0x65, 0x70, 0x70, 0x62, 0x2e, 0x74, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x52, 0x06, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x3c, 0x0a, 0x05, 0x41,
+	0x66, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x64, 0x65, 0x65,
+	0x70, 0x70, 0x62, 0x2e, 0x74, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x52, 0x05, 0x41, 0x66, 0x74, 0x65, 0x72, 0x22, 0x47, 0x0a, 0x0a, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x52, 0x45, 0x41, 0x54,
+	0x45, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x10, 0x01, 0x12,
+	0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x50,
+	0x41, 0x55, 0x53, 0x45, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x45, 0x53, 0x55, 0x4d, 0x45,
+	0x10, 0x04, 0x22, 0x4a, 0x0a, 0x12, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x34, 0x0a, 0x06, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70,
+	0x62, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x41, 0x75, 0x64, 0x69,
+	0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x3e,
+	0x0a, 0x18, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x54, 0x72,
+	0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x44, 0x22, 0x51,
+	0x0a, 0x19, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x06, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x64, 0x65,
+	0x65, 0x70, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x41,
+	0x75, 0x64, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x22, 0x77, 0x0a, 0x1c, 0x42, 0x75, 0x6c, 0x6b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54,
+	0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x41, 0x0a, 0x0b, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0b, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x63, 0x0a, 0x1d, 0x42, 0x75,
+	0x6c, 0x6b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0b, 0x54,
+	0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x52, 0x0b, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22,
+	0xb9, 0x01, 0x0a, 0x1c, 0x42, 0x75, 0x6c, 0x6b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x72,
+	0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x48, 0x0a, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x30, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x41, 0x63,
+	0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x41, 0x63, 0x74, 0x6f, 0x72,
+	0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x45, 0x0a, 0x1d, 0x42,
+	0x75, 0x6c, 0x6b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x0d,
+	0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0d, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49,
+	0x44, 0x73, 0x22, 0xcf, 0x01, 0x0a, 0x1b, 0x53, 0x65, 0x74, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x73, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x47, 0x0a, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x74, 0x54,
+	0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x50,
+	0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x50, 0x61, 0x75,
+	0x73, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x44, 0x0a, 0x1c, 0x53, 0x65, 0x74, 0x54, 0x72, 0x61, 0x63, 0x65,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x54, 0x72, 0x61,
+	0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x44, 0x73, 0x22, 0x6e, 0x0a, 0x1a, 0x53, 0x65,
+	0x74, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x50, 0x61, 0x75, 0x73, 0x65,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x63,
+	0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06,
+	0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x50, 0x61,
+	0x75, 0x73, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x9d, 0x01, 0x0a, 0x1b, 0x53,
+	0x65, 0x74, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x50, 0x61, 0x75, 0x73,
+	0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x0a, 0x54, 0x72,
+	0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26,
+	0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x74, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0a, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x12, 0x36, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x54, 0x72,
+	0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x52, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x19, 0x0a, 0x17, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x34, 0x0a, 0x18, 0x57, 0x61, 0x74, 0x63, 0x68, 0x54, 0x72,
+	0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x54, 0x73, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x07, 0x54, 0x73, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x22, 0x6f, 0x0a, 0x1a, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x67, 0x65,
+	0x6e, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x41, 0x67, 0x65, 0x6e,
+	0x74, 0x49, 0x44, 0x12, 0x37, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61,
+	0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x1d, 0x0a, 0x1b,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xe9, 0x02, 0x0a, 0x09,
+	0x41, 0x67, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x44, 0x12, 0x3b, 0x0a, 0x08, 0x52, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x64, 0x65,
+	0x65, 0x70, 0x70, 0x62, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x46, 0x69, 0x72, 0x73, 0x74, 0x53,
+	0x65, 0x65, 0x6e, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e,
+	0x46, 0x69, 0x72, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x12, 0x24,
+	0x0a, 0x0d, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x4e,
+	0x61, 0x6e, 0x6f, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x48,
+	0x61, 0x73, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x41, 0x70, 0x70, 0x6c, 0x69,
+	0x65, 0x64, 0x48, 0x61, 0x73, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x48, 0x61, 0x73, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x54, 0x72, 0x61, 0x63,
+	0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x54,
+	0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x1a, 0x3b, 0x0a, 0x0d, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x40, 0x0a, 0x13, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29,
+	0x0a, 0x06, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x06, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x16, 0x0a, 0x14, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x13, 0x0a, 0x11, 0x4c, 0x6f, 0x61, 0x64, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3f, 0x0a, 0x12, 0x4c, 0x6f, 0x61, 0x64, 0x41, 0x67,
+	0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x06,
+	0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x64,
+	0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x06, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x32, 0xb0, 0x04, 0x0a, 0x0e, 0x51, 0x75, 0x65, 0x72,
+	0x69, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4f, 0x0a, 0x10, 0x46, 0x69,
+	0x6e, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x42, 0x79, 0x49, 0x44, 0x12, 0x1b,
+	0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x64, 0x65,
+	0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x42, 0x79, 0x49,
+	0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x0c, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x15, 0x2e, 0x64, 0x65,
+	0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0b,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1a, 0x2e, 0x64, 0x65,
+	0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62,
+	0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x45, 0x0a, 0x0a, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x67, 0x73, 0x12,
+	0x19, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54,
+	0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x64, 0x65, 0x65,
+	0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x0f, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x1e, 0x2e, 0x64, 0x65,
+	0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x67, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x64, 0x65,
+	0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x67, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x58,
+	0x0a, 0x11, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x56, 0x32, 0x12, 0x1e, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x56, 0x32, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x0d, 0x54, 0x61, 0x69, 0x6c,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x12, 0x1c, 0x2e, 0x64, 0x65, 0x65, 0x70,
+	0x70, 0x62, 0x2e, 0x54, 0x61, 0x69, 0x6c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62,
+	0x2e, 0x54, 0x61, 0x69, 0x6c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x32, 0x5f, 0x0a, 0x10, 0x4d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x4b,
+	0x0a, 0x0c, 0x50, 0x75, 0x73, 0x68, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x1b,
+	0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x64, 0x65,
+	0x65, 0x70, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x55, 0x0a, 0x0f, 0x49,
+	0x6e, 0x67, 0x65, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x42,
+	0x0a, 0x09, 0x50, 0x75, 0x73, 0x68, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x18, 0x2e, 0x64, 0x65,
+	0x65, 0x70, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x42, 0x79, 0x74, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x50,
+	0x75, 0x73, 0x68, 0x42, 0x79, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x32, 0x92, 0x0a, 0x0a, 0x17, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x52,
+	0x0a, 0x0f, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x73, 0x12, 0x1d, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x54,
+	0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1e, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x72,
+	0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x57, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63,
+	0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1f, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x10, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12,
+	0x1f, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54,
+	0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x20, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x10, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x72,
+	0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1f, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70,
+	0x62, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x64, 0x65, 0x65, 0x70,
+	0x70, 0x62, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a,
+	0x0f, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73,
+	0x12, 0x1e, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1f, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x5a, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x20, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70,
+	0x62, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x64, 0x65, 0x65,
+	0x70, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x66, 0x0a, 0x15, 0x42, 0x75, 0x6c, 0x6b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61,
+	0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x24, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70,
+	0x62, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63,
+	0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25,
+	0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x66, 0x0a, 0x15, 0x42, 0x75, 0x6c, 0x6b, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73,
+	0x12, 0x24, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e,
+	0x42, 0x75, 0x6c, 0x6b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x63, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x73, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x12, 0x23, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62,
+	0x2e, 0x53, 0x65, 0x74, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x50,
+	0x61, 0x75, 0x73, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x64,
+	0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x74, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x73, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x60, 0x0a, 0x13, 0x53, 0x65, 0x74, 0x54, 0x72, 0x61, 0x63, 0x65,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x12, 0x22, 0x2e, 0x64, 0x65,
+	0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x74, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x23, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x74, 0x54, 0x72, 0x61, 0x63,
+	0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x59, 0x0a, 0x10, 0x57, 0x61, 0x74, 0x63, 0x68, 0x54,
+	0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x1f, 0x2e, 0x64, 0x65, 0x65,
+	0x70, 0x70, 0x62, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x64, 0x65,
+	0x65, 0x70, 0x70, 0x62, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30,
+	0x01, 0x12, 0x60, 0x0a, 0x13, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x22, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70,
+	0x62, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x64,
+	0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0c, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x41, 0x67, 0x65,
+	0x6e, 0x74, 0x73, 0x12, 0x1b, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1c, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x45, 0x0a, 0x0a, 0x4c, 0x6f, 0x61, 0x64, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x19,
+	0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x41, 0x67, 0x65, 0x6e,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x64, 0x65, 0x65, 0x70,
+	0x70, 0x62, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x5e, 0x0a, 0x10, 0x50, 0x6f, 0x6c, 0x6c, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4a, 0x0a, 0x09, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x1b, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70,
+	0x62, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x70,
+	0x6f, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x32, 0x5f, 0x0a, 0x10, 0x54, 0x72, 0x61, 0x63, 0x65,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4b, 0x0a, 0x0c, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x2e, 0x64, 0x65,
+	0x65, 0x70, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70,
+	0x62, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x26, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x67, 0x72, 0x61, 0x6c,
+	0x2f, 0x64, 0x65, 0x65, 0x70, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_deep_proto_rawDescOnce sync.Once
+	file_deep_proto_rawDescData = file_deep_proto_rawDesc
+)
+
+func file_deep_proto_rawDescGZIP() []byte {
+	file_deep_proto_rawDescOnce.Do(func() {
+		file_deep_proto_rawDescData = protoimpl.X.CompressGZIP(file_deep_proto_rawDescData)
+	})
+	return file_deep_proto_rawDescData
+}
+
+var file_deep_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_deep_proto_msgTypes = make([]protoimpl.MessageInfo, 83)
+var file_deep_proto_goTypes = []interface{}{
+	(TracepointAuditEvent_ActionType)(0),  // 0: deeppb.TracepointAuditEvent.ActionType
+	(*SearchRequest)(nil),                 // 1: deeppb.SearchRequest
+	(*SearchBlockRequest)(nil),            // 2: deeppb.SearchBlockRequest
+	(*SearchResponse)(nil),                // 3: deeppb.SearchResponse
+	(*SnapshotSearchMetadata)(nil),        // 4: deeppb.SnapshotSearchMetadata
+	(*DeepQLValue)(nil),                   // 5: deeppb.DeepQLValue
+	(*TailSnapshotsRequest)(nil),          // 6: deeppb.TailSnapshotsRequest
+	(*TailSnapshotsResponse)(nil),         // 7: deeppb.TailSnapshotsResponse
+	(*TimeSeries)(nil),                    // 8: deeppb.TimeSeries
+	(*Sample)(nil),                        // 9: deeppb.Sample
+	(*SearchMetrics)(nil),                 // 10: deeppb.SearchMetrics
+	(*SearchTagsRequest)(nil),             // 11: deeppb.SearchTagsRequest
+	(*SearchTagsResponse)(nil),            // 12: deeppb.SearchTagsResponse
+	(*SearchTagValuesRequest)(nil),        // 13: deeppb.SearchTagValuesRequest
+	(*SearchTagValuesResponse)(nil),       // 14: deeppb.SearchTagValuesResponse
+	(*TagValue)(nil),                      // 15: deeppb.TagValue
+	(*SearchTagValuesV2Response)(nil),     // 16: deeppb.SearchTagValuesV2Response
+	(*SnapshotByIDRequest)(nil),           // 17: deeppb.SnapshotByIDRequest
+	(*SnapshotByIDResponse)(nil),          // 18: deeppb.SnapshotByIDResponse
+	(*SnapshotByIDMetrics)(nil),           // 19: deeppb.SnapshotByIDMetrics
+	(*SnapshotDiffResponse)(nil),          // 20: deeppb.SnapshotDiffResponse
+	(*VariableDiff)(nil),                  // 21: deeppb.VariableDiff
+	(*FrameDiff)(nil),                     // 22: deeppb.FrameDiff
+	(*WatchDiff)(nil),                     // 23: deeppb.WatchDiff
+	(*DiffValue)(nil),                     // 24: deeppb.DiffValue
+	(*PushSnapshotRequest)(nil),           // 25: deeppb.PushSnapshotRequest
+	(*PushSnapshotResponse)(nil),          // 26: deeppb.PushSnapshotResponse
+	(*PushBytesRequest)(nil),              // 27: deeppb.PushBytesRequest
+	(*PushBytesResponse)(nil),             // 28: deeppb.PushBytesResponse
+	(*ReportStatusRequest)(nil),           // 29: deeppb.ReportStatusRequest
+	(*TracepointInstallStatus)(nil),       // 30: deeppb.TracepointInstallStatus
+	(*ReportStatusResponse)(nil),          // 31: deeppb.ReportStatusResponse
+	(*TracepointMetadata)(nil),            // 32: deeppb.TracepointMetadata
+	(*TracepointInstallSummary)(nil),      // 33: deeppb.TracepointInstallSummary
+	(*TracepointLimits)(nil),              // 34: deeppb.TracepointLimits
+	(*TracepointBlockMetadata)(nil),       // 35: deeppb.TracepointBlockMetadata
+	(*LoadTracepointRequest)(nil),         // 36: deeppb.LoadTracepointRequest
+	(*LoadTracepointResponse)(nil),        // 37: deeppb.LoadTracepointResponse
+	(*ListTracepointsResponse)(nil),       // 38: deeppb.ListTracepointsResponse
+	(*CreateTracepointRequest)(nil),       // 39: deeppb.CreateTracepointRequest
+	(*CreateTracepointResponse)(nil),      // 40: deeppb.CreateTracepointResponse
+	(*DeleteTracepointRequest)(nil),       // 41: deeppb.DeleteTracepointRequest
+	(*DeleteTracepointResponse)(nil),      // 42: deeppb.DeleteTracepointResponse
+	(*UpdateTracepointRequest)(nil),       // 43: deeppb.UpdateTracepointRequest
+	(*UpdateTracepointResponse)(nil),      // 44: deeppb.UpdateTracepointResponse
+	(*RecordSnapshotsRequest)(nil),        // 45: deeppb.RecordSnapshotsRequest
+	(*RecordSnapshotsResponse)(nil),       // 46: deeppb.RecordSnapshotsResponse
+	(*TracepointAuditEvent)(nil),          // 47: deeppb.TracepointAuditEvent
+	(*TracepointAuditLog)(nil),            // 48: deeppb.TracepointAuditLog
+	(*TracepointHistoryRequest)(nil),      // 49: deeppb.TracepointHistoryRequest
+	(*TracepointHistoryResponse)(nil),     // 50: deeppb.TracepointHistoryResponse
+	(*BulkCreateTracepointsRequest)(nil),  // 51: deeppb.BulkCreateTracepointsRequest
+	(*BulkCreateTracepointsResponse)(nil), // 52: deeppb.BulkCreateTracepointsResponse
+	(*BulkDeleteTracepointsRequest)(nil),  // 53: deeppb.BulkDeleteTracepointsRequest
+	(*BulkDeleteTracepointsResponse)(nil), // 54: deeppb.BulkDeleteTracepointsResponse
+	(*SetTracepointsPausedRequest)(nil),   // 55: deeppb.SetTracepointsPausedRequest
+	(*SetTracepointsPausedResponse)(nil),  // 56: deeppb.SetTracepointsPausedResponse
+	(*SetTracepointPausedRequest)(nil),    // 57: deeppb.SetTracepointPausedRequest
+	(*SetTracepointPausedResponse)(nil),   // 58: deeppb.SetTracepointPausedResponse
+	(*WatchTracepointsRequest)(nil),       // 59: deeppb.WatchTracepointsRequest
+	(*WatchTracepointsResponse)(nil),      // 60: deeppb.WatchTracepointsResponse
+	(*RecordInstallStatusRequest)(nil),    // 61: deeppb.RecordInstallStatusRequest
+	(*RecordInstallStatusResponse)(nil),   // 62: deeppb.RecordInstallStatusResponse
+	(*AgentInfo)(nil),                     // 63: deeppb.AgentInfo
+	(*RecordAgentsRequest)(nil),           // 64: deeppb.RecordAgentsRequest
+	(*RecordAgentsResponse)(nil),          // 65: deeppb.RecordAgentsResponse
+	(*LoadAgentsRequest)(nil),             // 66: deeppb.LoadAgentsRequest
+	(*LoadAgentsResponse)(nil),            // 67: deeppb.LoadAgentsResponse
+	nil,                                   // 68: deeppb.SearchRequest.TagsEntry
+	nil,                                   // 69: deeppb.SnapshotSearchMetadata.SeriesLabelsEntry
+	nil,                                   // 70: deeppb.SnapshotSearchMetadata.GroupEntry
+	nil,                                   // 71: deeppb.SnapshotSearchMetadata.PipelineValuesEntry
+	nil,                                   // 72: deeppb.TimeSeries.LabelsEntry
+	nil,                                   // 73: deeppb.TracepointMetadata.LabelsEntry
+	nil,                                   // 74: deeppb.TracepointBlockMetadata.TracepointsEntry
+	nil,                                   // 75: deeppb.LoadTracepointResponse.MetadataEntry
+	nil,                                   // 76: deeppb.LoadTracepointResponse.StatusEntry
+	nil,                                   // 77: deeppb.ListTracepointsResponse.StatusEntry
+	nil,                                   // 78: deeppb.CreateTracepointRequest.LabelsEntry
+	nil,                                   // 79: deeppb.UpdateTracepointRequest.LabelsEntry
+	nil,                                   // 80: deeppb.RecordSnapshotsRequest.CountsEntry
+	nil,                                   // 81: deeppb.BulkDeleteTracepointsRequest.LabelsEntry
+	nil,                                   // 82: deeppb.SetTracepointsPausedRequest.LabelsEntry
+	nil,                                   // 83: deeppb.AgentInfo.ResourceEntry
+	(*v1.Snapshot)(nil),                   // 84: deeppb.tracepoint.v1.Snapshot
+	(*v11.Resource)(nil),                  // 85: deeppb.resource.v1.Resource
+	(*v12.PollRequest)(nil),               // 86: deeppb.poll.v1.PollRequest
+	(*v12.PollResponse)(nil),              // 87: deeppb.poll.v1.PollResponse
+	(*v1.TracePointConfig)(nil),           // 88: deeppb.tracepoint.v1.TracePointConfig
+	(v12.ResponseType)(0),                 // 89: deeppb.poll.v1.ResponseType
+}
+var file_deep_proto_depIdxs = []int32{
+	68, // 0: deeppb.SearchRequest.Tags:type_name -> deeppb.SearchRequest.TagsEntry
+	1,  // 1: deeppb.SearchBlockRequest.searchReq:type_name -> deeppb.SearchRequest
+	4,  // 2: deeppb.SearchResponse.snapshots:type_name -> deeppb.SnapshotSearchMetadata
+	10, // 3: deeppb.SearchResponse.metrics:type_name -> deeppb.SearchMetrics
+	8,  // 4: deeppb.SearchResponse.series:type_name -> deeppb.TimeSeries
+	69, // 5: deeppb.SnapshotSearchMetadata.seriesLabels:type_name -> deeppb.SnapshotSearchMetadata.SeriesLabelsEntry
+	70, // 6: deeppb.SnapshotSearchMetadata.group:type_name -> deeppb.SnapshotSearchMetadata.GroupEntry
+	71, // 7: deeppb.SnapshotSearchMetadata.pipelineValues:type_name -> deeppb.SnapshotSearchMetadata.PipelineValuesEntry
+	4,  // 8: deeppb.TailSnapshotsResponse.snapshots:type_name -> deeppb.SnapshotSearchMetadata
+	72, // 9: deeppb.TimeSeries.labels:type_name -> deeppb.TimeSeries.LabelsEntry
+	9,  // 10: deeppb.TimeSeries.samples:type_name -> deeppb.Sample
+	15, // 11: deeppb.SearchTagValuesV2Response.tagValues:type_name -> deeppb.TagValue
+	84, // 12: deeppb.SnapshotByIDResponse.snapshot:type_name -> deeppb.tracepoint.v1.Snapshot
+	19, // 13: deeppb.SnapshotByIDResponse.metrics:type_name -> deeppb.SnapshotByIDMetrics
+	21, // 14: deeppb.SnapshotDiffResponse.variables:type_name -> deeppb.VariableDiff
+	22, // 15: deeppb.SnapshotDiffResponse.frames:type_name -> deeppb.FrameDiff
+	23, // 16: deeppb.SnapshotDiffResponse.watches:type_name -> deeppb.WatchDiff
+	24, // 17: deeppb.VariableDiff.a:type_name -> deeppb.DiffValue
+	24, // 18: deeppb.VariableDiff.b:type_name -> deeppb.DiffValue
+	24, // 19: deeppb.WatchDiff.a:type_name -> deeppb.DiffValue
+	24, // 20: deeppb.WatchDiff.b:type_name -> deeppb.DiffValue
+	84, // 21: deeppb.PushSnapshotRequest.snapshot:type_name -> deeppb.tracepoint.v1.Snapshot
+	85, // 22: deeppb.ReportStatusRequest.resource:type_name -> deeppb.resource.v1.Resource
+	30, // 23: deeppb.ReportStatusRequest.status:type_name -> deeppb.TracepointInstallStatus
+	34, // 24: deeppb.TracepointMetadata.Limits:type_name -> deeppb.TracepointLimits
+	73, // 25: deeppb.TracepointMetadata.Labels:type_name -> deeppb.TracepointMetadata.LabelsEntry
+	74, // 26: deeppb.TracepointBlockMetadata.Tracepoints:type_name -> deeppb.TracepointBlockMetadata.TracepointsEntry
+	86, // 27: deeppb.LoadTracepointRequest.Request:type_name -> deeppb.poll.v1.PollRequest
+	87, // 28: deeppb.LoadTracepointResponse.Response:type_name -> deeppb.poll.v1.PollResponse
+	75, // 29: deeppb.LoadTracepointResponse.Metadata:type_name -> deeppb.LoadTracepointResponse.MetadataEntry
+	76, // 30: deeppb.LoadTracepointResponse.Status:type_name -> deeppb.LoadTracepointResponse.StatusEntry
+	88, // 31: deeppb.ListTracepointsResponse.response:type_name -> deeppb.tracepoint.v1.TracePointConfig
+	89, // 32: deeppb.ListTracepointsResponse.response_type:type_name -> deeppb.poll.v1.ResponseType
+	77, // 33: deeppb.ListTracepointsResponse.status:type_name -> deeppb.ListTracepointsResponse.StatusEntry
+	88, // 34: deeppb.CreateTracepointRequest.Tracepoint:type_name -> deeppb.tracepoint.v1.TracePointConfig
+	34, // 35: deeppb.CreateTracepointRequest.Limits:type_name -> deeppb.TracepointLimits
+	78, // 36: deeppb.CreateTracepointRequest.Labels:type_name -> deeppb.CreateTracepointRequest.LabelsEntry
+	88, // 37: deeppb.CreateTracepointResponse.Tracepoint:type_name -> deeppb.tracepoint.v1.TracePointConfig
+	32, // 38: deeppb.CreateTracepointResponse.Metadata:type_name -> deeppb.TracepointMetadata
+	88, // 39: deeppb.UpdateTracepointRequest.Tracepoint:type_name -> deeppb.tracepoint.v1.TracePointConfig
+	34, // 40: deeppb.UpdateTracepointRequest.Limits:type_name -> deeppb.TracepointLimits
+	79, // 41: deeppb.UpdateTracepointRequest.Labels:type_name -> deeppb.UpdateTracepointRequest.LabelsEntry
+	88, // 42: deeppb.UpdateTracepointResponse.Tracepoint:type_name -> deeppb.tracepoint.v1.TracePointConfig
+	32, // 43: deeppb.UpdateTracepointResponse.Metadata:type_name -> deeppb.TracepointMetadata
+	80, // 44: deeppb.RecordSnapshotsRequest.Counts:type_name -> deeppb.RecordSnapshotsRequest.CountsEntry
+	0,  // 45: deeppb.TracepointAuditEvent.Action:type_name -> deeppb.TracepointAuditEvent.ActionType
+	88, // 46: deeppb.TracepointAuditEvent.Before:type_name -> deeppb.tracepoint.v1.TracePointConfig
+	88, // 47: deeppb.TracepointAuditEvent.After:type_name -> deeppb.tracepoint.v1.TracePointConfig
+	47, // 48: deeppb.TracepointAuditLog.Events:type_name -> deeppb.TracepointAuditEvent
+	47, // 49: deeppb.TracepointHistoryResponse.Events:type_name -> deeppb.TracepointAuditEvent
+	39, // 50: deeppb.BulkCreateTracepointsRequest.Tracepoints:type_name -> deeppb.CreateTracepointRequest
+	40, // 51: deeppb.BulkCreateTracepointsResponse.Tracepoints:type_name -> deeppb.CreateTracepointResponse
+	81, // 52: deeppb.BulkDeleteTracepointsRequest.Labels:type_name -> deeppb.BulkDeleteTracepointsRequest.LabelsEntry
+	82, // 53: deeppb.SetTracepointsPausedRequest.Labels:type_name -> deeppb.SetTracepointsPausedRequest.LabelsEntry
+	88, // 54: deeppb.SetTracepointPausedResponse.Tracepoint:type_name -> deeppb.tracepoint.v1.TracePointConfig
+	32, // 55: deeppb.SetTracepointPausedResponse.Metadata:type_name -> deeppb.TracepointMetadata
+	30, // 56: deeppb.RecordInstallStatusRequest.Status:type_name -> deeppb.TracepointInstallStatus
+	83, // 57: deeppb.AgentInfo.Resource:type_name -> deeppb.AgentInfo.ResourceEntry
+	63, // 58: deeppb.RecordAgentsRequest.Agents:type_name -> deeppb.AgentInfo
+	63, // 59: deeppb.LoadAgentsResponse.Agents:type_name -> deeppb.AgentInfo
+	5,  // 60: deeppb.SnapshotSearchMetadata.PipelineValuesEntry.value:type_name -> deeppb.DeepQLValue
+	32, // 61: deeppb.TracepointBlockMetadata.TracepointsEntry.value:type_name -> deeppb.TracepointMetadata
+	32, // 62: deeppb.LoadTracepointResponse.MetadataEntry.value:type_name -> deeppb.TracepointMetadata
+	33, // 63: deeppb.LoadTracepointResponse.StatusEntry.value:type_name -> deeppb.TracepointInstallSummary
+	33, // 64: deeppb.ListTracepointsResponse.StatusEntry.value:type_name -> deeppb.TracepointInstallSummary
+	17, // 65: deeppb.QuerierService.FindSnapshotByID:input_type -> deeppb.SnapshotByIDRequest
+	1,  // 66: deeppb.QuerierService.SearchRecent:input_type -> deeppb.SearchRequest
+	2,  // 67: deeppb.QuerierService.SearchBlock:input_type -> deeppb.SearchBlockRequest
+	11, // 68: deeppb.QuerierService.SearchTags:input_type -> deeppb.SearchTagsRequest
+	13, // 69: deeppb.QuerierService.SearchTagValues:input_type -> deeppb.SearchTagValuesRequest
+	13, // 70: deeppb.QuerierService.SearchTagValuesV2:input_type -> deeppb.SearchTagValuesRequest
+	6,  // 71: deeppb.QuerierService.TailSnapshots:input_type -> deeppb.TailSnapshotsRequest
+	25, // 72: deeppb.MetricsGenerator.PushSnapshot:input_type -> deeppb.PushSnapshotRequest
+	27, // 73: deeppb.IngesterService.PushBytes:input_type -> deeppb.PushBytesRequest
+	36, // 74: deeppb.TracepointConfigService.LoadTracepoints:input_type -> deeppb.LoadTracepointRequest
+	39, // 75: deeppb.TracepointConfigService.CreateTracepoint:input_type -> deeppb.CreateTracepointRequest
+	41, // 76: deeppb.TracepointConfigService.DeleteTracepoint:input_type -> deeppb.DeleteTracepointRequest
+	43, // 77: deeppb.TracepointConfigService.UpdateTracepoint:input_type -> deeppb.UpdateTracepointRequest
+	45, // 78: deeppb.TracepointConfigService.RecordSnapshots:input_type -> deeppb.RecordSnapshotsRequest
+	49, // 79: deeppb.TracepointConfigService.TracepointHistory:input_type -> deeppb.TracepointHistoryRequest
+	51, // 80: deeppb.TracepointConfigService.BulkCreateTracepoints:input_type -> deeppb.BulkCreateTracepointsRequest
+	53, // 81: deeppb.TracepointConfigService.BulkDeleteTracepoints:input_type -> deeppb.BulkDeleteTracepointsRequest
+	55, // 82: deeppb.TracepointConfigService.SetTracepointsPaused:input_type -> deeppb.SetTracepointsPausedRequest
+	57, // 83: deeppb.TracepointConfigService.SetTracepointPaused:input_type -> deeppb.SetTracepointPausedRequest
+	59, // 84: deeppb.TracepointConfigService.WatchTracepoints:input_type -> deeppb.WatchTracepointsRequest
+	61, // 85: deeppb.TracepointConfigService.RecordInstallStatus:input_type -> deeppb.RecordInstallStatusRequest
+	64, // 86: deeppb.TracepointConfigService.RecordAgents:input_type -> deeppb.RecordAgentsRequest
+	66, // 87: deeppb.TracepointConfigService.LoadAgents:input_type -> deeppb.LoadAgentsRequest
+	86, // 88: deeppb.PollSubscription.Subscribe:input_type -> deeppb.poll.v1.PollRequest
+	29, // 89: deeppb.TracepointStatus.ReportStatus:input_type -> deeppb.ReportStatusRequest
+	18, // 90: deeppb.QuerierService.FindSnapshotByID:output_type -> deeppb.SnapshotByIDResponse
+	3,  // 91: deeppb.QuerierService.SearchRecent:output_type -> deeppb.SearchResponse
+	3,  // 92: deeppb.QuerierService.SearchBlock:output_type -> deeppb.SearchResponse
+	12, // 93: deeppb.QuerierService.SearchTags:output_type -> deeppb.SearchTagsResponse
+	14, // 94: deeppb.QuerierService.SearchTagValues:output_type -> deeppb.SearchTagValuesResponse
+	16, // 95: deeppb.QuerierService.SearchTagValuesV2:output_type -> deeppb.SearchTagValuesV2Response
+	7,  // 96: deeppb.QuerierService.TailSnapshots:output_type -> deeppb.TailSnapshotsResponse
+	26, // 97: deeppb.MetricsGenerator.PushSnapshot:output_type -> deeppb.PushSnapshotResponse
+	28, // 98: deeppb.IngesterService.PushBytes:output_type -> deeppb.PushBytesResponse
+	37, // 99: deeppb.TracepointConfigService.LoadTracepoints:output_type -> deeppb.LoadTracepointResponse
+	40, // 100: deeppb.TracepointConfigService.CreateTracepoint:output_type -> deeppb.CreateTracepointResponse
+	42, // 101: deeppb.TracepointConfigService.DeleteTracepoint:output_type -> deeppb.DeleteTracepointResponse
+	44, // 102: deeppb.TracepointConfigService.UpdateTracepoint:output_type -> deeppb.UpdateTracepointResponse
+	46, // 103: deeppb.TracepointConfigService.RecordSnapshots:output_type -> deeppb.RecordSnapshotsResponse
+	50, // 104: deeppb.TracepointConfigService.TracepointHistory:output_type -> deeppb.TracepointHistoryResponse
+	52, // 105: deeppb.TracepointConfigService.BulkCreateTracepoints:output_type -> deeppb.BulkCreateTracepointsResponse
+	54, // 106: deeppb.TracepointConfigService.BulkDeleteTracepoints:output_type -> deeppb.BulkDeleteTracepointsResponse
+	56, // 107: deeppb.TracepointConfigService.SetTracepointsPaused:output_type -> deeppb.SetTracepointsPausedResponse
+	58, // 108: deeppb.TracepointConfigService.SetTracepointPaused:output_type -> deeppb.SetTracepointPausedResponse
+	60, // 109: deeppb.TracepointConfigService.WatchTracepoints:output_type -> deeppb.WatchTracepointsResponse
+	62, // 110: deeppb.TracepointConfigService.RecordInstallStatus:output_type -> deeppb.RecordInstallStatusResponse
+	65, // 111: deeppb.TracepointConfigService.RecordAgents:output_type -> deeppb.RecordAgentsResponse
+	67, // 112: deeppb.TracepointConfigService.LoadAgents:output_type -> deeppb.LoadAgentsResponse
+	87, // 113: deeppb.PollSubscription.Subscribe:output_type -> deeppb.poll.v1.PollResponse
+	31, // 114: deeppb.TracepointStatus.ReportStatus:output_type -> deeppb.ReportStatusResponse
+	90, // [90:115] is the sub-list for method output_type
+	65, // [65:90] is the sub-list for method input_type
+	65, // [65:65] is the sub-list for extension type_name
+	65, // [65:65] is the sub-list for extension extendee
+	0,  // [0:65] is the sub-list for field type_name
+}
+
+func init() { file_deep_proto_init() }
+func file_deep_proto_init() {
+	if File_deep_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_deep_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchBlockRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotSearchMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeepQLValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TailSnapshotsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TailSnapshotsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimeSeries); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Sample); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchMetrics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchTagsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchTagsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchTagValuesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchTagValuesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TagValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchTagValuesV2Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotByIDRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotByIDResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotByIDMetrics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotDiffResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VariableDiff); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FrameDiff); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchDiff); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DiffValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushSnapshotResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushBytesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushBytesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReportStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TracepointInstallStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReportStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TracepointMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TracepointInstallSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TracepointLimits); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TracepointBlockMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoadTracepointRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoadTracepointResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTracepointsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateTracepointRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use DeleteTracepointRequest.ProtoReflect.Descriptor instead.
-func (*DeleteTracepointRequest) Descriptor() ([]byte, []int) {
-	return file_deep_proto_rawDescGZIP(), []int{22}
-}
-
-func (x *DeleteTracepointRequest) GetTracepointID() string {
-	if x != nil {
-		return x.TracepointID
-	}
-	return ""
-}
-
-type DeleteTracepointResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-}
-
-func (x *DeleteTracepointResponse) Reset() {
-	*x = DeleteTracepointResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_deep_proto_msgTypes[23]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *DeleteTracepointResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*DeleteTracepointResponse) ProtoMessage() {}
-
-func (x *DeleteTracepointResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_deep_proto_msgTypes[23]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
+		file_deep_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateTracepointResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use DeleteTracepointResponse.ProtoReflect.Descriptor instead.
-func (*DeleteTracepointResponse) Descriptor() ([]byte, []int) {
-	return file_deep_proto_rawDescGZIP(), []int{23}
-}
-
-var File_deep_proto protoreflect.FileDescriptor
-
-var file_deep_proto_rawDesc = []byte{
-	0x0a, 0x0a, 0x64, 0x65, 0x65, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x64, 0x65,
-	0x65, 0x70, 0x70, 0x62, 0x1a, 0x1e, 0x74, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74,
-	0x2f, 0x76, 0x31, 0x2f, 0x74, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x12, 0x70, 0x6f, 0x6c, 0x6c, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x6f,
-	0x6c, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9d, 0x02, 0x0a, 0x0d, 0x53, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x04, 0x54, 0x61,
-	0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70,
-	0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
-	0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x54, 0x61, 0x67, 0x73, 0x12,
-	0x24, 0x0a, 0x0d, 0x4d, 0x69, 0x6e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x4d, 0x69, 0x6e, 0x44, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x4d, 0x61, 0x78, 0x44, 0x75, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x4d, 0x61,
-	0x78, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x4c,
-	0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x4c, 0x69, 0x6d, 0x69,
-	0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a,
-	0x37, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xff, 0x02, 0x0a, 0x12, 0x53, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x33, 0x0a, 0x09, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x09, 0x73, 0x65, 0x61, 0x72, 0x63,
-	0x68, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x12, 0x1c,
-	0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x50, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x50, 0x61, 0x67, 0x65, 0x12, 0x24, 0x0a, 0x0d,
-	0x70, 0x61, 0x67, 0x65, 0x73, 0x54, 0x6f, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x0d, 0x70, 0x61, 0x67, 0x65, 0x73, 0x54, 0x6f, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x24,
-	0x0a, 0x0d, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x50, 0x61, 0x67, 0x65,
-	0x53, 0x69, 0x7a, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x63,
-	0x6f, 0x72, 0x64, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61,
-	0x6c, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61,
-	0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
-	0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x18, 0x0a, 0x07,
-	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0a,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x6f,
-	0x6f, 0x74, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a,
-	0x66, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x7f, 0x0a, 0x0e, 0x53, 0x65,
-	0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x09,
-	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x1e, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
-	0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
-	0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x07, 0x6d, 0x65,
-	0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x64, 0x65,
-	0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x65, 0x74, 0x72, 0x69,
-	0x63, 0x73, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x22, 0xe0, 0x01, 0x0a, 0x16,
-	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68,
-	0x6f, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6e, 0x61, 0x70,
-	0x73, 0x68, 0x6f, 0x74, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65,
-	0x50, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65,
-	0x50, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x69, 0x6e, 0x65, 0x4e, 0x6f, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6c, 0x69, 0x6e, 0x65, 0x4e, 0x6f, 0x12, 0x2c, 0x0a, 0x11,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x55, 0x6e, 0x69, 0x78, 0x4e, 0x61, 0x6e,
-	0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69,
-	0x6d, 0x65, 0x55, 0x6e, 0x69, 0x78, 0x4e, 0x61, 0x6e, 0x6f, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x75,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6e, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x0c, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6e, 0x6f, 0x22, 0x8d,
-	0x02, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
-	0x12, 0x2e, 0x0a, 0x12, 0x69, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x53, 0x6e, 0x61,
-	0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x69, 0x6e,
-	0x73, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73,
-	0x12, 0x26, 0x0a, 0x0e, 0x69, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x42, 0x79, 0x74,
-	0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x69, 0x6e, 0x73, 0x70, 0x65, 0x63,
-	0x74, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x69, 0x6e, 0x73, 0x70,
-	0x65, 0x63, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x0f, 0x69, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63,
-	0x6b, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x42, 0x6c, 0x6f,
-	0x63, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x70,
-	0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x73, 0x6b, 0x69, 0x70,
-	0x70, 0x65, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x10, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73,
-	0x68, 0x6f, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x6c, 0x6f,
-	0x63, 0x6b, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x74,
-	0x6f, 0x74, 0x61, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x13,
-	0x0a, 0x11, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x22, 0x30, 0x0a, 0x12, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x67,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x61, 0x67,
-	0x4e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x74, 0x61, 0x67,
-	0x4e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x32, 0x0a, 0x16, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54,
-	0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x18, 0x0a, 0x07, 0x74, 0x61, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x74, 0x61, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x37, 0x0a, 0x17, 0x53, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x74, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x73, 0x22, 0x34, 0x0a, 0x08, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12,
-	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79,
-	0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x4b, 0x0a, 0x19, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x56, 0x32, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x09, 0x74, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70,
-	0x62, 0x2e, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x09, 0x74, 0x61, 0x67, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x7f, 0x0a, 0x13, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
-	0x74, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02,
-	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a,
-	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x1a, 0x0a, 0x08,
-	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x45, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x45, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x71, 0x75, 0x65, 0x72,
-	0x79, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x71, 0x75, 0x65,
-	0x72, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x22, 0x89, 0x01, 0x0a, 0x14, 0x53, 0x6e, 0x61, 0x70, 0x73,
-	0x68, 0x6f, 0x74, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x3a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1e, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x74, 0x72, 0x61, 0x63, 0x65,
-	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
-	0x74, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x35, 0x0a, 0x07, 0x6d,
-	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64,
-	0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x42, 0x79,
-	0x49, 0x44, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69,
-	0x63, 0x73, 0x22, 0x39, 0x0a, 0x13, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x42, 0x79,
-	0x49, 0x44, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x66, 0x61, 0x69,
-	0x6c, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x22, 0x51, 0x0a,
-	0x13, 0x50, 0x75, 0x73, 0x68, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e,
-	0x74, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e,
-	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
-	0x22, 0x16, 0x0a, 0x14, 0x50, 0x75, 0x73, 0x68, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x3e, 0x0a, 0x10, 0x50, 0x75, 0x73, 0x68,
-	0x42, 0x79, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08,
-	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08,
-	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x49, 0x44, 0x22, 0x13, 0x0a, 0x11, 0x50, 0x75, 0x73, 0x68,
-	0x42, 0x79, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x5f, 0x0a,
-	0x15, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62,
-	0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x88,
-	0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x52,
-	0x0a, 0x16, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x64, 0x65, 0x65,
-	0x70, 0x70, 0x62, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x6c, 0x6c,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x61, 0x0a, 0x17, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63,
-	0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x46, 0x0a,
-	0x0a, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x26, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x74, 0x72, 0x61, 0x63, 0x65,
-	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x50, 0x6f,
-	0x69, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0a, 0x54, 0x72, 0x61, 0x63, 0x65,
-	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x22, 0x1a, 0x0a, 0x18, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54,
-	0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x3d, 0x0a, 0x17, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65,
-	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c,
-	0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0c, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x44,
-	0x22, 0x1a, 0x0a, 0x18, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70,
-	0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xde, 0x03, 0x0a,
-	0x0e, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x4f, 0x0a, 0x10, 0x46, 0x69, 0x6e, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x42,
-	0x79, 0x49, 0x44, 0x12, 0x1b, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x6e, 0x61,
-	0x70, 0x73, 0x68, 0x6f, 0x74, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1c, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
-	0x6f, 0x74, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x3f, 0x0a, 0x0c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74,
-	0x12, 0x15, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62,
-	0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x43, 0x0a, 0x0b, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x12, 0x1a, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x64,
-	0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0a, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x54, 0x61, 0x67, 0x73, 0x12, 0x19, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65,
-	0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1a, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54,
-	0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a,
-	0x0f, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73,
-	0x12, 0x1e, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1f, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x11, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x67,
-	0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x56, 0x32, 0x12, 0x1e, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70,
-	0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70,
-	0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x73, 0x56, 0x32, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x5f, 0x0a,
-	0x10, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f,
-	0x72, 0x12, 0x4b, 0x0a, 0x0c, 0x50, 0x75, 0x73, 0x68, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
-	0x74, 0x12, 0x1b, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x53,
-	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c,
-	0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x53, 0x6e, 0x61, 0x70,
-	0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x55,
-	0x0a, 0x0f, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x42, 0x0a, 0x09, 0x50, 0x75, 0x73, 0x68, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x18,
-	0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x42, 0x79, 0x74, 0x65,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70,
-	0x62, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x42, 0x79, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x9f, 0x02, 0x0a, 0x17, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70,
-	0x6f, 0x69, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x52, 0x0a, 0x0f, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f,
-	0x69, 0x6e, 0x74, 0x73, 0x12, 0x1d, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x4c, 0x6f,
-	0x61, 0x64, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x4c, 0x6f, 0x61,
-	0x64, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54,
-	0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1f, 0x2e, 0x64, 0x65, 0x65, 0x70,
-	0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f,
-	0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x64, 0x65, 0x65,
-	0x70, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70,
-	0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x57,
-	0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69,
-	0x6e, 0x74, 0x12, 0x1f, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x26, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x67, 0x72, 0x61, 0x6c, 0x2f,
-	0x64, 0x65, 0x65, 0x70, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x64, 0x65, 0x65, 0x70, 0x70, 0x62, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
-
-var (
-	file_deep_proto_rawDescOnce sync.Once
-	file_deep_proto_rawDescData = file_deep_proto_rawDesc
-)
-
-func file_deep_proto_rawDescGZIP() []byte {
-	file_deep_proto_rawDescOnce.Do(func() {
-		file_deep_proto_rawDescData = protoimpl.X.CompressGZIP(file_deep_proto_rawDescData)
-	})
-	return file_deep_proto_rawDescData
-}
-
-var file_deep_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
-var file_deep_proto_goTypes = []interface{}{
-	(*SearchRequest)(nil),             // 0: deeppb.SearchRequest
-	(*SearchBlockRequest)(nil),        // 1: deeppb.SearchBlockRequest
-	(*SearchResponse)(nil),            // 2: deeppb.SearchResponse
-	(*SnapshotSearchMetadata)(nil),    // 3: deeppb.SnapshotSearchMetadata
-	(*SearchMetrics)(nil),             // 4: deeppb.SearchMetrics
-	(*SearchTagsRequest)(nil),         // 5: deeppb.SearchTagsRequest
-	(*SearchTagsResponse)(nil),        // 6: deeppb.SearchTagsResponse
-	(*SearchTagValuesRequest)(nil),    // 7: deeppb.SearchTagValuesRequest
-	(*SearchTagValuesResponse)(nil),   // 8: deeppb.SearchTagValuesResponse
-	(*TagValue)(nil),                  // 9: deeppb.TagValue
-	(*SearchTagValuesV2Response)(nil), // 10: deeppb.SearchTagValuesV2Response
-	(*SnapshotByIDRequest)(nil),       // 11: deeppb.SnapshotByIDRequest
-	(*SnapshotByIDResponse)(nil),      // 12: deeppb.SnapshotByIDResponse
-	(*SnapshotByIDMetrics)(nil),       // 13: deeppb.SnapshotByIDMetrics
-	(*PushSnapshotRequest)(nil),       // 14: deeppb.PushSnapshotRequest
-	(*PushSnapshotResponse)(nil),      // 15: deeppb.PushSnapshotResponse
-	(*PushBytesRequest)(nil),          // 16: deeppb.PushBytesRequest
-	(*PushBytesResponse)(nil),         // 17: deeppb.PushBytesResponse
-	(*LoadTracepointRequest)(nil),     // 18: deeppb.LoadTracepointRequest
-	(*LoadTracepointResponse)(nil),    // 19: deeppb.LoadTracepointResponse
-	(*CreateTracepointRequest)(nil),   // 20: deeppb.CreateTracepointRequest
-	(*CreateTracepointResponse)(nil),  // 21: deeppb.CreateTracepointResponse
-	(*DeleteTracepointRequest)(nil),   // 22: deeppb.DeleteTracepointRequest
-	(*DeleteTracepointResponse)(nil),  // 23: deeppb.DeleteTracepointResponse
-	nil,                               // 24: deeppb.SearchRequest.TagsEntry
-	(*v1.Snapshot)(nil),               // 25: deeppb.tracepoint.v1.Snapshot
-	(*v11.PollRequest)(nil),           // 26: deeppb.poll.v1.PollRequest
-	(*v11.PollResponse)(nil),          // 27: deeppb.poll.v1.PollResponse
-	(*v1.TracePointConfig)(nil),       // 28: deeppb.tracepoint.v1.TracePointConfig
-}
-var file_deep_proto_depIdxs = []int32{
-	24, // 0: deeppb.SearchRequest.Tags:type_name -> deeppb.SearchRequest.TagsEntry
-	0,  // 1: deeppb.SearchBlockRequest.searchReq:type_name -> deeppb.SearchRequest
-	3,  // 2: deeppb.SearchResponse.snapshots:type_name -> deeppb.SnapshotSearchMetadata
-	4,  // 3: deeppb.SearchResponse.metrics:type_name -> deeppb.SearchMetrics
-	9,  // 4: deeppb.SearchTagValuesV2Response.tagValues:type_name -> deeppb.TagValue
-	25, // 5: deeppb.SnapshotByIDResponse.snapshot:type_name -> deeppb.tracepoint.v1.Snapshot
-	13, // 6: deeppb.SnapshotByIDResponse.metrics:type_name -> deeppb.SnapshotByIDMetrics
-	25, // 7: deeppb.PushSnapshotRequest.snapshot:type_name -> deeppb.tracepoint.v1.Snapshot
-	26, // 8: deeppb.LoadTracepointRequest.Request:type_name -> deeppb.poll.v1.PollRequest
-	27, // 9: deeppb.LoadTracepointResponse.Response:type_name -> deeppb.poll.v1.PollResponse
-	28, // 10: deeppb.CreateTracepointRequest.Tracepoint:type_name -> deeppb.tracepoint.v1.TracePointConfig
-	11, // 11: deeppb.QuerierService.FindSnapshotByID:input_type -> deeppb.SnapshotByIDRequest
-	0,  // 12: deeppb.QuerierService.SearchRecent:input_type -> deeppb.SearchRequest
-	1,  // 13: deeppb.QuerierService.SearchBlock:input_type -> deeppb.SearchBlockRequest
-	5,  // 14: deeppb.QuerierService.SearchTags:input_type -> deeppb.SearchTagsRequest
-	7,  // 15: deeppb.QuerierService.SearchTagValues:input_type -> deeppb.SearchTagValuesRequest
-	7,  // 16: deeppb.QuerierService.SearchTagValuesV2:input_type -> deeppb.SearchTagValuesRequest
-	14, // 17: deeppb.MetricsGenerator.PushSnapshot:input_type -> deeppb.PushSnapshotRequest
-	16, // 18: deeppb.IngesterService.PushBytes:input_type -> deeppb.PushBytesRequest
-	18, // 19: deeppb.TracepointConfigService.LoadTracepoints:input_type -> deeppb.LoadTracepointRequest
-	20, // 20: deeppb.TracepointConfigService.CreateTracepoint:input_type -> deeppb.CreateTracepointRequest
-	22, // 21: deeppb.TracepointConfigService.DeleteTracepoint:input_type -> deeppb.DeleteTracepointRequest
-	12, // 22: deeppb.QuerierService.FindSnapshotByID:output_type -> deeppb.SnapshotByIDResponse
-	2,  // 23: deeppb.QuerierService.SearchRecent:output_type -> deeppb.SearchResponse
-	2,  // 24: deeppb.QuerierService.SearchBlock:output_type -> deeppb.SearchResponse
-	6,  // 25: deeppb.QuerierService.SearchTags:output_type -> deeppb.SearchTagsResponse
-	8,  // 26: deeppb.QuerierService.SearchTagValues:output_type -> deeppb.SearchTagValuesResponse
-	10, // 27: deeppb.QuerierService.SearchTagValuesV2:output_type -> deeppb.SearchTagValuesV2Response
-	15, // 28: deeppb.MetricsGenerator.PushSnapshot:output_type -> deeppb.PushSnapshotResponse
-	17, // 29: deeppb.IngesterService.PushBytes:output_type -> deeppb.PushBytesResponse
-	19, // 30: deeppb.TracepointConfigService.LoadTracepoints:output_type -> deeppb.LoadTracepointResponse
-	21, // 31: deeppb.TracepointConfigService.CreateTracepoint:output_type -> deeppb.CreateTracepointResponse
-	23, // 32: deeppb.TracepointConfigService.DeleteTracepoint:output_type -> deeppb.DeleteTracepointResponse
-	22, // [22:33] is the sub-list for method output_type
-	11, // [11:22] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
-}
-
-func init() { file_deep_proto_init() }
-func file_deep_proto_init() {
-	if File_deep_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_deep_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchRequest); i {
+		file_deep_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteTracepointRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1724,8 +5602,8 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchBlockRequest); i {
+		file_deep_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteTracepointResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1736,8 +5614,8 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchResponse); i {
+		file_deep_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateTracepointRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1748,8 +5626,8 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SnapshotSearchMetadata); i {
+		file_deep_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateTracepointResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1760,8 +5638,8 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchMetrics); i {
+		file_deep_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordSnapshotsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1772,8 +5650,8 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchTagsRequest); i {
+		file_deep_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordSnapshotsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1784,8 +5662,8 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchTagsResponse); i {
+		file_deep_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TracepointAuditEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1796,8 +5674,8 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchTagValuesRequest); i {
+		file_deep_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TracepointAuditLog); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1808,8 +5686,8 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchTagValuesResponse); i {
+		file_deep_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TracepointHistoryRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1820,8 +5698,8 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TagValue); i {
+		file_deep_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TracepointHistoryResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1832,8 +5710,8 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchTagValuesV2Response); i {
+		file_deep_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkCreateTracepointsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1844,8 +5722,8 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SnapshotByIDRequest); i {
+		file_deep_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkCreateTracepointsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1856,8 +5734,8 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SnapshotByIDResponse); i {
+		file_deep_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkDeleteTracepointsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1868,8 +5746,8 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SnapshotByIDMetrics); i {
+		file_deep_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkDeleteTracepointsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1880,8 +5758,8 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PushSnapshotRequest); i {
+		file_deep_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetTracepointsPausedRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1892,8 +5770,8 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PushSnapshotResponse); i {
+		file_deep_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetTracepointsPausedResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1904,8 +5782,8 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PushBytesRequest); i {
+		file_deep_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetTracepointPausedRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1916,8 +5794,8 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PushBytesResponse); i {
+		file_deep_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetTracepointPausedResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1928,8 +5806,8 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LoadTracepointRequest); i {
+		file_deep_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchTracepointsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1940,8 +5818,8 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LoadTracepointResponse); i {
+		file_deep_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchTracepointsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1952,8 +5830,8 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateTracepointRequest); i {
+		file_deep_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordInstallStatusRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1964,8 +5842,8 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateTracepointResponse); i {
+		file_deep_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordInstallStatusResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1976,8 +5854,8 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteTracepointRequest); i {
+		file_deep_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AgentInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1988,8 +5866,44 @@ func file_deep_proto_init() {
 				return nil
 			}
 		}
-		file_deep_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteTracepointResponse); i {
+		file_deep_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordAgentsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordAgentsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoadAgentsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deep_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoadAgentsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2001,19 +5915,20 @@ func file_deep_proto_init() {
 			}
 		}
 	}
-	file_deep_proto_msgTypes[18].OneofWrappers = []interface{}{}
+	file_deep_proto_msgTypes[35].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_deep_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   25,
+			NumEnums:      1,
+			NumMessages:   83,
 			NumExtensions: 0,
-			NumServices:   4,
+			NumServices:   6,
 		},
 		GoTypes:           file_deep_proto_goTypes,
 		DependencyIndexes: file_deep_proto_depIdxs,
+		EnumInfos:         file_deep_proto_enumTypes,
 		MessageInfos:      file_deep_proto_msgTypes,
 	}.Build()
 	File_deep_proto = out.File
@@ -8,6 +8,7 @@ package deeppb
 
 import (
 	context "context"
+	v1 "github.com/intergral/deep/pkg/deeppb/poll/v1"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -28,6 +29,7 @@ type QuerierServiceClient interface {
 	SearchTags(ctx context.Context, in *SearchTagsRequest, opts ...grpc.CallOption) (*SearchTagsResponse, error)
 	SearchTagValues(ctx context.Context, in *SearchTagValuesRequest, opts ...grpc.CallOption) (*SearchTagValuesResponse, error)
 	SearchTagValuesV2(ctx context.Context, in *SearchTagValuesRequest, opts ...grpc.CallOption) (*SearchTagValuesV2Response, error)
+	TailSnapshots(ctx context.Context, in *TailSnapshotsRequest, opts ...grpc.CallOption) (QuerierService_TailSnapshotsClient, error)
 }
 
 type querierServiceClient struct {
@@ -92,6 +94,38 @@ func (c *querierServiceClient) SearchTagValuesV2(ctx context.Context, in *Search
 	return out, nil
 }
 
+func (c *querierServiceClient) TailSnapshots(ctx context.Context, in *TailSnapshotsRequest, opts ...grpc.CallOption) (QuerierService_TailSnapshotsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &QuerierService_ServiceDesc.Streams[0], "/deeppb.QuerierService/TailSnapshots", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &querierServiceTailSnapshotsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type QuerierService_TailSnapshotsClient interface {
+	Recv() (*TailSnapshotsResponse, error)
+	grpc.ClientStream
+}
+
+type querierServiceTailSnapshotsClient struct {
+	grpc.ClientStream
+}
+
+func (x *querierServiceTailSnapshotsClient) Recv() (*TailSnapshotsResponse, error) {
+	m := new(TailSnapshotsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // QuerierServiceServer is the server API for QuerierService service.
 // All implementations must embed UnimplementedQuerierServiceServer
 // for forward compatibility
@@ -102,6 +136,7 @@ type QuerierServiceServer interface {
 	SearchTags(context.Context, *SearchTagsRequest) (*SearchTagsResponse, error)
 	SearchTagValues(context.Context, *SearchTagValuesRequest) (*SearchTagValuesResponse, error)
 	SearchTagValuesV2(context.Context, *SearchTagValuesRequest) (*SearchTagValuesV2Response, error)
+	TailSnapshots(*TailSnapshotsRequest, QuerierService_TailSnapshotsServer) error
 	mustEmbedUnimplementedQuerierServiceServer()
 }
 
@@ -127,6 +162,9 @@ func (UnimplementedQuerierServiceServer) SearchTagValues(context.Context, *Searc
 func (UnimplementedQuerierServiceServer) SearchTagValuesV2(context.Context, *SearchTagValuesRequest) (*SearchTagValuesV2Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SearchTagValuesV2 not implemented")
 }
+func (UnimplementedQuerierServiceServer) TailSnapshots(*TailSnapshotsRequest, QuerierService_TailSnapshotsServer) error {
+	return status.Errorf(codes.Unimplemented, "method TailSnapshots not implemented")
+}
 func (UnimplementedQuerierServiceServer) mustEmbedUnimplementedQuerierServiceServer() {}
 
 // UnsafeQuerierServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -248,6 +286,27 @@ func _QuerierService_SearchTagValuesV2_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QuerierService_TailSnapshots_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TailSnapshotsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QuerierServiceServer).TailSnapshots(m, &querierServiceTailSnapshotsServer{stream})
+}
+
+type QuerierService_TailSnapshotsServer interface {
+	Send(*TailSnapshotsResponse) error
+	grpc.ServerStream
+}
+
+type querierServiceTailSnapshotsServer struct {
+	grpc.ServerStream
+}
+
+func (x *querierServiceTailSnapshotsServer) Send(m *TailSnapshotsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // QuerierService_ServiceDesc is the grpc.ServiceDesc for QuerierService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -280,7 +339,13 @@ var QuerierService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _QuerierService_SearchTagValuesV2_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "TailSnapshots",
+			Handler:       _QuerierService_TailSnapshots_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "deep.proto",
 }
 
@@ -463,6 +528,17 @@ type TracepointConfigServiceClient interface {
 	LoadTracepoints(ctx context.Context, in *LoadTracepointRequest, opts ...grpc.CallOption) (*LoadTracepointResponse, error)
 	CreateTracepoint(ctx context.Context, in *CreateTracepointRequest, opts ...grpc.CallOption) (*CreateTracepointResponse, error)
 	DeleteTracepoint(ctx context.Context, in *DeleteTracepointRequest, opts ...grpc.CallOption) (*DeleteTracepointResponse, error)
+	UpdateTracepoint(ctx context.Context, in *UpdateTracepointRequest, opts ...grpc.CallOption) (*UpdateTracepointResponse, error)
+	RecordSnapshots(ctx context.Context, in *RecordSnapshotsRequest, opts ...grpc.CallOption) (*RecordSnapshotsResponse, error)
+	TracepointHistory(ctx context.Context, in *TracepointHistoryRequest, opts ...grpc.CallOption) (*TracepointHistoryResponse, error)
+	BulkCreateTracepoints(ctx context.Context, in *BulkCreateTracepointsRequest, opts ...grpc.CallOption) (*BulkCreateTracepointsResponse, error)
+	BulkDeleteTracepoints(ctx context.Context, in *BulkDeleteTracepointsRequest, opts ...grpc.CallOption) (*BulkDeleteTracepointsResponse, error)
+	SetTracepointsPaused(ctx context.Context, in *SetTracepointsPausedRequest, opts ...grpc.CallOption) (*SetTracepointsPausedResponse, error)
+	SetTracepointPaused(ctx context.Context, in *SetTracepointPausedRequest, opts ...grpc.CallOption) (*SetTracepointPausedResponse, error)
+	WatchTracepoints(ctx context.Context, in *WatchTracepointsRequest, opts ...grpc.CallOption) (TracepointConfigService_WatchTracepointsClient, error)
+	RecordInstallStatus(ctx context.Context, in *RecordInstallStatusRequest, opts ...grpc.CallOption) (*RecordInstallStatusResponse, error)
+	RecordAgents(ctx context.Context, in *RecordAgentsRequest, opts ...grpc.CallOption) (*RecordAgentsResponse, error)
+	LoadAgents(ctx context.Context, in *LoadAgentsRequest, opts ...grpc.CallOption) (*LoadAgentsResponse, error)
 }
 
 type tracepointConfigServiceClient struct {
@@ -500,6 +576,128 @@ func (c *tracepointConfigServiceClient) DeleteTracepoint(ctx context.Context, in
 	return out, nil
 }
 
+func (c *tracepointConfigServiceClient) UpdateTracepoint(ctx context.Context, in *UpdateTracepointRequest, opts ...grpc.CallOption) (*UpdateTracepointResponse, error) {
+	out := new(UpdateTracepointResponse)
+	err := c.cc.Invoke(ctx, "/deeppb.TracepointConfigService/UpdateTracepoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tracepointConfigServiceClient) RecordSnapshots(ctx context.Context, in *RecordSnapshotsRequest, opts ...grpc.CallOption) (*RecordSnapshotsResponse, error) {
+	out := new(RecordSnapshotsResponse)
+	err := c.cc.Invoke(ctx, "/deeppb.TracepointConfigService/RecordSnapshots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tracepointConfigServiceClient) TracepointHistory(ctx context.Context, in *TracepointHistoryRequest, opts ...grpc.CallOption) (*TracepointHistoryResponse, error) {
+	out := new(TracepointHistoryResponse)
+	err := c.cc.Invoke(ctx, "/deeppb.TracepointConfigService/TracepointHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tracepointConfigServiceClient) BulkCreateTracepoints(ctx context.Context, in *BulkCreateTracepointsRequest, opts ...grpc.CallOption) (*BulkCreateTracepointsResponse, error) {
+	out := new(BulkCreateTracepointsResponse)
+	err := c.cc.Invoke(ctx, "/deeppb.TracepointConfigService/BulkCreateTracepoints", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tracepointConfigServiceClient) BulkDeleteTracepoints(ctx context.Context, in *BulkDeleteTracepointsRequest, opts ...grpc.CallOption) (*BulkDeleteTracepointsResponse, error) {
+	out := new(BulkDeleteTracepointsResponse)
+	err := c.cc.Invoke(ctx, "/deeppb.TracepointConfigService/BulkDeleteTracepoints", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tracepointConfigServiceClient) SetTracepointsPaused(ctx context.Context, in *SetTracepointsPausedRequest, opts ...grpc.CallOption) (*SetTracepointsPausedResponse, error) {
+	out := new(SetTracepointsPausedResponse)
+	err := c.cc.Invoke(ctx, "/deeppb.TracepointConfigService/SetTracepointsPaused", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tracepointConfigServiceClient) SetTracepointPaused(ctx context.Context, in *SetTracepointPausedRequest, opts ...grpc.CallOption) (*SetTracepointPausedResponse, error) {
+	out := new(SetTracepointPausedResponse)
+	err := c.cc.Invoke(ctx, "/deeppb.TracepointConfigService/SetTracepointPaused", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tracepointConfigServiceClient) WatchTracepoints(ctx context.Context, in *WatchTracepointsRequest, opts ...grpc.CallOption) (TracepointConfigService_WatchTracepointsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TracepointConfigService_ServiceDesc.Streams[0], "/deeppb.TracepointConfigService/WatchTracepoints", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tracepointConfigServiceWatchTracepointsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TracepointConfigService_WatchTracepointsClient interface {
+	Recv() (*WatchTracepointsResponse, error)
+	grpc.ClientStream
+}
+
+type tracepointConfigServiceWatchTracepointsClient struct {
+	grpc.ClientStream
+}
+
+func (x *tracepointConfigServiceWatchTracepointsClient) Recv() (*WatchTracepointsResponse, error) {
+	m := new(WatchTracepointsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *tracepointConfigServiceClient) RecordInstallStatus(ctx context.Context, in *RecordInstallStatusRequest, opts ...grpc.CallOption) (*RecordInstallStatusResponse, error) {
+	out := new(RecordInstallStatusResponse)
+	err := c.cc.Invoke(ctx, "/deeppb.TracepointConfigService/RecordInstallStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tracepointConfigServiceClient) RecordAgents(ctx context.Context, in *RecordAgentsRequest, opts ...grpc.CallOption) (*RecordAgentsResponse, error) {
+	out := new(RecordAgentsResponse)
+	err := c.cc.Invoke(ctx, "/deeppb.TracepointConfigService/RecordAgents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tracepointConfigServiceClient) LoadAgents(ctx context.Context, in *LoadAgentsRequest, opts ...grpc.CallOption) (*LoadAgentsResponse, error) {
+	out := new(LoadAgentsResponse)
+	err := c.cc.Invoke(ctx, "/deeppb.TracepointConfigService/LoadAgents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TracepointConfigServiceServer is the server API for TracepointConfigService service.
 // All implementations must embed UnimplementedTracepointConfigServiceServer
 // for forward compatibility
@@ -507,6 +705,17 @@ type TracepointConfigServiceServer interface {
 	LoadTracepoints(context.Context, *LoadTracepointRequest) (*LoadTracepointResponse, error)
 	CreateTracepoint(context.Context, *CreateTracepointRequest) (*CreateTracepointResponse, error)
 	DeleteTracepoint(context.Context, *DeleteTracepointRequest) (*DeleteTracepointResponse, error)
+	UpdateTracepoint(context.Context, *UpdateTracepointRequest) (*UpdateTracepointResponse, error)
+	RecordSnapshots(context.Context, *RecordSnapshotsRequest) (*RecordSnapshotsResponse, error)
+	TracepointHistory(context.Context, *TracepointHistoryRequest) (*TracepointHistoryResponse, error)
+	BulkCreateTracepoints(context.Context, *BulkCreateTracepointsRequest) (*BulkCreateTracepointsResponse, error)
+	BulkDeleteTracepoints(context.Context, *BulkDeleteTracepointsRequest) (*BulkDeleteTracepointsResponse, error)
+	SetTracepointsPaused(context.Context, *SetTracepointsPausedRequest) (*SetTracepointsPausedResponse, error)
+	SetTracepointPaused(context.Context, *SetTracepointPausedRequest) (*SetTracepointPausedResponse, error)
+	WatchTracepoints(*WatchTracepointsRequest, TracepointConfigService_WatchTracepointsServer) error
+	RecordInstallStatus(context.Context, *RecordInstallStatusRequest) (*RecordInstallStatusResponse, error)
+	RecordAgents(context.Context, *RecordAgentsRequest) (*RecordAgentsResponse, error)
+	LoadAgents(context.Context, *LoadAgentsRequest) (*LoadAgentsResponse, error)
 	mustEmbedUnimplementedTracepointConfigServiceServer()
 }
 
@@ -523,6 +732,39 @@ func (UnimplementedTracepointConfigServiceServer) CreateTracepoint(context.Conte
 func (UnimplementedTracepointConfigServiceServer) DeleteTracepoint(context.Context, *DeleteTracepointRequest) (*DeleteTracepointResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteTracepoint not implemented")
 }
+func (UnimplementedTracepointConfigServiceServer) UpdateTracepoint(context.Context, *UpdateTracepointRequest) (*UpdateTracepointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTracepoint not implemented")
+}
+func (UnimplementedTracepointConfigServiceServer) RecordSnapshots(context.Context, *RecordSnapshotsRequest) (*RecordSnapshotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordSnapshots not implemented")
+}
+func (UnimplementedTracepointConfigServiceServer) TracepointHistory(context.Context, *TracepointHistoryRequest) (*TracepointHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TracepointHistory not implemented")
+}
+func (UnimplementedTracepointConfigServiceServer) BulkCreateTracepoints(context.Context, *BulkCreateTracepointsRequest) (*BulkCreateTracepointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkCreateTracepoints not implemented")
+}
+func (UnimplementedTracepointConfigServiceServer) BulkDeleteTracepoints(context.Context, *BulkDeleteTracepointsRequest) (*BulkDeleteTracepointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkDeleteTracepoints not implemented")
+}
+func (UnimplementedTracepointConfigServiceServer) SetTracepointsPaused(context.Context, *SetTracepointsPausedRequest) (*SetTracepointsPausedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTracepointsPaused not implemented")
+}
+func (UnimplementedTracepointConfigServiceServer) SetTracepointPaused(context.Context, *SetTracepointPausedRequest) (*SetTracepointPausedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTracepointPaused not implemented")
+}
+func (UnimplementedTracepointConfigServiceServer) WatchTracepoints(*WatchTracepointsRequest, TracepointConfigService_WatchTracepointsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchTracepoints not implemented")
+}
+func (UnimplementedTracepointConfigServiceServer) RecordInstallStatus(context.Context, *RecordInstallStatusRequest) (*RecordInstallStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordInstallStatus not implemented")
+}
+func (UnimplementedTracepointConfigServiceServer) RecordAgents(context.Context, *RecordAgentsRequest) (*RecordAgentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordAgents not implemented")
+}
+func (UnimplementedTracepointConfigServiceServer) LoadAgents(context.Context, *LoadAgentsRequest) (*LoadAgentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadAgents not implemented")
+}
 func (UnimplementedTracepointConfigServiceServer) mustEmbedUnimplementedTracepointConfigServiceServer() {
 }
 
@@ -591,6 +833,207 @@ func _TracepointConfigService_DeleteTracepoint_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TracepointConfigService_UpdateTracepoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTracepointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TracepointConfigServiceServer).UpdateTracepoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deeppb.TracepointConfigService/UpdateTracepoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TracepointConfigServiceServer).UpdateTracepoint(ctx, req.(*UpdateTracepointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TracepointConfigService_RecordSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TracepointConfigServiceServer).RecordSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deeppb.TracepointConfigService/RecordSnapshots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TracepointConfigServiceServer).RecordSnapshots(ctx, req.(*RecordSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TracepointConfigService_TracepointHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TracepointHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TracepointConfigServiceServer).TracepointHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deeppb.TracepointConfigService/TracepointHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TracepointConfigServiceServer).TracepointHistory(ctx, req.(*TracepointHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TracepointConfigService_BulkCreateTracepoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkCreateTracepointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TracepointConfigServiceServer).BulkCreateTracepoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deeppb.TracepointConfigService/BulkCreateTracepoints",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TracepointConfigServiceServer).BulkCreateTracepoints(ctx, req.(*BulkCreateTracepointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TracepointConfigService_BulkDeleteTracepoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkDeleteTracepointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TracepointConfigServiceServer).BulkDeleteTracepoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deeppb.TracepointConfigService/BulkDeleteTracepoints",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TracepointConfigServiceServer).BulkDeleteTracepoints(ctx, req.(*BulkDeleteTracepointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TracepointConfigService_SetTracepointsPaused_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTracepointsPausedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TracepointConfigServiceServer).SetTracepointsPaused(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deeppb.TracepointConfigService/SetTracepointsPaused",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TracepointConfigServiceServer).SetTracepointsPaused(ctx, req.(*SetTracepointsPausedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TracepointConfigService_SetTracepointPaused_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTracepointPausedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TracepointConfigServiceServer).SetTracepointPaused(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deeppb.TracepointConfigService/SetTracepointPaused",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TracepointConfigServiceServer).SetTracepointPaused(ctx, req.(*SetTracepointPausedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TracepointConfigService_WatchTracepoints_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchTracepointsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TracepointConfigServiceServer).WatchTracepoints(m, &tracepointConfigServiceWatchTracepointsServer{stream})
+}
+
+type TracepointConfigService_WatchTracepointsServer interface {
+	Send(*WatchTracepointsResponse) error
+	grpc.ServerStream
+}
+
+type tracepointConfigServiceWatchTracepointsServer struct {
+	grpc.ServerStream
+}
+
+func (x *tracepointConfigServiceWatchTracepointsServer) Send(m *WatchTracepointsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TracepointConfigService_RecordInstallStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordInstallStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TracepointConfigServiceServer).RecordInstallStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deeppb.TracepointConfigService/RecordInstallStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TracepointConfigServiceServer).RecordInstallStatus(ctx, req.(*RecordInstallStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TracepointConfigService_RecordAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordAgentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TracepointConfigServiceServer).RecordAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deeppb.TracepointConfigService/RecordAgents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TracepointConfigServiceServer).RecordAgents(ctx, req.(*RecordAgentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TracepointConfigService_LoadAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadAgentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TracepointConfigServiceServer).LoadAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deeppb.TracepointConfigService/LoadAgents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TracepointConfigServiceServer).LoadAgents(ctx, req.(*LoadAgentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TracepointConfigService_ServiceDesc is the grpc.ServiceDesc for TracepointConfigService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -610,6 +1053,251 @@ var TracepointConfigService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteTracepoint",
 			Handler:    _TracepointConfigService_DeleteTracepoint_Handler,
 		},
+		{
+			MethodName: "UpdateTracepoint",
+			Handler:    _TracepointConfigService_UpdateTracepoint_Handler,
+		},
+		{
+			MethodName: "RecordSnapshots",
+			Handler:    _TracepointConfigService_RecordSnapshots_Handler,
+		},
+		{
+			MethodName: "TracepointHistory",
+			Handler:    _TracepointConfigService_TracepointHistory_Handler,
+		},
+		{
+			MethodName: "BulkCreateTracepoints",
+			Handler:    _TracepointConfigService_BulkCreateTracepoints_Handler,
+		},
+		{
+			MethodName: "BulkDeleteTracepoints",
+			Handler:    _TracepointConfigService_BulkDeleteTracepoints_Handler,
+		},
+		{
+			MethodName: "SetTracepointsPaused",
+			Handler:    _TracepointConfigService_SetTracepointsPaused_Handler,
+		},
+		{
+			MethodName: "SetTracepointPaused",
+			Handler:    _TracepointConfigService_SetTracepointPaused_Handler,
+		},
+		{
+			MethodName: "RecordInstallStatus",
+			Handler:    _TracepointConfigService_RecordInstallStatus_Handler,
+		},
+		{
+			MethodName: "RecordAgents",
+			Handler:    _TracepointConfigService_RecordAgents_Handler,
+		},
+		{
+			MethodName: "LoadAgents",
+			Handler:    _TracepointConfigService_LoadAgents_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchTracepoints",
+			Handler:       _TracepointConfigService_WatchTracepoints_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "deep.proto",
+}
+
+// PollSubscriptionClient is the client API for PollSubscription service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PollSubscriptionClient interface {
+	Subscribe(ctx context.Context, in *v1.PollRequest, opts ...grpc.CallOption) (PollSubscription_SubscribeClient, error)
+}
+
+type pollSubscriptionClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPollSubscriptionClient(cc grpc.ClientConnInterface) PollSubscriptionClient {
+	return &pollSubscriptionClient{cc}
+}
+
+func (c *pollSubscriptionClient) Subscribe(ctx context.Context, in *v1.PollRequest, opts ...grpc.CallOption) (PollSubscription_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PollSubscription_ServiceDesc.Streams[0], "/deeppb.PollSubscription/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &pollSubscriptionSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PollSubscription_SubscribeClient interface {
+	Recv() (*v1.PollResponse, error)
+	grpc.ClientStream
+}
+
+type pollSubscriptionSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *pollSubscriptionSubscribeClient) Recv() (*v1.PollResponse, error) {
+	m := new(v1.PollResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PollSubscriptionServer is the server API for PollSubscription service.
+// All implementations must embed UnimplementedPollSubscriptionServer
+// for forward compatibility
+type PollSubscriptionServer interface {
+	Subscribe(*v1.PollRequest, PollSubscription_SubscribeServer) error
+	mustEmbedUnimplementedPollSubscriptionServer()
+}
+
+// UnimplementedPollSubscriptionServer must be embedded to have forward compatible implementations.
+type UnimplementedPollSubscriptionServer struct {
+}
+
+func (UnimplementedPollSubscriptionServer) Subscribe(*v1.PollRequest, PollSubscription_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedPollSubscriptionServer) mustEmbedUnimplementedPollSubscriptionServer() {}
+
+// UnsafePollSubscriptionServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PollSubscriptionServer will
+// result in compilation errors.
+type UnsafePollSubscriptionServer interface {
+	mustEmbedUnimplementedPollSubscriptionServer()
+}
+
+func RegisterPollSubscriptionServer(s grpc.ServiceRegistrar, srv PollSubscriptionServer) {
+	s.RegisterService(&PollSubscription_ServiceDesc, srv)
+}
+
+func _PollSubscription_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(v1.PollRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PollSubscriptionServer).Subscribe(m, &pollSubscriptionSubscribeServer{stream})
+}
+
+type PollSubscription_SubscribeServer interface {
+	Send(*v1.PollResponse) error
+	grpc.ServerStream
+}
+
+type pollSubscriptionSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *pollSubscriptionSubscribeServer) Send(m *v1.PollResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// PollSubscription_ServiceDesc is the grpc.ServiceDesc for PollSubscription service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PollSubscription_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "deeppb.PollSubscription",
+	HandlerType: (*PollSubscriptionServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _PollSubscription_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "deep.proto",
+}
+
+// TracepointStatusClient is the client API for TracepointStatus service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TracepointStatusClient interface {
+	ReportStatus(ctx context.Context, in *ReportStatusRequest, opts ...grpc.CallOption) (*ReportStatusResponse, error)
+}
+
+type tracepointStatusClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTracepointStatusClient(cc grpc.ClientConnInterface) TracepointStatusClient {
+	return &tracepointStatusClient{cc}
+}
+
+func (c *tracepointStatusClient) ReportStatus(ctx context.Context, in *ReportStatusRequest, opts ...grpc.CallOption) (*ReportStatusResponse, error) {
+	out := new(ReportStatusResponse)
+	err := c.cc.Invoke(ctx, "/deeppb.TracepointStatus/ReportStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TracepointStatusServer is the server API for TracepointStatus service.
+// All implementations must embed UnimplementedTracepointStatusServer
+// for forward compatibility
+type TracepointStatusServer interface {
+	ReportStatus(context.Context, *ReportStatusRequest) (*ReportStatusResponse, error)
+	mustEmbedUnimplementedTracepointStatusServer()
+}
+
+// UnimplementedTracepointStatusServer must be embedded to have forward compatible implementations.
+type UnimplementedTracepointStatusServer struct {
+}
+
+func (UnimplementedTracepointStatusServer) ReportStatus(context.Context, *ReportStatusRequest) (*ReportStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportStatus not implemented")
+}
+func (UnimplementedTracepointStatusServer) mustEmbedUnimplementedTracepointStatusServer() {}
+
+// UnsafeTracepointStatusServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TracepointStatusServer will
+// result in compilation errors.
+type UnsafeTracepointStatusServer interface {
+	mustEmbedUnimplementedTracepointStatusServer()
+}
+
+func RegisterTracepointStatusServer(s grpc.ServiceRegistrar, srv TracepointStatusServer) {
+	s.RegisterService(&TracepointStatus_ServiceDesc, srv)
+}
+
+func _TracepointStatus_ReportStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TracepointStatusServer).ReportStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deeppb.TracepointStatus/ReportStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TracepointStatusServer).ReportStatus(ctx, req.(*ReportStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TracepointStatus_ServiceDesc is the grpc.ServiceDesc for TracepointStatus service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TracepointStatus_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "deeppb.TracepointStatus",
+	HandlerType: (*TracepointStatusServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReportStatus",
+			Handler:    _TracepointStatus_ReportStatus_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "deep.proto",
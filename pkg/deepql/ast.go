@@ -17,11 +17,18 @@
 
 package deepql
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
 
 // region RootExpr
 type RootExpr struct {
 	Pipeline Pipeline
+	// Metrics is set when the query is a range function, e.g. count_over_time({}[5m]). In this case
+	// Pipeline is the snapshot pipeline wrapped by the range function.
+	Metrics *MetricsAggregate
 }
 
 func newRootExpr(e pipelineElement) *RootExpr {
@@ -35,6 +42,40 @@ func newRootExpr(e pipelineElement) *RootExpr {
 	}
 }
 
+func newMetricsRootExpr(m MetricsAggregate) *RootExpr {
+	return &RootExpr{
+		Pipeline: m.pipeline,
+		Metrics:  &m,
+	}
+}
+
+// endregion
+
+// region MetricsAggregate
+type MetricsAggregate struct {
+	op       MetricsAggregateOp
+	pipeline Pipeline
+	window   time.Duration
+	by       []FieldExpression
+}
+
+func newMetricsAggregate(op MetricsAggregateOp, pipeline Pipeline, window time.Duration, by []FieldExpression) MetricsAggregate {
+	return MetricsAggregate{
+		op:       op,
+		pipeline: pipeline,
+		window:   window,
+		by:       by,
+	}
+}
+
+// extractConditions adds the by() field expressions so the storage layer fetches the values needed to group
+// the snapshots. The pipeline conditions are extracted separately from RootExpr.Pipeline.
+func (m MetricsAggregate) extractConditions(request *FetchSnapshotRequest) {
+	for _, e := range m.by {
+		e.extractConditions(request)
+	}
+}
+
 // endregion
 
 // region Pipeline
@@ -72,11 +113,18 @@ func (p Pipeline) impliedType() StaticType {
 }
 
 func (p Pipeline) extractConditions(req *FetchSnapshotRequest) {
+	filters := 0
 	for _, element := range p.Elements {
 		element.extractConditions(req)
+
+		// grouping and aggregates only fetch the values they need, they don't filter the snapshots
+		switch element.(type) {
+		case GroupOperation, CoalesceOperation, ScalarFilter, Aggregate:
+		default:
+			filters++
+		}
 	}
-	// TODO this needs to be fine-tuned a bit, e.g. { .foo = "bar" } | by(.namespace), AllConditions can still be true
-	if len(p.Elements) > 1 {
+	if filters > 1 {
 		req.AllConditions = false
 	}
 }
@@ -98,6 +146,18 @@ func (p Pipeline) evaluate(input []*SnapshotResult) (result []*SnapshotResult, e
 	return result, nil
 }
 
+// split returns the leading elements that filter single snapshots, and the remaining elements which group or
+// aggregate and so need to be evaluated over the whole set of matching snapshots.
+func (p Pipeline) split() (Pipeline, Pipeline) {
+	for i, element := range p.Elements {
+		switch element.(type) {
+		case GroupOperation, CoalesceOperation, ScalarFilter, Aggregate:
+			return newPipeline(p.Elements[:i]...), newPipeline(p.Elements[i:]...)
+		}
+	}
+	return p, newPipeline()
+}
+
 type pipelineElement interface {
 	Element
 
@@ -124,10 +184,6 @@ func (o GroupOperation) extractConditions(request *FetchSnapshotRequest) {
 	o.Expression.extractConditions(request)
 }
 
-func (GroupOperation) evaluate(ss []*SnapshotResult) ([]*SnapshotResult, error) {
-	return ss, nil
-}
-
 func newGroupOperation(e FieldExpression) GroupOperation {
 	return GroupOperation{
 		Expression: e,
@@ -139,10 +195,6 @@ func newGroupOperation(e FieldExpression) GroupOperation {
 // region CoalesceOperation
 type CoalesceOperation struct{}
 
-func (CoalesceOperation) evaluate(ss []*SnapshotResult) ([]*SnapshotResult, error) {
-	return ss, nil
-}
-
 func (o CoalesceOperation) extractConditions(request *FetchSnapshotRequest) {
 }
 
@@ -421,6 +473,13 @@ func (o BinaryOperation) extractConditions(request *FetchSnapshotRequest) {
 }
 
 func newBinaryOperation(op Operator, lhs FieldExpression, rhs FieldExpression) BinaryOperation {
+	// compile the pattern once, rather than for every snapshot it is matched against. invalid patterns are reported
+	// by validate
+	if pattern, ok := rhs.(Static); ok && pattern.Type == TypeString && (op == OpRegex || op == OpNotRegex) {
+		pattern.regex, _ = regexp.Compile(pattern.S)
+		rhs = pattern
+	}
+
 	return BinaryOperation{
 		Op:  op,
 		LHS: lhs,
@@ -21,7 +21,6 @@ import (
 	"errors"
 	"fmt"
 	"math"
-	"regexp"
 )
 
 func (o SnapshotOperation) evaluate(input []*SnapshotResult) (output []*SnapshotResult, err error) {
@@ -93,73 +92,122 @@ func (f ScalarFilter) evaluate(input []*SnapshotResult) (output []*SnapshotResul
 	return output, nil
 }
 
+// evaluate computes the aggregate over each group of snapshots, created by by(). Every snapshot in a group is
+// returned with the group's value as its Scalar. Without any grouping all snapshots form a single group.
 func (a Aggregate) evaluate(input []*SnapshotResult) (output []*SnapshotResult, err error) {
-	for _, ss := range input {
-		switch a.op {
-		case aggregateCount:
+	for _, group := range groupResults(input) {
+		scalar, ok, err := a.aggregate(group)
+		if err != nil {
+			return nil, err
+		}
+		// no numeric values in this group to aggregate
+		if !ok {
+			continue
+		}
+
+		for _, ss := range group {
 			copy := ss.clone()
-			copy.Scalar = NewStaticInt(1)
+			copy.Scalar = scalar
 			output = append(output, copy)
+		}
+	}
 
-		case aggregateAvg:
-			sum := 0.0
-			count := 1
-			val, err := a.e.execute(ss.Snapshot)
-			if err != nil {
-				return nil, err
-			}
+	return output, nil
+}
 
-			sum += val.asFloat()
+func (a Aggregate) aggregate(group []*SnapshotResult) (Static, bool, error) {
+	if a.op == aggregateCount {
+		return NewStaticInt(len(group)), true, nil
+	}
 
-			copy := ss.clone()
-			copy.Scalar = NewStaticFloat(sum / float64(count))
-			output = append(output, copy)
+	count := 0
+	sum := 0.0
+	max := math.Inf(-1)
+	min := math.Inf(1)
+	for _, ss := range group {
+		val, err := a.e.execute(ss.Snapshot)
+		if err != nil {
+			return Static{}, false, err
+		}
+		if !val.Type.isNumeric() {
+			continue
+		}
 
-		case aggregateMax:
-			max := math.Inf(-1)
-			val, err := a.e.execute(ss.Snapshot)
-			if err != nil {
-				return nil, err
-			}
-			if val.asFloat() > max {
-				max = val.asFloat()
-			}
-			copy := ss.clone()
-			copy.Scalar = NewStaticFloat(max)
-			output = append(output, copy)
+		f := val.asFloat()
+		count++
+		sum += f
+		max = math.Max(max, f)
+		min = math.Min(min, f)
+	}
 
-		case aggregateMin:
-			min := math.Inf(1)
-			val, err := a.e.execute(ss.Snapshot)
-			if err != nil {
-				return nil, err
-			}
-			if val.asFloat() < min {
-				min = val.asFloat()
-			}
-			copy := ss.clone()
-			copy.Scalar = NewStaticFloat(min)
-			output = append(output, copy)
+	if count == 0 {
+		return Static{}, false, nil
+	}
 
-		case aggregateSum:
-			sum := 0.0
-			val, err := a.e.execute(ss.Snapshot)
-			if err != nil {
-				return nil, err
-			}
-			sum += val.asFloat()
-			copy := ss.clone()
-			copy.Scalar = NewStaticFloat(sum)
-			output = append(output, copy)
+	switch a.op {
+	case aggregateAvg:
+		return NewStaticFloat(sum / float64(count)), true, nil
+	case aggregateMax:
+		return NewStaticFloat(max), true, nil
+	case aggregateMin:
+		return NewStaticFloat(min), true, nil
+	case aggregateSum:
+		return NewStaticFloat(sum), true, nil
+	}
 
-		default:
-			return nil, fmt.Errorf("aggregate operation (%v) not supported", a.op)
+	return Static{}, false, fmt.Errorf("aggregate operation (%v) not supported", a.op)
+}
+
+func (o GroupOperation) evaluate(input []*SnapshotResult) (output []*SnapshotResult, err error) {
+	name := labelName(o.Expression)
+	for _, ss := range input {
+		val, err := o.Expression.execute(ss.Snapshot)
+		if err != nil {
+			return nil, err
+		}
+
+		// groups are shared between clones, so copy before adding to them
+		group := make(map[string]string, len(ss.Group)+1)
+		for k, v := range ss.Group {
+			group[k] = v
 		}
+		group[name] = val.EncodeToString(false)
+
+		copy := ss.clone()
+		copy.Group = group
+		output = append(output, copy)
+	}
+
+	return output, nil
+}
+
+func (CoalesceOperation) evaluate(input []*SnapshotResult) (output []*SnapshotResult, err error) {
+	for _, ss := range input {
+		copy := ss.clone()
+		copy.Group = nil
+		output = append(output, copy)
 	}
 
 	return output, nil
 }
 
+// groupResults partitions the snapshots by their group, keeping the order in which the groups are first seen.
+func groupResults(input []*SnapshotResult) [][]*SnapshotResult {
+	var groups [][]*SnapshotResult
+	index := map[string]int{}
+	for _, ss := range input {
+		key := labelsKey(ss.Group)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], ss)
+	}
+	return groups
+}
+
 func (o BinaryOperation) execute(snapshot Snapshot) (Static, error) {
 	lhs, err := o.LHS.execute(snapshot)
 	if err != nil {
@@ -208,10 +256,10 @@ func (o BinaryOperation) execute(snapshot Snapshot) (Static, error) {
 	case OpNotEqual:
 		return NewStaticBool(!lhs.Equals(rhs)), nil
 	case OpRegex:
-		matched, err := regexp.MatchString(rhs.S, lhs.S)
+		matched, err := rhs.matchRegex(lhs.S)
 		return NewStaticBool(matched), err
 	case OpNotRegex:
-		matched, err := regexp.MatchString(rhs.S, lhs.S)
+		matched, err := rhs.matchRegex(lhs.S)
 		return NewStaticBool(!matched), err
 	case OpAnd:
 		return NewStaticBool(lhs.B && rhs.B), nil
@@ -247,6 +295,11 @@ func binOp(op Operator, lhs, rhs Static) (bool, error) {
 		return lhs.Equals(rhs), nil
 	case OpNotEqual:
 		return !lhs.Equals(rhs), nil
+	case OpRegex:
+		return rhs.matchRegex(lhs.S)
+	case OpNotRegex:
+		matched, err := rhs.matchRegex(lhs.S)
+		return !matched, err
 	case OpAnd:
 		return lhs.B && rhs.B, nil
 	case OpOr:
@@ -0,0 +1,149 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package deepql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSnapshot struct {
+	id         []byte
+	attributes map[Attribute]Static
+}
+
+func (m *mockSnapshot) Attributes() map[Attribute]Static {
+	return m.attributes
+}
+
+func (m *mockSnapshot) ID() []byte {
+	return m.id
+}
+
+func (m *mockSnapshot) StartTimeUnixNanos() uint64 {
+	return 0
+}
+
+func (m *mockSnapshot) EndTimeUnixNanos() uint64 {
+	return 0
+}
+
+func newMockResult(id string, service string, line int) *SnapshotResult {
+	return &SnapshotResult{
+		SnapshotID: []byte(id),
+		Snapshot: &mockSnapshot{
+			id: []byte(id),
+			attributes: map[Attribute]Static{
+				NewScopedAttribute(AttributeScopeResource, false, "service"): NewStaticString(service),
+				NewScopedAttribute(AttributeScopeSnapshot, false, "line"):    NewStaticInt(line),
+			},
+		},
+	}
+}
+
+func TestPipelineGrouping(t *testing.T) {
+	input := []*SnapshotResult{
+		newMockResult("1", "a", 10),
+		newMockResult("2", "a", 20),
+		newMockResult("3", "b", 5),
+	}
+
+	tests := []struct {
+		query    string
+		expected []string
+		groups   []map[string]string
+	}{
+		{
+			query:    "{} | count() = 3",
+			expected: []string{"1", "2", "3"},
+			groups:   []map[string]string{nil, nil, nil},
+		},
+		{
+			query:    "{} | by(resource.service) | count() > 1",
+			expected: []string{"1", "2"},
+			groups:   []map[string]string{{"service": "a"}, {"service": "a"}},
+		},
+		{
+			query:    "{} | by(resource.service) | max(.line) < 10",
+			expected: []string{"3"},
+			groups:   []map[string]string{{"service": "b"}},
+		},
+		{
+			query:    "{} | by(resource.service) | by(.line > 10) | count() = 1",
+			expected: []string{"1", "2", "3"},
+			groups: []map[string]string{
+				{"service": "a", ".line > 10": "false"},
+				{"service": "a", ".line > 10": "true"},
+				{"service": "b", ".line > 10": "false"},
+			},
+		},
+		{
+			query:    "{} | by(resource.service) | coalesce() | avg(.line) > 11",
+			expected: []string{"1", "2", "3"},
+			groups:   []map[string]string{nil, nil, nil},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.query, func(t *testing.T) {
+			rootExpr, err := Parse(tc.query)
+			require.NoError(t, err)
+			require.NoError(t, rootExpr.validate())
+
+			output, err := rootExpr.Pipeline.evaluate(input)
+			require.NoError(t, err)
+
+			var ids []string
+			var groups []map[string]string
+			for _, ss := range output {
+				ids = append(ids, string(ss.SnapshotID))
+				groups = append(groups, ss.Group)
+			}
+			assert.Equal(t, tc.expected, ids)
+			assert.Equal(t, tc.groups, groups)
+		})
+	}
+}
+
+func TestRegexIsCompiledWhenParsed(t *testing.T) {
+	rootExpr, err := Parse(`{ resource.service =~ "a.*" }`)
+	require.NoError(t, err)
+	require.NoError(t, rootExpr.validate())
+
+	req := &FetchSnapshotRequest{}
+	rootExpr.Pipeline.extractConditions(req)
+	require.Len(t, req.Conditions, 1)
+	require.NotNil(t, req.Conditions[0].Operands[0].regex)
+
+	matched, err := req.Conditions[0].Matches(NewStaticString("abc"))
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	output, err := rootExpr.Pipeline.evaluate([]*SnapshotResult{newMockResult("1", "abc", 1), newMockResult("2", "b", 1)})
+	require.NoError(t, err)
+	require.Len(t, output, 1)
+	assert.Equal(t, "1", string(output[0].SnapshotID))
+}
+
+func TestInvalidRegexFailsValidation(t *testing.T) {
+	rootExpr, err := Parse(`{ resource.service =~ "a(" }`)
+	require.NoError(t, err)
+	assert.ErrorContains(t, rootExpr.validate(), "invalid regex")
+}
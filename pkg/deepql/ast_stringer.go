@@ -24,9 +24,25 @@ import (
 )
 
 func (r RootExpr) String() string {
+	if r.Metrics != nil {
+		return r.Metrics.String()
+	}
 	return r.Pipeline.String()
 }
 
+func (m MetricsAggregate) String() string {
+	s := m.op.String() + "(" + m.pipeline.String() + "[" + m.window.String() + "])"
+	if len(m.by) == 0 {
+		return s
+	}
+
+	by := make([]string, 0, len(m.by))
+	for _, e := range m.by {
+		by = append(by, e.String())
+	}
+	return s + " by(" + strings.Join(by, ", ") + ")"
+}
+
 func (p Pipeline) String() string {
 	s := make([]string, 0, len(p.Elements))
 	for _, p := range p.Elements {
@@ -113,6 +129,9 @@ func (a Attribute) String() string {
 	}
 
 	att := a.Name
+	if (a.Scope == AttributeScopeVariable || a.Scope == AttributeScopeWatch) && strings.IndexFunc(att, func(r rune) bool { return !isAttributeRune(r) }) >= 0 {
+		att = strconv.Quote(att)
+	}
 	if a.Intrinsic != IntrinsicNone {
 		att = a.Intrinsic.String()
 	}
@@ -17,7 +17,10 @@
 
 package deepql
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+)
 
 // unsupportedError is returned for deepql features that are not yet supported.
 type unsupportedError struct {
@@ -33,9 +36,44 @@ func (e unsupportedError) Error() string {
 }
 
 func (r RootExpr) validate() error {
+	if r.Metrics != nil {
+		return r.Metrics.validate()
+	}
 	return r.Pipeline.validate()
 }
 
+func (m MetricsAggregate) validate() error {
+	if err := m.pipeline.validate(); err != nil {
+		return err
+	}
+
+	if m.pipeline.impliedType() != TypeSnapshot {
+		return fmt.Errorf("range functions must operate on snapshots: %s", m.String())
+	}
+
+	if m.window <= 0 {
+		return fmt.Errorf("range functions require a positive range: %s", m.String())
+	}
+
+	for _, e := range m.by {
+		if err := e.validate(); err != nil {
+			return err
+		}
+
+		if !e.referencesSnapshot() {
+			return fmt.Errorf("grouping field expressions must reference the snapshot: %s", m.String())
+		}
+	}
+
+	switch m.op {
+	case metricsAggregateCountOverTime, metricsAggregateRate:
+	default:
+		return newUnsupportedError(fmt.Sprintf("range function (%v)", m.op))
+	}
+
+	return nil
+}
+
 func (p Pipeline) validate() error {
 	for _, p := range p.Elements {
 		err := p.validate()
@@ -47,18 +85,15 @@ func (p Pipeline) validate() error {
 }
 
 func (o GroupOperation) validate() error {
-	return newUnsupportedError("by()")
-
-	// todo: once grouping is supported the below validation will apply
-	// if !o.Expression.referencesSnapshot() {
-	// 	return fmt.Errorf("grouping field expressions must reference the snapshot: %s", o.String())
-	// }
+	if !o.Expression.referencesSnapshot() {
+		return fmt.Errorf("grouping field expressions must reference the snapshot: %s", o.String())
+	}
 
-	// return o.Expression.validate()
+	return o.Expression.validate()
 }
 
 func (o CoalesceOperation) validate() error {
-	return newUnsupportedError("coalesce()")
+	return nil
 }
 
 func (o ScalarOperation) validate() error {
@@ -193,6 +228,13 @@ func (o BinaryOperation) validate() error {
 		return fmt.Errorf("illegal operation for the given types: %s", o.String())
 	}
 
+	// the pattern is compiled by newBinaryOperation, so it is only missing when it is invalid
+	if pattern, ok := o.RHS.(Static); ok && pattern.Type == TypeString && pattern.regex == nil && (o.Op == OpRegex || o.Op == OpNotRegex) {
+		if _, err := regexp.Compile(pattern.S); err != nil {
+			return fmt.Errorf("invalid regex %s: %w", o.String(), err)
+		}
+	}
+
 	switch o.Op {
 	case OpNotRegex,
 		OpSnapshotChild,
@@ -25,6 +25,8 @@ const (
 	AttributeScopeNone AttributeScope = iota
 	AttributeScopeResource
 	AttributeScopeSnapshot
+	AttributeScopeVariable
+	AttributeScopeWatch
 )
 
 func (s AttributeScope) String() string {
@@ -35,6 +37,10 @@ func (s AttributeScope) String() string {
 		return "snapshot"
 	case AttributeScopeResource:
 		return "resource"
+	case AttributeScopeVariable:
+		return "var"
+	case AttributeScopeWatch:
+		return "watch"
 	}
 
 	return fmt.Sprintf("att(%d).", s)
@@ -45,6 +51,15 @@ type Intrinsic int
 const (
 	IntrinsicNone Intrinsic = iota
 	IntrinsicDuration
+	IntrinsicTracepoint
+	IntrinsicPath
+	IntrinsicLine
+	IntrinsicMethod
+	IntrinsicClass
+	IntrinsicLog
+	IntrinsicFrames
+	IntrinsicTraceID
+	IntrinsicSpanID
 )
 
 func (i Intrinsic) String() string {
@@ -53,6 +68,24 @@ func (i Intrinsic) String() string {
 		return "none"
 	case IntrinsicDuration:
 		return "duration"
+	case IntrinsicTracepoint:
+		return "tracepoint"
+	case IntrinsicPath:
+		return "path"
+	case IntrinsicLine:
+		return "line"
+	case IntrinsicMethod:
+		return "method"
+	case IntrinsicClass:
+		return "class"
+	case IntrinsicLog:
+		return "log"
+	case IntrinsicFrames:
+		return "frames"
+	case IntrinsicTraceID:
+		return "traceID"
+	case IntrinsicSpanID:
+		return "spanID"
 	}
 
 	return fmt.Sprintf("intrinsic(%d)", i)
@@ -63,6 +96,24 @@ func intrinsicFromString(s string) Intrinsic {
 	switch s {
 	case "duration":
 		return IntrinsicDuration
+	case "tracepoint":
+		return IntrinsicTracepoint
+	case "path":
+		return IntrinsicPath
+	case "line":
+		return IntrinsicLine
+	case "method":
+		return IntrinsicMethod
+	case "class":
+		return IntrinsicClass
+	case "log":
+		return IntrinsicLog
+	case "frames":
+		return IntrinsicFrames
+	case "traceID":
+		return IntrinsicTraceID
+	case "spanID":
+		return IntrinsicSpanID
 	}
 
 	return IntrinsicNone
@@ -96,6 +147,10 @@ func (a Attribute) impliedType() StaticType {
 	switch a.Intrinsic {
 	case IntrinsicDuration:
 		return TypeDuration
+	case IntrinsicTracepoint, IntrinsicPath, IntrinsicMethod, IntrinsicClass, IntrinsicLog, IntrinsicTraceID, IntrinsicSpanID:
+		return TypeString
+	case IntrinsicLine, IntrinsicFrames:
+		return TypeInt
 	}
 
 	return TypeAttribute
@@ -114,11 +169,11 @@ func (a Attribute) extractConditions(request *FetchSnapshotRequest) {
 }
 
 // NewScopedAttribute creates a new scopedattribute with the given identifier string.
-// this handles parent, snapshot, and resource scopes.
+// this handles parent, snapshot, resource, variable and watch scopes.
 func NewScopedAttribute(scope AttributeScope, parent bool, att string) Attribute {
 	intrinsic := IntrinsicNone
-	// if we are explicitly passed a resource or snapshot scopes then we shouldn't parse for intrinsic
-	if scope != AttributeScopeResource && scope != AttributeScopeSnapshot {
+	// if we are explicitly passed a scope then we shouldn't parse for intrinsic
+	if scope == AttributeScopeNone {
 		intrinsic = intrinsicFromString(att)
 	}
 
@@ -0,0 +1,71 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package deepql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIntrinsics(t *testing.T) {
+	tests := []struct {
+		query     string
+		attribute Attribute
+		typ       StaticType
+	}{
+		{query: `{ duration > 1s }`, attribute: NewIntrinsic(IntrinsicDuration), typ: TypeDuration},
+		{query: `{ tracepoint = "abc" }`, attribute: NewIntrinsic(IntrinsicTracepoint), typ: TypeString},
+		{query: `{ path =~ ".*checkout.*" }`, attribute: NewIntrinsic(IntrinsicPath), typ: TypeString},
+		{query: `{ line = 42 }`, attribute: NewIntrinsic(IntrinsicLine), typ: TypeInt},
+		{query: `{ method = "checkout" }`, attribute: NewIntrinsic(IntrinsicMethod), typ: TypeString},
+		{query: `{ class = "Cart" }`, attribute: NewIntrinsic(IntrinsicClass), typ: TypeString},
+		{query: `{ log != "" }`, attribute: NewIntrinsic(IntrinsicLog), typ: TypeString},
+		{query: `{ frames > 10 }`, attribute: NewIntrinsic(IntrinsicFrames), typ: TypeInt},
+		{query: `{ traceID = "abc" }`, attribute: NewIntrinsic(IntrinsicTraceID), typ: TypeString},
+		{query: `{ spanID = "abc" }`, attribute: NewIntrinsic(IntrinsicSpanID), typ: TypeString},
+		{query: `{ .trace_id = "abc" }`, attribute: NewAttribute("trace_id"), typ: TypeAttribute},
+		{query: `{ .line = 42 }`, attribute: NewAttribute("line"), typ: TypeAttribute},
+		{query: `{ resource.path = "/" }`, attribute: NewScopedAttribute(AttributeScopeResource, false, "path"), typ: TypeAttribute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			req, err := ExtractFetchSnapshotRequest(tt.query)
+			require.NoError(t, err)
+			require.Len(t, req.Conditions, 1)
+			assert.Equal(t, tt.attribute, req.Conditions[0].Attribute)
+			assert.Equal(t, tt.typ, tt.attribute.impliedType())
+
+			expr, err := Parse(tt.query)
+			require.NoError(t, err)
+			roundTrip, err := Parse(expr.String())
+			require.NoError(t, err)
+			assert.Equal(t, expr, roundTrip)
+		})
+	}
+}
+
+func TestParseIntrinsicsInvalidType(t *testing.T) {
+	for _, q := range []string{`{ line = "42" }`, `{ path > 1 }`, `{ frames =~ "1" }`} {
+		expr, err := Parse(q)
+		require.NoError(t, err, q)
+		assert.Error(t, expr.validate(), q)
+	}
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package deepql
+
+import (
+	"strconv"
+	"strings"
+
+	tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+)
+
+// IsCapturedAttribute returns true if the attribute references the variables or watch results captured in the
+// snapshot, rather than one of its attributes.
+func IsCapturedAttribute(a Attribute) bool {
+	return a.Scope == AttributeScopeVariable || a.Scope == AttributeScopeWatch
+}
+
+// ResolveCapturedAttribute returns the value of a variable or watch attribute from the data captured in the snapshot.
+//
+// Variables are referenced by their path, e.g. var.user.id is the child 'id' of the variable 'user'. The path is
+// resolved against the variables of each frame in turn, starting with the frame the tracepoint fired in. Watches
+// are referenced by their expression, e.g. watch."order.total", and resolve to the result of the first watch with a
+// matching expression. The bool is false if the attribute could not be resolved.
+func ResolveCapturedAttribute(snapshot *tp.Snapshot, a Attribute) (Static, bool) {
+	switch a.Scope {
+	case AttributeScopeVariable:
+		path := strings.Split(a.Name, ".")
+		for _, frame := range snapshot.Frames {
+			if variable, ok := resolveVariablePath(snapshot.VarLookup, frame.Variables, path); ok {
+				return variableStatic(variable), true
+			}
+		}
+	case AttributeScopeWatch:
+		for _, watch := range snapshot.Watches {
+			if watch.Expression != a.Name {
+				continue
+			}
+			if result, ok := watch.Result.(*tp.WatchResult_GoodResult); ok {
+				if variable, ok := snapshot.VarLookup[result.GoodResult.GetID()]; ok {
+					return variableStatic(variable), true
+				}
+			}
+		}
+	}
+
+	return NewStaticNil(), false
+}
+
+// resolveVariablePath follows the path through the children of the variables in the lookup table
+func resolveVariablePath(lookup map[string]*tp.Variable, variables []*tp.VariableID, path []string) (*tp.Variable, bool) {
+	for _, id := range variables {
+		if id.Name != path[0] {
+			continue
+		}
+
+		variable, ok := lookup[id.ID]
+		if !ok {
+			return nil, false
+		}
+		if len(path) == 1 {
+			return variable, true
+		}
+		return resolveVariablePath(lookup, variable.Children, path[1:])
+	}
+
+	return nil, false
+}
+
+// variableStatic converts the value of a variable to a static. Variable values are always captured as strings,
+// so the type reported by the agent is used to decide if the value is a number or boolean.
+func variableStatic(variable *tp.Variable) Static {
+	typ := strings.ToLower(variable.Type)
+	if i := strings.LastIndex(typ, "."); i >= 0 {
+		typ = typ[i+1:]
+	}
+
+	switch typ {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64",
+		"short", "long", "integer", "biginteger", "byte":
+		if i, err := strconv.Atoi(variable.Value); err == nil {
+			return NewStaticInt(i)
+		}
+	case "float", "float32", "float64", "double", "bigdecimal", "decimal", "number":
+		if f, err := strconv.ParseFloat(variable.Value, 64); err == nil {
+			return NewStaticFloat(f)
+		}
+	case "bool", "boolean":
+		if b, err := strconv.ParseBool(variable.Value); err == nil {
+			return NewStaticBool(b)
+		}
+	case "nonetype", "null", "nil", "undefined":
+		return NewStaticNil()
+	}
+
+	return NewStaticString(variable.Value)
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package deepql
+
+import (
+	"testing"
+
+	tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCapturedAttributes(t *testing.T) {
+	tests := []struct {
+		query     string
+		attribute Attribute
+		str       string
+	}{
+		{
+			query:     `{ var.user.id = "42" }`,
+			attribute: NewScopedAttribute(AttributeScopeVariable, false, "user.id"),
+			str:       "{ var.user.id = `42` }",
+		},
+		{
+			query:     `{ watch."order.total" > 100 }`,
+			attribute: NewScopedAttribute(AttributeScopeWatch, false, "order.total"),
+			str:       `{ watch.order.total > 100 }`,
+		},
+		{
+			query:     `{ watch."len(items) > 0" = true }`,
+			attribute: NewScopedAttribute(AttributeScopeWatch, false, "len(items) > 0"),
+			str:       `{ watch."len(items) > 0" = true }`,
+		},
+		{
+			query:     `{ var.duration = 1 }`,
+			attribute: NewScopedAttribute(AttributeScopeVariable, false, "duration"),
+			str:       `{ var.duration = 1 }`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			req, err := ExtractFetchSnapshotRequest(tt.query)
+			require.NoError(t, err)
+			require.Len(t, req.Conditions, 1)
+			assert.Equal(t, tt.attribute, req.Conditions[0].Attribute)
+
+			expr, err := Parse(tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.str, expr.String())
+		})
+	}
+}
+
+func TestResolveCapturedAttribute(t *testing.T) {
+	snapshot := &tp.Snapshot{
+		Frames: []*tp.StackFrame{
+			{Variables: []*tp.VariableID{{ID: "1", Name: "user"}}},
+			{Variables: []*tp.VariableID{{ID: "3", Name: "request"}, {ID: "6", Name: "user"}}},
+		},
+		VarLookup: map[string]*tp.Variable{
+			"1": {Type: "User", Children: []*tp.VariableID{{ID: "2", Name: "id"}}},
+			"2": {Type: "java.lang.Integer", Value: "42"},
+			"3": {Type: "dict", Children: []*tp.VariableID{{ID: "4", Name: "total"}, {ID: "5", Name: "paid"}}},
+			"4": {Type: "float", Value: "149.99"},
+			"5": {Type: "bool", Value: "True"},
+			"6": {Type: "str", Value: "shadowed"},
+			"7": {Type: "NoneType", Value: "None"},
+		},
+		Watches: []*tp.WatchResult{
+			{Expression: "order", Result: &tp.WatchResult_GoodResult{GoodResult: &tp.VariableID{ID: "7"}}},
+			{Expression: "broken", Result: &tp.WatchResult_ErrorResult{ErrorResult: "NameError"}},
+		},
+	}
+
+	tests := []struct {
+		attribute Attribute
+		value     Static
+		found     bool
+	}{
+		{attribute: NewScopedAttribute(AttributeScopeVariable, false, "user.id"), value: NewStaticInt(42), found: true},
+		{attribute: NewScopedAttribute(AttributeScopeVariable, false, "request.total"), value: NewStaticFloat(149.99), found: true},
+		{attribute: NewScopedAttribute(AttributeScopeVariable, false, "request.paid"), value: NewStaticBool(true), found: true},
+		{attribute: NewScopedAttribute(AttributeScopeVariable, false, "user.name"), value: NewStaticNil()},
+		{attribute: NewScopedAttribute(AttributeScopeVariable, false, "missing"), value: NewStaticNil()},
+		{attribute: NewScopedAttribute(AttributeScopeWatch, false, "order"), value: NewStaticNil(), found: true},
+		{attribute: NewScopedAttribute(AttributeScopeWatch, false, "broken"), value: NewStaticNil()},
+		{attribute: NewScopedAttribute(AttributeScopeWatch, false, "missing"), value: NewStaticNil()},
+		{attribute: NewScopedAttribute(AttributeScopeSnapshot, false, "user.id"), value: NewStaticNil()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.attribute.String(), func(t *testing.T) {
+			value, found := ResolveCapturedAttribute(snapshot, tt.attribute)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.value, value)
+		})
+	}
+}
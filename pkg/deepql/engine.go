@@ -42,7 +42,11 @@ func (e *Engine) Execute(ctx context.Context, searchReq *deeppb.SearchRequest, s
 		return nil, err
 	}
 
-	snapshotRequest := e.createFetchSnapshotRequest(searchReq, rootExpr.Pipeline)
+	snapshotRequest := e.createFetchSnapshotRequest(searchReq, rootExpr)
+
+	// snapshots are filtered one at a time as they are fetched, by() and aggregates need every matching snapshot
+	// so they are evaluated once the results of all shards are combined, see EvaluatePipeline
+	snapshotPipeline, setPipeline := rootExpr.Pipeline.split()
 
 	span.SetTag("pipeline", rootExpr.Pipeline)
 	span.SetTag("fetchSnapshotRequest", snapshotRequest)
@@ -54,7 +58,7 @@ func (e *Engine) Execute(ctx context.Context, searchReq *deeppb.SearchRequest, s
 			return nil, nil
 		}
 
-		evalSS, err := rootExpr.Pipeline.evaluate([]*SnapshotResult{inSS})
+		evalSS, err := snapshotPipeline.evaluate([]*SnapshotResult{inSS})
 		if err != nil {
 			span.LogKV("msg", "pipeline.evaluate", "err", err)
 			return nil, err
@@ -75,11 +79,7 @@ func (e *Engine) Execute(ctx context.Context, searchReq *deeppb.SearchRequest, s
 	iterator := fetchSnapshotResponse.Results
 	defer iterator.Close()
 
-	res := &deeppb.SearchResponse{
-		Snapshots: nil,
-		// TODO capture and update metrics
-		Metrics: &deeppb.SearchMetrics{},
-	}
+	var results []*SnapshotResult
 	for {
 		snapshotResult, err := iterator.Next(ctx)
 		if err != nil && err != io.EOF {
@@ -89,13 +89,38 @@ func (e *Engine) Execute(ctx context.Context, searchReq *deeppb.SearchRequest, s
 		if snapshotResult == nil {
 			break
 		}
-		res.Snapshots = append(res.Snapshots, e.asSnapshotSearchMetadata(snapshotResult))
+		results = append(results, snapshotResult)
 
-		if len(res.Snapshots) >= int(searchReq.Limit) && searchReq.Limit > 0 {
+		if len(results) >= int(searchReq.Limit) && searchReq.Limit > 0 {
 			break
 		}
 	}
 
+	res := &deeppb.SearchResponse{
+		Snapshots: nil,
+		// TODO capture and update metrics
+		Metrics: &deeppb.SearchMetrics{},
+	}
+	pipelineAttributes := setPipeline.attributes()
+	for _, snapshotResult := range results {
+		metadata := e.asSnapshotSearchMetadata(snapshotResult)
+		res.Snapshots = append(res.Snapshots, metadata)
+
+		// the series and the pipeline are evaluated once all results are combined, so we send the values they need
+		if rootExpr.Metrics != nil {
+			metadata.SeriesLabels, err = rootExpr.Metrics.labels(snapshotResult.Snapshot)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if len(setPipeline.Elements) > 0 {
+			metadata.PipelineValues, err = pipelineValues(pipelineAttributes, snapshotResult.Snapshot)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	span.SetTag("snapshots_evaluated", evaluated)
 	span.SetTag("snapshots_found", len(res.Snapshots))
 
@@ -112,7 +137,7 @@ func (e *Engine) parseQuery(searchReq *deeppb.SearchRequest) (*RootExpr, error)
 
 // createFetchSnapshotRequest will flatten the SearchRequest in simple conditions the storage layer
 // can work with.
-func (e *Engine) createFetchSnapshotRequest(searchReq *deeppb.SearchRequest, pipeline Pipeline) FetchSnapshotRequest {
+func (e *Engine) createFetchSnapshotRequest(searchReq *deeppb.SearchRequest, rootExpr *RootExpr) FetchSnapshotRequest {
 	req := FetchSnapshotRequest{
 		StartTimeUnixNanos: unixSecToNano(searchReq.Start),
 		EndTimeUnixNanos:   unixSecToNano(searchReq.End),
@@ -120,7 +145,10 @@ func (e *Engine) createFetchSnapshotRequest(searchReq *deeppb.SearchRequest, pip
 		AllConditions:      true,
 	}
 
-	pipeline.extractConditions(&req)
+	rootExpr.Pipeline.extractConditions(&req)
+	if rootExpr.Metrics != nil {
+		rootExpr.Metrics.extractConditions(&req)
+	}
 	return req
 }
 
@@ -132,6 +160,7 @@ func (e *Engine) asSnapshotSearchMetadata(result *SnapshotResult) *deeppb.Snapsh
 		LineNo:            result.LineNo,
 		StartTimeUnixNano: result.StartTimeUnixNanos,
 		DurationNano:      result.DurationNanos,
+		Group:             result.Group,
 	}
 }
 
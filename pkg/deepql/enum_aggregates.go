@@ -45,3 +45,21 @@ func (a AggregateOp) String() string {
 
 	return fmt.Sprintf("aggregate(%d)", a)
 }
+
+type MetricsAggregateOp int
+
+const (
+	metricsAggregateCountOverTime MetricsAggregateOp = iota
+	metricsAggregateRate
+)
+
+func (a MetricsAggregateOp) String() string {
+	switch a {
+	case metricsAggregateCountOverTime:
+		return "count_over_time"
+	case metricsAggregateRate:
+		return "rate"
+	}
+
+	return fmt.Sprintf("metricsAggregate(%d)", a)
+}
@@ -10,6 +10,7 @@ import (
 
 %union {
     root RootExpr
+    metricsAggregate MetricsAggregate
     groupOperation GroupOperation
     coalesceOperation CoalesceOperation
 
@@ -29,6 +30,7 @@ import (
     aggregate Aggregate
 
     fieldExpression FieldExpression
+    fieldExpressionList []FieldExpression
     static Static
     intrinsicField Attribute
     attributeField Attribute
@@ -41,6 +43,7 @@ import (
 }
 
 %type <RootExpr> root
+%type <metricsAggregate> metricsAggregate
 %type <groupOperation> groupOperation
 %type <coalesceOperation> coalesceOperation
 
@@ -60,6 +63,7 @@ import (
 %type <aggregate> aggregate
 
 %type <fieldExpression> fieldExpression
+%type <fieldExpressionList> fieldExpressionList
 %type <static> static
 %type <intrinsicField> intrinsicField
 %type <attributeField> attributeField
@@ -69,11 +73,13 @@ import (
 %token <staticFloat>    FLOAT
 %token <staticDuration> DURATION
 %token <val>            DOT OPEN_BRACE CLOSE_BRACE OPEN_PARENS CLOSE_PARENS
+                        OPEN_BRACKET CLOSE_BRACKET COMMA
                         NIL TRUE FALSE
-                        IDURATION NAME
-                        RESOURCE_DOT
+                        IDURATION ITRACEPOINT IPATH ILINE IMETHOD ICLASS ILOG IFRAMES ITRACEID ISPANID NAME
+                        RESOURCE_DOT VAR_DOT WATCH_DOT
                         COUNT AVG MAX MIN SUM
                         BY COALESCE
+                        COUNT_OVER_TIME RATE
                         END_ATTRIBUTE
 
 // Operators are listed with increasing precedence.
@@ -93,6 +99,17 @@ root:
     snapshotPipeline                             { yylex.(*lexer).expr = newRootExpr($1) }
   | snapshotPipelineExpression                   { yylex.(*lexer).expr = newRootExpr($1) }
   | scalarPipelineExpressionFilter               { yylex.(*lexer).expr = newRootExpr($1) }
+  | metricsAggregate                             { yylex.(*lexer).expr = newMetricsRootExpr($1) }
+  ;
+
+// **********************
+// Metrics
+// **********************
+metricsAggregate:
+    COUNT_OVER_TIME OPEN_PARENS snapshotPipeline OPEN_BRACKET DURATION CLOSE_BRACKET CLOSE_PARENS                                           { $$ = newMetricsAggregate(metricsAggregateCountOverTime, $3, $5, nil) }
+  | COUNT_OVER_TIME OPEN_PARENS snapshotPipeline OPEN_BRACKET DURATION CLOSE_BRACKET CLOSE_PARENS BY OPEN_PARENS fieldExpressionList CLOSE_PARENS { $$ = newMetricsAggregate(metricsAggregateCountOverTime, $3, $5, $10) }
+  | RATE OPEN_PARENS snapshotPipeline OPEN_BRACKET DURATION CLOSE_BRACKET CLOSE_PARENS                                                      { $$ = newMetricsAggregate(metricsAggregateRate, $3, $5, nil) }
+  | RATE OPEN_PARENS snapshotPipeline OPEN_BRACKET DURATION CLOSE_BRACKET CLOSE_PARENS BY OPEN_PARENS fieldExpressionList CLOSE_PARENS      { $$ = newMetricsAggregate(metricsAggregateRate, $3, $5, $10) }
   ;
 
 // **********************
@@ -232,6 +249,11 @@ fieldExpression:
   | attributeField                           { $$ = $1 }
   ;
 
+fieldExpressionList:
+    fieldExpression                           { $$ = []FieldExpression{$1} }
+  | fieldExpressionList COMMA fieldExpression { $$ = append($1, $3) }
+  ;
+
 // **********************
 // Statics
 // **********************
@@ -247,9 +269,20 @@ static:
 
 intrinsicField:
     IDURATION      { $$ = NewIntrinsic(IntrinsicDuration)   }
+  | ITRACEPOINT    { $$ = NewIntrinsic(IntrinsicTracepoint) }
+  | IPATH          { $$ = NewIntrinsic(IntrinsicPath)       }
+  | ILINE          { $$ = NewIntrinsic(IntrinsicLine)       }
+  | IMETHOD        { $$ = NewIntrinsic(IntrinsicMethod)     }
+  | ICLASS         { $$ = NewIntrinsic(IntrinsicClass)      }
+  | ILOG           { $$ = NewIntrinsic(IntrinsicLog)        }
+  | IFRAMES        { $$ = NewIntrinsic(IntrinsicFrames)     }
+  | ITRACEID       { $$ = NewIntrinsic(IntrinsicTraceID)    }
+  | ISPANID        { $$ = NewIntrinsic(IntrinsicSpanID)     }
   ;
 
 attributeField:
     DOT IDENTIFIER END_ATTRIBUTE                      { $$ = NewAttribute($2)                                      }
   | RESOURCE_DOT IDENTIFIER END_ATTRIBUTE             { $$ = NewScopedAttribute(AttributeScopeResource, false, $2) }
+  | VAR_DOT IDENTIFIER END_ATTRIBUTE                  { $$ = NewScopedAttribute(AttributeScopeVariable, false, $2) }
+  | WATCH_DOT IDENTIFIER END_ATTRIBUTE                { $$ = NewScopedAttribute(AttributeScopeWatch, false, $2) }
   ;
\ No newline at end of file
@@ -15,6 +15,7 @@ import (
 type yySymType struct {
 	yys               int
 	root              RootExpr
+	metricsAggregate  MetricsAggregate
 	groupOperation    GroupOperation
 	coalesceOperation CoalesceOperation
 
@@ -33,10 +34,11 @@ type yySymType struct {
 	scalarPipeline                 Pipeline
 	aggregate                      Aggregate
 
-	fieldExpression FieldExpression
-	static          Static
-	intrinsicField  Attribute
-	attributeField  Attribute
+	fieldExpression     FieldExpression
+	fieldExpressionList []FieldExpression
+	static              Static
+	intrinsicField      Attribute
+	attributeField      Attribute
 
 	binOp          Operator
 	staticInt      int
@@ -55,40 +57,56 @@ const OPEN_BRACE = 57352
 const CLOSE_BRACE = 57353
 const OPEN_PARENS = 57354
 const CLOSE_PARENS = 57355
-const NIL = 57356
-const TRUE = 57357
-const FALSE = 57358
-const IDURATION = 57359
-const NAME = 57360
-const RESOURCE_DOT = 57361
-const COUNT = 57362
-const AVG = 57363
-const MAX = 57364
-const MIN = 57365
-const SUM = 57366
-const BY = 57367
-const COALESCE = 57368
-const END_ATTRIBUTE = 57369
-const PIPE = 57370
-const AND = 57371
-const OR = 57372
-const EQ = 57373
-const NEQ = 57374
-const LT = 57375
-const LTE = 57376
-const GT = 57377
-const GTE = 57378
-const NRE = 57379
-const RE = 57380
-const DESC = 57381
-const TILDE = 57382
-const ADD = 57383
-const SUB = 57384
-const NOT = 57385
-const MUL = 57386
-const DIV = 57387
-const MOD = 57388
-const POW = 57389
+const OPEN_BRACKET = 57356
+const CLOSE_BRACKET = 57357
+const COMMA = 57358
+const NIL = 57359
+const TRUE = 57360
+const FALSE = 57361
+const IDURATION = 57362
+const ITRACEPOINT = 57363
+const IPATH = 57364
+const ILINE = 57365
+const IMETHOD = 57366
+const ICLASS = 57367
+const ILOG = 57368
+const IFRAMES = 57369
+const ITRACEID = 57370
+const ISPANID = 57371
+const NAME = 57372
+const RESOURCE_DOT = 57373
+const VAR_DOT = 57374
+const WATCH_DOT = 57375
+const COUNT = 57376
+const AVG = 57377
+const MAX = 57378
+const MIN = 57379
+const SUM = 57380
+const BY = 57381
+const COALESCE = 57382
+const COUNT_OVER_TIME = 57383
+const RATE = 57384
+const END_ATTRIBUTE = 57385
+const PIPE = 57386
+const AND = 57387
+const OR = 57388
+const EQ = 57389
+const NEQ = 57390
+const LT = 57391
+const LTE = 57392
+const GT = 57393
+const GTE = 57394
+const NRE = 57395
+const RE = 57396
+const DESC = 57397
+const TILDE = 57398
+const ADD = 57399
+const SUB = 57400
+const NOT = 57401
+const MUL = 57402
+const DIV = 57403
+const MOD = 57404
+const POW = 57405
 
 var yyToknames = [...]string{
 	"$end",
@@ -104,12 +122,26 @@ var yyToknames = [...]string{
 	"CLOSE_BRACE",
 	"OPEN_PARENS",
 	"CLOSE_PARENS",
+	"OPEN_BRACKET",
+	"CLOSE_BRACKET",
+	"COMMA",
 	"NIL",
 	"TRUE",
 	"FALSE",
 	"IDURATION",
+	"ITRACEPOINT",
+	"IPATH",
+	"ILINE",
+	"IMETHOD",
+	"ICLASS",
+	"ILOG",
+	"IFRAMES",
+	"ITRACEID",
+	"ISPANID",
 	"NAME",
 	"RESOURCE_DOT",
+	"VAR_DOT",
+	"WATCH_DOT",
 	"COUNT",
 	"AVG",
 	"MAX",
@@ -117,6 +149,8 @@ var yyToknames = [...]string{
 	"SUM",
 	"BY",
 	"COALESCE",
+	"COUNT_OVER_TIME",
+	"RATE",
 	"END_ATTRIBUTE",
 	"PIPE",
 	"AND",
@@ -139,6 +173,7 @@ var yyToknames = [...]string{
 	"MOD",
 	"POW",
 }
+
 var yyStatenames = [...]string{}
 
 const yyEofCode = 1
@@ -146,197 +181,228 @@ const yyErrCode = 2
 const yyInitialStackSize = 16
 
 //line yacctab:1
-var yyExca = [...]int{
+var yyExca = [...]int16{
 	-1, 1,
 	1, -1,
 	-2, 0,
-	-1, 159,
-	13, 48,
-	-2, 56,
+	-1, 179,
+	13, 53,
+	-2, 61,
 }
 
 const yyPrivate = 57344
 
-const yyLast = 636
-
-var yyAct = [...]int{
-
-	73, 17, 6, 7, 5, 16, 141, 12, 66, 17,
-	53, 104, 30, 43, 157, 2, 46, 130, 131, 132,
-	141, 37, 181, 42, 108, 38, 40, 44, 10, 180,
-	186, 17, 103, 85, 86, 84, 63, 64, 65, 66,
-	149, 96, 98, 99, 100, 101, 107, 30, 110, 15,
-	69, 97, 61, 62, 106, 63, 64, 65, 66, 156,
-	111, 17, 17, 17, 17, 17, 17, 17, 118, 120,
-	121, 122, 123, 124, 125, 109, 112, 113, 114, 115,
-	116, 117, 48, 49, 91, 50, 51, 52, 53, 17,
-	60, 103, 17, 154, 128, 129, 155, 130, 131, 132,
-	141, 47, 154, 83, 82, 17, 104, 85, 86, 84,
-	159, 81, 17, 50, 51, 52, 53, 80, 126, 185,
-	17, 144, 145, 146, 32, 79, 161, 155, 33, 35,
-	67, 150, 151, 152, 153, 142, 143, 133, 134, 135,
-	136, 137, 138, 140, 139, 148, 147, 128, 129, 75,
-	130, 131, 132, 141, 74, 45, 14, 4, 11, 17,
-	9, 17, 87, 43, 1, 43, 46, 0, 46, 0,
-	0, 0, 0, 0, 0, 161, 0, 0, 0, 163,
-	164, 165, 166, 167, 168, 169, 170, 171, 172, 173,
-	174, 175, 176, 177, 178, 184, 23, 24, 25, 29,
-	77, 0, 68, 70, 0, 28, 26, 27, 76, 0,
-	78, 142, 143, 133, 134, 135, 136, 137, 138, 140,
-	139, 183, 0, 128, 129, 0, 130, 131, 132, 141,
-	0, 0, 0, 71, 72, 0, 0, 142, 143, 133,
-	134, 135, 136, 137, 138, 140, 139, 182, 0, 128,
-	129, 0, 130, 131, 132, 141, 61, 62, 0, 63,
-	64, 65, 66, 142, 143, 133, 134, 135, 136, 137,
-	138, 140, 139, 179, 0, 128, 129, 0, 130, 131,
-	132, 141, 48, 49, 0, 50, 51, 52, 53, 142,
-	143, 133, 134, 135, 136, 137, 138, 140, 139, 162,
-	0, 128, 129, 0, 130, 131, 132, 141, 0, 0,
-	0, 0, 0, 0, 0, 142, 143, 133, 134, 135,
-	136, 137, 138, 140, 139, 127, 0, 128, 129, 0,
-	130, 131, 132, 141, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 142, 143, 133, 134, 135, 136, 137,
-	138, 140, 139, 0, 0, 128, 129, 0, 130, 131,
-	132, 141, 133, 134, 135, 136, 137, 138, 140, 139,
-	108, 0, 128, 129, 0, 130, 131, 132, 141, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 54, 55,
-	56, 57, 58, 59, 0, 0, 0, 0, 61, 62,
-	0, 63, 64, 65, 66, 54, 55, 56, 57, 58,
-	59, 0, 0, 0, 0, 61, 62, 0, 63, 64,
-	65, 66, 54, 55, 56, 57, 58, 59, 0, 0,
-	0, 0, 48, 49, 0, 50, 51, 52, 53, 23,
-	24, 25, 29, 77, 105, 0, 70, 0, 28, 26,
-	27, 76, 102, 78, 0, 0, 0, 0, 0, 0,
-	36, 39, 0, 0, 0, 0, 37, 0, 31, 34,
-	38, 40, 0, 0, 32, 0, 71, 72, 33, 35,
-	36, 39, 31, 34, 41, 3, 37, 0, 32, 0,
-	38, 40, 33, 35, 23, 24, 25, 29, 0, 15,
-	0, 88, 0, 28, 26, 27, 0, 0, 0, 18,
-	21, 19, 20, 22, 13, 89, 90, 92, 93, 94,
-	95, 23, 24, 25, 29, 0, 15, 0, 160, 0,
-	28, 26, 27, 0, 0, 0, 18, 21, 19, 20,
-	22, 13, 23, 24, 25, 29, 0, 15, 0, 158,
-	0, 28, 26, 27, 0, 0, 0, 18, 21, 19,
-	20, 22, 13, 23, 24, 25, 29, 0, 15, 0,
-	8, 0, 28, 26, 27, 0, 0, 0, 18, 21,
-	19, 20, 22, 13, 23, 24, 25, 29, 0, 15,
-	0, 88, 0, 28, 26, 27, 0, 0, 0, 18,
-	21, 19, 20, 22, 23, 24, 25, 29, 0, 0,
-	0, 119, 0, 28, 26, 27, 0, 0, 0, 18,
-	21, 19, 20, 22, 23, 24, 25, 29, 0, 0,
-	0, 111, 0, 28, 26, 27,
+const yyLast = 768
+
+var yyAct = [...]uint8{
+	222, 221, 148, 149, 150, 159, 177, 2, 159, 71,
+	7, 19, 8, 56, 145, 120, 45, 33, 205, 74,
+	204, 160, 161, 151, 152, 153, 154, 155, 156, 158,
+	157, 119, 203, 146, 147, 15, 148, 149, 150, 159,
+	68, 69, 70, 71, 101, 49, 102, 202, 160, 161,
+	151, 152, 153, 154, 155, 156, 158, 157, 78, 20,
+	146, 147, 33, 148, 149, 150, 159, 124, 20, 218,
+	134, 135, 226, 144, 217, 225, 162, 163, 164, 119,
+	151, 152, 153, 154, 155, 156, 158, 157, 214, 213,
+	146, 147, 20, 148, 149, 150, 159, 170, 171, 172,
+	173, 136, 138, 139, 140, 141, 142, 143, 216, 126,
+	120, 66, 67, 215, 68, 69, 70, 71, 53, 54,
+	55, 56, 20, 20, 20, 20, 20, 20, 20, 20,
+	20, 101, 179, 102, 181, 39, 42, 210, 40, 169,
+	175, 40, 41, 43, 122, 41, 43, 185, 186, 187,
+	188, 189, 190, 191, 192, 193, 194, 195, 196, 197,
+	198, 199, 200, 20, 6, 123, 20, 57, 58, 59,
+	60, 61, 62, 175, 46, 220, 212, 66, 67, 20,
+	68, 69, 70, 71, 219, 176, 20, 181, 51, 52,
+	127, 53, 54, 55, 56, 35, 20, 107, 100, 36,
+	38, 211, 99, 183, 112, 114, 115, 116, 117, 34,
+	37, 18, 98, 113, 49, 35, 49, 80, 224, 36,
+	38, 225, 223, 182, 97, 96, 227, 209, 26, 27,
+	28, 32, 91, 33, 73, 75, 95, 20, 72, 20,
+	31, 29, 30, 81, 82, 83, 84, 85, 86, 87,
+	88, 89, 90, 33, 92, 93, 94, 64, 63, 160,
+	161, 151, 152, 153, 154, 155, 156, 158, 157, 174,
+	168, 146, 147, 167, 148, 149, 150, 159, 174, 166,
+	165, 76, 77, 208, 79, 100, 26, 27, 28, 32,
+	91, 146, 147, 75, 148, 149, 150, 159, 31, 29,
+	30, 81, 82, 83, 84, 85, 86, 87, 88, 89,
+	90, 48, 92, 93, 94, 160, 161, 151, 152, 153,
+	154, 155, 156, 158, 157, 207, 65, 146, 147, 17,
+	148, 149, 150, 159, 4, 14, 10, 103, 50, 76,
+	77, 5, 1, 46, 206, 46, 66, 67, 0, 68,
+	69, 70, 71, 0, 0, 0, 0, 160, 161, 151,
+	152, 153, 154, 155, 156, 158, 157, 201, 0, 146,
+	147, 0, 148, 149, 150, 159, 160, 161, 151, 152,
+	153, 154, 155, 156, 158, 157, 184, 0, 146, 147,
+	0, 148, 149, 150, 159, 0, 0, 0, 0, 160,
+	161, 151, 152, 153, 154, 155, 156, 158, 157, 124,
+	0, 146, 147, 0, 148, 149, 150, 159, 160, 161,
+	151, 152, 153, 154, 155, 156, 158, 157, 0, 0,
+	146, 147, 0, 148, 149, 150, 159, 47, 11, 0,
+	0, 0, 0, 57, 58, 59, 60, 61, 62, 0,
+	0, 0, 0, 66, 67, 0, 68, 69, 70, 71,
+	57, 58, 59, 60, 61, 62, 121, 0, 118, 0,
+	51, 52, 0, 53, 54, 55, 56, 51, 52, 0,
+	53, 54, 55, 56, 0, 0, 0, 0, 125, 128,
+	129, 130, 131, 132, 133, 44, 3, 0, 39, 42,
+	34, 37, 0, 0, 40, 0, 35, 0, 41, 43,
+	36, 38, 26, 27, 28, 32, 0, 18, 0, 9,
+	0, 0, 0, 0, 31, 29, 30, 0, 0, 0,
+	106, 108, 109, 110, 111, 0, 0, 0, 0, 0,
+	0, 21, 24, 22, 23, 25, 16, 0, 12, 13,
+	26, 27, 28, 32, 0, 18, 0, 104, 26, 27,
+	28, 32, 31, 29, 30, 127, 0, 0, 0, 0,
+	31, 29, 30, 0, 0, 0, 0, 0, 0, 21,
+	24, 22, 23, 25, 16, 105, 26, 27, 28, 32,
+	0, 18, 0, 180, 0, 0, 0, 0, 31, 29,
+	30, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 21, 24, 22, 23, 25,
+	16, 26, 27, 28, 32, 0, 18, 0, 178, 0,
+	0, 0, 0, 31, 29, 30, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	21, 24, 22, 23, 25, 16, 26, 27, 28, 32,
+	0, 18, 0, 104, 0, 0, 0, 0, 31, 29,
+	30, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 21, 24, 22, 23, 25,
+	16, 26, 27, 28, 32, 0, 18, 0, 9, 0,
+	0, 0, 0, 31, 29, 30, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	21, 24, 22, 23, 25, 16, 26, 27, 28, 32,
+	0, 18, 0, 104, 26, 27, 28, 32, 31, 29,
+	30, 137, 0, 0, 0, 0, 31, 29, 30, 0,
+	0, 0, 0, 0, 0, 21, 24, 22, 23, 25,
+	0, 0, 0, 21, 24, 22, 23, 25,
 }
-var yyPact = [...]int{
-
-	558, -1000, -16, 453, -1000, 451, -1000, -1000, 558, -1000,
-	391, -1000, 374, 118, -1000, 191, -1000, -1000, 113, 105,
-	99, 92, 91, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	489, 72, 72, 72, 72, 72, 39, 39, 39, 39,
-	39, 439, 78, 431, 41, 33, 357, 619, 48, 48,
-	48, 48, 48, 48, -1000, -1000, -1000, -1000, -1000, -1000,
-	599, 599, 599, 599, 599, 599, 599, 434, -1000, 314,
-	434, 434, 434, -1000, -1000, -1000, -1000, 142, 141, 27,
-	434, 434, 434, 434, 451, -1000, -1000, -1000, 579, 47,
-	89, 537, -1000, -1000, 89, -1000, -14, 39, -1000, -1000,
-	-14, -1000, -1000, -1000, 489, -1000, -1000, -1000, -1000, 241,
-	-1000, 516, 69, 69, -37, -37, -37, -37, 215, 599,
-	-8, -8, -39, -39, -39, -39, 286, -1000, 434, 434,
-	434, 434, 434, 434, 434, 434, 434, 434, 434, 434,
-	434, 434, 434, 434, 260, -27, -27, 2, -5, -1000,
-	234, 208, 182, 106, 431, 11, 17, 19, 537, -1000,
-	516, -17, -1000, -27, -27, -41, -41, -41, 53, 53,
-	53, 53, 53, 53, 53, 53, -41, 331, 331, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000,
+
+var yyPact = [...]int16{
+	507, -1000, -27, 164, -1000, -1000, 90, -1000, -1000, 686,
+	-1000, 413, 246, 245, -1000, 120, 226, -1000, 223, -1000,
+	-1000, 224, 213, 212, 200, 190, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, 545, 185, 185, 185, 185, 185, 201,
+	201, 201, 201, 201, 455, 66, 453, 131, 152, 396,
+	553, 178, 178, 178, 178, 178, 178, -1000, -1000, -1000,
+	-1000, -1000, -1000, 651, 651, 729, 729, 729, 729, 729,
+	729, 729, 281, -1000, 3, 281, 281, 281, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, 276, 275, 269, 266, 126, 281, 281, 281, 281,
+	90, -1000, -1000, -1000, 721, 173, 144, 616, -1000, -1000,
+	144, -1000, 87, 201, -1000, -1000, 87, -1000, -1000, -1000,
+	545, -1000, -1000, -1000, -1000, 420, -1000, 581, 58, 58,
+	-50, -50, -50, -50, 209, 189, 289, 729, -20, -20,
+	-54, -54, -54, -54, 373, -1000, 281, 281, 281, 281,
+	281, 281, 281, 281, 281, 281, 281, 281, 281, 281,
+	281, 281, 354, -58, -58, 4, -11, -23, -25, -1000,
+	331, 312, 270, 214, 453, 54, 124, 18, 616, -1000,
+	581, -29, 193, 168, -1000, -58, -58, -55, -55, -55,
+	234, 234, 234, 234, 234, 234, 234, 234, -55, 33,
+	33, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, 74, 73, 100, 95, 35, 30, 172, 163, 281,
+	281, 205, -24, 59, -1000, 281, -1000, -24,
 }
-var yyPgo = [...]int{
 
-	0, 164, 3, 162, 4, 484, 160, 14, 158, 2,
-	90, 157, 27, 7, 156, 155, 5, 50, 0, 154,
-	149,
+var yyPgo = [...]int16{
+	0, 342, 341, 12, 337, 164, 495, 336, 6, 335,
+	10, 326, 334, 437, 35, 329, 311, 11, 0, 1,
+	58, 284, 217,
 }
-var yyR1 = [...]int{
-
-	0, 1, 1, 1, 5, 5, 5, 5, 5, 5,
-	5, 6, 7, 7, 7, 7, 7, 7, 7, 2,
-	3, 4, 4, 4, 4, 4, 4, 4, 8, 8,
-	9, 10, 10, 10, 10, 10, 10, 11, 11, 12,
-	12, 12, 12, 12, 12, 12, 12, 14, 15, 13,
-	13, 13, 13, 13, 13, 13, 13, 13, 16, 16,
-	16, 16, 16, 17, 17, 17, 17, 17, 17, 17,
-	17, 17, 17, 17, 17, 17, 17, 17, 17, 17,
-	17, 17, 17, 17, 17, 18, 18, 18, 18, 18,
-	18, 18, 19, 20, 20,
+
+var yyR1 = [...]int8{
+	0, 1, 1, 1, 1, 2, 2, 2, 2, 6,
+	6, 6, 6, 6, 6, 6, 7, 8, 8, 8,
+	8, 8, 8, 8, 3, 4, 5, 5, 5, 5,
+	5, 5, 5, 9, 9, 10, 11, 11, 11, 11,
+	11, 11, 12, 12, 13, 13, 13, 13, 13, 13,
+	13, 13, 15, 16, 14, 14, 14, 14, 14, 14,
+	14, 14, 14, 17, 17, 17, 17, 17, 18, 18,
+	18, 18, 18, 18, 18, 18, 18, 18, 18, 18,
+	18, 18, 18, 18, 18, 18, 18, 18, 18, 18,
+	19, 19, 20, 20, 20, 20, 20, 20, 20, 21,
+	21, 21, 21, 21, 21, 21, 21, 21, 21, 22,
+	22, 22, 22,
 }
-var yyR2 = [...]int{
-
-	0, 1, 1, 1, 3, 3, 3, 3, 3, 3,
-	1, 3, 1, 1, 1, 3, 3, 3, 3, 4,
-	3, 3, 3, 3, 3, 3, 3, 1, 2, 3,
-	3, 1, 1, 1, 1, 1, 1, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 1, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 1, 1, 3, 4,
-	4, 4, 4, 3, 3, 3, 3, 3, 3, 3,
+
+var yyR2 = [...]int8{
+	0, 1, 1, 1, 1, 7, 11, 7, 11, 3,
+	3, 3, 3, 3, 3, 1, 3, 1, 1, 1,
+	3, 3, 3, 3, 4, 3, 3, 3, 3, 3,
+	3, 3, 1, 2, 3, 3, 1, 1, 1, 1,
+	1, 1, 3, 3, 3, 3, 3, 3, 3, 3,
+	3, 1, 3, 3, 3, 3, 3, 3, 3, 3,
+	3, 1, 1, 3, 4, 4, 4, 4, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	2, 2, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 1, 1, 3, 3,
+	3, 3, 3, 3, 3, 2, 2, 1, 1, 1,
+	1, 3, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 3,
+	3, 3, 3,
 }
-var yyChk = [...]int{
-
-	-1000, -1, -7, -5, -11, -4, -9, -2, 12, -6,
-	-12, -8, -13, 25, -14, 10, -16, -18, 20, 22,
-	23, 21, 24, 5, 6, 7, 15, 16, 14, 8,
-	28, 29, 35, 39, 30, 40, 29, 35, 39, 30,
-	40, -5, -7, -4, -12, -15, -13, -10, 41, 42,
-	44, 45, 46, 47, 31, 32, 33, 34, 35, 36,
-	-10, 41, 42, 44, 45, 46, 47, 12, 11, -17,
-	12, 42, 43, -18, -19, -20, 17, 9, 19, 12,
-	12, 12, 12, 12, -4, -9, -2, -3, 12, 26,
-	-5, 12, -5, -5, -5, -5, -4, 12, -4, -4,
-	-4, -4, 13, 13, 28, 13, 13, 13, 13, -12,
-	-18, 12, -12, -12, -12, -12, -12, -12, -13, 12,
-	-13, -13, -13, -13, -13, -13, -17, 11, 41, 42,
-	44, 45, 46, 31, 32, 33, 34, 35, 36, 38,
-	37, 47, 29, 30, -17, -17, -17, 4, 4, 13,
-	-17, -17, -17, -17, -4, -13, 12, -7, 12, -16,
-	12, -7, 13, -17, -17, -17, -17, -17, -17, -17,
-	-17, -17, -17, -17, -17, -17, -17, -17, -17, 13,
-	27, 27, 13, 13, 13, 13, 13,
+
+var yyChk = [...]int16{
+	-1000, -1, -8, -6, -12, -2, -5, -10, -3, 12,
+	-7, -13, 41, 42, -9, -14, 39, -15, 10, -17,
+	-20, 34, 36, 37, 35, 38, 5, 6, 7, 18,
+	19, 17, 8, 44, 45, 51, 55, 46, 56, 45,
+	51, 55, 46, 56, -6, -8, -5, -13, -16, -14,
+	-11, 57, 58, 60, 61, 62, 63, 47, 48, 49,
+	50, 51, 52, 12, 12, -11, 57, 58, 60, 61,
+	62, 63, 12, 11, -18, 12, 58, 59, -20, -21,
+	-22, 20, 21, 22, 23, 24, 25, 26, 27, 28,
+	29, 9, 31, 32, 33, 12, 12, 12, 12, 12,
+	-5, -10, -3, -4, 12, 40, -6, 12, -6, -6,
+	-6, -6, -5, 12, -5, -5, -5, -5, 13, 13,
+	44, 13, 13, 13, 13, -13, -20, 12, -13, -13,
+	-13, -13, -13, -13, -8, -8, -14, 12, -14, -14,
+	-14, -14, -14, -14, -18, 11, 57, 58, 60, 61,
+	62, 47, 48, 49, 50, 51, 52, 54, 53, 63,
+	45, 46, -18, -18, -18, 4, 4, 4, 4, 13,
+	-18, -18, -18, -18, -5, -14, 12, -8, 12, -17,
+	12, -8, 14, 14, 13, -18, -18, -18, -18, -18,
+	-18, -18, -18, -18, -18, -18, -18, -18, -18, -18,
+	-18, 13, 43, 43, 43, 43, 13, 13, 13, 13,
+	13, 8, 8, 15, 15, 13, 13, 39, 39, 12,
+	12, -19, -18, -19, 13, 16, 13, -18,
 }
-var yyDef = [...]int{
 
-	0, -2, 1, 2, 3, 12, 13, 14, 0, 10,
-	0, 27, 0, 0, 46, 0, 56, 57, 0, 0,
-	0, 0, 0, 85, 86, 87, 88, 89, 90, 91,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 12, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 31, 32, 33, 34, 35, 36,
-	0, 0, 0, 0, 0, 0, 0, 0, 28, 0,
-	0, 0, 0, 82, 83, 84, 92, 0, 0, 0,
-	0, 0, 0, 0, 15, 16, 17, 18, 0, 0,
-	5, 0, 6, 7, 8, 9, 22, 0, 23, 24,
-	25, 26, 4, 11, 0, 21, 39, 47, 49, 37,
-	38, 0, 40, 41, 42, 43, 44, 45, 30, 0,
-	50, 51, 52, 53, 54, 55, 0, 29, 0, 0,
+var yyDef = [...]int8{
+	0, -2, 1, 2, 3, 4, 17, 18, 19, 0,
+	15, 0, 0, 0, 32, 0, 0, 51, 0, 61,
+	62, 0, 0, 0, 0, 0, 92, 93, 94, 95,
+	96, 97, 98, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 17, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 36, 37, 38,
+	39, 40, 41, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 33, 0, 0, 0, 0, 87, 88,
+	89, 99, 100, 101, 102, 103, 104, 105, 106, 107,
+	108, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	20, 21, 22, 23, 0, 0, 10, 0, 11, 12,
+	13, 14, 27, 0, 28, 29, 30, 31, 9, 16,
+	0, 26, 44, 52, 54, 42, 43, 0, 45, 46,
+	47, 48, 49, 50, 0, 0, 35, 0, 55, 56,
+	57, 58, 59, 60, 0, 34, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 80, 81, 0, 0, 58,
+	0, 0, 0, 85, 86, 0, 0, 0, 0, 63,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, -2,
-	0, 0, 19, 64, 65, 66, 67, 68, 69, 70,
-	71, 72, 73, 74, 75, 76, 77, 78, 79, 63,
-	93, 94, 59, 60, 61, 62, 20,
+	0, 0, 0, 0, 24, 69, 70, 71, 72, 73,
+	74, 75, 76, 77, 78, 79, 80, 81, 82, 83,
+	84, 68, 109, 110, 111, 112, 64, 65, 66, 67,
+	25, 0, 0, 0, 0, 5, 7, 0, 0, 0,
+	0, 0, 90, 0, 6, 0, 8, 91,
 }
-var yyTok1 = [...]int{
 
+var yyTok1 = [...]int8{
 	1,
 }
-var yyTok2 = [...]int{
 
+var yyTok2 = [...]int8{
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
 	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
 	22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
 	32, 33, 34, 35, 36, 37, 38, 39, 40, 41,
-	42, 43, 44, 45, 46, 47,
+	42, 43, 44, 45, 46, 47, 48, 49, 50, 51,
+	52, 53, 54, 55, 56, 57, 58, 59, 60, 61,
+	62, 63,
 }
-var yyTok3 = [...]int{
+
+var yyTok3 = [...]int8{
 	0,
 }
 
@@ -418,9 +484,9 @@ func yyErrorMessage(state, lookAhead int) string {
 	expected := make([]int, 0, 4)
 
 	// Look for shiftable tokens.
-	base := yyPact[state]
+	base := int(yyPact[state])
 	for tok := TOKSTART; tok-1 < len(yyToknames); tok++ {
-		if n := base + tok; n >= 0 && n < yyLast && yyChk[yyAct[n]] == tok {
+		if n := base + tok; n >= 0 && n < yyLast && int(yyChk[int(yyAct[n])]) == tok {
 			if len(expected) == cap(expected) {
 				return res
 			}
@@ -430,13 +496,13 @@ func yyErrorMessage(state, lookAhead int) string {
 
 	if yyDef[state] == -2 {
 		i := 0
-		for yyExca[i] != -1 || yyExca[i+1] != state {
+		for yyExca[i] != -1 || int(yyExca[i+1]) != state {
 			i += 2
 		}
 
 		// Look for tokens that we accept or reduce.
 		for i += 2; yyExca[i] >= 0; i += 2 {
-			tok := yyExca[i]
+			tok := int(yyExca[i])
 			if tok < TOKSTART || yyExca[i+1] == 0 {
 				continue
 			}
@@ -467,30 +533,30 @@ func yylex1(lex yyLexer, lval *yySymType) (char, token int) {
 	token = 0
 	char = lex.Lex(lval)
 	if char <= 0 {
-		token = yyTok1[0]
+		token = int(yyTok1[0])
 		goto out
 	}
 	if char < len(yyTok1) {
-		token = yyTok1[char]
+		token = int(yyTok1[char])
 		goto out
 	}
 	if char >= yyPrivate {
 		if char < yyPrivate+len(yyTok2) {
-			token = yyTok2[char-yyPrivate]
+			token = int(yyTok2[char-yyPrivate])
 			goto out
 		}
 	}
 	for i := 0; i < len(yyTok3); i += 2 {
-		token = yyTok3[i+0]
+		token = int(yyTok3[i+0])
 		if token == char {
-			token = yyTok3[i+1]
+			token = int(yyTok3[i+1])
 			goto out
 		}
 	}
 
 out:
 	if token == 0 {
-		token = yyTok2[1] /* unknown char */
+		token = int(yyTok2[1]) /* unknown char */
 	}
 	if yyDebug >= 3 {
 		__yyfmt__.Printf("lex %s(%d)\n", yyTokname(token), uint(char))
@@ -545,7 +611,7 @@ yystack:
 	yyS[yyp].yys = yystate
 
 yynewstate:
-	yyn = yyPact[yystate]
+	yyn = int(yyPact[yystate])
 	if yyn <= yyFlag {
 		goto yydefault /* simple state */
 	}
@@ -556,8 +622,8 @@ yynewstate:
 	if yyn < 0 || yyn >= yyLast {
 		goto yydefault
 	}
-	yyn = yyAct[yyn]
-	if yyChk[yyn] == yytoken { /* valid shift */
+	yyn = int(yyAct[yyn])
+	if int(yyChk[yyn]) == yytoken { /* valid shift */
 		yyrcvr.char = -1
 		yytoken = -1
 		yyVAL = yyrcvr.lval
@@ -570,7 +636,7 @@ yynewstate:
 
 yydefault:
 	/* default state action */
-	yyn = yyDef[yystate]
+	yyn = int(yyDef[yystate])
 	if yyn == -2 {
 		if yyrcvr.char < 0 {
 			yyrcvr.char, yytoken = yylex1(yylex, &yyrcvr.lval)
@@ -579,18 +645,18 @@ yydefault:
 		/* look through exception table */
 		xi := 0
 		for {
-			if yyExca[xi+0] == -1 && yyExca[xi+1] == yystate {
+			if yyExca[xi+0] == -1 && int(yyExca[xi+1]) == yystate {
 				break
 			}
 			xi += 2
 		}
 		for xi += 2; ; xi += 2 {
-			yyn = yyExca[xi+0]
+			yyn = int(yyExca[xi+0])
 			if yyn < 0 || yyn == yytoken {
 				break
 			}
 		}
-		yyn = yyExca[xi+1]
+		yyn = int(yyExca[xi+1])
 		if yyn < 0 {
 			goto ret0
 		}
@@ -612,10 +678,10 @@ yydefault:
 
 			/* find a state where "error" is a legal shift action */
 			for yyp >= 0 {
-				yyn = yyPact[yyS[yyp].yys] + yyErrCode
+				yyn = int(yyPact[yyS[yyp].yys]) + yyErrCode
 				if yyn >= 0 && yyn < yyLast {
-					yystate = yyAct[yyn] /* simulate a shift of "error" */
-					if yyChk[yystate] == yyErrCode {
+					yystate = int(yyAct[yyn]) /* simulate a shift of "error" */
+					if int(yyChk[yystate]) == yyErrCode {
 						goto yystack
 					}
 				}
@@ -651,7 +717,7 @@ yydefault:
 	yypt := yyp
 	_ = yypt // guard against "declared and not used"
 
-	yyp -= yyR2[yyn]
+	yyp -= int(yyR2[yyn])
 	// yyp is now the index of $0. Perform the default action. Iff the
 	// reduced production is ε, $1 is possibly out of range.
 	if yyp+1 >= len(yyS) {
@@ -662,16 +728,16 @@ yydefault:
 	yyVAL = yyS[yyp+1]
 
 	/* consult goto table to find next state */
-	yyn = yyR1[yyn]
-	yyg := yyPgo[yyn]
+	yyn = int(yyR1[yyn])
+	yyg := int(yyPgo[yyn])
 	yyj := yyg + yyS[yyp].yys + 1
 
 	if yyj >= yyLast {
-		yystate = yyAct[yyg]
+		yystate = int(yyAct[yyg])
 	} else {
-		yystate = yyAct[yyj]
-		if yyChk[yystate] != -yyn {
-			yystate = yyAct[yyg]
+		yystate = int(yyAct[yyj])
+		if int(yyChk[yystate]) != -yyn {
+			yystate = int(yyAct[yyg])
 		}
 	}
 	// dummy call; replaced with literal code
@@ -679,568 +745,676 @@ yydefault:
 
 	case 1:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:93
+//line pkg/deepql/expr.y:99
 		{
 			yylex.(*lexer).expr = newRootExpr(yyDollar[1].snapshotPipeline)
 		}
 	case 2:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:94
+//line pkg/deepql/expr.y:100
 		{
 			yylex.(*lexer).expr = newRootExpr(yyDollar[1].snapshotPipelineExpression)
 		}
 	case 3:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:95
+//line pkg/deepql/expr.y:101
 		{
 			yylex.(*lexer).expr = newRootExpr(yyDollar[1].scalarPipelineExpressionFilter)
 		}
 	case 4:
-		yyDollar = yyS[yypt-3 : yypt+1]
+		yyDollar = yyS[yypt-1 : yypt+1]
 //line pkg/deepql/expr.y:102
 		{
-			yyVAL.snapshotPipelineExpression = yyDollar[2].snapshotPipelineExpression
+			yylex.(*lexer).expr = newMetricsRootExpr(yyDollar[1].metricsAggregate)
 		}
 	case 5:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line pkg/deepql/expr.y:109
+		{
+			yyVAL.metricsAggregate = newMetricsAggregate(metricsAggregateCountOverTime, yyDollar[3].snapshotPipeline, yyDollar[5].staticDuration, nil)
+		}
+	case 6:
+		yyDollar = yyS[yypt-11 : yypt+1]
+//line pkg/deepql/expr.y:110
+		{
+			yyVAL.metricsAggregate = newMetricsAggregate(metricsAggregateCountOverTime, yyDollar[3].snapshotPipeline, yyDollar[5].staticDuration, yyDollar[10].fieldExpressionList)
+		}
+	case 7:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line pkg/deepql/expr.y:111
+		{
+			yyVAL.metricsAggregate = newMetricsAggregate(metricsAggregateRate, yyDollar[3].snapshotPipeline, yyDollar[5].staticDuration, nil)
+		}
+	case 8:
+		yyDollar = yyS[yypt-11 : yypt+1]
+//line pkg/deepql/expr.y:112
+		{
+			yyVAL.metricsAggregate = newMetricsAggregate(metricsAggregateRate, yyDollar[3].snapshotPipeline, yyDollar[5].staticDuration, yyDollar[10].fieldExpressionList)
+		}
+	case 9:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:103
+//line pkg/deepql/expr.y:119
+		{
+			yyVAL.snapshotPipelineExpression = yyDollar[2].snapshotPipelineExpression
+		}
+	case 10:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line pkg/deepql/expr.y:120
 		{
 			yyVAL.snapshotPipelineExpression = newSnapshotOperation(OpSnapshotAnd, yyDollar[1].snapshotPipelineExpression, yyDollar[3].snapshotPipelineExpression)
 		}
-	case 6:
+	case 11:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:104
+//line pkg/deepql/expr.y:121
 		{
 			yyVAL.snapshotPipelineExpression = newSnapshotOperation(OpSnapshotChild, yyDollar[1].snapshotPipelineExpression, yyDollar[3].snapshotPipelineExpression)
 		}
-	case 7:
+	case 12:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:105
+//line pkg/deepql/expr.y:122
 		{
 			yyVAL.snapshotPipelineExpression = newSnapshotOperation(OpSnapshotDescendant, yyDollar[1].snapshotPipelineExpression, yyDollar[3].snapshotPipelineExpression)
 		}
-	case 8:
+	case 13:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:106
+//line pkg/deepql/expr.y:123
 		{
 			yyVAL.snapshotPipelineExpression = newSnapshotOperation(OpSnapshotUnion, yyDollar[1].snapshotPipelineExpression, yyDollar[3].snapshotPipelineExpression)
 		}
-	case 9:
+	case 14:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:107
+//line pkg/deepql/expr.y:124
 		{
 			yyVAL.snapshotPipelineExpression = newSnapshotOperation(OpSnapshotSibling, yyDollar[1].snapshotPipelineExpression, yyDollar[3].snapshotPipelineExpression)
 		}
-	case 10:
+	case 15:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:108
+//line pkg/deepql/expr.y:125
 		{
 			yyVAL.snapshotPipelineExpression = yyDollar[1].wrappedSnapshotPipeline
 		}
-	case 11:
+	case 16:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:112
+//line pkg/deepql/expr.y:129
 		{
 			yyVAL.wrappedSnapshotPipeline = yyDollar[2].snapshotPipeline
 		}
-	case 12:
+	case 17:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:115
+//line pkg/deepql/expr.y:132
 		{
 			yyVAL.snapshotPipeline = newPipeline(yyDollar[1].snapshotExpression)
 		}
-	case 13:
+	case 18:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:116
+//line pkg/deepql/expr.y:133
 		{
 			yyVAL.snapshotPipeline = newPipeline(yyDollar[1].scalarFilter)
 		}
-	case 14:
+	case 19:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:117
+//line pkg/deepql/expr.y:134
 		{
 			yyVAL.snapshotPipeline = newPipeline(yyDollar[1].groupOperation)
 		}
-	case 15:
+	case 20:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:118
+//line pkg/deepql/expr.y:135
 		{
 			yyVAL.snapshotPipeline = yyDollar[1].snapshotPipeline.addItem(yyDollar[3].snapshotExpression)
 		}
-	case 16:
+	case 21:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:119
+//line pkg/deepql/expr.y:136
 		{
 			yyVAL.snapshotPipeline = yyDollar[1].snapshotPipeline.addItem(yyDollar[3].scalarFilter)
 		}
-	case 17:
+	case 22:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:120
+//line pkg/deepql/expr.y:137
 		{
 			yyVAL.snapshotPipeline = yyDollar[1].snapshotPipeline.addItem(yyDollar[3].groupOperation)
 		}
-	case 18:
+	case 23:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:121
+//line pkg/deepql/expr.y:138
 		{
 			yyVAL.snapshotPipeline = yyDollar[1].snapshotPipeline.addItem(yyDollar[3].coalesceOperation)
 		}
-	case 19:
+	case 24:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line pkg/deepql/expr.y:125
+//line pkg/deepql/expr.y:142
 		{
 			yyVAL.groupOperation = newGroupOperation(yyDollar[3].fieldExpression)
 		}
-	case 20:
+	case 25:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:129
+//line pkg/deepql/expr.y:146
 		{
 			yyVAL.coalesceOperation = newCoalesceOperation()
 		}
-	case 21:
+	case 26:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:133
+//line pkg/deepql/expr.y:150
 		{
 			yyVAL.snapshotExpression = yyDollar[2].snapshotExpression
 		}
-	case 22:
+	case 27:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:134
+//line pkg/deepql/expr.y:151
 		{
 			yyVAL.snapshotExpression = newSnapshotOperation(OpSnapshotAnd, yyDollar[1].snapshotExpression, yyDollar[3].snapshotExpression)
 		}
-	case 23:
+	case 28:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:135
+//line pkg/deepql/expr.y:152
 		{
 			yyVAL.snapshotExpression = newSnapshotOperation(OpSnapshotChild, yyDollar[1].snapshotExpression, yyDollar[3].snapshotExpression)
 		}
-	case 24:
+	case 29:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:136
+//line pkg/deepql/expr.y:153
 		{
 			yyVAL.snapshotExpression = newSnapshotOperation(OpSnapshotDescendant, yyDollar[1].snapshotExpression, yyDollar[3].snapshotExpression)
 		}
-	case 25:
+	case 30:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:137
+//line pkg/deepql/expr.y:154
 		{
 			yyVAL.snapshotExpression = newSnapshotOperation(OpSnapshotUnion, yyDollar[1].snapshotExpression, yyDollar[3].snapshotExpression)
 		}
-	case 26:
+	case 31:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:138
+//line pkg/deepql/expr.y:155
 		{
 			yyVAL.snapshotExpression = newSnapshotOperation(OpSnapshotSibling, yyDollar[1].snapshotExpression, yyDollar[3].snapshotExpression)
 		}
-	case 27:
+	case 32:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:139
+//line pkg/deepql/expr.y:156
 		{
 			yyVAL.snapshotExpression = yyDollar[1].snapshotFilter
 		}
-	case 28:
+	case 33:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line pkg/deepql/expr.y:143
+//line pkg/deepql/expr.y:160
 		{
 			yyVAL.snapshotFilter = newSnapshotFilter(NewStaticBool(true))
 		}
-	case 29:
+	case 34:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:144
+//line pkg/deepql/expr.y:161
 		{
 			yyVAL.snapshotFilter = newSnapshotFilter(yyDollar[2].fieldExpression)
 		}
-	case 30:
+	case 35:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:148
+//line pkg/deepql/expr.y:165
 		{
 			yyVAL.scalarFilter = newScalarFilter(yyDollar[2].scalarFilterOperation, yyDollar[1].scalarExpression, yyDollar[3].scalarExpression)
 		}
-	case 31:
+	case 36:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:152
+//line pkg/deepql/expr.y:169
 		{
 			yyVAL.scalarFilterOperation = OpEqual
 		}
-	case 32:
+	case 37:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:153
+//line pkg/deepql/expr.y:170
 		{
 			yyVAL.scalarFilterOperation = OpNotEqual
 		}
-	case 33:
+	case 38:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:154
+//line pkg/deepql/expr.y:171
 		{
 			yyVAL.scalarFilterOperation = OpLess
 		}
-	case 34:
+	case 39:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:155
+//line pkg/deepql/expr.y:172
 		{
 			yyVAL.scalarFilterOperation = OpLessEqual
 		}
-	case 35:
+	case 40:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:156
+//line pkg/deepql/expr.y:173
 		{
 			yyVAL.scalarFilterOperation = OpGreater
 		}
-	case 36:
+	case 41:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:157
+//line pkg/deepql/expr.y:174
 		{
 			yyVAL.scalarFilterOperation = OpGreaterEqual
 		}
-	case 37:
+	case 42:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:164
+//line pkg/deepql/expr.y:181
 		{
 			yyVAL.scalarPipelineExpressionFilter = newScalarFilter(yyDollar[2].scalarFilterOperation, yyDollar[1].scalarPipelineExpression, yyDollar[3].scalarPipelineExpression)
 		}
-	case 38:
+	case 43:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:165
+//line pkg/deepql/expr.y:182
 		{
 			yyVAL.scalarPipelineExpressionFilter = newScalarFilter(yyDollar[2].scalarFilterOperation, yyDollar[1].scalarPipelineExpression, yyDollar[3].static)
 		}
-	case 39:
+	case 44:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:169
+//line pkg/deepql/expr.y:186
 		{
 			yyVAL.scalarPipelineExpression = yyDollar[2].scalarPipelineExpression
 		}
-	case 40:
+	case 45:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:170
+//line pkg/deepql/expr.y:187
 		{
 			yyVAL.scalarPipelineExpression = newScalarOperation(OpAdd, yyDollar[1].scalarPipelineExpression, yyDollar[3].scalarPipelineExpression)
 		}
-	case 41:
+	case 46:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:171
+//line pkg/deepql/expr.y:188
 		{
 			yyVAL.scalarPipelineExpression = newScalarOperation(OpSub, yyDollar[1].scalarPipelineExpression, yyDollar[3].scalarPipelineExpression)
 		}
-	case 42:
+	case 47:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:172
+//line pkg/deepql/expr.y:189
 		{
 			yyVAL.scalarPipelineExpression = newScalarOperation(OpMult, yyDollar[1].scalarPipelineExpression, yyDollar[3].scalarPipelineExpression)
 		}
-	case 43:
+	case 48:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:173
+//line pkg/deepql/expr.y:190
 		{
 			yyVAL.scalarPipelineExpression = newScalarOperation(OpDiv, yyDollar[1].scalarPipelineExpression, yyDollar[3].scalarPipelineExpression)
 		}
-	case 44:
+	case 49:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:174
+//line pkg/deepql/expr.y:191
 		{
 			yyVAL.scalarPipelineExpression = newScalarOperation(OpMod, yyDollar[1].scalarPipelineExpression, yyDollar[3].scalarPipelineExpression)
 		}
-	case 45:
+	case 50:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:175
+//line pkg/deepql/expr.y:192
 		{
 			yyVAL.scalarPipelineExpression = newScalarOperation(OpPower, yyDollar[1].scalarPipelineExpression, yyDollar[3].scalarPipelineExpression)
 		}
-	case 46:
+	case 51:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:176
+//line pkg/deepql/expr.y:193
 		{
 			yyVAL.scalarPipelineExpression = yyDollar[1].wrappedScalarPipeline
 		}
-	case 47:
+	case 52:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:180
+//line pkg/deepql/expr.y:197
 		{
 			yyVAL.wrappedScalarPipeline = yyDollar[2].scalarPipeline
 		}
-	case 48:
+	case 53:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:184
+//line pkg/deepql/expr.y:201
 		{
 			yyVAL.scalarPipeline = yyDollar[1].snapshotPipeline.addItem(yyDollar[3].aggregate)
 		}
-	case 49:
+	case 54:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:188
+//line pkg/deepql/expr.y:205
 		{
 			yyVAL.scalarExpression = yyDollar[2].scalarExpression
 		}
-	case 50:
+	case 55:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:189
+//line pkg/deepql/expr.y:206
 		{
 			yyVAL.scalarExpression = newScalarOperation(OpAdd, yyDollar[1].scalarExpression, yyDollar[3].scalarExpression)
 		}
-	case 51:
+	case 56:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:190
+//line pkg/deepql/expr.y:207
 		{
 			yyVAL.scalarExpression = newScalarOperation(OpSub, yyDollar[1].scalarExpression, yyDollar[3].scalarExpression)
 		}
-	case 52:
+	case 57:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:191
+//line pkg/deepql/expr.y:208
 		{
 			yyVAL.scalarExpression = newScalarOperation(OpMult, yyDollar[1].scalarExpression, yyDollar[3].scalarExpression)
 		}
-	case 53:
+	case 58:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:192
+//line pkg/deepql/expr.y:209
 		{
 			yyVAL.scalarExpression = newScalarOperation(OpDiv, yyDollar[1].scalarExpression, yyDollar[3].scalarExpression)
 		}
-	case 54:
+	case 59:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:193
+//line pkg/deepql/expr.y:210
 		{
 			yyVAL.scalarExpression = newScalarOperation(OpMod, yyDollar[1].scalarExpression, yyDollar[3].scalarExpression)
 		}
-	case 55:
+	case 60:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:194
+//line pkg/deepql/expr.y:211
 		{
 			yyVAL.scalarExpression = newScalarOperation(OpPower, yyDollar[1].scalarExpression, yyDollar[3].scalarExpression)
 		}
-	case 56:
+	case 61:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:195
+//line pkg/deepql/expr.y:212
 		{
 			yyVAL.scalarExpression = yyDollar[1].aggregate
 		}
-	case 57:
+	case 62:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:196
+//line pkg/deepql/expr.y:213
 		{
 			yyVAL.scalarExpression = yyDollar[1].static
 		}
-	case 58:
+	case 63:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:200
+//line pkg/deepql/expr.y:217
 		{
 			yyVAL.aggregate = newAggregate(aggregateCount, nil)
 		}
-	case 59:
+	case 64:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line pkg/deepql/expr.y:201
+//line pkg/deepql/expr.y:218
 		{
 			yyVAL.aggregate = newAggregate(aggregateMax, yyDollar[3].fieldExpression)
 		}
-	case 60:
+	case 65:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line pkg/deepql/expr.y:202
+//line pkg/deepql/expr.y:219
 		{
 			yyVAL.aggregate = newAggregate(aggregateMin, yyDollar[3].fieldExpression)
 		}
-	case 61:
+	case 66:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line pkg/deepql/expr.y:203
+//line pkg/deepql/expr.y:220
 		{
 			yyVAL.aggregate = newAggregate(aggregateAvg, yyDollar[3].fieldExpression)
 		}
-	case 62:
+	case 67:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line pkg/deepql/expr.y:204
+//line pkg/deepql/expr.y:221
 		{
 			yyVAL.aggregate = newAggregate(aggregateSum, yyDollar[3].fieldExpression)
 		}
-	case 63:
+	case 68:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:211
+//line pkg/deepql/expr.y:228
 		{
 			yyVAL.fieldExpression = yyDollar[2].fieldExpression
 		}
-	case 64:
+	case 69:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:212
+//line pkg/deepql/expr.y:229
 		{
 			yyVAL.fieldExpression = newBinaryOperation(OpAdd, yyDollar[1].fieldExpression, yyDollar[3].fieldExpression)
 		}
-	case 65:
+	case 70:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:213
+//line pkg/deepql/expr.y:230
 		{
 			yyVAL.fieldExpression = newBinaryOperation(OpSub, yyDollar[1].fieldExpression, yyDollar[3].fieldExpression)
 		}
-	case 66:
+	case 71:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:214
+//line pkg/deepql/expr.y:231
 		{
 			yyVAL.fieldExpression = newBinaryOperation(OpMult, yyDollar[1].fieldExpression, yyDollar[3].fieldExpression)
 		}
-	case 67:
+	case 72:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:215
+//line pkg/deepql/expr.y:232
 		{
 			yyVAL.fieldExpression = newBinaryOperation(OpDiv, yyDollar[1].fieldExpression, yyDollar[3].fieldExpression)
 		}
-	case 68:
+	case 73:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:216
+//line pkg/deepql/expr.y:233
 		{
 			yyVAL.fieldExpression = newBinaryOperation(OpMod, yyDollar[1].fieldExpression, yyDollar[3].fieldExpression)
 		}
-	case 69:
+	case 74:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:217
+//line pkg/deepql/expr.y:234
 		{
 			yyVAL.fieldExpression = newBinaryOperation(OpEqual, yyDollar[1].fieldExpression, yyDollar[3].fieldExpression)
 		}
-	case 70:
+	case 75:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:218
+//line pkg/deepql/expr.y:235
 		{
 			yyVAL.fieldExpression = newBinaryOperation(OpNotEqual, yyDollar[1].fieldExpression, yyDollar[3].fieldExpression)
 		}
-	case 71:
+	case 76:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:219
+//line pkg/deepql/expr.y:236
 		{
 			yyVAL.fieldExpression = newBinaryOperation(OpLess, yyDollar[1].fieldExpression, yyDollar[3].fieldExpression)
 		}
-	case 72:
+	case 77:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:220
+//line pkg/deepql/expr.y:237
 		{
 			yyVAL.fieldExpression = newBinaryOperation(OpLessEqual, yyDollar[1].fieldExpression, yyDollar[3].fieldExpression)
 		}
-	case 73:
+	case 78:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:221
+//line pkg/deepql/expr.y:238
 		{
 			yyVAL.fieldExpression = newBinaryOperation(OpGreater, yyDollar[1].fieldExpression, yyDollar[3].fieldExpression)
 		}
-	case 74:
+	case 79:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:222
+//line pkg/deepql/expr.y:239
 		{
 			yyVAL.fieldExpression = newBinaryOperation(OpGreaterEqual, yyDollar[1].fieldExpression, yyDollar[3].fieldExpression)
 		}
-	case 75:
+	case 80:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:223
+//line pkg/deepql/expr.y:240
 		{
 			yyVAL.fieldExpression = newBinaryOperation(OpRegex, yyDollar[1].fieldExpression, yyDollar[3].fieldExpression)
 		}
-	case 76:
+	case 81:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:224
+//line pkg/deepql/expr.y:241
 		{
 			yyVAL.fieldExpression = newBinaryOperation(OpNotRegex, yyDollar[1].fieldExpression, yyDollar[3].fieldExpression)
 		}
-	case 77:
+	case 82:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:225
+//line pkg/deepql/expr.y:242
 		{
 			yyVAL.fieldExpression = newBinaryOperation(OpPower, yyDollar[1].fieldExpression, yyDollar[3].fieldExpression)
 		}
-	case 78:
+	case 83:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:226
+//line pkg/deepql/expr.y:243
 		{
 			yyVAL.fieldExpression = newBinaryOperation(OpAnd, yyDollar[1].fieldExpression, yyDollar[3].fieldExpression)
 		}
-	case 79:
+	case 84:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:227
+//line pkg/deepql/expr.y:244
 		{
 			yyVAL.fieldExpression = newBinaryOperation(OpOr, yyDollar[1].fieldExpression, yyDollar[3].fieldExpression)
 		}
-	case 80:
+	case 85:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line pkg/deepql/expr.y:228
+//line pkg/deepql/expr.y:245
 		{
 			yyVAL.fieldExpression = newUnaryOperation(OpSub, yyDollar[2].fieldExpression)
 		}
-	case 81:
+	case 86:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line pkg/deepql/expr.y:229
+//line pkg/deepql/expr.y:246
 		{
 			yyVAL.fieldExpression = newUnaryOperation(OpNot, yyDollar[2].fieldExpression)
 		}
-	case 82:
+	case 87:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:230
+//line pkg/deepql/expr.y:247
 		{
 			yyVAL.fieldExpression = yyDollar[1].static
 		}
-	case 83:
+	case 88:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:231
+//line pkg/deepql/expr.y:248
 		{
 			yyVAL.fieldExpression = yyDollar[1].intrinsicField
 		}
-	case 84:
+	case 89:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:232
+//line pkg/deepql/expr.y:249
 		{
 			yyVAL.fieldExpression = yyDollar[1].attributeField
 		}
-	case 85:
+	case 90:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:239
+//line pkg/deepql/expr.y:253
+		{
+			yyVAL.fieldExpressionList = []FieldExpression{yyDollar[1].fieldExpression}
+		}
+	case 91:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line pkg/deepql/expr.y:254
+		{
+			yyVAL.fieldExpressionList = append(yyDollar[1].fieldExpressionList, yyDollar[3].fieldExpression)
+		}
+	case 92:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line pkg/deepql/expr.y:261
 		{
 			yyVAL.static = NewStaticString(yyDollar[1].staticStr)
 		}
-	case 86:
+	case 93:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:240
+//line pkg/deepql/expr.y:262
 		{
 			yyVAL.static = NewStaticInt(yyDollar[1].staticInt)
 		}
-	case 87:
+	case 94:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:241
+//line pkg/deepql/expr.y:263
 		{
 			yyVAL.static = NewStaticFloat(yyDollar[1].staticFloat)
 		}
-	case 88:
+	case 95:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:242
+//line pkg/deepql/expr.y:264
 		{
 			yyVAL.static = NewStaticBool(true)
 		}
-	case 89:
+	case 96:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:243
+//line pkg/deepql/expr.y:265
 		{
 			yyVAL.static = NewStaticBool(false)
 		}
-	case 90:
+	case 97:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:244
+//line pkg/deepql/expr.y:266
 		{
 			yyVAL.static = NewStaticNil()
 		}
-	case 91:
+	case 98:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:245
+//line pkg/deepql/expr.y:267
 		{
 			yyVAL.static = NewStaticDuration(yyDollar[1].staticDuration)
 		}
-	case 92:
+	case 99:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line pkg/deepql/expr.y:249
+//line pkg/deepql/expr.y:271
 		{
 			yyVAL.intrinsicField = NewIntrinsic(IntrinsicDuration)
 		}
-	case 93:
+	case 100:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line pkg/deepql/expr.y:272
+		{
+			yyVAL.intrinsicField = NewIntrinsic(IntrinsicTracepoint)
+		}
+	case 101:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line pkg/deepql/expr.y:273
+		{
+			yyVAL.intrinsicField = NewIntrinsic(IntrinsicPath)
+		}
+	case 102:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line pkg/deepql/expr.y:274
+		{
+			yyVAL.intrinsicField = NewIntrinsic(IntrinsicLine)
+		}
+	case 103:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line pkg/deepql/expr.y:275
+		{
+			yyVAL.intrinsicField = NewIntrinsic(IntrinsicMethod)
+		}
+	case 104:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line pkg/deepql/expr.y:276
+		{
+			yyVAL.intrinsicField = NewIntrinsic(IntrinsicClass)
+		}
+	case 105:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line pkg/deepql/expr.y:277
+		{
+			yyVAL.intrinsicField = NewIntrinsic(IntrinsicLog)
+		}
+	case 106:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line pkg/deepql/expr.y:278
+		{
+			yyVAL.intrinsicField = NewIntrinsic(IntrinsicFrames)
+		}
+	case 107:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line pkg/deepql/expr.y:279
+		{
+			yyVAL.intrinsicField = NewIntrinsic(IntrinsicTraceID)
+		}
+	case 108:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line pkg/deepql/expr.y:280
+		{
+			yyVAL.intrinsicField = NewIntrinsic(IntrinsicSpanID)
+		}
+	case 109:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:253
+//line pkg/deepql/expr.y:284
 		{
 			yyVAL.attributeField = NewAttribute(yyDollar[2].staticStr)
 		}
-	case 94:
+	case 110:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line pkg/deepql/expr.y:254
+//line pkg/deepql/expr.y:285
 		{
 			yyVAL.attributeField = NewScopedAttribute(AttributeScopeResource, false, yyDollar[2].staticStr)
 		}
+	case 111:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line pkg/deepql/expr.y:286
+		{
+			yyVAL.attributeField = NewScopedAttribute(AttributeScopeVariable, false, yyDollar[2].staticStr)
+		}
+	case 112:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line pkg/deepql/expr.y:287
+		{
+			yyVAL.attributeField = NewScopedAttribute(AttributeScopeWatch, false, yyDollar[2].staticStr)
+		}
 	}
 	goto yystack /* stack new state and value */
 }
@@ -28,44 +28,61 @@ import (
 )
 
 var tokens = map[string]int{
-	".":         DOT,
-	"{":         OPEN_BRACE,
-	"}":         CLOSE_BRACE,
-	"(":         OPEN_PARENS,
-	")":         CLOSE_PARENS,
-	"=":         EQ,
-	"!=":        NEQ,
-	"=~":        RE,
-	"!~":        NRE,
-	">":         GT,
-	">=":        GTE,
-	"<":         LT,
-	"<=":        LTE,
-	"+":         ADD,
-	"-":         SUB,
-	"/":         DIV,
-	"%":         MOD,
-	"*":         MUL,
-	"^":         POW,
-	"true":      TRUE,
-	"false":     FALSE,
-	"nil":       NIL,
-	"&&":        AND,
-	"||":        OR,
-	"!":         NOT,
-	"|":         PIPE,
-	">>":        DESC,
-	"~":         TILDE,
-	"duration":  IDURATION,
-	"name":      NAME,
-	"resource.": RESOURCE_DOT,
-	"count":     COUNT,
-	"avg":       AVG,
-	"max":       MAX,
-	"min":       MIN,
-	"sum":       SUM,
-	"by":        BY,
-	"coalesce":  COALESCE,
+	".":          DOT,
+	"{":          OPEN_BRACE,
+	"}":          CLOSE_BRACE,
+	"(":          OPEN_PARENS,
+	")":          CLOSE_PARENS,
+	"[":          OPEN_BRACKET,
+	"]":          CLOSE_BRACKET,
+	",":          COMMA,
+	"=":          EQ,
+	"!=":         NEQ,
+	"=~":         RE,
+	"!~":         NRE,
+	">":          GT,
+	">=":         GTE,
+	"<":          LT,
+	"<=":         LTE,
+	"+":          ADD,
+	"-":          SUB,
+	"/":          DIV,
+	"%":          MOD,
+	"*":          MUL,
+	"^":          POW,
+	"true":       TRUE,
+	"false":      FALSE,
+	"nil":        NIL,
+	"&&":         AND,
+	"||":         OR,
+	"!":          NOT,
+	"|":          PIPE,
+	">>":         DESC,
+	"~":          TILDE,
+	"duration":   IDURATION,
+	"tracepoint": ITRACEPOINT,
+	"path":       IPATH,
+	"line":       ILINE,
+	"method":     IMETHOD,
+	"class":      ICLASS,
+	"log":        ILOG,
+	"frames":     IFRAMES,
+	"traceID":    ITRACEID,
+	"spanID":     ISPANID,
+	"name":       NAME,
+	"resource.":  RESOURCE_DOT,
+	"var.":       VAR_DOT,
+	"watch.":     WATCH_DOT,
+	"count":      COUNT,
+	"avg":        AVG,
+	"max":        MAX,
+	"min":        MIN,
+	"sum":        SUM,
+	"by":         BY,
+	"coalesce":   COALESCE,
+
+	"count_over_time": COUNT_OVER_TIME,
+	"rate":            RATE,
 }
 
 type lexer struct {
@@ -91,6 +108,17 @@ func (l *lexer) Lex(lval *yySymType) int {
 	// if we are currently parsing an attribute then just grab everything until we find a character that ends the attribute.
 	// we will handle parsing this out in ast.go
 	if l.parsingAttribute {
+		// quoted names allow any character in the attribute, e.g. watch."order.total * 2"
+		if r == scanner.String || r == scanner.RawString {
+			var err error
+			lval.staticStr, err = strconv.Unquote(l.TokenText())
+			if err != nil {
+				l.Error(err.Error())
+				return 0
+			}
+			return IDENTIFIER
+		}
+
 		str := l.TokenText()
 		// parse out any scopes here
 		tok := tokens[str+string(l.Peek())]
@@ -238,7 +266,7 @@ func isAttributeRune(r rune) bool {
 	}
 
 	switch r {
-	case scanner.EOF, '{', '}', '(', ')', '=', '~', '!', '<', '>', '&', '|', '^':
+	case scanner.EOF, '{', '}', '(', ')', '=', '~', '!', '<', '>', '&', '|', '^', ',':
 		return false
 	default:
 		return true
@@ -247,5 +275,7 @@ func isAttributeRune(r rune) bool {
 
 func startsAttribute(tok int) bool {
 	return tok == DOT ||
-		tok == RESOURCE_DOT
+		tok == RESOURCE_DOT ||
+		tok == VAR_DOT ||
+		tok == WATCH_DOT
 }
@@ -0,0 +1,228 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package deepql
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/intergral/deep/pkg/deeppb"
+	v1 "github.com/intergral/deep/pkg/deeppb/common/v1"
+	tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/intergral/deep/pkg/util"
+)
+
+// SnapshotMatcher evaluates a query against single snapshots as they are received, rather than against the
+// snapshots stored in a block. Only snapshot filters are supported, as by(), coalesce() and aggregates need
+// the complete set of snapshots.
+type SnapshotMatcher struct {
+	pipeline   Pipeline
+	attributes []Attribute
+}
+
+// NewSnapshotMatcher parses and validates the query
+func NewSnapshotMatcher(query string) (*SnapshotMatcher, error) {
+	root, err := Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	if err := root.validate(); err != nil {
+		return nil, err
+	}
+
+	if root.Metrics != nil {
+		return nil, errors.New("metrics queries cannot be matched against single snapshots")
+	}
+
+	snapshotPipeline, setPipeline := root.Pipeline.split()
+	if len(setPipeline.Elements) > 0 {
+		return nil, fmt.Errorf("only snapshot filters can be matched against single snapshots: %s", setPipeline)
+	}
+
+	req := FetchSnapshotRequest{}
+	snapshotPipeline.extractConditions(&req)
+
+	attributes := make([]Attribute, 0, len(req.Conditions))
+	for _, condition := range req.Conditions {
+		attributes = append(attributes, condition.Attribute)
+	}
+
+	return &SnapshotMatcher{
+		pipeline:   snapshotPipeline,
+		attributes: attributes,
+	}, nil
+}
+
+// Match returns the search metadata for the snapshot if it matches the query, or nil if it does not.
+func (m *SnapshotMatcher) Match(snapshot *tp.Snapshot) (*deeppb.SnapshotSearchMetadata, error) {
+	result := &SnapshotResult{
+		SnapshotID:         snapshot.ID,
+		ServiceName:        findKeyValue(snapshot.Resource, "service.name").GetStringValue(),
+		FilePath:           snapshot.GetTracepoint().GetPath(),
+		LineNo:             snapshot.GetTracepoint().GetLineNumber(),
+		StartTimeUnixNanos: snapshot.TsNanos,
+		DurationNanos:      snapshot.DurationNanos,
+	}
+	result.Snapshot = newProtoSnapshot(snapshot, m.attributes)
+
+	matched, err := m.pipeline.evaluate([]*SnapshotResult{result})
+	if err != nil {
+		return nil, err
+	}
+	if len(matched) == 0 {
+		return nil, nil
+	}
+
+	return NewEngine().asSnapshotSearchMetadata(matched[0]), nil
+}
+
+// protoSnapshot holds the attributes of a tp.Snapshot that are referenced by the query
+type protoSnapshot struct {
+	id         []byte
+	startTime  uint64
+	duration   uint64
+	attributes map[Attribute]Static
+}
+
+var _ Snapshot = (*protoSnapshot)(nil)
+
+func newProtoSnapshot(snapshot *tp.Snapshot, attributes []Attribute) *protoSnapshot {
+	s := &protoSnapshot{
+		id:         snapshot.ID,
+		startTime:  snapshot.TsNanos,
+		duration:   snapshot.DurationNanos,
+		attributes: make(map[Attribute]Static, len(attributes)),
+	}
+
+	for _, a := range attributes {
+		if value, ok := resolveProtoAttribute(snapshot, a); ok {
+			s.attributes[a] = value
+		}
+	}
+
+	return s
+}
+
+func (s *protoSnapshot) Attributes() map[Attribute]Static {
+	return s.attributes
+}
+
+func (s *protoSnapshot) ID() []byte {
+	return s.id
+}
+
+func (s *protoSnapshot) StartTimeUnixNanos() uint64 {
+	return s.startTime
+}
+
+func (s *protoSnapshot) EndTimeUnixNanos() uint64 {
+	return s.startTime + s.duration
+}
+
+// ResolveAttribute returns the value of the attribute from the snapshot, in the same way it is resolved when the
+// snapshot is matched against a query. The bool is false if the snapshot does not have the attribute.
+func ResolveAttribute(snapshot *tp.Snapshot, a Attribute) (Static, bool) {
+	return resolveProtoAttribute(snapshot, a)
+}
+
+// resolveProtoAttribute returns the value of the attribute from the snapshot, unscoped attributes are looked up
+// in the snapshot attributes and then the resource.
+func resolveProtoAttribute(snapshot *tp.Snapshot, a Attribute) (Static, bool) {
+	if a.Intrinsic != IntrinsicNone {
+		return resolveProtoIntrinsic(snapshot, a.Intrinsic)
+	}
+
+	switch a.Scope {
+	case AttributeScopeNone:
+		if value, ok := anyValueStatic(findKeyValue(snapshot.Attributes, a.Name)); ok {
+			return value, true
+		}
+		return anyValueStatic(findKeyValue(snapshot.Resource, a.Name))
+	case AttributeScopeSnapshot:
+		return anyValueStatic(findKeyValue(snapshot.Attributes, a.Name))
+	case AttributeScopeResource:
+		return anyValueStatic(findKeyValue(snapshot.Resource, a.Name))
+	case AttributeScopeVariable, AttributeScopeWatch:
+		return ResolveCapturedAttribute(snapshot, a)
+	}
+
+	return NewStaticNil(), false
+}
+
+func resolveProtoIntrinsic(snapshot *tp.Snapshot, intrinsic Intrinsic) (Static, bool) {
+	switch intrinsic {
+	case IntrinsicDuration:
+		return NewStaticDuration(time.Duration(snapshot.DurationNanos)), true
+	case IntrinsicTracepoint:
+		return NewStaticString(snapshot.GetTracepoint().GetID()), true
+	case IntrinsicPath:
+		return NewStaticString(snapshot.GetTracepoint().GetPath()), true
+	case IntrinsicLine:
+		return NewStaticInt(int(snapshot.GetTracepoint().GetLineNumber())), true
+	case IntrinsicMethod:
+		if len(snapshot.Frames) > 0 {
+			return NewStaticString(snapshot.Frames[0].MethodName), true
+		}
+	case IntrinsicClass:
+		if len(snapshot.Frames) > 0 && snapshot.Frames[0].ClassName != nil {
+			return NewStaticString(*snapshot.Frames[0].ClassName), true
+		}
+	case IntrinsicLog:
+		if snapshot.LogMsg != nil {
+			return NewStaticString(*snapshot.LogMsg), true
+		}
+	case IntrinsicFrames:
+		return NewStaticInt(len(snapshot.Frames)), true
+	case IntrinsicTraceID, IntrinsicSpanID:
+		traceID, spanID := util.TraceIDsFromAttributes(snapshot.Attributes)
+		if intrinsic == IntrinsicSpanID {
+			traceID = spanID
+		}
+		if traceID != "" {
+			return NewStaticString(traceID), true
+		}
+	}
+
+	return NewStaticNil(), false
+}
+
+func findKeyValue(kvs []*v1.KeyValue, key string) *v1.AnyValue {
+	for _, kv := range kvs {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return nil
+}
+
+// anyValueStatic converts the scalar values that are stored as attributes, other values are not supported.
+func anyValueStatic(value *v1.AnyValue) (Static, bool) {
+	switch v := value.GetValue().(type) {
+	case *v1.AnyValue_StringValue:
+		return NewStaticString(v.StringValue), true
+	case *v1.AnyValue_IntValue:
+		return NewStaticInt(int(v.IntValue)), true
+	case *v1.AnyValue_DoubleValue:
+		return NewStaticFloat(v.DoubleValue), true
+	case *v1.AnyValue_BoolValue:
+		return NewStaticBool(v.BoolValue), true
+	}
+
+	return NewStaticNil(), false
+}
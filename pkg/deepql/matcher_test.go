@@ -0,0 +1,111 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package deepql
+
+import (
+	"testing"
+
+	v1 "github.com/intergral/deep/pkg/deeppb/common/v1"
+	tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotMatcher(t *testing.T) {
+	className := "Cart"
+	snapshot := &tp.Snapshot{
+		ID:            []byte{1, 2, 3, 4},
+		TsNanos:       1500,
+		DurationNanos: 100,
+		Tracepoint:    &tp.TracePointConfig{ID: "tp-1", Path: "/app/checkout.py", LineNumber: 42},
+		Attributes: []*v1.KeyValue{
+			{Key: "foo", Value: &v1.AnyValue{Value: &v1.AnyValue_StringValue{StringValue: "def"}}},
+			{Key: "count", Value: &v1.AnyValue{Value: &v1.AnyValue_IntValue{IntValue: 3}}},
+			{Key: "trace_id", Value: &v1.AnyValue{Value: &v1.AnyValue_StringValue{StringValue: "ABCDEF"}}},
+		},
+		Resource: []*v1.KeyValue{
+			{Key: "service.name", Value: &v1.AnyValue{Value: &v1.AnyValue_StringValue{StringValue: "checkout"}}},
+			{Key: "foo", Value: &v1.AnyValue{Value: &v1.AnyValue_StringValue{StringValue: "abc"}}},
+		},
+		Frames: []*tp.StackFrame{
+			{MethodName: "checkout", ClassName: &className, Variables: []*tp.VariableID{{ID: "1", Name: "total"}}},
+		},
+		VarLookup: map[string]*tp.Variable{
+			"1": {Type: "float", Value: "149.99"},
+		},
+	}
+
+	matches := []string{
+		`{}`,
+		`{ .foo = "def" }`,
+		`{ resource.foo = "abc" }`,
+		`{ resource.service.name = "checkout" }`,
+		`{ .count > 2 }`,
+		`{ duration = 100ns }`,
+		`{ tracepoint = "tp-1" && line = 42 && path =~ ".*checkout.*" }`,
+		`{ method = "checkout" && class = "Cart" && frames = 1 }`,
+		`{ traceID = "00000000000000000000000000abcdef" }`,
+		`{ var.total > 100 }`,
+		`{ .foo = "nope" || .count = 3 }`,
+		`{ .foo = "def" } && { .count = 3 }`,
+	}
+	for _, q := range matches {
+		matcher, err := NewSnapshotMatcher(q)
+		require.NoError(t, err, q)
+
+		metadata, err := matcher.Match(snapshot)
+		require.NoError(t, err, q)
+		require.NotNil(t, metadata, q)
+		assert.Equal(t, "1020304", metadata.SnapshotID, q)
+		assert.Equal(t, "checkout", metadata.ServiceName, q)
+		assert.Equal(t, "/app/checkout.py", metadata.FilePath, q)
+		assert.Equal(t, uint32(42), metadata.LineNo, q)
+		assert.Equal(t, uint64(1500), metadata.StartTimeUnixNano, q)
+		assert.Equal(t, uint64(100), metadata.DurationNano, q)
+	}
+
+	noMatches := []string{
+		`{ .foo = "abc" && resource.foo = "def" }`,
+		`{ .missing = "def" }`,
+		`{ log = "message" }`,
+		`{ spanID != "" }`,
+		`{ var.total < 100 }`,
+		`{ watch.total = 1 }`,
+	}
+	for _, q := range noMatches {
+		matcher, err := NewSnapshotMatcher(q)
+		require.NoError(t, err, q)
+
+		metadata, err := matcher.Match(snapshot)
+		require.NoError(t, err, q)
+		assert.Nil(t, metadata, q)
+	}
+}
+
+func TestSnapshotMatcherUnsupportedQueries(t *testing.T) {
+	for _, q := range []string{
+		`{ .foo = `,
+		`{ line = "42" }`,
+		`{} | by(.foo)`,
+		`{} | count() > 1`,
+		`count_over_time({}[1m])`,
+	} {
+		_, err := NewSnapshotMatcher(q)
+		assert.Error(t, err, q)
+	}
+}
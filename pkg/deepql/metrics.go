@@ -0,0 +1,163 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package deepql
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/intergral/deep/pkg/deeppb"
+)
+
+// IsMetricsQuery returns true if the query is a range function, e.g. count_over_time({}[5m]). The series of metrics
+// queries are built once the results from all shards have been combined and deduplicated, see BuildSeries.
+func IsMetricsQuery(query string) bool {
+	rootExpr, err := Parse(query)
+	if err != nil {
+		return false
+	}
+	return rootExpr.Metrics != nil
+}
+
+// BuildSeries evaluates the range function of a metrics query over the given snapshots and returns one
+// series per by() grouping. The snapshots must have been returned by the Engine for the same query. A nil
+// result is returned if the query is not a metrics query.
+func BuildSeries(searchReq *deeppb.SearchRequest, snapshots []*deeppb.SnapshotSearchMetadata) ([]*deeppb.TimeSeries, error) {
+	rootExpr, err := Parse(searchReq.Query)
+	if err != nil {
+		return nil, err
+	}
+	if rootExpr.Metrics == nil {
+		return nil, nil
+	}
+
+	step := time.Duration(searchReq.Step) * time.Second
+	return rootExpr.Metrics.series(unixSecToNano(searchReq.Start), unixSecToNano(searchReq.End), step, snapshots), nil
+}
+
+// labels returns the values of the by() grouping for the given snapshot
+func (m MetricsAggregate) labels(snapshot Snapshot) (map[string]string, error) {
+	if len(m.by) == 0 || snapshot == nil {
+		return nil, nil
+	}
+
+	labels := make(map[string]string, len(m.by))
+	for _, e := range m.by {
+		val, err := e.execute(snapshot)
+		if err != nil {
+			return nil, err
+		}
+		labels[labelName(e)] = val.EncodeToString(false)
+	}
+	return labels, nil
+}
+
+// series evaluates the range function at every step between start and end. Each snapshot is counted at
+// every evaluation point t where it falls within (t-window, t].
+func (m MetricsAggregate) series(start, end uint64, step time.Duration, snapshots []*deeppb.SnapshotSearchMetadata) []*deeppb.TimeSeries {
+	if step <= 0 {
+		step = m.window
+	}
+	stepNanos := uint64(step)
+	windowNanos := uint64(m.window)
+
+	labels := map[string]map[string]string{}
+	counts := map[string]map[uint64]float64{}
+	for _, s := range snapshots {
+		ts := s.StartTimeUnixNano
+
+		// first evaluation point at or after the snapshot
+		first := start
+		if ts > start {
+			first += (ts - start + stepNanos - 1) / stepNanos * stepNanos
+		}
+		if first > end || first >= ts+windowNanos {
+			continue
+		}
+
+		key := labelsKey(s.SeriesLabels)
+		if _, ok := counts[key]; !ok {
+			labels[key] = s.SeriesLabels
+			counts[key] = map[uint64]float64{}
+		}
+		for t := first; t <= end && t < ts+windowNanos; t += stepNanos {
+			counts[key][t]++
+		}
+	}
+
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	series := make([]*deeppb.TimeSeries, 0, len(keys))
+	for _, key := range keys {
+		timestamps := make([]uint64, 0, len(counts[key]))
+		for t := range counts[key] {
+			timestamps = append(timestamps, t)
+		}
+		sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+
+		samples := make([]*deeppb.Sample, 0, len(timestamps))
+		for _, t := range timestamps {
+			value := counts[key][t]
+			if m.op == metricsAggregateRate {
+				value /= m.window.Seconds()
+			}
+			samples = append(samples, &deeppb.Sample{
+				TimestampMs: int64(t / uint64(time.Millisecond)),
+				Value:       value,
+			})
+		}
+
+		series = append(series, &deeppb.TimeSeries{
+			Labels:  labels[key],
+			Samples: samples,
+		})
+	}
+	return series
+}
+
+// labelName returns the name of the series label for a by() field expression. Attributes use their
+// name, so by(.service) results in the label service.
+func labelName(e FieldExpression) string {
+	if a, ok := e.(Attribute); ok {
+		return a.Name
+	}
+	return e.String()
+}
+
+func labelsKey(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		sb.WriteString(name)
+		sb.WriteString("=")
+		sb.WriteString(strconv.Quote(labels[name]))
+		sb.WriteString(",")
+	}
+	return sb.String()
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package deepql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/intergral/deep/pkg/deeppb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMetricsQuery(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+		err      bool
+	}{
+		{query: `count_over_time({ .foo = "bar" }[5m])`, expected: "count_over_time({ .foo = `bar` }[5m0s])"},
+		{query: `rate({}[30s]) by(.service, resource.region)`, expected: "rate({ true }[30s]) by(.service, resource.region)"},
+		{query: `rate({}[30s]) by(1)`, err: true},
+		{query: `rate({})`, err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.query, func(t *testing.T) {
+			rootExpr, err := Parse(tc.query)
+			if err == nil {
+				err = rootExpr.validate()
+			}
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, rootExpr.Metrics)
+			assert.Equal(t, tc.expected, rootExpr.String())
+			assert.True(t, IsMetricsQuery(tc.query))
+		})
+	}
+
+	assert.False(t, IsMetricsQuery(`{ .foo = "bar" }`))
+}
+
+func TestBuildSeries(t *testing.T) {
+	start := uint32(1000)
+	snapshot := func(offset time.Duration, service string) *deeppb.SnapshotSearchMetadata {
+		return &deeppb.SnapshotSearchMetadata{
+			StartTimeUnixNano: unixSecToNano(start) + uint64(offset),
+			SeriesLabels:      map[string]string{"service": service},
+		}
+	}
+	snapshots := []*deeppb.SnapshotSearchMetadata{
+		snapshot(0, "a"),
+		snapshot(10*time.Second, "a"),
+		snapshot(70*time.Second, "a"),
+		snapshot(30*time.Second, "b"),
+	}
+
+	series, err := BuildSeries(&deeppb.SearchRequest{
+		Query: `count_over_time({}[1m]) by(.service)`,
+		Start: start,
+		End:   start + 120,
+		Step:  60,
+	}, snapshots)
+	require.NoError(t, err)
+
+	ms := func(seconds uint32) int64 { return int64(seconds) * 1000 }
+	assert.Equal(t, []*deeppb.TimeSeries{
+		{
+			Labels: map[string]string{"service": "a"},
+			Samples: []*deeppb.Sample{
+				{TimestampMs: ms(start), Value: 1},
+				{TimestampMs: ms(start + 60), Value: 1},
+				{TimestampMs: ms(start + 120), Value: 1},
+			},
+		},
+		{
+			Labels: map[string]string{"service": "b"},
+			Samples: []*deeppb.Sample{
+				{TimestampMs: ms(start + 60), Value: 1},
+			},
+		},
+	}, series)
+
+	// without by() the engine does not set any labels
+	for _, s := range snapshots {
+		s.SeriesLabels = nil
+	}
+	series, err = BuildSeries(&deeppb.SearchRequest{
+		Query: `rate({}[2m])`,
+		Start: start,
+		End:   start + 120,
+		Step:  120,
+	}, snapshots)
+	require.NoError(t, err)
+	assert.Equal(t, []*deeppb.TimeSeries{
+		{
+			Samples: []*deeppb.Sample{
+				{TimestampMs: ms(start), Value: 1.0 / 120},
+				{TimestampMs: ms(start + 120), Value: 3.0 / 120},
+			},
+		},
+	}, series)
+}
@@ -76,6 +76,10 @@ func ParseIdentifier(s string) (Attribute, error) {
 		return NewScopedAttribute(AttributeScopeResource, false, strings.TrimPrefix(s, "resource.")), nil
 	case strings.HasPrefix(s, "span."):
 		return NewScopedAttribute(AttributeScopeSnapshot, false, strings.TrimPrefix(s, "span.")), nil
+	case strings.HasPrefix(s, "var."):
+		return NewScopedAttribute(AttributeScopeVariable, false, strings.TrimPrefix(s, "var.")), nil
+	case strings.HasPrefix(s, "watch."):
+		return NewScopedAttribute(AttributeScopeWatch, false, strings.TrimPrefix(s, "watch.")), nil
 	default:
 		return Attribute{}, fmt.Errorf("tag name is not valid intrinsic or scoped attribute: %s", s)
 	}
@@ -0,0 +1,171 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+package deepql
+
+import (
+	"time"
+
+	"github.com/intergral/deep/pkg/deeppb"
+)
+
+// CollectsAllSnapshots returns true if the query needs every matching snapshot, rather than a limited set. These are
+// metrics queries and pipelines that group or aggregate, which are evaluated once the results from all shards have
+// been combined and deduplicated.
+func CollectsAllSnapshots(query string) bool {
+	rootExpr, err := Parse(query)
+	if err != nil {
+		return false
+	}
+	_, setPipeline := rootExpr.Pipeline.split()
+	return rootExpr.Metrics != nil || len(setPipeline.Elements) > 0
+}
+
+// EvaluatePipeline evaluates the elements of the pipeline that group or aggregate over the given snapshots, these
+// must have been returned by the Engine for the same query. The Engine only filters the snapshots of each shard, so
+// this must be called once the results of all shards have been combined. The snapshots are returned unchanged if the
+// pipeline does not group or aggregate.
+func EvaluatePipeline(searchReq *deeppb.SearchRequest, snapshots []*deeppb.SnapshotSearchMetadata) ([]*deeppb.SnapshotSearchMetadata, error) {
+	rootExpr, err := Parse(searchReq.Query)
+	if err != nil {
+		return nil, err
+	}
+	_, setPipeline := rootExpr.Pipeline.split()
+	if len(setPipeline.Elements) == 0 {
+		return snapshots, nil
+	}
+
+	attributes := setPipeline.attributes()
+	input := make([]*SnapshotResult, len(snapshots))
+	for i, metadata := range snapshots {
+		input[i] = &SnapshotResult{Snapshot: newPipelineSnapshot(metadata, attributes)}
+	}
+
+	results, err := setPipeline.evaluate(input)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]*deeppb.SnapshotSearchMetadata, len(results))
+	for i, result := range results {
+		metadata := result.Snapshot.(*pipelineSnapshot).metadata
+		output[i] = &deeppb.SnapshotSearchMetadata{
+			SnapshotID:        metadata.SnapshotID,
+			ServiceName:       metadata.ServiceName,
+			FilePath:          metadata.FilePath,
+			LineNo:            metadata.LineNo,
+			StartTimeUnixNano: metadata.StartTimeUnixNano,
+			DurationNano:      metadata.DurationNano,
+			SeriesLabels:      metadata.SeriesLabels,
+			Group:             result.Group,
+		}
+	}
+	return output, nil
+}
+
+// attributes returns the attributes used by the pipeline, by their string form
+func (p Pipeline) attributes() map[string]Attribute {
+	req := &FetchSnapshotRequest{}
+	p.extractConditions(req)
+
+	attributes := make(map[string]Attribute, len(req.Conditions))
+	for _, condition := range req.Conditions {
+		attributes[condition.Attribute.String()] = condition.Attribute
+	}
+	return attributes
+}
+
+// pipelineValues returns the values of the attributes used by the pipeline, so the pipeline can be evaluated once
+// the results of all shards are combined, see EvaluatePipeline
+func pipelineValues(attributes map[string]Attribute, snapshot Snapshot) (map[string]*deeppb.DeepQLValue, error) {
+	values := make(map[string]*deeppb.DeepQLValue, len(attributes))
+	for key, attribute := range attributes {
+		static, err := attribute.execute(snapshot)
+		if err != nil {
+			return nil, err
+		}
+		if static.Type == TypeNil {
+			continue
+		}
+		values[key] = staticToValue(static)
+	}
+	return values, nil
+}
+
+// pipelineSnapshot is a snapshot that only has the values sent by the Engine for the pipeline
+type pipelineSnapshot struct {
+	metadata   *deeppb.SnapshotSearchMetadata
+	attributes map[Attribute]Static
+}
+
+func newPipelineSnapshot(metadata *deeppb.SnapshotSearchMetadata, attributes map[string]Attribute) *pipelineSnapshot {
+	snapshot := &pipelineSnapshot{metadata: metadata, attributes: make(map[Attribute]Static, len(metadata.PipelineValues))}
+	for key, value := range metadata.PipelineValues {
+		if attribute, ok := attributes[key]; ok {
+			snapshot.attributes[attribute] = staticFromValue(value)
+		}
+	}
+	return snapshot
+}
+
+func (s *pipelineSnapshot) Attributes() map[Attribute]Static {
+	return s.attributes
+}
+
+func (s *pipelineSnapshot) ID() []byte {
+	return []byte(s.metadata.SnapshotID)
+}
+
+func (s *pipelineSnapshot) StartTimeUnixNanos() uint64 {
+	return s.metadata.StartTimeUnixNano
+}
+
+func (s *pipelineSnapshot) EndTimeUnixNanos() uint64 {
+	return s.metadata.StartTimeUnixNano + s.metadata.DurationNano
+}
+
+func staticToValue(static Static) *deeppb.DeepQLValue {
+	value := &deeppb.DeepQLValue{Type: int32(static.Type)}
+	switch static.Type {
+	case TypeInt:
+		value.IntValue = int64(static.N)
+	case TypeDuration:
+		value.IntValue = int64(static.D)
+	case TypeFloat:
+		value.FloatValue = static.F
+	case TypeString:
+		value.StringValue = static.S
+	case TypeBoolean:
+		value.BoolValue = static.B
+	}
+	return value
+}
+
+func staticFromValue(value *deeppb.DeepQLValue) Static {
+	switch StaticType(value.Type) {
+	case TypeInt:
+		return NewStaticInt(int(value.IntValue))
+	case TypeDuration:
+		return NewStaticDuration(time.Duration(value.IntValue))
+	case TypeFloat:
+		return NewStaticFloat(value.FloatValue)
+	case TypeString:
+		return NewStaticString(value.StringValue)
+	case TypeBoolean:
+		return NewStaticBool(value.BoolValue)
+	}
+	return NewStaticNil()
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package deepql
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/intergral/deep/pkg/deeppb"
+	"github.com/intergral/deep/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockIterator struct {
+	results []*SnapshotResult
+}
+
+func (m *mockIterator) Next(context.Context) (*SnapshotResult, error) {
+	if len(m.results) == 0 {
+		return nil, io.EOF
+	}
+	result := m.results[0]
+	m.results = m.results[1:]
+	return result, nil
+}
+
+func (m *mockIterator) Close() {}
+
+// mockShard returns a fetcher that filters the given results, as the storage layer does
+func mockShard(results ...*SnapshotResult) SnapshotResultFetcher {
+	return NewSnapshotResultFetcherWrapper(func(_ context.Context, req FetchSnapshotRequest) (FetchSnapshotResponse, error) {
+		var filtered []*SnapshotResult
+		for _, result := range results {
+			evaluated, err := req.Filter(result)
+			if err != nil {
+				return FetchSnapshotResponse{}, err
+			}
+			filtered = append(filtered, evaluated...)
+		}
+		return FetchSnapshotResponse{Results: &mockIterator{results: filtered}}, nil
+	})
+}
+
+func TestEvaluatePipelineAcrossShards(t *testing.T) {
+	shards := []SnapshotResultFetcher{
+		mockShard(newMockResult("1", "a", 1), newMockResult("2", "b", 1)),
+		mockShard(newMockResult("3", "a", 1), newMockResult("4", "a", 2)),
+	}
+
+	tests := []struct {
+		query    string
+		expected map[string]map[string]string
+	}{
+		{
+			query:    "{ .line = 1 } | count() = 3",
+			expected: map[string]map[string]string{"1": nil, "2": nil, "3": nil},
+		},
+		{
+			query:    "{ .line = 1 } | count() = 1",
+			expected: map[string]map[string]string{},
+		},
+		{
+			query: "{ } | by(resource.service) | count() > 1",
+			expected: map[string]map[string]string{
+				"1": {"service": "a"},
+				"3": {"service": "a"},
+				"4": {"service": "a"},
+			},
+		},
+	}
+
+	e := NewEngine()
+	for _, tc := range tests {
+		t.Run(tc.query, func(t *testing.T) {
+			req := &deeppb.SearchRequest{Query: tc.query}
+			require.True(t, CollectsAllSnapshots(tc.query))
+
+			var combined []*deeppb.SnapshotSearchMetadata
+			for _, shard := range shards {
+				res, err := e.Execute(context.Background(), req, shard)
+				require.NoError(t, err)
+				combined = append(combined, res.Snapshots...)
+			}
+
+			snapshots, err := EvaluatePipeline(req, combined)
+			require.NoError(t, err)
+
+			actual := map[string]map[string]string{}
+			for _, snapshot := range snapshots {
+				assert.Nil(t, snapshot.PipelineValues)
+				actual[string(snapshot.SnapshotID)] = snapshot.Group
+			}
+			expected := map[string]map[string]string{}
+			for id, group := range tc.expected {
+				expected[util.SnapshotIDToHexString([]byte(id))] = group
+			}
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestEvaluatePipelineWithoutSetPipeline(t *testing.T) {
+	snapshots := []*deeppb.SnapshotSearchMetadata{{SnapshotID: "1"}, {SnapshotID: "2"}}
+
+	assert.False(t, CollectsAllSnapshots("{ .line = 1 } | { .line > 0 }"))
+	evaluated, err := EvaluatePipeline(&deeppb.SearchRequest{Query: "{ .line = 1 } | { .line > 0 }"}, snapshots)
+	require.NoError(t, err)
+	assert.Equal(t, snapshots, evaluated)
+}
+
+func TestExecuteRespectsLimit(t *testing.T) {
+	shard := mockShard(newMockResult("1", "a", 1), newMockResult("2", "a", 1), newMockResult("3", "a", 1))
+
+	res, err := NewEngine().Execute(context.Background(), &deeppb.SearchRequest{Query: "{ } | count() > 0", Limit: 2}, shard)
+	require.NoError(t, err)
+	assert.Len(t, res.Snapshots, 2)
+}
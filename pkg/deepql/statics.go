@@ -20,6 +20,7 @@ package deepql
 import (
 	"fmt"
 	"math"
+	"regexp"
 	"time"
 )
 
@@ -121,6 +122,9 @@ type Static struct {
 	S    string
 	B    bool
 	D    time.Duration
+
+	// regex is the compiled form of S when the static is the pattern of a regex operation, see newBinaryOperation
+	regex *regexp.Regexp
 }
 
 func (Static) __fieldExpression() {}
@@ -138,6 +142,14 @@ func (s Static) impliedType() StaticType {
 	return s.Type
 }
 
+// matchRegex returns true if the value matches the pattern held by this static
+func (s Static) matchRegex(value string) (bool, error) {
+	if s.regex != nil {
+		return s.regex.MatchString(value), nil
+	}
+	return regexp.MatchString(s.S, value)
+}
+
 func (s Static) Equals(other Static) bool {
 	// if they are different number types. compare them as floats. however, if they are the same type just fall through to
 	// a normal comparison which should be more efficient
@@ -17,7 +17,10 @@
 
 package deepql
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 type Operands []Static
 
@@ -27,6 +30,20 @@ type Condition struct {
 	Operands  Operands
 }
 
+// Matches returns true if the value satisfies the condition. Conditions with OpNone only fetch the value, so they
+// match any value.
+func (c Condition) Matches(value Static) (bool, error) {
+	if c.Op == OpNone {
+		return true, nil
+	}
+
+	if len(c.Operands) != 1 {
+		return false, fmt.Errorf("operation %v must have exactly 1 argument", c.Op)
+	}
+
+	return binOp(c.Op, value, c.Operands[0])
+}
+
 // FilterSnapshots is a hint that allows the calling code to filter down snapshot to only
 // those that metadata needs to be retrieved for. If the returned snapshots have no
 // snapshots it is discarded and will not appear in FetchSnapshotResponse. The bool
@@ -51,6 +68,8 @@ type SnapshotResult struct {
 	// these fields are actually used by the engine to evaluate queries
 	Scalar   Static
 	Snapshot Snapshot
+	// Group holds the values of the by() operations this snapshot has been grouped by
+	Group map[string]string
 
 	SnapshotID         []byte
 	ServiceName        string
@@ -70,6 +89,7 @@ func (s *SnapshotResult) clone() *SnapshotResult {
 		StartTimeUnixNanos: s.StartTimeUnixNanos,
 		DurationNanos:      s.DurationNanos,
 		Snapshot:           s.Snapshot, // we're not deep cloning into the snapshots themselves
+		Group:              s.Group,
 	}
 }
 
@@ -0,0 +1,355 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package deepql
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TargetingExpr is a snapshot filter that is evaluated against the resource attributes of a client, rather than
+// against a snapshot. It is used to decide which clients should receive a tracepoint.
+//
+// e.g. { resource.k8s.pod.name =~ "^checkout-.*" && resource.deployment != "canary" && resource.version >= 2.3 }
+//
+// Dotted versions are compared by each part, so 2.10 and 2.10.1 are both after 2.3. A version with a trailing zero must
+// be quoted, e.g. resource.version >= "2.10", as the number 2.10 is 2.1.
+//
+// Sets can be expressed with either an or-chain { resource.env = "prod" || resource.env = "staging" } or an anchored
+// regex { resource.env =~ "^(prod|staging)$" }.
+type TargetingExpr struct {
+	expression FieldExpression
+	regexes    map[string]*regexp.Regexp
+}
+
+// ParseTargeting will parse and validate the given targeting expression.
+func ParseTargeting(s string) (*TargetingExpr, error) {
+	root, err := Parse(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(root.Pipeline.Elements) != 1 {
+		return nil, fmt.Errorf("targeting expression must be a single snapshot filter: %s", root.String())
+	}
+
+	filter, ok := root.Pipeline.Elements[0].(SnapshotFilter)
+	if !ok {
+		return nil, fmt.Errorf("targeting expression must be a single snapshot filter: %s", root.String())
+	}
+
+	t := &TargetingExpr{
+		expression: filter.Expression,
+		regexes:    map[string]*regexp.Regexp{},
+	}
+
+	if err := t.validate(filter.Expression); err != nil {
+		return nil, err
+	}
+
+	if typ := filter.Expression.impliedType(); typ != TypeAttribute && typ != TypeBoolean {
+		return nil, fmt.Errorf("targeting expression must resolve to a boolean: %s", filter.String())
+	}
+
+	return t, nil
+}
+
+func (t *TargetingExpr) String() string {
+	return newSnapshotFilter(t.expression).String()
+}
+
+// Matches returns true if the given resource attributes satisfy the expression. Attributes that are not present
+// on the resource resolve to nil, so { resource.canary = nil } can be used to test for absence.
+func (t *TargetingExpr) Matches(resource map[Attribute]Static) (bool, error) {
+	result, err := t.execute(t.expression, resource)
+	if err != nil {
+		return false, err
+	}
+
+	return result.Type == TypeBoolean && result.B, nil
+}
+
+// validate ensures the expression only references resource attributes and that any regex can be compiled.
+// We do not use the query validation here as targeting allows nil and !~ which are not supported by the storage layer.
+func (t *TargetingExpr) validate(e FieldExpression) error {
+	switch expr := e.(type) {
+	case BinaryOperation:
+		if err := t.validate(expr.LHS); err != nil {
+			return err
+		}
+		if err := t.validate(expr.RHS); err != nil {
+			return err
+		}
+
+		lhsT := expr.LHS.impliedType()
+		rhsT := expr.RHS.impliedType()
+		if !lhsT.isMatchingOperand(rhsT) {
+			return fmt.Errorf("binary operations must operate on the same type: %s", expr.String())
+		}
+
+		if !expr.Op.binaryTypesValid(lhsT, rhsT) && !isVersionComparison(expr) {
+			return fmt.Errorf("illegal operation for the given types: %s", expr.String())
+		}
+
+		if expr.Op == OpRegex || expr.Op == OpNotRegex {
+			pattern, ok := expr.RHS.(Static)
+			if !ok || pattern.Type != TypeString {
+				return fmt.Errorf("regex must be a string: %s", expr.String())
+			}
+			compiled, err := regexp.Compile(pattern.S)
+			if err != nil {
+				return fmt.Errorf("invalid regex %s: %w", expr.String(), err)
+			}
+			t.regexes[pattern.S] = compiled
+		}
+	case UnaryOperation:
+		if err := t.validate(expr.Expression); err != nil {
+			return err
+		}
+
+		typ := expr.Expression.impliedType()
+		if typ != TypeAttribute && !expr.Op.unaryTypesValid(typ) {
+			return fmt.Errorf("illegal operation for the given type: %s", expr.String())
+		}
+	case Attribute:
+		if expr.Intrinsic != IntrinsicNone {
+			return fmt.Errorf("intrinsic %s cannot be used in targeting", expr.String())
+		}
+		if expr.Parent || (expr.Scope != AttributeScopeNone && expr.Scope != AttributeScopeResource) {
+			return fmt.Errorf("targeting can only reference resource attributes: %s", expr.String())
+		}
+	case Static:
+	default:
+		return newUnsupportedError(fmt.Sprintf("targeting expression (%v)", e))
+	}
+
+	return nil
+}
+
+func (t *TargetingExpr) execute(e FieldExpression, resource map[Attribute]Static) (Static, error) {
+	switch expr := e.(type) {
+	case Attribute:
+		return t.resolve(expr, resource), nil
+	case Static:
+		return expr, nil
+	case UnaryOperation:
+		static, err := t.execute(expr.Expression, resource)
+		if err != nil {
+			return NewStaticNil(), err
+		}
+		return newUnaryOperation(expr.Op, static).execute(nil)
+	case BinaryOperation:
+		lhs, err := t.execute(expr.LHS, resource)
+		if err != nil {
+			return NewStaticNil(), err
+		}
+
+		// short circuit boolean operators
+		if expr.Op == OpAnd && !(lhs.Type == TypeBoolean && lhs.B) {
+			return NewStaticBool(false), nil
+		}
+		if expr.Op == OpOr && lhs.Type == TypeBoolean && lhs.B {
+			return NewStaticBool(true), nil
+		}
+
+		rhs, err := t.execute(expr.RHS, resource)
+		if err != nil {
+			return NewStaticNil(), err
+		}
+
+		switch expr.Op {
+		case OpRegex, OpNotRegex:
+			matched := t.regexes[rhs.S].MatchString(asString(lhs))
+			return NewStaticBool(matched == (expr.Op == OpRegex)), nil
+		case OpGreater, OpGreaterEqual, OpLess, OpLessEqual:
+			// dotted versions such as 2.10.1 are compared by each part, as they do not order as numbers
+			if cmp, ok := compareVersions(lhs, rhs); ok {
+				return NewStaticBool(compareResult(expr.Op, cmp)), nil
+			}
+			// resource attributes are most often sent as strings, so allow them to be compared numerically
+			lhs, rhs = asNumeric(lhs), asNumeric(rhs)
+		case OpEqual, OpNotEqual:
+			// a missing attribute is never equal to a value, so != should match it
+			if lhs.Type == TypeNil || rhs.Type == TypeNil {
+				return NewStaticBool(lhs.Equals(rhs) == (expr.Op == OpEqual)), nil
+			}
+			if lhs.Type == TypeString && rhs.Type.isNumeric() || rhs.Type == TypeString && lhs.Type.isNumeric() {
+				lhs, rhs = asNumeric(lhs), asNumeric(rhs)
+			}
+		}
+
+		return newBinaryOperation(expr.Op, lhs, rhs).execute(nil)
+	}
+
+	return NewStaticNil(), newUnsupportedError(fmt.Sprintf("targeting expression (%v)", e))
+}
+
+func (t *TargetingExpr) resolve(a Attribute, resource map[Attribute]Static) Static {
+	if static, ok := resource[a]; ok {
+		return static
+	}
+
+	// unscoped attributes will match resource attributes of the same name
+	if a.Scope == AttributeScopeNone {
+		if static, ok := resource[NewScopedAttribute(AttributeScopeResource, false, a.Name)]; ok {
+			return static
+		}
+	}
+
+	return NewStaticNil()
+}
+
+// asNumeric will convert string statics that hold a number into a float static, all other statics are returned as is
+func asNumeric(s Static) Static {
+	if s.Type != TypeString {
+		return s
+	}
+
+	f, err := strconv.ParseFloat(s.S, 64)
+	if err != nil {
+		return s
+	}
+
+	return NewStaticFloat(f)
+}
+
+var (
+	// versionPattern matches dotted versions such as 2.10 or v2.10.1
+	versionPattern = regexp.MustCompile(`^v?[0-9]+(\.[0-9]+)+$`)
+	// numericPattern matches a whole number, which is a version with a single part
+	numericPattern = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// compareVersions compares the statics as dotted versions, parts that are missing are 0 so 2.10 = 2.10.0. The
+// comparison is only made if one side is a string version, the other side can be a version or a number. Returns
+// false if the statics are not versions.
+func compareVersions(lhs Static, rhs Static) (int, bool) {
+	if !isVersion(lhs) && !isVersion(rhs) {
+		return 0, false
+	}
+
+	lhsParts, ok := versionParts(lhs)
+	if !ok {
+		return 0, false
+	}
+	rhsParts, ok := versionParts(rhs)
+	if !ok {
+		return 0, false
+	}
+
+	for i := 0; i < len(lhsParts) || i < len(rhsParts); i++ {
+		var l, r uint64
+		if i < len(lhsParts) {
+			l = lhsParts[i]
+		}
+		if i < len(rhsParts) {
+			r = rhsParts[i]
+		}
+		if l != r {
+			if l < r {
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+	return 0, true
+}
+
+// isVersionComparison returns true if the operation orders an attribute against a version string, e.g. >= "2.10.1"
+func isVersionComparison(expr BinaryOperation) bool {
+	switch expr.Op {
+	case OpGreater, OpGreaterEqual, OpLess, OpLessEqual:
+	default:
+		return false
+	}
+
+	if _, ok := expr.LHS.(Attribute); ok {
+		static, ok := expr.RHS.(Static)
+		return ok && isVersion(static)
+	}
+	if _, ok := expr.RHS.(Attribute); ok {
+		static, ok := expr.LHS.(Static)
+		return ok && isVersion(static)
+	}
+	return false
+}
+
+func isVersion(s Static) bool {
+	return s.Type == TypeString && versionPattern.MatchString(s.S)
+}
+
+// versionParts splits a version, or a number, into its parts
+func versionParts(s Static) ([]uint64, bool) {
+	var version string
+	switch s.Type {
+	case TypeString:
+		version = strings.TrimPrefix(s.S, "v")
+	case TypeInt:
+		version = strconv.Itoa(s.N)
+	case TypeFloat:
+		version = strconv.FormatFloat(s.F, 'f', -1, 64)
+	default:
+		return nil, false
+	}
+
+	if !versionPattern.MatchString(version) && !numericPattern.MatchString(version) {
+		return nil, false
+	}
+
+	split := strings.Split(version, ".")
+	parts := make([]uint64, len(split))
+	for i, part := range split {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
+
+// compareResult returns the result of the comparison operator, given the result of comparing the two sides
+func compareResult(op Operator, cmp int) bool {
+	switch op {
+	case OpGreater:
+		return cmp > 0
+	case OpGreaterEqual:
+		return cmp >= 0
+	case OpLess:
+		return cmp < 0
+	case OpLessEqual:
+		return cmp <= 0
+	}
+	return false
+}
+
+// asString will convert the static into a string that can be matched with a regex
+func asString(s Static) string {
+	switch s.Type {
+	case TypeString:
+		return s.S
+	case TypeNil:
+		return ""
+	case TypeFloat:
+		return strconv.FormatFloat(s.F, 'f', -1, 64)
+	}
+
+	return s.EncodeToString(false)
+}
@@ -0,0 +1,215 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package snapshot
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/intergral/deep/pkg/deeppb"
+	deep_tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/intergral/deep/pkg/util"
+)
+
+const (
+	DiffAdded   = "added"
+	DiffRemoved = "removed"
+	DiffChanged = "changed"
+)
+
+// diffVariable is a flattened variable, keyed by its path
+type diffVariable struct {
+	value *deeppb.DiffValue
+	// variables with children usually have a summary or identity as the value, e.g. 'User@1234', which changes on
+	// every capture. These are compared on their type, and their children are compared on their own.
+	composite bool
+}
+
+// Diff compares the variables, frames and watch results of snapshot a with snapshot b. The variable graphs are
+// walked from the frame variables and watch results, so each variable is compared by the path used to reach it.
+func Diff(a, b *deep_tp.Snapshot) *deeppb.SnapshotDiffResponse {
+	resp := &deeppb.SnapshotDiffResponse{
+		SnapshotA: util.SnapshotIDToHexString(a.ID),
+		SnapshotB: util.SnapshotIDToHexString(b.ID),
+	}
+
+	resp.Frames = diffFrames(a.Frames, b.Frames)
+
+	aVars, aWatches := flattenSnapshot(a)
+	bVars, bWatches := flattenSnapshot(b)
+
+	for _, path := range unionKeys(aVars, bVars) {
+		change := diffChange(aVars, bVars, path)
+		if change == "" {
+			continue
+		}
+		resp.Variables = append(resp.Variables, &deeppb.VariableDiff{
+			Path:   path,
+			Change: change,
+			A:      aVars[path].value,
+			B:      bVars[path].value,
+		})
+	}
+
+	for _, expression := range unionKeys(aWatches, bWatches) {
+		change := diffChange(aWatches, bWatches, expression)
+		if change == "" {
+			continue
+		}
+		resp.Watches = append(resp.Watches, &deeppb.WatchDiff{
+			Expression: expression,
+			Change:     change,
+			A:          aWatches[expression].value,
+			B:          bWatches[expression].value,
+		})
+	}
+
+	return resp
+}
+
+func diffFrames(a, b []*deep_tp.StackFrame) []*deeppb.FrameDiff {
+	var diffs []*deeppb.FrameDiff
+	for i := 0; i < len(a) || i < len(b); i++ {
+		diff := &deeppb.FrameDiff{Index: uint32(i)}
+		switch {
+		case i >= len(a):
+			diff.Change = DiffAdded
+			diff.B = describeFrame(b[i])
+		case i >= len(b):
+			diff.Change = DiffRemoved
+			diff.A = describeFrame(a[i])
+		default:
+			diff.A = describeFrame(a[i])
+			diff.B = describeFrame(b[i])
+			if diff.A == diff.B {
+				continue
+			}
+			diff.Change = DiffChanged
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
+
+func describeFrame(frame *deep_tp.StackFrame) string {
+	method := frame.MethodName
+	if frame.GetClassName() != "" {
+		method = frame.GetClassName() + "." + method
+	}
+	return fmt.Sprintf("%s (%s:%d)", method, frame.FileName, frame.LineNumber)
+}
+
+// flattenSnapshot returns the variables of the snapshot keyed by path, and the watch results keyed by expression
+func flattenSnapshot(snapshot *deep_tp.Snapshot) (map[string]diffVariable, map[string]diffVariable) {
+	variables := map[string]diffVariable{}
+	for i, frame := range snapshot.Frames {
+		flattenVariables(snapshot.VarLookup, fmt.Sprintf("frames[%d]", i), frame.Variables, map[string]struct{}{}, variables)
+	}
+
+	watches := map[string]diffVariable{}
+	for _, watch := range snapshot.Watches {
+		if _, ok := watches[watch.Expression]; ok {
+			continue
+		}
+
+		if watch.GetErrorResult() != "" {
+			watches[watch.Expression] = diffVariable{value: &deeppb.DiffValue{Type: "error", Value: watch.GetErrorResult()}}
+			continue
+		}
+
+		result := watch.GetGoodResult()
+		if result == nil {
+			continue
+		}
+		watches[watch.Expression] = lookupVariable(snapshot.VarLookup, result)
+		flattenVariables(snapshot.VarLookup, fmt.Sprintf("watch[%s]", watch.Expression), lookupChildren(snapshot.VarLookup, result), map[string]struct{}{result.ID: {}}, variables)
+	}
+
+	return variables, watches
+}
+
+// flattenVariables adds the variables, and their children, to out. onPath holds the ids of the variables that
+// lead to these, so references back up the graph are not followed.
+func flattenVariables(lookup map[string]*deep_tp.Variable, prefix string, ids []*deep_tp.VariableID, onPath map[string]struct{}, out map[string]diffVariable) {
+	for _, id := range ids {
+		path := prefix + "." + id.Name
+		if _, ok := out[path]; ok {
+			continue
+		}
+		out[path] = lookupVariable(lookup, id)
+
+		if _, ok := onPath[id.ID]; ok {
+			continue
+		}
+		onPath[id.ID] = struct{}{}
+		flattenVariables(lookup, path, lookupChildren(lookup, id), onPath, out)
+		delete(onPath, id.ID)
+	}
+}
+
+func lookupVariable(lookup map[string]*deep_tp.Variable, id *deep_tp.VariableID) diffVariable {
+	variable, ok := lookup[id.ID]
+	if !ok || variable == nil {
+		return diffVariable{value: &deeppb.DiffValue{}}
+	}
+	return diffVariable{
+		value:     &deeppb.DiffValue{Type: variable.Type, Value: variable.Value},
+		composite: len(variable.Children) > 0,
+	}
+}
+
+func lookupChildren(lookup map[string]*deep_tp.Variable, id *deep_tp.VariableID) []*deep_tp.VariableID {
+	variable, ok := lookup[id.ID]
+	if !ok || variable == nil {
+		return nil
+	}
+	return variable.Children
+}
+
+// diffChange returns how key changed from a to b, or an empty string if it has not changed
+func diffChange(a, b map[string]diffVariable, key string) string {
+	aVar, inA := a[key]
+	bVar, inB := b[key]
+	switch {
+	case !inA:
+		return DiffAdded
+	case !inB:
+		return DiffRemoved
+	case aVar.value.Type != bVar.value.Type:
+		return DiffChanged
+	case aVar.composite && bVar.composite:
+		return ""
+	case aVar.value.Value != bVar.value.Value:
+		return DiffChanged
+	}
+	return ""
+}
+
+func unionKeys(a, b map[string]diffVariable) []string {
+	keys := make([]string, 0, len(a))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
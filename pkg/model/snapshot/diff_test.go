@@ -0,0 +1,126 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package snapshot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/intergral/deep/pkg/deeppb"
+	deep_tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+)
+
+func diffTestSnapshot(id byte, line uint32, total, status string, withToken bool, watch *deep_tp.WatchResult) *deep_tp.Snapshot {
+	className := "Checkout"
+	userChildren := []*deep_tp.VariableID{{ID: "2", Name: "name"}, {ID: "1", Name: "self"}}
+	if withToken {
+		userChildren = append(userChildren, &deep_tp.VariableID{ID: "5", Name: "token"})
+	}
+
+	snapshot := &deep_tp.Snapshot{
+		ID: []byte{id},
+		Frames: []*deep_tp.StackFrame{
+			{
+				FileName:   "checkout.py",
+				MethodName: "pay",
+				ClassName:  &className,
+				LineNumber: line,
+				Variables: []*deep_tp.VariableID{
+					{ID: "1", Name: "user"},
+					{ID: "3", Name: "total"},
+					{ID: "4", Name: "status"},
+				},
+			},
+			{FileName: "main.py", MethodName: "main", LineNumber: 10},
+		},
+		VarLookup: map[string]*deep_tp.Variable{
+			// the value of the user changes, but as it has children only the type is compared
+			"1": {Type: "User", Value: "User@" + total, Children: userChildren},
+			"2": {Type: "str", Value: "ben"},
+			"3": {Type: "float", Value: total},
+			"4": {Type: "str", Value: status},
+			"5": {Type: "str", Value: "abc"},
+		},
+	}
+	if watch != nil {
+		snapshot.Watches = []*deep_tp.WatchResult{watch}
+	}
+	return snapshot
+}
+
+func TestDiff(t *testing.T) {
+	a := diffTestSnapshot(1, 42, "10.5", "new", false, &deep_tp.WatchResult{
+		Expression: "user.name",
+		Result:     &deep_tp.WatchResult_GoodResult{GoodResult: &deep_tp.VariableID{ID: "2", Name: "user.name"}},
+	})
+	b := diffTestSnapshot(2, 43, "12.0", "new", true, &deep_tp.WatchResult{
+		Expression: "user.name",
+		Result:     &deep_tp.WatchResult_ErrorResult{ErrorResult: "NameError"},
+	})
+	b.Frames = b.Frames[:1]
+
+	diff := Diff(a, b)
+
+	assert.Equal(t, "1", diff.SnapshotA)
+	assert.Equal(t, "2", diff.SnapshotB)
+
+	assert.Equal(t, []*deeppb.VariableDiff{
+		{Path: "frames[0].total", Change: DiffChanged, A: &deeppb.DiffValue{Type: "float", Value: "10.5"}, B: &deeppb.DiffValue{Type: "float", Value: "12.0"}},
+		{Path: "frames[0].user.token", Change: DiffAdded, B: &deeppb.DiffValue{Type: "str", Value: "abc"}},
+	}, diff.Variables)
+
+	assert.Equal(t, []*deeppb.FrameDiff{
+		{Index: 0, Change: DiffChanged, A: "Checkout.pay (checkout.py:42)", B: "Checkout.pay (checkout.py:43)"},
+		{Index: 1, Change: DiffRemoved, A: "main (main.py:10)"},
+	}, diff.Frames)
+
+	assert.Equal(t, []*deeppb.WatchDiff{
+		{Expression: "user.name", Change: DiffChanged, A: &deeppb.DiffValue{Type: "str", Value: "ben"}, B: &deeppb.DiffValue{Type: "error", Value: "NameError"}},
+	}, diff.Watches)
+}
+
+func TestDiffSameSnapshot(t *testing.T) {
+	a := diffTestSnapshot(1, 42, "10.5", "new", true, nil)
+
+	diff := Diff(a, a)
+	assert.Empty(t, diff.Variables)
+	assert.Empty(t, diff.Frames)
+	assert.Empty(t, diff.Watches)
+}
+
+func TestDiffWatchChildren(t *testing.T) {
+	watch := func(id string) *deep_tp.WatchResult {
+		return &deep_tp.WatchResult{
+			Expression: "user",
+			Result:     &deep_tp.WatchResult_GoodResult{GoodResult: &deep_tp.VariableID{ID: id, Name: "user"}},
+		}
+	}
+	a := diffTestSnapshot(1, 42, "10.5", "new", false, watch("1"))
+	b := diffTestSnapshot(2, 42, "10.5", "paid", false, watch("1"))
+	b.VarLookup["2"] = &deep_tp.Variable{Type: "str", Value: "bob"}
+
+	diff := Diff(a, b)
+
+	assert.Equal(t, []*deeppb.VariableDiff{
+		{Path: "frames[0].status", Change: DiffChanged, A: &deeppb.DiffValue{Type: "str", Value: "new"}, B: &deeppb.DiffValue{Type: "str", Value: "paid"}},
+		{Path: "frames[0].user.name", Change: DiffChanged, A: &deeppb.DiffValue{Type: "str", Value: "ben"}, B: &deeppb.DiffValue{Type: "str", Value: "bob"}},
+		{Path: "watch[user].name", Change: DiffChanged, A: &deeppb.DiffValue{Type: "str", Value: "ben"}, B: &deeppb.DiffValue{Type: "str", Value: "bob"}},
+	}, diff.Variables)
+	assert.Empty(t, diff.Watches)
+}
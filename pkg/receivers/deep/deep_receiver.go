@@ -25,6 +25,8 @@ import (
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/gogo/protobuf/proto"
+	"github.com/intergral/deep/pkg/deeppb"
+	deeppb_poll "github.com/intergral/deep/pkg/deeppb/poll/v1"
 	"github.com/intergral/deep/pkg/receivers/config/configgrpc"
 	"github.com/intergral/deep/pkg/receivers/config/confignet"
 	"github.com/intergral/deep/pkg/receivers/types"
@@ -47,13 +49,17 @@ type DeepConfig struct {
 type deepReceiver struct {
 	tp.UnimplementedSnapshotServiceServer
 	pb.UnimplementedPollConfigServer
-
-	cfg        *DeepConfig
-	next       types.ProcessSnapshots
-	logger     log.Logger
-	pollNext   types.ProcessPoll
-	serverGRPC *grpc.Server
-	shutdownWG sync.WaitGroup
+	deeppb.UnimplementedPollSubscriptionServer
+	deeppb.UnimplementedTracepointStatusServer
+
+	cfg           *DeepConfig
+	next          types.ProcessSnapshots
+	logger        log.Logger
+	pollNext      types.ProcessPoll
+	subscribeNext types.ProcessSubscribe
+	statusNext    types.ProcessStatus
+	serverGRPC    *grpc.Server
+	shutdownWG    sync.WaitGroup
 }
 
 func CreateConfig(cfg interface{}) (*DeepConfig, error) {
@@ -84,8 +90,8 @@ func CreateConfig(cfg interface{}) (*DeepConfig, error) {
 	return &result, nil
 }
 
-func NewDeepReceiver(cfg *DeepConfig, next types.ProcessSnapshots, pollNext types.ProcessPoll, logger log.Logger) (types.Receiver, error) {
-	return &deepReceiver{cfg: cfg, next: next, pollNext: pollNext, logger: logger}, nil
+func NewDeepReceiver(cfg *DeepConfig, next types.ProcessSnapshots, pollNext types.ProcessPoll, subscribeNext types.ProcessSubscribe, statusNext types.ProcessStatus, logger log.Logger) (types.Receiver, error) {
+	return &deepReceiver{cfg: cfg, next: next, pollNext: pollNext, subscribeNext: subscribeNext, statusNext: statusNext, logger: logger}, nil
 }
 
 func (d *deepReceiver) Start(ctx context.Context, host types.Host) error {
@@ -112,6 +118,8 @@ func (d *deepReceiver) startProtocolServers(host types.Host) error {
 
 		tp.RegisterSnapshotServiceServer(d.serverGRPC, d)
 		pb.RegisterPollConfigServer(d.serverGRPC, d)
+		deeppb.RegisterPollSubscriptionServer(d.serverGRPC, d)
+		deeppb.RegisterTracepointStatusServer(d.serverGRPC, d)
 
 		err = d.startGRPCServer(host)
 	}
@@ -132,6 +140,23 @@ func (d *deepReceiver) Poll(ctx context.Context, pollRequest *pb.PollRequest) (*
 	return d.pollNext(ctx, pollRequest)
 }
 
+// Subscribe lets agents receive tracepoint changes as soon as they happen, agents that do not support this will
+// continue to use Poll
+func (d *deepReceiver) Subscribe(pollRequest *deeppb_poll.PollRequest, stream deeppb.PollSubscription_SubscribeServer) error {
+	if d.cfg.Debug {
+		d.logMessage("Received subscription", pollRequest)
+	}
+	return d.subscribeNext(stream.Context(), pollRequest, stream.Send)
+}
+
+// ReportStatus lets agents tell us if they could install each tracepoint
+func (d *deepReceiver) ReportStatus(ctx context.Context, req *deeppb.ReportStatusRequest) (*deeppb.ReportStatusResponse, error) {
+	if d.cfg.Debug {
+		d.logMessage("Received status", req)
+	}
+	return d.statusNext(ctx, req)
+}
+
 func (d *deepReceiver) startGRPCServer(host types.Host) error {
 	level.Info(d.logger).Log("msg", "Starting GRPC server on endpoint "+d.cfg.Protocols.GRPC.NetAddr.Endpoint)
 
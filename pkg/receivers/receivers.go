@@ -22,13 +22,15 @@ import (
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
+	"github.com/intergral/deep/pkg/deeppb"
+	deeppb_poll "github.com/intergral/deep/pkg/deeppb/poll/v1"
 	"github.com/intergral/deep/pkg/receivers/deep"
 	"github.com/intergral/deep/pkg/receivers/types"
 	pb "github.com/intergral/go-deep-proto/poll/v1"
 	tp "github.com/intergral/go-deep-proto/tracepoint/v1"
 )
 
-func ForConfig(receiverCfg map[string]interface{}, snapshotNext types.ProcessSnapshots, pollNext types.ProcessPoll, logger log.Logger) ([]types.Receiver, error) {
+func ForConfig(receiverCfg map[string]interface{}, snapshotNext types.ProcessSnapshots, pollNext types.ProcessPoll, subscribeNext types.ProcessSubscribe, statusNext types.ProcessStatus, logger log.Logger) ([]types.Receiver, error) {
 	var receivers []types.Receiver
 	for key, cfg := range receiverCfg {
 		level.Info(logger).Log("msg", "Configuring and starting receiver: "+key)
@@ -38,7 +40,7 @@ func ForConfig(receiverCfg map[string]interface{}, snapshotNext types.ProcessSna
 			if err != nil {
 				return nil, err
 			}
-			receiver, err := deep.NewDeepReceiver(deepCfg, wrappedSnapshot("deep", snapshotNext), wrappedPoll("deep", pollNext), logger)
+			receiver, err := deep.NewDeepReceiver(deepCfg, wrappedSnapshot("deep", snapshotNext), wrappedPoll("deep", pollNext), wrappedSubscribe("deep", subscribeNext), wrappedStatus("deep", statusNext), logger)
 			if err != nil {
 				return nil, err
 			}
@@ -56,6 +58,22 @@ func wrappedPoll(receiver string, pollNext types.ProcessPoll) types.ProcessPoll
 	}
 }
 
+// wrappedSubscribe wraps the subscribe process function to attach metrics for all receivers
+func wrappedSubscribe(receiver string, subscribeNext types.ProcessSubscribe) types.ProcessSubscribe {
+	return func(ctx context.Context, pollRequest *deeppb_poll.PollRequest, send types.PollSender) error {
+		value := context.WithValue(ctx, "receiver", receiver)
+		return subscribeNext(value, pollRequest, send)
+	}
+}
+
+// wrappedStatus wraps the status process function to attach metrics for all receivers
+func wrappedStatus(receiver string, statusNext types.ProcessStatus) types.ProcessStatus {
+	return func(ctx context.Context, req *deeppb.ReportStatusRequest) (*deeppb.ReportStatusResponse, error) {
+		value := context.WithValue(ctx, "receiver", receiver)
+		return statusNext(value, req)
+	}
+}
+
 // wrappedSnapshot wraps the snapshot process function to attach metrics for all receivers
 func wrappedSnapshot(receiver string, snapshotNext types.ProcessSnapshots) types.ProcessSnapshots {
 	return func(ctx context.Context, in *tp.Snapshot) (*tp.SnapshotResponse, error) {
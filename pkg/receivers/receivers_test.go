@@ -22,6 +22,9 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/intergral/deep/pkg/deeppb"
+	deeppb_poll "github.com/intergral/deep/pkg/deeppb/poll/v1"
+	"github.com/intergral/deep/pkg/receivers/types"
 	"github.com/intergral/deep/pkg/util/log"
 	pb "github.com/intergral/go-deep-proto/poll/v1"
 	tp "github.com/intergral/go-deep-proto/tracepoint/v1"
@@ -35,12 +38,20 @@ func Poll(ctx context.Context, pollRequest *pb.PollRequest) (*pb.PollResponse, e
 	return nil, nil
 }
 
+func Subscribe(ctx context.Context, pollRequest *deeppb_poll.PollRequest, send types.PollSender) error {
+	return nil
+}
+
+func Status(ctx context.Context, req *deeppb.ReportStatusRequest) (*deeppb.ReportStatusResponse, error) {
+	return nil, nil
+}
+
 func TestLoadDeepReceiver(t *testing.T) {
 	cfg := map[string]interface{}{
 		"deep": nil,
 	}
 
-	receivers, err := ForConfig(cfg, Snapshot, Poll, log.Logger)
+	receivers, err := ForConfig(cfg, Snapshot, Poll, Subscribe, Status, log.Logger)
 	if err != nil {
 		t.Errorf("Error = %v", err)
 		return
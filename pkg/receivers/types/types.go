@@ -20,6 +20,8 @@ package types
 import (
 	"context"
 
+	"github.com/intergral/deep/pkg/deeppb"
+	deeppb_poll "github.com/intergral/deep/pkg/deeppb/poll/v1"
 	pb "github.com/intergral/go-deep-proto/poll/v1"
 	tp "github.com/intergral/go-deep-proto/tracepoint/v1"
 )
@@ -37,4 +39,8 @@ type Receiver interface {
 type (
 	ProcessSnapshots func(ctx context.Context, in *tp.Snapshot) (*tp.SnapshotResponse, error)
 	ProcessPoll      func(ctx context.Context, pollRequest *pb.PollRequest) (*pb.PollResponse, error)
+	// ProcessSubscribe should block until the context is done, calling send each time the tracepoints change
+	ProcessSubscribe func(ctx context.Context, pollRequest *deeppb_poll.PollRequest, send PollSender) error
+	PollSender       func(response *deeppb_poll.PollResponse) error
+	ProcessStatus    func(ctx context.Context, req *deeppb.ReportStatusRequest) (*deeppb.ReportStatusResponse, error)
 )
@@ -75,4 +75,16 @@ func CombineSearchResults(existing *deeppb.SnapshotSearchMetadata, incoming *dee
 	if existing.DurationNano < incoming.DurationNano {
 		existing.DurationNano = incoming.DurationNano
 	}
+
+	if len(existing.SeriesLabels) == 0 {
+		existing.SeriesLabels = incoming.SeriesLabels
+	}
+
+	if len(existing.Group) == 0 {
+		existing.Group = incoming.Group
+	}
+
+	if len(existing.PipelineValues) == 0 {
+		existing.PipelineValues = incoming.PipelineValues
+	}
 }
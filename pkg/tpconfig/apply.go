@@ -0,0 +1,119 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package tpconfig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/intergral/deep/pkg/deeppb"
+	"google.golang.org/grpc"
+)
+
+// Client is the part of the tracepoint config service used to load and apply the declared tracepoints
+type Client interface {
+	LoadTracepoints(ctx context.Context, in *deeppb.LoadTracepointRequest, opts ...grpc.CallOption) (*deeppb.LoadTracepointResponse, error)
+	CreateTracepoint(ctx context.Context, in *deeppb.CreateTracepointRequest, opts ...grpc.CallOption) (*deeppb.CreateTracepointResponse, error)
+	DeleteTracepoint(ctx context.Context, in *deeppb.DeleteTracepointRequest, opts ...grpc.CallOption) (*deeppb.DeleteTracepointResponse, error)
+	UpdateTracepoint(ctx context.Context, in *deeppb.UpdateTracepointRequest, opts ...grpc.CallOption) (*deeppb.UpdateTracepointResponse, error)
+	SetTracepointPaused(ctx context.Context, in *deeppb.SetTracepointPausedRequest, opts ...grpc.CallOption) (*deeppb.SetTracepointPausedResponse, error)
+}
+
+var _ Client = (deeppb.TracepointConfigServiceClient)(nil)
+
+// Load returns all the tracepoints of the tenant, including paused tracepoints
+func Load(ctx context.Context, client Client) (*deeppb.LoadTracepointResponse, error) {
+	return client.LoadTracepoints(ctx, &deeppb.LoadTracepointRequest{IncludePaused: true})
+}
+
+// Apply makes the changes in order, stopping at the first change that fails. The actor is recorded in the audit log
+// of the tracepoint service.
+func Apply(ctx context.Context, client Client, changes []Change, actor string) error {
+	for _, change := range changes {
+		var err error
+		switch change.Action {
+		case ActionCreate:
+			err = create(ctx, client, change.Desired, actor)
+		case ActionUpdate:
+			err = update(ctx, client, change, actor)
+		case ActionDelete:
+			err = remove(ctx, client, change.ID, actor)
+		default:
+			err = fmt.Errorf("unknown action %s", change.Action)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to %s tracepoint %s: %w", change.Action, change.Name, err)
+		}
+	}
+	return nil
+}
+
+func create(ctx context.Context, client Client, tracepoint *Tracepoint, actor string) error {
+	config := tracepoint.toConfig()
+	config.ID = uuid.New().String()
+
+	_, err := client.CreateTracepoint(ctx, &deeppb.CreateTracepointRequest{
+		Tracepoint: config,
+		Limits:     tracepoint.Limits.toLimits(),
+		Actor:      actor,
+		Labels:     tracepoint.labels(),
+		Paused:     tracepoint.Paused,
+	})
+	return err
+}
+
+func update(ctx context.Context, client Client, change Change, actor string) error {
+	config := change.Desired.toConfig()
+	config.ID = change.ID
+
+	// the current limits are kept if none are sent, so send empty limits to remove them
+	limits := change.Desired.Limits.toLimits()
+	if limits == nil {
+		limits = &deeppb.TracepointLimits{}
+	}
+
+	// the labels always include the name, so they are never empty and will replace the current labels
+	_, err := client.UpdateTracepoint(ctx, &deeppb.UpdateTracepointRequest{
+		Tracepoint: config,
+		Version:    change.Version,
+		Limits:     limits,
+		Actor:      actor,
+		Labels:     change.Desired.labels(),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, field := range change.Fields {
+		if field == "paused" {
+			return setPaused(ctx, client, change.ID, change.Desired.Paused, actor)
+		}
+	}
+	return nil
+}
+
+func remove(ctx context.Context, client Client, id string, actor string) error {
+	_, err := client.DeleteTracepoint(ctx, &deeppb.DeleteTracepointRequest{TracepointID: id, Actor: actor})
+	return err
+}
+
+func setPaused(ctx context.Context, client Client, id string, paused bool, actor string) error {
+	_, err := client.SetTracepointPaused(ctx, &deeppb.SetTracepointPausedRequest{TracepointID: id, Paused: paused, Actor: actor})
+	return err
+}
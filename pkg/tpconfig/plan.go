@@ -0,0 +1,171 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package tpconfig
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/intergral/deep/pkg/deeppb"
+)
+
+// Action is the change that is needed to make a tracepoint match the file
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Change is a single change needed to make the tracepoints of the tenant match the file
+type Change struct {
+	Action Action
+	Name   string
+	// ID of the current tracepoint, this is empty when creating a tracepoint
+	ID string
+	// Version of the current tracepoint, so an update is rejected if the tracepoint has changed since the plan
+	Version uint64
+	// Desired is the declared tracepoint, this is nil when deleting a tracepoint
+	Desired *Tracepoint
+	// Fields that have changed for an update
+	Fields []string
+}
+
+func (c Change) String() string {
+	symbol := map[Action]string{ActionCreate: "+", ActionUpdate: "~", ActionDelete: "-"}[c.Action]
+	if len(c.Fields) == 0 {
+		return fmt.Sprintf("%s %s %s", symbol, c.Action, c.Name)
+	}
+	return fmt.Sprintf("%s %s %s (%s)", symbol, c.Action, c.Name, strings.Join(c.Fields, ", "))
+}
+
+// Plan returns the changes needed to make the current tracepoints match the file. Tracepoints that are not in the
+// file are only deleted if prune is set. The changes are ordered by name.
+func Plan(file *File, current *deeppb.LoadTracepointResponse, prune bool) []Change {
+	configs := current.GetResponse().GetResponse()
+	existing := make([]Tracepoint, len(configs))
+	byName := map[string]int{}
+	for i, config := range configs {
+		existing[i] = fromConfig(config, current.Metadata[config.ID])
+		// if more than one tracepoint has the same name, only the first is managed by the file
+		if _, ok := byName[existing[i].Name]; !ok {
+			byName[existing[i].Name] = i
+		}
+	}
+
+	var changes []Change
+	declared := map[string]struct{}{}
+	for i := range file.Tracepoints {
+		desired := &file.Tracepoints[i]
+		declared[desired.Name] = struct{}{}
+
+		index, ok := byName[desired.Name]
+		if !ok {
+			changes = append(changes, Change{Action: ActionCreate, Name: desired.Name, Desired: desired})
+			continue
+		}
+
+		id := configs[index].ID
+		fields := changedFields(desired, &existing[index])
+		if len(fields) == 0 {
+			continue
+		}
+
+		changes = append(changes, Change{
+			Action:  ActionUpdate,
+			Name:    desired.Name,
+			ID:      id,
+			Version: current.Metadata[id].GetVersion(),
+			Desired: desired,
+			Fields:  fields,
+		})
+	}
+
+	if prune {
+		for i, tracepoint := range existing {
+			_, isDeclared := declared[tracepoint.Name]
+			if isDeclared && byName[tracepoint.Name] == i {
+				continue
+			}
+			changes = append(changes, Change{Action: ActionDelete, Name: tracepoint.Name, ID: configs[i].ID})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+
+	return changes
+}
+
+// changedFields returns the names of the fields that are different between the declared and current tracepoint
+func changedFields(desired *Tracepoint, current *Tracepoint) []string {
+	var fields []string
+	if desired.Path != current.Path {
+		fields = append(fields, "path")
+	}
+	if desired.Line != current.Line {
+		fields = append(fields, "line")
+	}
+	if !mapsEqual(declaredArgs(desired.Args, desired.Limits), current.Args) {
+		fields = append(fields, "args")
+	}
+	if !reflect.DeepEqual(desired.Watches, current.Watches) && (len(desired.Watches) != 0 || len(current.Watches) != 0) {
+		fields = append(fields, "watches")
+	}
+	if !mapsEqual(desired.Targeting, current.Targeting) {
+		fields = append(fields, "targeting")
+	}
+	if !mapsEqual(desired.Labels, current.Labels) {
+		fields = append(fields, "labels")
+	}
+	if !limitsEqual(desired.Limits, current.Limits) {
+		fields = append(fields, "limits")
+	}
+	if desired.Paused != current.Paused {
+		fields = append(fields, "paused")
+	}
+	return fields
+}
+
+// mapsEqual compares the maps, treating nil and empty maps as equal
+func mapsEqual(a map[string]string, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
+
+// limitsEqual compares the limits, treating nil and empty limits as equal
+func limitsEqual(a *Limits, b *Limits) bool {
+	if a == nil {
+		a = &Limits{}
+	}
+	if b == nil {
+		b = &Limits{}
+	}
+	return *a == *b
+}
@@ -0,0 +1,244 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package tpconfig is the declarative form of the tracepoints of a tenant, so they can be kept in a repository and
+// applied to the tracepoint service.
+package tpconfig
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/intergral/deep/pkg/deeppb"
+	cp "github.com/intergral/deep/pkg/deeppb/common/v1"
+	tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/intergral/deep/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+// NameLabel is the label used to store the name of a declared tracepoint, as the tracepoint ID is generated when the
+// tracepoint is created
+const NameLabel = "name"
+
+// File is the declared tracepoints of a tenant
+type File struct {
+	Tracepoints []Tracepoint `yaml:"tracepoints"`
+}
+
+// Tracepoint is a declared tracepoint, the name identifies the tracepoint between applies
+type Tracepoint struct {
+	Name      string            `yaml:"name"`
+	Path      string            `yaml:"path"`
+	Line      uint32            `yaml:"line"`
+	Args      map[string]string `yaml:"args,omitempty"`
+	Watches   []string          `yaml:"watches,omitempty"`
+	Targeting map[string]string `yaml:"targeting,omitempty"`
+	Labels    map[string]string `yaml:"labels,omitempty"`
+	Limits    *Limits           `yaml:"limits,omitempty"`
+	Paused    bool              `yaml:"paused,omitempty"`
+}
+
+// Limits are the limits used to retire and rate limit a tracepoint
+type Limits struct {
+	TTL                time.Duration `yaml:"ttl,omitempty"`
+	MaxSnapshots       uint64        `yaml:"max_snapshots,omitempty"`
+	SnapshotsPerSecond float64       `yaml:"snapshots_per_second,omitempty"`
+	BytesPerSecond     uint64        `yaml:"bytes_per_second,omitempty"`
+}
+
+// Parse reads and validates the declared tracepoints, unknown fields are an error so typos are not ignored
+func Parse(data []byte) (*File, error) {
+	file := &File{}
+	err := yaml.UnmarshalStrict(data, file)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]struct{}{}
+	for i, tracepoint := range file.Tracepoints {
+		if tracepoint.Name == "" {
+			return nil, fmt.Errorf("tracepoint %d: name is required", i)
+		}
+		if _, ok := names[tracepoint.Name]; ok {
+			return nil, fmt.Errorf("tracepoint %d: duplicate name %s", i, tracepoint.Name)
+		}
+		names[tracepoint.Name] = struct{}{}
+
+		if tracepoint.Path == "" {
+			return nil, fmt.Errorf("tracepoint %s: path is required", tracepoint.Name)
+		}
+		if _, ok := tracepoint.Labels[NameLabel]; ok {
+			return nil, fmt.Errorf("tracepoint %s: the %s label is set from the tracepoint name", tracepoint.Name, NameLabel)
+		}
+	}
+
+	return file, nil
+}
+
+// Marshal writes the declared tracepoints as yaml
+func Marshal(file *File) ([]byte, error) {
+	return yaml.Marshal(file)
+}
+
+// FromResponse converts the tracepoints loaded from the tracepoint service to their declared form. Tracepoints that
+// were not created from a file are named by their ID.
+func FromResponse(response *deeppb.LoadTracepointResponse) *File {
+	file := &File{Tracepoints: []Tracepoint{}}
+	for _, config := range response.GetResponse().GetResponse() {
+		file.Tracepoints = append(file.Tracepoints, fromConfig(config, response.Metadata[config.ID]))
+	}
+
+	sort.Slice(file.Tracepoints, func(i, j int) bool {
+		return file.Tracepoints[i].Name < file.Tracepoints[j].Name
+	})
+
+	return file
+}
+
+func fromConfig(config *tp.TracePointConfig, metadata *deeppb.TracepointMetadata) Tracepoint {
+	limits := fromLimits(metadata.GetLimits())
+
+	tracepoint := Tracepoint{
+		Name:    nameOf(config, metadata),
+		Path:    config.Path,
+		Line:    config.LineNumber,
+		Args:    declaredArgs(config.Args, limits),
+		Watches: config.Watches,
+		Limits:  limits,
+		Paused:  metadata.GetPaused(),
+	}
+
+	for key, value := range metadata.GetLabels() {
+		if key == NameLabel {
+			continue
+		}
+		if tracepoint.Labels == nil {
+			tracepoint.Labels = map[string]string{}
+		}
+		tracepoint.Labels[key] = value
+	}
+
+	for _, kv := range config.Targeting {
+		if tracepoint.Targeting == nil {
+			tracepoint.Targeting = map[string]string{}
+		}
+		tracepoint.Targeting[kv.Key] = util.StringifyAnyValue(kv.Value)
+	}
+
+	return tracepoint
+}
+
+// nameOf returns the name of the tracepoint from its labels, or the ID if it was not created from a file
+func nameOf(config *tp.TracePointConfig, metadata *deeppb.TracepointMetadata) string {
+	if name, ok := metadata.GetLabels()[NameLabel]; ok && name != "" {
+		return name
+	}
+	return config.ID
+}
+
+func fromLimits(limits *deeppb.TracepointLimits) *Limits {
+	if limits == nil {
+		return nil
+	}
+
+	declared := &Limits{
+		TTL:                time.Duration(limits.TTLSeconds) * time.Second,
+		MaxSnapshots:       limits.MaxSnapshots,
+		SnapshotsPerSecond: limits.SnapshotsPerSecond,
+		BytesPerSecond:     limits.BytesPerSecond,
+	}
+	if *declared == (Limits{}) {
+		return nil
+	}
+	return declared
+}
+
+func (l *Limits) toLimits() *deeppb.TracepointLimits {
+	if l == nil {
+		return nil
+	}
+
+	return &deeppb.TracepointLimits{
+		TTLSeconds:         uint64(l.TTL / time.Second),
+		MaxSnapshots:       l.MaxSnapshots,
+		SnapshotsPerSecond: l.SnapshotsPerSecond,
+		BytesPerSecond:     l.BytesPerSecond,
+	}
+}
+
+// toConfig returns the tracepoint config to send to the tracepoint service, the ID is not set
+func (t *Tracepoint) toConfig() *tp.TracePointConfig {
+	config := &tp.TracePointConfig{
+		Path:       t.Path,
+		LineNumber: t.Line,
+		Args:       map[string]string{},
+		Watches:    t.Watches,
+	}
+	for key, value := range t.Args {
+		config.Args[key] = value
+	}
+
+	keys := make([]string, 0, len(t.Targeting))
+	for key := range t.Targeting {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		config.Targeting = append(config.Targeting, &cp.KeyValue{
+			Key:   key,
+			Value: &cp.AnyValue{Value: &cp.AnyValue_StringValue{StringValue: t.Targeting[key]}},
+		})
+	}
+
+	return config
+}
+
+// labels returns the labels to create the tracepoint with, including the name label
+func (t *Tracepoint) labels() map[string]string {
+	labels := map[string]string{NameLabel: t.Name}
+	for key, value := range t.Labels {
+		labels[key] = value
+	}
+	return labels
+}
+
+// declaredArgs removes the args that the tracepoint service adds from the limits, so they are not seen as a change
+func declaredArgs(args map[string]string, limits *Limits) map[string]string {
+	declared := map[string]string{}
+	for key, value := range args {
+		declared[key] = value
+	}
+
+	if limits != nil {
+		if limits.MaxSnapshots != 0 && declared[util.ArgFireCount] == strconv.FormatUint(limits.MaxSnapshots, 10) {
+			delete(declared, util.ArgFireCount)
+		}
+		if limits.SnapshotsPerSecond != 0 {
+			delete(declared, util.ArgSnapshotRateLimit)
+		}
+		if limits.BytesPerSecond != 0 {
+			delete(declared, util.ArgBytesRateLimit)
+		}
+	}
+
+	if len(declared) == 0 {
+		return nil
+	}
+	return declared
+}
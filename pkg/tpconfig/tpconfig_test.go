@@ -0,0 +1,265 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package tpconfig
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/intergral/deep/pkg/deeppb"
+	pp "github.com/intergral/deep/pkg/deeppb/poll/v1"
+	tp "github.com/intergral/deep/pkg/deeppb/tracepoint/v1"
+	"github.com/intergral/deep/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+const testFile = `
+tracepoints:
+  - name: checkout
+    path: checkout.py
+    line: 12
+    watches:
+      - cart.total
+    targeting:
+      service.name: shop
+    labels:
+      team: payments
+    limits:
+      ttl: 1h
+      max_snapshots: 10
+  - name: login
+    path: auth.py
+    line: 40
+    args:
+      condition: user == None
+    paused: true
+`
+
+func TestParse(t *testing.T) {
+	file, err := Parse([]byte(testFile))
+	require.NoError(t, err)
+	require.Len(t, file.Tracepoints, 2)
+
+	assert.Equal(t, Tracepoint{
+		Name:      "checkout",
+		Path:      "checkout.py",
+		Line:      12,
+		Watches:   []string{"cart.total"},
+		Targeting: map[string]string{"service.name": "shop"},
+		Labels:    map[string]string{"team": "payments"},
+		Limits:    &Limits{TTL: time.Hour, MaxSnapshots: 10},
+	}, file.Tracepoints[0])
+	assert.True(t, file.Tracepoints[1].Paused)
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{
+			name: "unknown field",
+			data: "tracepoints:\n  - name: a\n    path: a.py\n    lines: 1\n",
+			err:  "field lines not found",
+		},
+		{
+			name: "missing name",
+			data: "tracepoints:\n  - path: a.py\n",
+			err:  "tracepoint 0: name is required",
+		},
+		{
+			name: "duplicate name",
+			data: "tracepoints:\n  - name: a\n    path: a.py\n  - name: a\n    path: b.py\n",
+			err:  "tracepoint 1: duplicate name a",
+		},
+		{
+			name: "missing path",
+			data: "tracepoints:\n  - name: a\n",
+			err:  "tracepoint a: path is required",
+		},
+		{
+			name: "name label",
+			data: "tracepoints:\n  - name: a\n    path: a.py\n    labels:\n      name: b\n",
+			err:  "tracepoint a: the name label is set from the tracepoint name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestPlanNoChangesAfterApply(t *testing.T) {
+	file, err := Parse([]byte(testFile))
+	require.NoError(t, err)
+
+	client := newFakeClient()
+	changes := Plan(file, client.load(), false)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "+ create checkout", changes[0].String())
+	assert.Equal(t, "+ create login", changes[1].String())
+
+	require.NoError(t, Apply(context.Background(), client, changes, "test"))
+	assert.Empty(t, Plan(file, client.load(), true))
+
+	// the args added by the limits are not exported
+	exported := FromResponse(client.load())
+	assert.Equal(t, file, exported)
+
+	data, err := Marshal(exported)
+	require.NoError(t, err)
+	parsed, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, file, parsed)
+}
+
+func TestPlanChanges(t *testing.T) {
+	file, err := Parse([]byte(testFile))
+	require.NoError(t, err)
+
+	client := newFakeClient()
+	require.NoError(t, Apply(context.Background(), client, Plan(file, client.load(), false), "test"))
+	client.add("unmanaged", "other.py", nil)
+
+	file.Tracepoints[0].Line = 14
+	file.Tracepoints[0].Limits = nil
+	file.Tracepoints[1].Labels = map[string]string{"team": "auth"}
+	file.Tracepoints[1].Paused = false
+
+	changes := Plan(file, client.load(), false)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "~ update checkout (line, limits)", changes[0].String())
+	assert.Equal(t, "~ update login (labels, paused)", changes[1].String())
+	loginID := changes[1].ID
+
+	changes = Plan(file, client.load(), true)
+	require.Len(t, changes, 3)
+	assert.Equal(t, "- delete unmanaged", changes[2].String())
+
+	require.NoError(t, Apply(context.Background(), client, changes, "test"))
+	assert.Empty(t, Plan(file, client.load(), true))
+	// changing the labels keeps the tracepoint
+	assert.Equal(t, "auth", client.metadata[loginID].Labels["team"])
+	// changing the labels keeps the tracepoint
+	assert.Equal(t, "auth", client.metadata[loginID].Labels["team"])
+
+	current := client.load()
+	require.Len(t, current.Response.Response, 2)
+	for _, config := range current.Response.Response {
+		_, ok := config.Args[util.ArgFireCount]
+		assert.False(t, ok)
+	}
+}
+
+func TestPlanDuplicateNames(t *testing.T) {
+	client := newFakeClient()
+	client.add("a", "a.py", map[string]string{NameLabel: "checkout"})
+	client.add("b", "a.py", map[string]string{NameLabel: "checkout"})
+
+	file := &File{Tracepoints: []Tracepoint{{Name: "checkout", Path: "a.py"}}}
+	assert.Empty(t, Plan(file, client.load(), false))
+
+	changes := Plan(file, client.load(), true)
+	require.Len(t, changes, 1)
+	assert.Equal(t, ActionDelete, changes[0].Action)
+	assert.Equal(t, "b", changes[0].ID)
+}
+
+// fakeClient stores the tracepoints in memory, applying the limits in the same way as the tracepoint service
+type fakeClient struct {
+	order    []string
+	configs  map[string]*tp.TracePointConfig
+	metadata map[string]*deeppb.TracepointMetadata
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		configs:  map[string]*tp.TracePointConfig{},
+		metadata: map[string]*deeppb.TracepointMetadata{},
+	}
+}
+
+func (f *fakeClient) add(id string, path string, labels map[string]string) {
+	f.order = append(f.order, id)
+	f.configs[id] = &tp.TracePointConfig{ID: id, Path: path}
+	f.metadata[id] = &deeppb.TracepointMetadata{Labels: labels, Version: 1}
+}
+
+func (f *fakeClient) load() *deeppb.LoadTracepointResponse {
+	response, _ := f.LoadTracepoints(context.Background(), &deeppb.LoadTracepointRequest{IncludePaused: true})
+	return response
+}
+
+func (f *fakeClient) setLimits(config *tp.TracePointConfig, limits *deeppb.TracepointLimits) {
+	if limits.GetMaxSnapshots() != 0 {
+		config.Args[util.ArgFireCount] = strconv.FormatUint(limits.MaxSnapshots, 10)
+	}
+	f.metadata[config.ID].Limits = limits
+}
+
+func (f *fakeClient) LoadTracepoints(_ context.Context, _ *deeppb.LoadTracepointRequest, _ ...grpc.CallOption) (*deeppb.LoadTracepointResponse, error) {
+	response := &deeppb.LoadTracepointResponse{Response: &pp.PollResponse{}, Metadata: map[string]*deeppb.TracepointMetadata{}}
+	for _, id := range f.order {
+		response.Response.Response = append(response.Response.Response, f.configs[id])
+		response.Metadata[id] = f.metadata[id]
+	}
+	return response, nil
+}
+
+func (f *fakeClient) CreateTracepoint(_ context.Context, in *deeppb.CreateTracepointRequest, _ ...grpc.CallOption) (*deeppb.CreateTracepointResponse, error) {
+	f.add(in.Tracepoint.ID, in.Tracepoint.Path, in.Labels)
+	f.configs[in.Tracepoint.ID] = in.Tracepoint
+	f.metadata[in.Tracepoint.ID].Paused = in.Paused
+	f.setLimits(in.Tracepoint, in.Limits)
+	return &deeppb.CreateTracepointResponse{Tracepoint: in.Tracepoint}, nil
+}
+
+func (f *fakeClient) DeleteTracepoint(_ context.Context, in *deeppb.DeleteTracepointRequest, _ ...grpc.CallOption) (*deeppb.DeleteTracepointResponse, error) {
+	for i, id := range f.order {
+		if id == in.TracepointID {
+			f.order = append(f.order[:i], f.order[i+1:]...)
+		}
+	}
+	delete(f.configs, in.TracepointID)
+	delete(f.metadata, in.TracepointID)
+	return &deeppb.DeleteTracepointResponse{}, nil
+}
+
+func (f *fakeClient) UpdateTracepoint(_ context.Context, in *deeppb.UpdateTracepointRequest, _ ...grpc.CallOption) (*deeppb.UpdateTracepointResponse, error) {
+	f.configs[in.Tracepoint.ID] = in.Tracepoint
+	f.metadata[in.Tracepoint.ID].Version++
+	if len(in.Labels) > 0 {
+		f.metadata[in.Tracepoint.ID].Labels = in.Labels
+	}
+	f.setLimits(in.Tracepoint, in.Limits)
+	return &deeppb.UpdateTracepointResponse{}, nil
+}
+
+func (f *fakeClient) SetTracepointPaused(_ context.Context, in *deeppb.SetTracepointPausedRequest, _ ...grpc.CallOption) (*deeppb.SetTracepointPausedResponse, error) {
+	f.metadata[in.TracepointID].Paused = in.Paused
+	return &deeppb.SetTracepointPausedResponse{}, nil
+}
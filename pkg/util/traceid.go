@@ -0,0 +1,79 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	v1common "github.com/intergral/deep/pkg/deeppb/common/v1"
+)
+
+const (
+	// AttributeTraceID is the snapshot attribute agents use to attach the id of the active trace
+	AttributeTraceID = "trace_id"
+	// AttributeSpanID is the snapshot attribute agents use to attach the id of the active span
+	AttributeSpanID = "span_id"
+
+	traceIDLength = 32
+	spanIDLength  = 16
+)
+
+// NormalizeTraceID returns the trace ID in the form snapshots are indexed by: lower case hex, padded with leading
+// zeros to 128 bits. This allows 64 bit trace ids to match the padded form used by tracing backends.
+func NormalizeTraceID(id string) (string, error) {
+	return normalizeHexID(id, traceIDLength, "trace")
+}
+
+// NormalizeSpanID returns the span ID in the form snapshots are indexed by: lower case hex, padded with leading
+// zeros to 64 bits.
+func NormalizeSpanID(id string) (string, error) {
+	return normalizeHexID(id, spanIDLength, "span")
+}
+
+func normalizeHexID(id string, length int, kind string) (string, error) {
+	if id == "" {
+		return "", fmt.Errorf("%s IDs can not be empty", kind)
+	}
+	if len(id) > length {
+		return "", fmt.Errorf("%s IDs can't be larger than %d bits", kind, length*4)
+	}
+
+	for pos, c := range id {
+		if (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F') || (c >= '0' && c <= '9') {
+			continue
+		}
+		return "", fmt.Errorf("%s IDs can only contain hex characters: invalid character '%c' at position %d", kind, c, pos+1)
+	}
+
+	return strings.Repeat("0", length-len(id)) + strings.ToLower(id), nil
+}
+
+// TraceIDsFromAttributes returns the normalized trace and span ID attached to a snapshot. An ID is empty if the
+// attribute is not set, or is not a valid ID.
+func TraceIDsFromAttributes(attributes []*v1common.KeyValue) (traceID, spanID string) {
+	for _, kv := range attributes {
+		switch kv.Key {
+		case AttributeTraceID:
+			traceID, _ = NormalizeTraceID(kv.GetValue().GetStringValue())
+		case AttributeSpanID:
+			spanID, _ = NormalizeSpanID(kv.GetValue().GetStringValue())
+		}
+	}
+	return traceID, spanID
+}
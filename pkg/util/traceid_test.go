@@ -0,0 +1,100 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1common "github.com/intergral/deep/pkg/deeppb/common/v1"
+)
+
+func TestNormalizeTraceID(t *testing.T) {
+	tc := []struct {
+		id          string
+		expected    string
+		expectError error
+	}{
+		{
+			id:       "1234567890abcdef1234567890abcdef", // 128 bit
+			expected: "1234567890abcdef1234567890abcdef",
+		},
+		{
+			id:       "1234567890ABCDEF", // 64 bit
+			expected: "00000000000000001234567890abcdef",
+		},
+		{
+			id:          "121234567890abcdef1234567890abcdef",
+			expectError: errors.New("trace IDs can't be larger than 128 bits"),
+		},
+		{
+			id:          "1234-5678",
+			expectError: errors.New("trace IDs can only contain hex characters: invalid character '-' at position 5"),
+		},
+		{
+			id:          "",
+			expectError: errors.New("trace IDs can not be empty"),
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.id, func(t *testing.T) {
+			actual, err := NormalizeTraceID(tt.id)
+
+			if tt.expectError != nil {
+				assert.Equal(t, tt.expectError, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestNormalizeSpanID(t *testing.T) {
+	actual, err := NormalizeSpanID("ABC")
+	assert.NoError(t, err)
+	assert.Equal(t, "0000000000000abc", actual)
+
+	_, err = NormalizeSpanID("1234567890abcdef1")
+	assert.EqualError(t, err, "span IDs can't be larger than 64 bits")
+}
+
+func TestTraceIDsFromAttributes(t *testing.T) {
+	stringValue := func(s string) *v1common.AnyValue {
+		return &v1common.AnyValue{Value: &v1common.AnyValue_StringValue{StringValue: s}}
+	}
+
+	traceID, spanID := TraceIDsFromAttributes([]*v1common.KeyValue{
+		{Key: "other", Value: stringValue("value")},
+		{Key: AttributeTraceID, Value: stringValue("1234567890ABCDEF")},
+		{Key: AttributeSpanID, Value: stringValue("abc")},
+	})
+	assert.Equal(t, "00000000000000001234567890abcdef", traceID)
+	assert.Equal(t, "0000000000000abc", spanID)
+
+	traceID, spanID = TraceIDsFromAttributes([]*v1common.KeyValue{
+		{Key: AttributeTraceID, Value: stringValue("not a trace id")},
+		{Key: AttributeSpanID, Value: &v1common.AnyValue{Value: &v1common.AnyValue_IntValue{IntValue: 12}}},
+	})
+	assert.Empty(t, traceID)
+	assert.Empty(t, spanID)
+}
@@ -0,0 +1,29 @@
+/*
+ * Copyright (C) 2023  Intergral GmbH
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package util
+
+const (
+	// ArgFireCount is the tracepoint arg that tells the agents how many times the tracepoint can fire
+	ArgFireCount = "fire_count"
+	// ArgSnapshotRateLimit is the tracepoint arg that tells the agents and distributors how many snapshots per
+	// second are allowed for the tracepoint
+	ArgSnapshotRateLimit = "snapshot_rate_limit"
+	// ArgBytesRateLimit is the tracepoint arg that tells the agents and distributors how many snapshot bytes per
+	// second are allowed for the tracepoint
+	ArgBytesRateLimit = "snapshot_bytes_rate_limit"
+)